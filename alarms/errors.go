@@ -0,0 +1,13 @@
+package alarms
+
+import "errors"
+
+var errEmptyIdentifier = errors.New("empty identifier")
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errNilPublicKeysProvider = errors.New("nil public keys provider")
+var errEmptyApiUrl = errors.New("empty API url")
+var errNoApiUrls = errors.New("no API urls provided")
+var errInvalidThreshold = errors.New("invalid rating threshold")
+var errInvalidNonceDifference = errors.New("invalid nonce difference")
+var errInvalidPollingInterval = errors.New("invalid polling interval")
+var errNodeApiError = errors.New("node API error")
@@ -0,0 +1,15 @@
+package alarms
+
+import "context"
+
+// HTTPClient defines the operations of the HTTP client used to call the nodes' API
+type HTTPClient interface {
+	CallGetRestEndPoint(ctx context.Context, url string) ([]byte, error)
+	IsInterfaceNil() bool
+}
+
+// PublicKeysProvider defines the operations of a component providing the up to date public keys to be monitored
+type PublicKeysProvider interface {
+	PublicKeys(ctx context.Context) []string
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,50 @@
+package alarms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/iulianpascalau/node-monitoring/formatting"
+)
+
+const (
+	successfulCode     = "successful"
+	maxErrorBodyLength = 512
+)
+
+// nodeApiResponse is the envelope of all the responses of the node and proxy API
+type nodeApiResponse struct {
+	Data  json.RawMessage `json:"data"`
+	Error string          `json:"error"`
+	Code  string          `json:"code"`
+}
+
+// getNodeApiData calls the node API end point and decodes its data field into the provided value. The node and
+// proxy API wrap their errors in the same envelope, so a body that can not be decoded comes from something else
+// (e.g. a reverse proxy) and is reported as is
+func getNodeApiData(ctx context.Context, httpClient HTTPClient, endpointUrl string, value interface{}) error {
+	body, err := httpClient.CallGetRestEndPoint(ctx, endpointUrl)
+	if err != nil {
+		return err
+	}
+
+	apiResponse := nodeApiResponse{}
+	err = json.Unmarshal(body, &apiResponse)
+	if err != nil {
+		return fmt.Errorf("%w while decoding the response from %s: %s", err, endpointUrl,
+			formatting.Truncate(strings.TrimSpace(string(body)), maxErrorBodyLength))
+	}
+	if apiResponse.Code != successfulCode {
+		return fmt.Errorf("%w for %s: %s", errNodeApiError, endpointUrl,
+			formatting.Truncate(apiResponse.Error, maxErrorBodyLength))
+	}
+
+	err = json.Unmarshal(apiResponse.Data, value)
+	if err != nil {
+		return fmt.Errorf("%w while decoding the data from %s", err, endpointUrl)
+	}
+
+	return nil
+}
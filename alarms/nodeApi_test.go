@@ -0,0 +1,75 @@
+package alarms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type httpClientStub struct {
+	CallGetRestEndPointCalled func(ctx context.Context, url string) ([]byte, error)
+}
+
+// CallGetRestEndPoint -
+func (stub *httpClientStub) CallGetRestEndPoint(ctx context.Context, url string) ([]byte, error) {
+	if stub.CallGetRestEndPointCalled != nil {
+		return stub.CallGetRestEndPointCalled(ctx, url)
+	}
+
+	return []byte(`{"data":{},"code":"successful"}`), nil
+}
+
+// IsInterfaceNil -
+func (stub *httpClientStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createApiResponse(data string) []byte {
+	return []byte(`{"data":` + data + `,"error":"","code":"successful"}`)
+}
+
+func TestGetNodeApiData(t *testing.T) {
+	t.Parallel()
+
+	callWithResponse := func(body []byte, err error) (nodeStatusDTO, error) {
+		status := nodeStatusDTO{}
+		errGet := getNodeApiData(context.Background(), &httpClientStub{
+			CallGetRestEndPointCalled: func(ctx context.Context, url string) ([]byte, error) {
+				assert.Equal(t, "http://127.0.0.1:8080/node/status", url)
+				return body, err
+			},
+		}, "http://127.0.0.1:8080/node/status", &status)
+
+		return status, errGet
+	}
+
+	t.Run("request error should error", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		_, err := callWithResponse(nil, expectedErr)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("invalid JSON should error with the body", func(t *testing.T) {
+		_, err := callWithResponse([]byte("<html>bad gateway</html>\n"), nil)
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "while decoding the response from http://127.0.0.1:8080/node/status"))
+		assert.True(t, strings.HasSuffix(err.Error(), ": <html>bad gateway</html>"))
+	})
+	t.Run("long invalid body should be truncated", func(t *testing.T) {
+		_, err := callWithResponse([]byte(strings.Repeat("x", 2*maxErrorBodyLength)), nil)
+		assert.NotNil(t, err)
+		assert.True(t, strings.HasSuffix(err.Error(), strings.Repeat("x", maxErrorBodyLength-1)+"…"))
+	})
+	t.Run("API error should error", func(t *testing.T) {
+		_, err := callWithResponse([]byte(`{"data":null,"error":"node is starting","code":"internal_issue"}`), nil)
+		assert.True(t, errors.Is(err, errNodeApiError))
+		assert.Equal(t, "node API error for http://127.0.0.1:8080/node/status: node is starting", err.Error())
+	})
+	t.Run("should decode the data", func(t *testing.T) {
+		status, err := callWithResponse(createApiResponse(`{"metrics":{"erd_nonce":120,"erd_probable_highest_nonce":122}}`), nil)
+		assert.Nil(t, err)
+		assert.Equal(t, nodeMetricsDTO{Nonce: 120, ProbableHighestNonce: 122}, status.Metrics)
+	})
+}
@@ -0,0 +1,196 @@
+package alarms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+const (
+	// NonceMetric is the metric holding the highest nonce known by the monitored nodes
+	NonceMetric = "nonce"
+	// NonceDifferenceMetric is the metric holding the largest difference between the highest nonce and a node's nonce
+	NonceDifferenceMetric = "nonceDifference"
+
+	nodeStatusPath = "/node/status"
+)
+
+// ArgsNodeNonceAlarm represents the arguments DTO for the nodeNonceAlarm constructor
+type ArgsNodeNonceAlarm struct {
+	Identifier      string
+	HTTPClient      HTTPClient
+	ApiUrls         []string
+	NonceDifference int
+	PollingInterval time.Duration
+}
+
+type nodeStatusDTO struct {
+	Metrics nodeMetricsDTO `json:"metrics"`
+}
+
+type nodeMetricsDTO struct {
+	Nonce                uint64 `json:"erd_nonce"`
+	ProbableHighestNonce uint64 `json:"erd_probable_highest_nonce"`
+}
+
+type nodeNonceResult struct {
+	apiUrl string
+	nonce  uint64
+	err    error
+}
+
+type nodeNonceAlarm struct {
+	*pollingSchedule
+	identifier      string
+	httpClient      HTTPClient
+	apiUrls         []string
+	nonceDifference uint64
+}
+
+// NewNodeNonceAlarm creates an alarm that reports an error when any of the provided nodes is unreachable or its
+// nonce is behind the highest nonce known by all the nodes by more than the allowed difference
+func NewNodeNonceAlarm(args ArgsNodeNonceAlarm) (*nodeNonceAlarm, error) {
+	err := checkNodeNonceArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := newPollingSchedule(args.PollingInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	alarm := &nodeNonceAlarm{
+		pollingSchedule: schedule,
+		identifier:      args.Identifier,
+		httpClient:      args.HTTPClient,
+		apiUrls:         make([]string, 0, len(args.ApiUrls)),
+		nonceDifference: uint64(args.NonceDifference),
+	}
+	for _, apiUrl := range args.ApiUrls {
+		alarm.apiUrls = append(alarm.apiUrls, strings.TrimSuffix(apiUrl, "/"))
+	}
+
+	return alarm, nil
+}
+
+func checkNodeNonceArgs(args ArgsNodeNonceAlarm) error {
+	if len(strings.TrimSpace(args.Identifier)) == 0 {
+		return errEmptyIdentifier
+	}
+	if check.IfNil(args.HTTPClient) {
+		return errNilHTTPClient
+	}
+	if len(args.ApiUrls) == 0 {
+		return errNoApiUrls
+	}
+	for _, apiUrl := range args.ApiUrls {
+		if len(strings.TrimSpace(apiUrl)) == 0 {
+			return errEmptyApiUrl
+		}
+	}
+	if args.NonceDifference <= 0 {
+		return fmt.Errorf("%w, provided: %d", errInvalidNonceDifference, args.NonceDifference)
+	}
+
+	return nil
+}
+
+// Query fetches the nonces of all the nodes and checks how far behind each node is
+func (alarm *nodeNonceAlarm) Query(ctx context.Context) (data.AlarmResponse, error) {
+	alarm.scheduleNextQuery()
+
+	return alarm.createResponse(ctx), nil
+}
+
+func (alarm *nodeNonceAlarm) createResponse(ctx context.Context) data.AlarmResponse {
+	results := make([]nodeNonceResult, 0, len(alarm.apiUrls))
+	highestNonce := uint64(0)
+	for _, apiUrl := range alarm.apiUrls {
+		status := nodeStatusDTO{}
+		err := getNodeApiData(ctx, alarm.httpClient, apiUrl+nodeStatusPath, &status)
+		results = append(results, nodeNonceResult{
+			apiUrl: apiUrl,
+			nonce:  status.Metrics.Nonce,
+			err:    err,
+		})
+		if err != nil {
+			continue
+		}
+
+		highestNonce = maxNonce(highestNonce, maxNonce(status.Metrics.Nonce, status.Metrics.ProbableHighestNonce))
+	}
+
+	response := data.AlarmResponse{
+		Identifier: alarm.identifier,
+		Level:      data.NoEvent,
+	}
+	urlsInError := make([]string, 0)
+	lines := make([]string, 0, len(results))
+	largestDifference := uint64(0)
+	numReachable := 0
+	for _, result := range results {
+		if result.err != nil {
+			urlsInError = append(urlsInError, result.apiUrl)
+			lines = append(lines, fmt.Sprintf("%s: unreachable, %s", result.apiUrl, result.err.Error()))
+			continue
+		}
+
+		numReachable++
+		difference := highestNonce - result.nonce
+		largestDifference = maxNonce(largestDifference, difference)
+		if difference > alarm.nonceDifference {
+			urlsInError = append(urlsInError, result.apiUrl)
+			lines = append(lines, fmt.Sprintf("%s: nonce %d is %d behind the highest nonce %d", result.apiUrl,
+				result.nonce, difference, highestNonce))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: nonce %d", result.apiUrl, result.nonce))
+	}
+	if numReachable > 0 {
+		response.Metrics = map[string]float64{
+			NonceMetric:           float64(highestNonce),
+			NonceDifferenceMetric: float64(largestDifference),
+		}
+	}
+
+	summary := fmt.Sprintf("all %d node(s) are synchronized within %d nonce(s)", len(results), alarm.nonceDifference)
+	if len(urlsInError) > 0 {
+		response.Level = data.Error
+		summary = fmt.Sprintf("%d of %d node(s) are unreachable or behind by more than %d nonce(s)",
+			len(urlsInError), len(results), alarm.nonceDifference)
+	}
+	if len(urlsInError) == 1 {
+		response.Labels = map[string]string{data.ApiUrlLabel: urlsInError[0]}
+	}
+	response.Data = strings.Join(append([]string{summary}, lines...), "\n")
+
+	return response
+}
+
+func maxNonce(first uint64, second uint64) uint64 {
+	if first > second {
+		return first
+	}
+
+	return second
+}
+
+// QueryInfo returns the current nonce of all the monitored nodes
+func (alarm *nodeNonceAlarm) QueryInfo(ctx context.Context) (string, error) {
+	return alarm.createResponse(ctx).Data, nil
+}
+
+// Identifier returns the alarm's identifier
+func (alarm *nodeNonceAlarm) Identifier() string {
+	return alarm.identifier
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (alarm *nodeNonceAlarm) IsInterfaceNil() bool {
+	return alarm == nil
+}
@@ -0,0 +1,180 @@
+package alarms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockArgsNodeNonceAlarm() ArgsNodeNonceAlarm {
+	return ArgsNodeNonceAlarm{
+		Identifier:      "node nonce",
+		HTTPClient:      &httpClientStub{},
+		ApiUrls:         []string{"http://node-0:8080/", "http://node-1:8080"},
+		NonceDifference: 3,
+		PollingInterval: time.Minute,
+	}
+}
+
+// createNodeStatusClient returns the provided nonce and probable highest nonce for each node, the missing nodes
+// being unreachable
+func createNodeStatusClient(nonces map[string][2]uint64) *httpClientStub {
+	return &httpClientStub{
+		CallGetRestEndPointCalled: func(ctx context.Context, url string) ([]byte, error) {
+			apiUrl := strings.TrimSuffix(url, "/node/status")
+			nonce, found := nonces[apiUrl]
+			if !found {
+				return nil, errors.New("connection refused")
+			}
+
+			return createApiResponse(fmt.Sprintf(`{"metrics":{"erd_nonce":%d,"erd_probable_highest_nonce":%d}}`,
+				nonce[0], nonce[1])), nil
+		},
+	}
+}
+
+func TestNewNodeNonceAlarm(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty identifier should error", func(t *testing.T) {
+		args := createMockArgsNodeNonceAlarm()
+		args.Identifier = ""
+
+		alarm, err := NewNodeNonceAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.Equal(t, errEmptyIdentifier, err)
+	})
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsNodeNonceAlarm()
+		args.HTTPClient = nil
+
+		alarm, err := NewNodeNonceAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("no API urls should error", func(t *testing.T) {
+		args := createMockArgsNodeNonceAlarm()
+		args.ApiUrls = nil
+
+		alarm, err := NewNodeNonceAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.Equal(t, errNoApiUrls, err)
+	})
+	t.Run("empty API url should error", func(t *testing.T) {
+		args := createMockArgsNodeNonceAlarm()
+		args.ApiUrls = append(args.ApiUrls, " ")
+
+		alarm, err := NewNodeNonceAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.Equal(t, errEmptyApiUrl, err)
+	})
+	t.Run("invalid nonce difference should error", func(t *testing.T) {
+		args := createMockArgsNodeNonceAlarm()
+		args.NonceDifference = 0
+
+		alarm, err := NewNodeNonceAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.True(t, errors.Is(err, errInvalidNonceDifference))
+	})
+	t.Run("invalid polling interval should error", func(t *testing.T) {
+		args := createMockArgsNodeNonceAlarm()
+		args.PollingInterval = -time.Second
+
+		alarm, err := NewNodeNonceAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.True(t, errors.Is(err, errInvalidPollingInterval))
+	})
+	t.Run("should work", func(t *testing.T) {
+		alarm, err := NewNodeNonceAlarm(createMockArgsNodeNonceAlarm())
+		assert.False(t, check.IfNil(alarm))
+		assert.Nil(t, err)
+		assert.Equal(t, "node nonce", alarm.Identifier())
+	})
+}
+
+func TestNodeNonceAlarm_Query(t *testing.T) {
+	t.Parallel()
+
+	t.Run("synchronized nodes should not be an event", func(t *testing.T) {
+		args := createMockArgsNodeNonceAlarm()
+		args.HTTPClient = createNodeStatusClient(map[string][2]uint64{
+			"http://node-0:8080": {100, 101},
+			"http://node-1:8080": {98, 101},
+		})
+		alarm, _ := NewNodeNonceAlarm(args)
+
+		response, err := alarm.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, data.AlarmResponse{
+			Identifier: "node nonce",
+			Level:      data.NoEvent,
+			Data: "all 2 node(s) are synchronized within 3 nonce(s)\nhttp://node-0:8080: nonce 100\n" +
+				"http://node-1:8080: nonce 98",
+			Metrics: map[string]float64{NonceMetric: 101, NonceDifferenceMetric: 3},
+		}, response)
+		assert.False(t, alarm.ShouldQuery())
+	})
+	t.Run("node behind should error with the node's label", func(t *testing.T) {
+		args := createMockArgsNodeNonceAlarm()
+		args.HTTPClient = createNodeStatusClient(map[string][2]uint64{
+			"http://node-0:8080": {110, 110},
+			"http://node-1:8080": {100, 100},
+		})
+		alarm, _ := NewNodeNonceAlarm(args)
+
+		response, err := alarm.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, data.Error, response.Level)
+		assert.Equal(t, "1 of 2 node(s) are unreachable or behind by more than 3 nonce(s)\nhttp://node-0:8080: nonce 110\n"+
+			"http://node-1:8080: nonce 100 is 10 behind the highest nonce 110", response.Data)
+		assert.Equal(t, map[string]float64{NonceMetric: 110, NonceDifferenceMetric: 10}, response.Metrics)
+		assert.Equal(t, map[string]string{data.ApiUrlLabel: "http://node-1:8080"}, response.Labels)
+	})
+	t.Run("single node should use its probable highest nonce", func(t *testing.T) {
+		args := createMockArgsNodeNonceAlarm()
+		args.ApiUrls = []string{"http://node-0:8080"}
+		args.HTTPClient = createNodeStatusClient(map[string][2]uint64{"http://node-0:8080": {100, 120}})
+		alarm, _ := NewNodeNonceAlarm(args)
+
+		response, err := alarm.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, data.Error, response.Level)
+		assert.Equal(t, "1 of 1 node(s) are unreachable or behind by more than 3 nonce(s)\n"+
+			"http://node-0:8080: nonce 100 is 20 behind the highest nonce 120", response.Data)
+		assert.Equal(t, map[string]float64{NonceMetric: 120, NonceDifferenceMetric: 20}, response.Metrics)
+	})
+	t.Run("unreachable nodes should error", func(t *testing.T) {
+		args := createMockArgsNodeNonceAlarm()
+		args.HTTPClient = createNodeStatusClient(nil)
+		alarm, _ := NewNodeNonceAlarm(args)
+
+		response, err := alarm.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, data.Error, response.Level)
+		assert.Equal(t, "2 of 2 node(s) are unreachable or behind by more than 3 nonce(s)\n"+
+			"http://node-0:8080: unreachable, connection refused\nhttp://node-1:8080: unreachable, connection refused", response.Data)
+		assert.Nil(t, response.Metrics)
+		assert.Nil(t, response.Labels)
+	})
+}
+
+func TestNodeNonceAlarm_QueryInfo(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsNodeNonceAlarm()
+	args.HTTPClient = createNodeStatusClient(map[string][2]uint64{"http://node-0:8080": {100, 100}})
+	alarm, _ := NewNodeNonceAlarm(args)
+
+	info, err := alarm.QueryInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "1 of 2 node(s) are unreachable or behind by more than 3 nonce(s)\nhttp://node-0:8080: nonce 100\n"+
+		"http://node-1:8080: unreachable, connection refused", info)
+	assert.True(t, alarm.ShouldQuery())
+}
@@ -0,0 +1,197 @@
+package alarms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+const (
+	// RatingMetric is the metric holding the lowest rating of the monitored public keys
+	RatingMetric = "rating"
+
+	validatorStatisticsPath = "/validator/statistics"
+	maxRating               = 100
+)
+
+// ArgsNodeRatingAlarm represents the arguments DTO for the nodeRatingAlarm constructor
+type ArgsNodeRatingAlarm struct {
+	Identifier         string
+	HTTPClient         HTTPClient
+	ApiUrl             string
+	Threshold          float64
+	PublicKeysProvider PublicKeysProvider
+	PollingInterval    time.Duration
+}
+
+type validatorStatisticsDTO struct {
+	Statistics map[string]validatorRatingDTO `json:"statistics"`
+}
+
+type validatorRatingDTO struct {
+	TempRating float64 `json:"tempRating"`
+}
+
+type nodeRatingAlarm struct {
+	*pollingSchedule
+	identifier         string
+	httpClient         HTTPClient
+	apiUrl             string
+	statisticsUrl      string
+	threshold          float64
+	publicKeysProvider PublicKeysProvider
+}
+
+// NewNodeRatingAlarm creates an alarm that reports an error when the rating of any of the provided public keys
+// drops below the threshold or the key is missing from the validator statistics of the node (or proxy) API. The
+// public keys are requested from the provider on each query so the keys loaded from files or discovered are used
+// as soon as they change
+func NewNodeRatingAlarm(args ArgsNodeRatingAlarm) (*nodeRatingAlarm, error) {
+	err := checkNodeRatingArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := newPollingSchedule(args.PollingInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	apiUrl := strings.TrimSuffix(args.ApiUrl, "/")
+
+	return &nodeRatingAlarm{
+		pollingSchedule:    schedule,
+		identifier:         args.Identifier,
+		httpClient:         args.HTTPClient,
+		apiUrl:             apiUrl,
+		statisticsUrl:      apiUrl + validatorStatisticsPath,
+		threshold:          args.Threshold,
+		publicKeysProvider: args.PublicKeysProvider,
+	}, nil
+}
+
+func checkNodeRatingArgs(args ArgsNodeRatingAlarm) error {
+	if len(strings.TrimSpace(args.Identifier)) == 0 {
+		return errEmptyIdentifier
+	}
+	if check.IfNil(args.HTTPClient) {
+		return errNilHTTPClient
+	}
+	if len(strings.TrimSpace(args.ApiUrl)) == 0 {
+		return errEmptyApiUrl
+	}
+	if args.Threshold <= 0 || args.Threshold > maxRating {
+		return fmt.Errorf("%w, provided: %v, expected a value in (0, %d]", errInvalidThreshold, args.Threshold, maxRating)
+	}
+	if check.IfNil(args.PublicKeysProvider) {
+		return errNilPublicKeysProvider
+	}
+
+	return nil
+}
+
+// Query fetches the validator statistics and checks the rating of all the monitored public keys
+func (alarm *nodeRatingAlarm) Query(ctx context.Context) (data.AlarmResponse, error) {
+	alarm.scheduleNextQuery()
+
+	statistics, err := alarm.fetchStatistics(ctx)
+	if err != nil {
+		return data.AlarmResponse{}, err
+	}
+
+	return alarm.createResponse(ctx, statistics), nil
+}
+
+func (alarm *nodeRatingAlarm) fetchStatistics(ctx context.Context) (map[string]validatorRatingDTO, error) {
+	statistics := validatorStatisticsDTO{}
+	err := getNodeApiData(ctx, alarm.httpClient, alarm.statisticsUrl, &statistics)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings := make(map[string]validatorRatingDTO, len(statistics.Statistics))
+	for publicKey, rating := range statistics.Statistics {
+		ratings[strings.ToLower(publicKey)] = rating
+	}
+
+	return ratings, nil
+}
+
+func (alarm *nodeRatingAlarm) createResponse(ctx context.Context, ratings map[string]validatorRatingDTO) data.AlarmResponse {
+	response := data.AlarmResponse{
+		Identifier: alarm.identifier,
+		Level:      data.NoEvent,
+		Labels:     map[string]string{data.ApiUrlLabel: alarm.apiUrl},
+	}
+
+	publicKeys := alarm.publicKeysProvider.PublicKeys(ctx)
+	if len(publicKeys) == 0 {
+		response.Level = data.Error
+		response.Data = "no public keys to monitor"
+		return response
+	}
+
+	keysInError := make([]string, 0)
+	lines := make([]string, 0, len(publicKeys))
+	lowestRating := float64(maxRating)
+	numFound := 0
+	for _, publicKey := range publicKeys {
+		rating, found := ratings[strings.ToLower(publicKey)]
+		if !found {
+			keysInError = append(keysInError, publicKey)
+			lines = append(lines, fmt.Sprintf("%s: not found in the validator statistics", publicKey))
+			continue
+		}
+
+		numFound++
+		if rating.TempRating < lowestRating {
+			lowestRating = rating.TempRating
+		}
+		if rating.TempRating < alarm.threshold {
+			keysInError = append(keysInError, publicKey)
+			lines = append(lines, fmt.Sprintf("%s: rating %.2f is below the threshold %.2f", publicKey, rating.TempRating, alarm.threshold))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: rating %.2f", publicKey, rating.TempRating))
+	}
+	if numFound > 0 {
+		response.Metrics = map[string]float64{RatingMetric: lowestRating}
+	}
+
+	summary := fmt.Sprintf("all %d node(s) have the rating of at least %.2f", len(publicKeys), alarm.threshold)
+	if len(keysInError) > 0 {
+		response.Level = data.Error
+		summary = fmt.Sprintf("%d of %d node(s) have the rating below %.2f or are missing", len(keysInError),
+			len(publicKeys), alarm.threshold)
+	}
+	if len(keysInError) == 1 {
+		response.Labels[data.PubkeyLabel] = keysInError[0]
+	}
+	response.Data = strings.Join(append([]string{summary}, lines...), "\n")
+
+	return response
+}
+
+// QueryInfo returns the current rating of all the monitored public keys
+func (alarm *nodeRatingAlarm) QueryInfo(ctx context.Context) (string, error) {
+	statistics, err := alarm.fetchStatistics(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return alarm.createResponse(ctx, statistics).Data, nil
+}
+
+// Identifier returns the alarm's identifier
+func (alarm *nodeRatingAlarm) Identifier() string {
+	return alarm.identifier
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (alarm *nodeRatingAlarm) IsInterfaceNil() bool {
+	return alarm == nil
+}
@@ -0,0 +1,231 @@
+package alarms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testPubkey1 = "pubkey1"
+	testPubkey2 = "pubkey2"
+)
+
+type publicKeysProviderStub struct {
+	PublicKeysCalled func(ctx context.Context) []string
+}
+
+// PublicKeys -
+func (stub *publicKeysProviderStub) PublicKeys(ctx context.Context) []string {
+	if stub.PublicKeysCalled != nil {
+		return stub.PublicKeysCalled(ctx)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *publicKeysProviderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createMockArgsNodeRatingAlarm() ArgsNodeRatingAlarm {
+	return ArgsNodeRatingAlarm{
+		Identifier: "node rating",
+		HTTPClient: &httpClientStub{},
+		ApiUrl:     "http://127.0.0.1:8080/",
+		Threshold:  90,
+		PublicKeysProvider: &publicKeysProviderStub{
+			PublicKeysCalled: func(ctx context.Context) []string {
+				return []string{testPubkey1, testPubkey2}
+			},
+		},
+		PollingInterval: time.Minute,
+	}
+}
+
+func createStatisticsClient(ratings map[string]float64) *httpClientStub {
+	return &httpClientStub{
+		CallGetRestEndPointCalled: func(ctx context.Context, url string) ([]byte, error) {
+			entries := make([]string, 0, len(ratings))
+			for publicKey, rating := range ratings {
+				entries = append(entries, fmt.Sprintf(`"%s":{"tempRating":%v,"rating":100}`, publicKey, rating))
+			}
+
+			return createApiResponse(`{"statistics":{` + strings.Join(entries, ",") + `}}`), nil
+		},
+	}
+}
+
+func TestNewNodeRatingAlarm(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty identifier should error", func(t *testing.T) {
+		args := createMockArgsNodeRatingAlarm()
+		args.Identifier = " "
+
+		alarm, err := NewNodeRatingAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.Equal(t, errEmptyIdentifier, err)
+	})
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsNodeRatingAlarm()
+		args.HTTPClient = nil
+
+		alarm, err := NewNodeRatingAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty API url should error", func(t *testing.T) {
+		args := createMockArgsNodeRatingAlarm()
+		args.ApiUrl = ""
+
+		alarm, err := NewNodeRatingAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.Equal(t, errEmptyApiUrl, err)
+	})
+	t.Run("invalid threshold should error", func(t *testing.T) {
+		for _, threshold := range []float64{0, -1, 100.5} {
+			args := createMockArgsNodeRatingAlarm()
+			args.Threshold = threshold
+
+			alarm, err := NewNodeRatingAlarm(args)
+			assert.True(t, check.IfNil(alarm))
+			assert.True(t, errors.Is(err, errInvalidThreshold))
+		}
+	})
+	t.Run("nil public keys provider should error", func(t *testing.T) {
+		args := createMockArgsNodeRatingAlarm()
+		args.PublicKeysProvider = nil
+
+		alarm, err := NewNodeRatingAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.Equal(t, errNilPublicKeysProvider, err)
+	})
+	t.Run("invalid polling interval should error", func(t *testing.T) {
+		args := createMockArgsNodeRatingAlarm()
+		args.PollingInterval = 0
+
+		alarm, err := NewNodeRatingAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.True(t, errors.Is(err, errInvalidPollingInterval))
+	})
+	t.Run("should work", func(t *testing.T) {
+		alarm, err := NewNodeRatingAlarm(createMockArgsNodeRatingAlarm())
+		assert.False(t, check.IfNil(alarm))
+		assert.Nil(t, err)
+		assert.Equal(t, "node rating", alarm.Identifier())
+		assert.True(t, alarm.ShouldQuery())
+	})
+}
+
+func TestNodeRatingAlarm_Query(t *testing.T) {
+	t.Parallel()
+
+	t.Run("API error should error and postpone the next query", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		args := createMockArgsNodeRatingAlarm()
+		args.HTTPClient = &httpClientStub{
+			CallGetRestEndPointCalled: func(ctx context.Context, url string) ([]byte, error) {
+				assert.Equal(t, "http://127.0.0.1:8080/validator/statistics", url)
+				return nil, expectedErr
+			},
+		}
+		alarm, _ := NewNodeRatingAlarm(args)
+
+		response, err := alarm.Query(context.Background())
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, data.AlarmResponse{}, response)
+		assert.False(t, alarm.ShouldQuery())
+	})
+	t.Run("all ratings above the threshold should not be an event", func(t *testing.T) {
+		args := createMockArgsNodeRatingAlarm()
+		args.HTTPClient = createStatisticsClient(map[string]float64{testPubkey1: 100, "PUBKEY2": 92.5, "other": 10})
+		alarm, _ := NewNodeRatingAlarm(args)
+
+		response, err := alarm.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, data.AlarmResponse{
+			Identifier: "node rating",
+			Level:      data.NoEvent,
+			Data:       "all 2 node(s) have the rating of at least 90.00\npubkey1: rating 100.00\npubkey2: rating 92.50",
+			Metrics:    map[string]float64{RatingMetric: 92.5},
+			Labels:     map[string]string{data.ApiUrlLabel: "http://127.0.0.1:8080"},
+		}, response)
+	})
+	t.Run("rating below the threshold should error with the key's label", func(t *testing.T) {
+		args := createMockArgsNodeRatingAlarm()
+		args.HTTPClient = createStatisticsClient(map[string]float64{testPubkey1: 100, testPubkey2: 89.99})
+		alarm, _ := NewNodeRatingAlarm(args)
+
+		response, err := alarm.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, data.Error, response.Level)
+		assert.Equal(t, "1 of 2 node(s) have the rating below 90.00 or are missing\npubkey1: rating 100.00\n"+
+			"pubkey2: rating 89.99 is below the threshold 90.00", response.Data)
+		assert.Equal(t, map[string]float64{RatingMetric: 89.99}, response.Metrics)
+		assert.Equal(t, map[string]string{data.ApiUrlLabel: "http://127.0.0.1:8080", data.PubkeyLabel: testPubkey2}, response.Labels)
+	})
+	t.Run("missing keys should error", func(t *testing.T) {
+		args := createMockArgsNodeRatingAlarm()
+		args.HTTPClient = createStatisticsClient(map[string]float64{"other": 100})
+		alarm, _ := NewNodeRatingAlarm(args)
+
+		response, err := alarm.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, data.Error, response.Level)
+		assert.Equal(t, "2 of 2 node(s) have the rating below 90.00 or are missing\n"+
+			"pubkey1: not found in the validator statistics\npubkey2: not found in the validator statistics", response.Data)
+		assert.Nil(t, response.Metrics)
+		assert.Equal(t, map[string]string{data.ApiUrlLabel: "http://127.0.0.1:8080"}, response.Labels)
+	})
+	t.Run("no public keys should error", func(t *testing.T) {
+		args := createMockArgsNodeRatingAlarm()
+		args.HTTPClient = createStatisticsClient(map[string]float64{testPubkey1: 100})
+		args.PublicKeysProvider = &publicKeysProviderStub{}
+		alarm, _ := NewNodeRatingAlarm(args)
+
+		response, err := alarm.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, data.Error, response.Level)
+		assert.Equal(t, "no public keys to monitor", response.Data)
+	})
+	t.Run("should use the current public keys on each query", func(t *testing.T) {
+		publicKeys := []string{testPubkey1}
+		args := createMockArgsNodeRatingAlarm()
+		args.HTTPClient = createStatisticsClient(map[string]float64{testPubkey1: 100, testPubkey2: 50})
+		args.PublicKeysProvider = &publicKeysProviderStub{
+			PublicKeysCalled: func(ctx context.Context) []string {
+				return publicKeys
+			},
+		}
+		alarm, _ := NewNodeRatingAlarm(args)
+
+		response, _ := alarm.Query(context.Background())
+		assert.Equal(t, data.NoEvent, response.Level)
+
+		publicKeys = []string{testPubkey1, testPubkey2}
+		response, _ = alarm.Query(context.Background())
+		assert.Equal(t, data.Error, response.Level)
+	})
+}
+
+func TestNodeRatingAlarm_QueryInfo(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsNodeRatingAlarm()
+	args.HTTPClient = createStatisticsClient(map[string]float64{testPubkey1: 100, testPubkey2: 95})
+	alarm, _ := NewNodeRatingAlarm(args)
+
+	info, err := alarm.QueryInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "all 2 node(s) have the rating of at least 90.00\npubkey1: rating 100.00\npubkey2: rating 95.00", info)
+	assert.True(t, alarm.ShouldQuery())
+}
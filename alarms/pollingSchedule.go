@@ -0,0 +1,46 @@
+package alarms
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// pollingSchedule holds the next query time of an alarm queried at a fixed interval
+type pollingSchedule struct {
+	pollingInterval time.Duration
+	getTime         func() time.Time
+
+	mutSchedule   sync.RWMutex
+	nextQueryTime time.Time
+}
+
+func newPollingSchedule(pollingInterval time.Duration) (*pollingSchedule, error) {
+	if pollingInterval <= 0 {
+		return nil, fmt.Errorf("%w, provided: %v", errInvalidPollingInterval, pollingInterval)
+	}
+
+	return &pollingSchedule{
+		pollingInterval: pollingInterval,
+		getTime:         time.Now,
+	}, nil
+}
+
+// ShouldQuery returns true if the polling interval elapsed since the last query
+func (schedule *pollingSchedule) ShouldQuery() bool {
+	return !schedule.getTime().Before(schedule.NextQueryTime())
+}
+
+// NextQueryTime returns the time of the next query
+func (schedule *pollingSchedule) NextQueryTime() time.Time {
+	schedule.mutSchedule.RLock()
+	defer schedule.mutSchedule.RUnlock()
+
+	return schedule.nextQueryTime
+}
+
+func (schedule *pollingSchedule) scheduleNextQuery() {
+	schedule.mutSchedule.Lock()
+	schedule.nextQueryTime = schedule.getTime().Add(schedule.pollingInterval)
+	schedule.mutSchedule.Unlock()
+}
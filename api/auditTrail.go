@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+)
+
+var auditLog = logger.GetOrCreate("api/audit")
+
+const auditFilePermissions = 0600
+
+type auditEntry struct {
+	Timestamp     time.Time `json:"timestamp"`
+	RemoteAddress string    `json:"remoteAddress"`
+	Action        string    `json:"action"`
+	Identifier    string    `json:"identifier,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// auditTrail will record all the control actions requested through the API. The entries are always written in the
+// logs and, optionally, appended as JSON lines in a file
+type auditTrail struct {
+	mut  sync.Mutex
+	file *os.File
+}
+
+func newAuditTrail(filePath string) (*auditTrail, error) {
+	trail := &auditTrail{}
+	if len(filePath) == 0 {
+		return trail, nil
+	}
+
+	var err error
+	trail.file, err = os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, auditFilePermissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return trail, nil
+}
+
+func (trail *auditTrail) record(remoteAddress string, action string, identifier string, err error) {
+	entry := auditEntry{
+		Timestamp:     time.Now(),
+		RemoteAddress: remoteAddress,
+		Action:        action,
+		Identifier:    identifier,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	auditLog.Info("control action", "action", action, "identifier", identifier,
+		"remote address", remoteAddress, "error", entry.Error)
+
+	if trail.file == nil {
+		return
+	}
+
+	buff, errMarshal := json.Marshal(entry)
+	if errMarshal != nil {
+		auditLog.Error("error marshaling audit entry", "error", errMarshal.Error())
+		return
+	}
+
+	trail.mut.Lock()
+	defer trail.mut.Unlock()
+
+	_, errWrite := trail.file.Write(append(buff, '\n'))
+	if errWrite != nil {
+		auditLog.Error("error writing audit entry", "error", errWrite.Error())
+	}
+}
+
+func (trail *auditTrail) close() error {
+	if trail.file == nil {
+		return nil
+	}
+
+	trail.mut.Lock()
+	defer trail.mut.Unlock()
+
+	return trail.file.Close()
+}
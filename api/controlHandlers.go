@@ -0,0 +1,136 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	reportAction  = "report"
+	reloadAction  = "reload"
+	durationParam = "duration"
+)
+
+// handleAlarmPause will respond to the POST /alarms/{identifier}/pause requests
+func (ws *webServer) handleAlarmPause(w http.ResponseWriter, r *http.Request, identifier string) {
+	if r.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	err := ws.controlHandler.PauseAlarm(identifier)
+	ws.audit.record(r.RemoteAddr, pauseAction, identifier, err)
+	if err != nil {
+		writeResponse(w, statusFromError(err), nil, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, nil, nil)
+}
+
+// handleAlarmResume will respond to the POST /alarms/{identifier}/resume requests
+func (ws *webServer) handleAlarmResume(w http.ResponseWriter, r *http.Request, identifier string) {
+	if r.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	err := ws.controlHandler.ResumeAlarm(identifier)
+	ws.audit.record(r.RemoteAddr, resumeAction, identifier, err)
+	if err != nil {
+		writeResponse(w, statusFromError(err), nil, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, nil, nil)
+}
+
+// handleAlarmAck will respond to the POST /alarms/{identifier}/ack requests
+func (ws *webServer) handleAlarmAck(w http.ResponseWriter, r *http.Request, identifier string) {
+	if r.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	err := ws.controlHandler.AcknowledgeAlarm(identifier)
+	ws.audit.record(r.RemoteAddr, ackAction, identifier, err)
+	if err != nil {
+		writeResponse(w, statusFromError(err), nil, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, nil, nil)
+}
+
+// handleAlarmSilence will respond to the POST /alarms/{identifier}/silence?duration=1h requests, a zero duration
+// removing the silence
+func (ws *webServer) handleAlarmSilence(w http.ResponseWriter, r *http.Request, identifier string) {
+	if r.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	value := r.URL.Query().Get(durationParam)
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, nil, fmt.Errorf("%w for %s query parameter: %s", errInvalidValue, durationParam, value))
+		return
+	}
+
+	err = ws.controlHandler.SilenceAlarm(identifier, duration)
+	ws.audit.record(r.RemoteAddr, silenceAction, identifier, err)
+	if err != nil {
+		writeResponse(w, statusFromError(err), nil, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, nil, nil)
+}
+
+// handleAlarmQuery will respond to the POST /alarms/{identifier}/query requests
+func (ws *webServer) handleAlarmQuery(w http.ResponseWriter, r *http.Request, identifier string) {
+	if r.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	response, err := ws.controlHandler.TriggerAlarmQuery(r.Context(), identifier)
+	ws.audit.record(r.RemoteAddr, queryAction, identifier, err)
+	if err != nil {
+		writeResponse(w, statusFromError(err), nil, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, response, nil)
+}
+
+// handleReport will respond to the POST /report requests
+func (ws *webServer) handleReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	response := ws.controlHandler.TriggerInfoMessage(r.Context())
+	ws.audit.record(r.RemoteAddr, reportAction, "", nil)
+
+	writeResponse(w, http.StatusOK, response, nil)
+}
+
+// handleReload will respond to the POST /reload requests
+func (ws *webServer) handleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	err := ws.configReloader.Reload()
+	ws.audit.record(r.RemoteAddr, reloadAction, "", err)
+	if err != nil {
+		writeResponse(w, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, nil, nil)
+}
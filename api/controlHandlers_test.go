@@ -0,0 +1,270 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/poll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebServer_AlarmPauseResume(t *testing.T) {
+	t.Parallel()
+
+	paused := make(map[string]bool)
+	args := createMockArgsWebServer()
+	args.ControlHandler = &mocks.ControlHandlerStub{
+		PauseAlarmCalled: func(identifier string) error {
+			if identifier != "alarm" {
+				return fmt.Errorf("%w for identifier %s", poll.ErrAlarmNotFound, identifier)
+			}
+			paused[identifier] = true
+			return nil
+		},
+		ResumeAlarmCalled: func(identifier string) error {
+			if identifier != "alarm" {
+				return fmt.Errorf("%w for identifier %s", poll.ErrAlarmNotFound, identifier)
+			}
+			paused[identifier] = false
+			return nil
+		},
+	}
+	ws, _ := NewWebServer(args)
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	t.Run("wrong method should error", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms/alarm/pause", ws.Address()), testToken)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+
+		status, _ = doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms/alarm/resume", ws.Address()), testToken)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+	})
+	t.Run("unknown alarm should return not found", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/missing/pause", ws.Address()), testToken)
+		assert.Equal(t, http.StatusNotFound, status)
+
+		status, _ = doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/missing/resume", ws.Address()), testToken)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+	t.Run("should work", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/alarm/pause", ws.Address()), testToken)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, codeSuccess, response.Code)
+		assert.True(t, paused["alarm"])
+
+		status, response = doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/alarm/resume", ws.Address()), testToken)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, codeSuccess, response.Code)
+		assert.False(t, paused["alarm"])
+	})
+}
+
+func TestWebServer_AlarmAckSilence(t *testing.T) {
+	t.Parallel()
+
+	acknowledged := make(map[string]bool)
+	silences := make(map[string]time.Duration)
+	args := createMockArgsWebServer()
+	args.ControlHandler = &mocks.ControlHandlerStub{
+		AcknowledgeAlarmCalled: func(identifier string) error {
+			if identifier != "alarm" {
+				return fmt.Errorf("%w: %s", poll.ErrAlarmNotAlerting, identifier)
+			}
+			acknowledged[identifier] = true
+			return nil
+		},
+		SilenceAlarmCalled: func(identifier string, duration time.Duration) error {
+			if identifier != "alarm" {
+				return fmt.Errorf("%w for identifier %s", poll.ErrAlarmNotFound, identifier)
+			}
+			silences[identifier] = duration
+			return nil
+		},
+	}
+	ws, _ := NewWebServer(args)
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	t.Run("wrong method should error", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms/alarm/ack", ws.Address()), testToken)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+
+		status, _ = doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms/alarm/silence?duration=1h", ws.Address()), testToken)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+	})
+	t.Run("not alerting alarm should return conflict", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/other/ack", ws.Address()), testToken)
+		assert.Equal(t, http.StatusConflict, status)
+		assert.Equal(t, codeBadRequest, response.Code)
+	})
+	t.Run("invalid duration should return bad request", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/alarm/silence?duration=1x", ws.Address()), testToken)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.True(t, strings.Contains(response.Error, errInvalidValue.Error()))
+	})
+	t.Run("unknown alarm should return not found", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/missing/silence?duration=1h", ws.Address()), testToken)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+	t.Run("should work", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/alarm/ack", ws.Address()), testToken)
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, acknowledged["alarm"])
+
+		status, _ = doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/alarm/silence?duration=30m", ws.Address()), testToken)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 30*time.Minute, silences["alarm"])
+
+		status, _ = doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/alarm/silence?duration=0", ws.Address()), testToken)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, time.Duration(0), silences["alarm"])
+	})
+}
+
+func TestWebServer_AlarmQuery(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	args := createMockArgsWebServer()
+	args.ControlHandler = &mocks.ControlHandlerStub{
+		TriggerAlarmQueryCalled: func(ctx context.Context, identifier string) (data.AlarmResponse, error) {
+			if identifier == "failing" {
+				return data.AlarmResponse{}, expectedErr
+			}
+
+			return data.AlarmResponse{
+				Identifier: identifier,
+				Level:      data.Info,
+				Data:       "message",
+			}, nil
+		},
+	}
+	ws, _ := NewWebServer(args)
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	t.Run("wrong method should error", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms/alarm/query", ws.Address()), testToken)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+	})
+	t.Run("query error should return internal issue", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/failing/query", ws.Address()), testToken)
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.Equal(t, expectedErr.Error(), response.Error)
+	})
+	t.Run("should work", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/alarm/query", ws.Address()), testToken)
+		assert.Equal(t, http.StatusOK, status)
+		expectedData := map[string]interface{}{
+			"identifier": "alarm",
+			"level":      "Info",
+			"data":       "message",
+		}
+		assert.Equal(t, expectedData, response.Data)
+	})
+}
+
+func TestWebServer_Report(t *testing.T) {
+	t.Parallel()
+
+	numCalls := 0
+	args := createMockArgsWebServer()
+	args.ControlHandler = &mocks.ControlHandlerStub{
+		TriggerInfoMessageCalled: func(ctx context.Context) data.AlarmResponse {
+			numCalls++
+			return data.AlarmResponse{
+				Identifier: "system",
+				Level:      data.Info,
+				Data:       "report",
+			}
+		},
+	}
+	ws, _ := NewWebServer(args)
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	status, _ := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/report", ws.Address()), testToken)
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+	assert.Equal(t, 0, numCalls)
+
+	status, response := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/report", ws.Address()), testToken)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, numCalls)
+	assert.Equal(t, "report", response.Data.(map[string]interface{})["data"])
+}
+
+func TestWebServer_Reload(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	var reloadErr error
+	args := createMockArgsWebServer()
+	args.ConfigReloader = &mocks.ConfigReloaderStub{
+		ReloadCalled: func() error {
+			return reloadErr
+		},
+	}
+	ws, _ := NewWebServer(args)
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	t.Run("wrong method should error", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/reload", ws.Address()), testToken)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+	})
+	t.Run("should work", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/reload", ws.Address()), testToken)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, codeSuccess, response.Code)
+	})
+	t.Run("reload error should return internal issue", func(t *testing.T) {
+		reloadErr = expectedErr
+
+		status, response := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/reload", ws.Address()), testToken)
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.Equal(t, expectedErr.Error(), response.Error)
+	})
+}
+
+func TestWebServer_AuditTrail(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsWebServer()
+	args.AuditLogFile = filepath.Join(t.TempDir(), "audit.log")
+	args.ControlHandler = &mocks.ControlHandlerStub{
+		ResumeAlarmCalled: func(identifier string) error {
+			return fmt.Errorf("%w for identifier %s", poll.ErrAlarmNotFound, identifier)
+		},
+	}
+	ws, _ := NewWebServer(args)
+
+	_, _ = doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/alarm/pause", ws.Address()), testToken)
+	_, _ = doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/alarm/resume", ws.Address()), testToken)
+	_, _ = doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms", ws.Address()), testToken)
+	_, _ = doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/reload", ws.Address()), "wrong token")
+	_ = ws.Close()
+
+	buff, err := ioutil.ReadFile(args.AuditLogFile)
+	assert.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(buff)), "\n")
+	assert.Equal(t, 2, len(lines))
+	assert.Contains(t, lines[0], `"action":"pause","identifier":"alarm"`)
+	assert.NotContains(t, lines[0], `"error"`)
+	assert.Contains(t, lines[1], `"action":"resume","identifier":"alarm","error":"alarm not found for identifier alarm"`)
+}
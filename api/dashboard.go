@@ -0,0 +1,76 @@
+package api
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"time"
+)
+
+const (
+	dashboardPath         = "/dashboard/"
+	dashboardSnapshotPath = dashboardPath + "snapshot"
+	dashboardEventsPath   = dashboardPath + "events"
+	snapshotEvent         = "snapshot"
+)
+
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+func createDashboardFilesHandler() (http.Handler, error) {
+	files, err := fs.Sub(dashboardFiles, "dashboard")
+	if err != nil {
+		return nil, err
+	}
+
+	return http.StripPrefix(dashboardPath, http.FileServer(http.FS(files))), nil
+}
+
+func (ws *webServer) createDashboardSnapshot() dashboardSnapshot {
+	return dashboardSnapshot{
+		Timestamp: time.Now(),
+		Alarms:    ws.alarmsStatusHandler.AlarmsStatus(),
+		History:   ws.alarmsStatusHandler.AlarmsHistory(),
+		Notifiers: ws.alarmsStatusHandler.NotifiersStatus(),
+	}
+}
+
+// handleDashboardSnapshot will respond to the GET /dashboard/snapshot requests
+func (ws *webServer) handleDashboardSnapshot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, ws.createDashboardSnapshot(), nil)
+}
+
+// handleDashboardEvents will push a dashboard snapshot each refresh interval as server-sent events
+func (ws *webServer) handleDashboardEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	flusher, err := prepareEventStream(w)
+	if err != nil {
+		writeResponse(w, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	for {
+		err = writeEvent(w, flusher, snapshotEvent, ws.createDashboardSnapshot())
+		if err != nil {
+			log.Debug("dashboard events stream closed", "remote address", r.RemoteAddr, "error", err.Error())
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ws.closing:
+			return
+		case <-time.After(ws.dashboardRefreshInterval):
+		}
+	}
+}
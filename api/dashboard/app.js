@@ -0,0 +1,220 @@
+"use strict";
+
+const tokenStorageKey = "node-monitoring-token";
+const sparklineWidth = 160;
+const sparklineHeight = 24;
+const silenceDuration = "1h";
+let eventSource = null;
+
+function formatTime(value) {
+    const date = new Date(value);
+    if (isNaN(date.getTime()) || date.getFullYear() <= 1) {
+        return "-";
+    }
+
+    return date.toLocaleString();
+}
+
+function createCell(row, content) {
+    const cell = document.createElement("td");
+    if (content instanceof Node) {
+        cell.appendChild(content);
+    } else {
+        cell.textContent = content;
+    }
+    row.appendChild(cell);
+
+    return cell;
+}
+
+function createBadge(text, cssClass) {
+    const badge = document.createElement("span");
+    badge.className = "badge " + (cssClass || text);
+    badge.textContent = text;
+
+    return badge;
+}
+
+function isSilenced(alarm) {
+    return new Date(alarm.silencedUntil).getTime() > Date.now();
+}
+
+function createState(alarm) {
+    const container = document.createElement("div");
+    container.appendChild(createBadge(alarm.state));
+    if (alarm.acknowledged) {
+        container.appendChild(createBadge("acknowledged"));
+    }
+    if (isSilenced(alarm)) {
+        container.appendChild(createBadge("silenced until " + formatTime(alarm.silencedUntil), "silenced"));
+    }
+
+    return container;
+}
+
+function runAction(identifier, action, query) {
+    const token = localStorage.getItem(tokenStorageKey) || "";
+    const url = "../alarms/" + encodeURIComponent(identifier) + "/" + action + (query ? "?" + query : "");
+    fetch(url, {method: "POST", headers: {"Authorization": "Bearer " + token}})
+        .then((response) => response.json())
+        .then((response) => {
+            if (response.error) {
+                showError(action + " " + identifier + ": " + response.error);
+            }
+        })
+        .catch((err) => showError(action + " " + identifier + ": " + err));
+}
+
+function showError(message) {
+    document.getElementById("action-error").textContent = message;
+}
+
+function createButton(text, onClick) {
+    const button = document.createElement("button");
+    button.type = "button";
+    button.textContent = text;
+    button.addEventListener("click", onClick);
+
+    return button;
+}
+
+function createActions(alarm) {
+    const container = document.createElement("div");
+    container.className = "actions";
+    if (alarm.state === "Paused") {
+        container.appendChild(createButton("Resume", () => runAction(alarm.identifier, "resume")));
+    } else {
+        container.appendChild(createButton("Pause", () => runAction(alarm.identifier, "pause")));
+    }
+    if (alarm.state === "Alerting" && !alarm.acknowledged) {
+        container.appendChild(createButton("Ack", () => runAction(alarm.identifier, "ack")));
+    }
+    if (isSilenced(alarm)) {
+        container.appendChild(createButton("Unsilence", () => runAction(alarm.identifier, "silence", "duration=0")));
+    } else {
+        container.appendChild(createButton("Silence " + silenceDuration,
+            () => runAction(alarm.identifier, "silence", "duration=" + silenceDuration)));
+    }
+
+    return container;
+}
+
+function createSparkline(name, values) {
+    const ns = "http://www.w3.org/2000/svg";
+    const svg = document.createElementNS(ns, "svg");
+    svg.setAttribute("class", "sparkline");
+    svg.setAttribute("width", sparklineWidth);
+    svg.setAttribute("height", sparklineHeight);
+
+    const min = Math.min(...values);
+    const max = Math.max(...values);
+    const span = max - min || 1;
+    const step = values.length > 1 ? (sparklineWidth - 60) / (values.length - 1) : 0;
+    const points = values.map((value, idx) => {
+        const x = idx * step;
+        const y = sparklineHeight - 2 - ((value - min) / span) * (sparklineHeight - 4);
+        return x.toFixed(1) + "," + y.toFixed(1);
+    });
+
+    const polyline = document.createElementNS(ns, "polyline");
+    polyline.setAttribute("points", points.join(" "));
+    svg.appendChild(polyline);
+
+    const label = document.createElementNS(ns, "text");
+    label.setAttribute("x", sparklineWidth - 56);
+    label.setAttribute("y", sparklineHeight / 2 + 3);
+    label.textContent = name + ": " + values[values.length - 1];
+    svg.appendChild(label);
+
+    return svg;
+}
+
+function createHistory(points) {
+    const container = document.createElement("div");
+    const metrics = {};
+    (points || []).forEach((point) => {
+        Object.entries(point.metrics || {}).forEach(([name, value]) => {
+            metrics[name] = metrics[name] || [];
+            metrics[name].push(value);
+        });
+    });
+
+    Object.keys(metrics).sort().forEach((name) => {
+        container.appendChild(createSparkline(name, metrics[name]));
+    });
+    if (container.childElementCount === 0) {
+        container.textContent = "-";
+    }
+
+    return container;
+}
+
+function render(snapshot) {
+    const alarms = document.getElementById("alarms");
+    const paused = document.getElementById("paused");
+    alarms.replaceChildren();
+    paused.replaceChildren();
+    (snapshot.alarms || []).forEach((alarm) => {
+        const row = document.createElement("tr");
+        createCell(row, alarm.identifier);
+        createCell(row, createState(alarm));
+        createCell(row, alarm.lastResponse.level ? createBadge(alarm.lastResponse.level) : "-");
+        createCell(row, formatTime(alarm.lastQueryTime)).title = alarm.lastError || alarm.lastResponse.data || "";
+        createCell(row, formatTime(alarm.nextQueryTime));
+        createCell(row, createHistory((snapshot.history || {})[alarm.identifier]));
+        createCell(row, createActions(alarm));
+        alarms.appendChild(row);
+
+        if (alarm.state === "Paused") {
+            const item = document.createElement("li");
+            item.textContent = alarm.identifier;
+            paused.appendChild(item);
+        }
+    });
+
+    const notifiers = document.getElementById("notifiers");
+    notifiers.replaceChildren();
+    (snapshot.notifiers || []).forEach((notifier) => {
+        const row = document.createElement("tr");
+        createCell(row, notifier.name);
+        createCell(row, createBadge(notifier.healthy ? "healthy" : "unhealthy"));
+        createCell(row, notifier.numSent);
+        createCell(row, notifier.numErrors);
+        createCell(row, notifier.lastError ? formatTime(notifier.lastErrorTime) + ": " + notifier.lastError : "-");
+        notifiers.appendChild(row);
+    });
+
+    document.getElementById("updated").textContent = "Last update: " + formatTime(snapshot.timestamp);
+}
+
+function setConnected(connected) {
+    const badge = document.getElementById("connection");
+    badge.textContent = connected ? "connected" : "disconnected";
+    badge.className = "badge " + badge.textContent;
+}
+
+function connect(token) {
+    if (eventSource !== null) {
+        eventSource.close();
+    }
+
+    eventSource = new EventSource("events?access_token=" + encodeURIComponent(token));
+    eventSource.addEventListener("snapshot", (event) => {
+        setConnected(true);
+        render(JSON.parse(event.data));
+    });
+    eventSource.onerror = () => setConnected(false);
+}
+
+document.getElementById("token-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    const token = document.getElementById("token").value;
+    localStorage.setItem(tokenStorageKey, token);
+    connect(token);
+});
+
+const storedToken = localStorage.getItem(tokenStorageKey);
+if (storedToken) {
+    document.getElementById("token").value = storedToken;
+    connect(storedToken);
+}
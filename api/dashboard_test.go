@@ -0,0 +1,130 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDashboardStatusHandler() *mocks.AlarmsStatusHandlerStub {
+	return &mocks.AlarmsStatusHandlerStub{
+		AlarmsStatusCalled: func() []data.AlarmStatus {
+			return []data.AlarmStatus{{Identifier: "alarm", State: data.StateOk}}
+		},
+		AlarmsHistoryCalled: func() map[string][]data.AlarmHistoryPoint {
+			return map[string][]data.AlarmHistoryPoint{
+				"alarm": {{Level: data.Info, Metrics: map[string]float64{"rating": 100}}},
+			}
+		},
+		NotifiersStatusCalled: func() []data.NotifierStatus {
+			return []data.NotifierStatus{{Name: "0: notifier", Healthy: true, NumSent: 1}}
+		},
+	}
+}
+
+func readEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+	event := ""
+	payload := ""
+	for {
+		line, err := reader.ReadString('\n')
+		require.Nil(t, err)
+
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			payload = strings.TrimPrefix(line, "data: ")
+		case len(line) == 0 && len(event) > 0:
+			return event, payload
+		}
+	}
+}
+
+func TestWebServer_DashboardFiles(t *testing.T) {
+	t.Parallel()
+
+	ws, _ := NewWebServer(createMockArgsWebServer())
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	for _, file := range []string{"", "app.js", "style.css"} {
+		resp, err := http.Get(fmt.Sprintf("http://%s/dashboard/%s", ws.Address(), file))
+		require.Nil(t, err)
+
+		buff, _ := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, file)
+		assert.True(t, len(buff) > 0)
+		assert.NotContains(t, string(buff), "https://", "no external resources should be loaded")
+	}
+}
+
+func TestWebServer_DashboardSnapshot(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsWebServer()
+	args.AlarmsStatusHandler = createDashboardStatusHandler()
+	ws, _ := NewWebServer(args)
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/dashboard/snapshot", ws.Address()), testToken)
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+
+	status, _ = doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/dashboard/snapshot", ws.Address()), "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, response := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/dashboard/snapshot", ws.Address()), testToken)
+	assert.Equal(t, http.StatusOK, status)
+
+	buff, _ := json.Marshal(response.Data)
+	snapshot := dashboardSnapshot{}
+	_ = json.Unmarshal(buff, &snapshot)
+	assert.Equal(t, "alarm", snapshot.Alarms[0].Identifier)
+	assert.Equal(t, 100.0, snapshot.History["alarm"][0].Metrics["rating"])
+	assert.Equal(t, uint64(1), snapshot.Notifiers[0].NumSent)
+}
+
+func TestWebServer_DashboardEvents(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsWebServer()
+	args.AlarmsStatusHandler = createDashboardStatusHandler()
+	ws, _ := NewWebServer(args)
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/dashboard/events?access_token=test%%20token", ws.Address()))
+	require.Nil(t, err)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	for i := 0; i < 2; i++ {
+		event, payload := readEvent(t, reader)
+		assert.Equal(t, snapshotEvent, event)
+
+		snapshot := dashboardSnapshot{}
+		err = json.Unmarshal([]byte(payload), &snapshot)
+		assert.Nil(t, err)
+		assert.Equal(t, "alarm", snapshot.Alarms[0].Identifier)
+	}
+
+	closeStart := time.Now()
+	_ = ws.Close()
+	assert.True(t, time.Since(closeStart) < shutdownTimeout, "open streams should not block the shutdown")
+
+	_, err = ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+type responseCode string
+
+const (
+	codeSuccess       responseCode = "successful"
+	codeBadRequest    responseCode = "bad_request"
+	codeUnauthorized  responseCode = "unauthorized"
+	codeNotFound      responseCode = "not_found"
+	codeInternalIssue responseCode = "internal_issue"
+)
+
+type genericApiResponse struct {
+	Data  interface{}  `json:"data"`
+	Error string       `json:"error"`
+	Code  responseCode `json:"code"`
+}
+
+type alarmInfoResponse struct {
+	Identifier string `json:"identifier"`
+	Info       string `json:"info"`
+}
+
+type dashboardSnapshot struct {
+	Timestamp time.Time                           `json:"timestamp"`
+	Alarms    []data.AlarmStatus                  `json:"alarms"`
+	History   map[string][]data.AlarmHistoryPoint `json:"history"`
+	Notifiers []data.NotifierStatus               `json:"notifiers"`
+}
+
+type droppedEventsResponse struct {
+	NumDropped uint64 `json:"numDropped"`
+}
@@ -0,0 +1,14 @@
+package api
+
+import "errors"
+
+var errNilAlarmsStatusHandler = errors.New("nil alarms status handler")
+var errNilControlHandler = errors.New("nil control handler")
+var errNilConfigReloader = errors.New("nil config reloader")
+var errNilEventsSubscriber = errors.New("nil events subscriber")
+var errEmptyBearerToken = errors.New("empty bearer token")
+var errUnauthorized = errors.New("unauthorized")
+var errMethodNotAllowed = errors.New("method not allowed")
+var errInvalidPath = errors.New("invalid path")
+var errStreamingNotSupported = errors.New("streaming not supported")
+var errInvalidValue = errors.New("invalid value")
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/events"
+)
+
+const (
+	eventsPath        = "/events"
+	levelParam        = "level"
+	identifierParam   = "identifier"
+	droppedEvent      = "dropped"
+	keepAliveInterval = time.Second * 15
+)
+
+var knownLevels = []data.EventLevel{data.NoEvent, data.Info, data.Error}
+
+// handleEvents will stream the alarm responses and the alarm state transitions as server-sent events. The events
+// can be filtered using the level and identifier query parameters, both accepting multiple or comma separated values.
+// A slow consumer will not block the polling: the events that do not fit in its buffer are dropped and a dropped
+// event holding the number of lost events is sent before the next delivered event
+func (ws *webServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseEventsFilter(r.URL.Query())
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, nil, err)
+		return
+	}
+
+	flusher, err := prepareEventStream(w)
+	if err != nil {
+		writeResponse(w, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	subscription := ws.eventsSubscriber.Subscribe(filter)
+	defer ws.eventsSubscriber.Unsubscribe(subscription)
+
+	log.Debug("events stream opened", "remote address", r.RemoteAddr, "levels", filter.Levels, "identifiers", filter.Identifiers)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ws.closing:
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+
+			err = writeDroppedEvent(w, flusher, subscription)
+			if err == nil {
+				err = writeEvent(w, flusher, string(event.Type), event)
+			}
+		case <-time.After(keepAliveInterval):
+			err = writeDroppedEvent(w, flusher, subscription)
+			if err == nil {
+				err = writeKeepAlive(w, flusher)
+			}
+		}
+
+		if err != nil {
+			log.Debug("events stream closed", "remote address", r.RemoteAddr, "error", err.Error())
+			return
+		}
+	}
+}
+
+func parseEventsFilter(values url.Values) (events.Filter, error) {
+	filter := events.Filter{
+		Identifiers: splitValues(values[identifierParam]),
+	}
+
+	for _, value := range splitValues(values[levelParam]) {
+		level, err := parseLevel(value)
+		if err != nil {
+			return events.Filter{}, err
+		}
+
+		filter.Levels = append(filter.Levels, level)
+	}
+
+	return filter, nil
+}
+
+func splitValues(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if len(part) > 0 {
+				result = append(result, part)
+			}
+		}
+	}
+
+	return result
+}
+
+func parseLevel(value string) (data.EventLevel, error) {
+	for _, level := range knownLevels {
+		if strings.EqualFold(string(level), value) {
+			return level, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w for %s query parameter: %s", errInvalidValue, levelParam, value)
+}
+
+func writeDroppedEvent(w http.ResponseWriter, flusher http.Flusher, subscription *events.Subscription) error {
+	numDropped := subscription.TakeNumDropped()
+	if numDropped == 0 {
+		return nil
+	}
+
+	return writeEvent(w, flusher, droppedEvent, droppedEventsResponse{NumDropped: numDropped})
+}
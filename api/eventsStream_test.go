@@ -0,0 +1,124 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEventsFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty values should match everything", func(t *testing.T) {
+		filter, err := parseEventsFilter(url.Values{})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(filter.Levels))
+		assert.Equal(t, 0, len(filter.Identifiers))
+	})
+	t.Run("unknown level should error", func(t *testing.T) {
+		_, err := parseEventsFilter(url.Values{levelParam: []string{"Warning"}})
+		assert.True(t, errors.Is(err, errInvalidValue))
+	})
+	t.Run("should accept multiple and comma separated values", func(t *testing.T) {
+		values := url.Values{
+			levelParam:      []string{"error,info"},
+			identifierParam: []string{"alarm 1, alarm 2", "alarm 3"},
+		}
+
+		filter, err := parseEventsFilter(values)
+		assert.Nil(t, err)
+		assert.Equal(t, []data.EventLevel{data.Error, data.Info}, filter.Levels)
+		assert.Equal(t, []string{"alarm 1", "alarm 2", "alarm 3"}, filter.Identifiers)
+	})
+}
+
+func TestWriteDroppedEvent(t *testing.T) {
+	t.Parallel()
+
+	broker, _ := events.NewEventsBroker(1)
+	subscription := broker.Subscribe(events.Filter{})
+	recorder := httptest.NewRecorder()
+
+	err := writeDroppedEvent(recorder, recorder, subscription)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, recorder.Body.Len())
+
+	for i := 0; i < 3; i++ {
+		broker.Publish(data.AlarmEvent{Identifier: "alarm"})
+	}
+	err = writeDroppedEvent(recorder, recorder, subscription)
+	assert.Nil(t, err)
+	assert.Equal(t, "event: dropped\ndata: {\"numDropped\":2}\n\n", recorder.Body.String())
+}
+
+func TestWebServer_Events(t *testing.T) {
+	t.Parallel()
+
+	broker, _ := events.NewEventsBroker(10)
+	args := createMockArgsWebServer()
+	args.EventsSubscriber = broker
+	ws, _ := NewWebServer(args)
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	t.Run("invalid method should error", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/events", ws.Address()), testToken)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+	})
+	t.Run("unauthorized should error", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/events", ws.Address()), "")
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+	t.Run("invalid level should error", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/events?level=warning", ws.Address()), testToken)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.True(t, strings.Contains(response.Error, errInvalidValue.Error()))
+	})
+	t.Run("should stream the filtered events", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("http://%s/events?level=Error&identifier=alarm&access_token=test%%20token", ws.Address()))
+		require.Nil(t, err)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		for broker.NumSubscribers() == 0 {
+			time.Sleep(time.Millisecond * 10)
+		}
+
+		broker.Publish(data.AlarmEvent{Type: data.ResponseEvent, Identifier: "alarm", Level: data.Info})
+		broker.Publish(data.AlarmEvent{Type: data.ResponseEvent, Identifier: "other alarm", Level: data.Error})
+		broker.Publish(data.AlarmEvent{
+			Type:          data.StateTransitionEvent,
+			Identifier:    "alarm",
+			Level:         data.Error,
+			PreviousState: data.StateOk,
+			State:         data.StateAlerting,
+		})
+
+		reader := bufio.NewReader(resp.Body)
+		event, payload := readEvent(t, reader)
+		assert.Equal(t, string(data.StateTransitionEvent), event)
+
+		alarmEvent := data.AlarmEvent{}
+		err = json.Unmarshal([]byte(payload), &alarmEvent)
+		assert.Nil(t, err)
+		assert.Equal(t, "alarm", alarmEvent.Identifier)
+		assert.Equal(t, data.StateAlerting, alarmEvent.State)
+
+		_ = resp.Body.Close()
+		for broker.NumSubscribers() > 0 {
+			time.Sleep(time.Millisecond * 10)
+		}
+	})
+}
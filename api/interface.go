@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/events"
+)
+
+// AlarmsStatusHandler defines the operations of a component able to provide the alarms status
+type AlarmsStatusHandler interface {
+	AlarmsStatus() []data.AlarmStatus
+	AlarmsHistory() map[string][]data.AlarmHistoryPoint
+	NotifiersStatus() []data.NotifierStatus
+	QueryAlarmInfo(ctx context.Context, identifier string) (string, error)
+	IsInterfaceNil() bool
+}
+
+// ControlHandler defines the operations of a component able to control the alarms at runtime
+type ControlHandler interface {
+	PauseAlarm(identifier string) error
+	ResumeAlarm(identifier string) error
+	AcknowledgeAlarm(identifier string) error
+	SilenceAlarm(identifier string, duration time.Duration) error
+	TriggerAlarmQuery(ctx context.Context, identifier string) (data.AlarmResponse, error)
+	TriggerInfoMessage(ctx context.Context) data.AlarmResponse
+	IsInterfaceNil() bool
+}
+
+// ConfigReloader defines the operations of a component able to reload the configuration
+type ConfigReloader interface {
+	Reload() error
+	IsInterfaceNil() bool
+}
+
+// EventsSubscriber defines the operations of a component able to register subscribers for the alarm events
+type EventsSubscriber interface {
+	Subscribe(filter events.Filter) *events.Subscription
+	Unsubscribe(subscription *events.Subscription)
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// prepareEventStream will write the server-sent events headers and will return the flusher used to push the events
+func prepareEventStream(w http.ResponseWriter) (http.Flusher, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errStreamingNotSupported
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return flusher, nil
+}
+
+func writeEvent(w http.ResponseWriter, flusher http.Flusher, event string, data interface{}) error {
+	buff, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, buff)
+	if err != nil {
+		return err
+	}
+	flusher.Flush()
+
+	return nil
+}
+
+func writeKeepAlive(w http.ResponseWriter, flusher http.Flusher) error {
+	_, err := fmt.Fprint(w, ": keep-alive\n\n")
+	if err != nil {
+		return err
+	}
+	flusher.Flush()
+
+	return nil
+}
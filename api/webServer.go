@@ -0,0 +1,333 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+var log = logger.GetOrCreate("api")
+
+const (
+	alarmsPath        = "/alarms"
+	reportPath        = "/report"
+	reloadPath        = "/reload"
+	infoAction        = "info"
+	pauseAction       = "pause"
+	resumeAction      = "resume"
+	queryAction       = "query"
+	ackAction         = "ack"
+	silenceAction     = "silence"
+	bearerPrefix      = "Bearer "
+	accessTokenParam  = "access_token"
+	readHeaderTimeout = time.Second * 5
+	shutdownTimeout   = time.Second * 5
+
+	minDashboardRefreshInterval = time.Second
+)
+
+// ArgsWebServer represents the arguments DTO for the webServer constructor
+type ArgsWebServer struct {
+	NetworkAddress           string
+	BearerToken              string
+	AuditLogFile             string
+	DashboardRefreshInterval time.Duration
+	AlarmsStatusHandler      AlarmsStatusHandler
+	ControlHandler           ControlHandler
+	ConfigReloader           ConfigReloader
+	EventsSubscriber         EventsSubscriber
+}
+
+type webServer struct {
+	bearerToken              string
+	dashboardRefreshInterval time.Duration
+	alarmsStatusHandler      AlarmsStatusHandler
+	controlHandler           ControlHandler
+	configReloader           ConfigReloader
+	eventsSubscriber         EventsSubscriber
+	audit                    *auditTrail
+	listener                 net.Listener
+	server                   *http.Server
+	closing                  chan struct{}
+	closeOnce                sync.Once
+}
+
+// NewWebServer creates a new web server instance that will start serving the REST API requests
+func NewWebServer(args ArgsWebServer) (*webServer, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboardFilesHandler, err := createDashboardFilesHandler()
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := newAuditTrail(args.AuditLogFile)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", args.NetworkAddress)
+	if err != nil {
+		_ = audit.close()
+		return nil, err
+	}
+
+	ws := &webServer{
+		bearerToken:              args.BearerToken,
+		dashboardRefreshInterval: args.DashboardRefreshInterval,
+		alarmsStatusHandler:      args.AlarmsStatusHandler,
+		controlHandler:           args.ControlHandler,
+		configReloader:           args.ConfigReloader,
+		eventsSubscriber:         args.EventsSubscriber,
+		audit:                    audit,
+		listener:                 listener,
+		closing:                  make(chan struct{}),
+	}
+	ws.server = &http.Server{
+		Handler:           ws.createRouter(dashboardFilesHandler),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go ws.serve()
+
+	return ws, nil
+}
+
+func checkArgs(args ArgsWebServer) error {
+	if len(args.BearerToken) == 0 {
+		return errEmptyBearerToken
+	}
+	if args.DashboardRefreshInterval < minDashboardRefreshInterval {
+		return fmt.Errorf("%w for DashboardRefreshInterval, provided: %v, minimum: %v", errInvalidValue, args.DashboardRefreshInterval, minDashboardRefreshInterval)
+	}
+	if check.IfNil(args.AlarmsStatusHandler) {
+		return errNilAlarmsStatusHandler
+	}
+	if check.IfNil(args.ControlHandler) {
+		return errNilControlHandler
+	}
+	if check.IfNil(args.ConfigReloader) {
+		return errNilConfigReloader
+	}
+	if check.IfNil(args.EventsSubscriber) {
+		return errNilEventsSubscriber
+	}
+
+	return nil
+}
+
+func (ws *webServer) serve() {
+	log.Debug("web server started", "address", ws.Address())
+
+	err := ws.server.Serve(ws.listener)
+	if err != nil && err != http.ErrServerClosed {
+		log.Error("web server stopped unexpectedly", "error", err.Error())
+		return
+	}
+
+	log.Debug("web server stopped")
+}
+
+func (ws *webServer) createRouter(dashboardFilesHandler http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(alarmsPath, ws.authorize(ws.handleAlarms))
+	mux.HandleFunc(alarmsPath+"/", ws.authorize(ws.handleAlarm))
+	mux.HandleFunc(reportPath, ws.authorize(ws.handleReport))
+	mux.HandleFunc(reloadPath, ws.authorize(ws.handleReload))
+	mux.HandleFunc(eventsPath, ws.authorizeStream(ws.handleEvents))
+	mux.Handle(dashboardPath, dashboardFilesHandler)
+	mux.HandleFunc(dashboardSnapshotPath, ws.authorize(ws.handleDashboardSnapshot))
+	mux.HandleFunc(dashboardEventsPath, ws.authorizeStream(ws.handleDashboardEvents))
+
+	return mux
+}
+
+// authorize will check the bearer token provided in the Authorization header
+func (ws *webServer) authorize(handler http.HandlerFunc) http.HandlerFunc {
+	return ws.authorizeRequest(handler, false)
+}
+
+// authorizeStream will check the bearer token of the server-sent events requests. As the browsers can not set
+// headers on these requests, the token can also be provided in the access_token query parameter of the GET requests
+func (ws *webServer) authorizeStream(handler http.HandlerFunc) http.HandlerFunc {
+	return ws.authorizeRequest(handler, true)
+}
+
+func (ws *webServer) authorizeRequest(handler http.HandlerFunc, acceptsAccessToken bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := ""
+		if acceptsAccessToken && r.Method == http.MethodGet {
+			token = r.URL.Query().Get(accessTokenParam)
+		}
+		authorization := r.Header.Get("Authorization")
+		if strings.HasPrefix(authorization, bearerPrefix) {
+			token = strings.TrimPrefix(authorization, bearerPrefix)
+		}
+
+		isAuthorized := len(token) > 0 && subtle.ConstantTimeCompare([]byte(token), []byte(ws.bearerToken)) == 1
+		if !isAuthorized {
+			log.Debug("unauthorized API request", "remote address", r.RemoteAddr, "path", r.URL.Path)
+			writeResponse(w, http.StatusUnauthorized, nil, errUnauthorized)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// handleAlarms will respond to the GET /alarms requests
+func (ws *webServer) handleAlarms(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, ws.alarmsStatusHandler.AlarmsStatus(), nil)
+}
+
+// handleAlarm will respond to the /alarms/{identifier}/{action} requests
+func (ws *webServer) handleAlarm(w http.ResponseWriter, r *http.Request) {
+	identifier, action, err := parseAlarmPath(r.URL)
+	if err != nil {
+		writeResponse(w, http.StatusNotFound, nil, err)
+		return
+	}
+
+	switch action {
+	case infoAction:
+		ws.handleAlarmInfo(w, r, identifier)
+	case pauseAction:
+		ws.handleAlarmPause(w, r, identifier)
+	case resumeAction:
+		ws.handleAlarmResume(w, r, identifier)
+	case queryAction:
+		ws.handleAlarmQuery(w, r, identifier)
+	case ackAction:
+		ws.handleAlarmAck(w, r, identifier)
+	case silenceAction:
+		ws.handleAlarmSilence(w, r, identifier)
+	default:
+		writeResponse(w, http.StatusNotFound, nil, fmt.Errorf("%w, unknown action %s", errInvalidPath, action))
+	}
+}
+
+func (ws *webServer) handleAlarmInfo(w http.ResponseWriter, r *http.Request, identifier string) {
+	if r.Method != http.MethodGet {
+		writeResponse(w, http.StatusMethodNotAllowed, nil, errMethodNotAllowed)
+		return
+	}
+
+	info, err := ws.alarmsStatusHandler.QueryAlarmInfo(r.Context(), identifier)
+	if err != nil {
+		writeResponse(w, statusFromError(err), nil, err)
+		return
+	}
+
+	response := alarmInfoResponse{
+		Identifier: identifier,
+		Info:       info,
+	}
+	writeResponse(w, http.StatusOK, response, nil)
+}
+
+// parseAlarmPath will split a path like /alarms/{identifier}/{action} in its components. The identifier
+// should be URL encoded as it might contain spaces or slashes
+func parseAlarmPath(u *url.URL) (string, string, error) {
+	path := strings.TrimPrefix(u.EscapedPath(), alarmsPath+"/")
+	idx := strings.LastIndex(path, "/")
+	if idx <= 0 {
+		return "", "", fmt.Errorf("%w %s", errInvalidPath, u.Path)
+	}
+
+	identifier, err := url.PathUnescape(path[:idx])
+	if err != nil {
+		return "", "", fmt.Errorf("%w %s: %s", errInvalidPath, u.Path, err.Error())
+	}
+
+	return identifier, path[idx+1:], nil
+}
+
+func statusFromError(err error) int {
+	if errors.Is(err, poll.ErrAlarmNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, poll.ErrAlarmNotAlerting) {
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
+func writeResponse(w http.ResponseWriter, status int, data interface{}, err error) {
+	response := genericApiResponse{
+		Data: data,
+		Code: codeFromStatus(status),
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	errEncode := json.NewEncoder(w).Encode(response)
+	if errEncode != nil {
+		log.Warn("error writing API response", "error", errEncode.Error())
+	}
+}
+
+func codeFromStatus(status int) responseCode {
+	switch status {
+	case http.StatusOK:
+		return codeSuccess
+	case http.StatusUnauthorized:
+		return codeUnauthorized
+	case http.StatusNotFound:
+		return codeNotFound
+	case http.StatusInternalServerError:
+		return codeInternalIssue
+	default:
+		return codeBadRequest
+	}
+}
+
+// Address returns the address the web server listens on
+func (ws *webServer) Address() string {
+	return ws.listener.Addr().String()
+}
+
+// Close will stop the web server
+func (ws *webServer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	ws.closeOnce.Do(func() {
+		close(ws.closing)
+	})
+	err := ws.server.Shutdown(ctx)
+	errClose := ws.audit.close()
+	if err != nil {
+		return err
+	}
+
+	return errClose
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ws *webServer) IsInterfaceNil() bool {
+	return ws == nil
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/events"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/poll"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "test token"
+
+func createMockArgsWebServer() ArgsWebServer {
+	return ArgsWebServer{
+		NetworkAddress:           "127.0.0.1:0",
+		BearerToken:              testToken,
+		DashboardRefreshInterval: time.Second,
+		AlarmsStatusHandler:      &mocks.AlarmsStatusHandlerStub{},
+		ControlHandler:           &mocks.ControlHandlerStub{},
+		ConfigReloader:           &mocks.ConfigReloaderStub{},
+		EventsSubscriber:         createEventsBroker(),
+	}
+}
+
+func createEventsBroker() EventsSubscriber {
+	broker, _ := events.NewEventsBroker(10)
+
+	return broker
+}
+
+func doRequest(t *testing.T, method string, url string, token string) (int, genericApiResponse) {
+	req, err := http.NewRequest(method, url, nil)
+	require.Nil(t, err)
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	buff, err := ioutil.ReadAll(resp.Body)
+	require.Nil(t, err)
+
+	response := genericApiResponse{}
+	err = json.Unmarshal(buff, &response)
+	require.Nil(t, err)
+
+	return resp.StatusCode, response
+}
+
+func TestNewWebServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty bearer token should error", func(t *testing.T) {
+		args := createMockArgsWebServer()
+		args.BearerToken = ""
+
+		ws, err := NewWebServer(args)
+		assert.True(t, check.IfNil(ws))
+		assert.Equal(t, errEmptyBearerToken, err)
+	})
+	t.Run("invalid dashboard refresh interval should error", func(t *testing.T) {
+		args := createMockArgsWebServer()
+		args.DashboardRefreshInterval = time.Second - time.Nanosecond
+
+		ws, err := NewWebServer(args)
+		assert.True(t, check.IfNil(ws))
+		assert.True(t, errors.Is(err, errInvalidValue))
+	})
+	t.Run("nil alarms status handler should error", func(t *testing.T) {
+		args := createMockArgsWebServer()
+		args.AlarmsStatusHandler = nil
+
+		ws, err := NewWebServer(args)
+		assert.True(t, check.IfNil(ws))
+		assert.Equal(t, errNilAlarmsStatusHandler, err)
+	})
+	t.Run("nil control handler should error", func(t *testing.T) {
+		args := createMockArgsWebServer()
+		args.ControlHandler = nil
+
+		ws, err := NewWebServer(args)
+		assert.True(t, check.IfNil(ws))
+		assert.Equal(t, errNilControlHandler, err)
+	})
+	t.Run("nil config reloader should error", func(t *testing.T) {
+		args := createMockArgsWebServer()
+		args.ConfigReloader = nil
+
+		ws, err := NewWebServer(args)
+		assert.True(t, check.IfNil(ws))
+		assert.Equal(t, errNilConfigReloader, err)
+	})
+	t.Run("nil events subscriber should error", func(t *testing.T) {
+		args := createMockArgsWebServer()
+		args.EventsSubscriber = nil
+
+		ws, err := NewWebServer(args)
+		assert.True(t, check.IfNil(ws))
+		assert.Equal(t, errNilEventsSubscriber, err)
+	})
+	t.Run("invalid audit file should error", func(t *testing.T) {
+		args := createMockArgsWebServer()
+		args.AuditLogFile = filepath.Join(t.TempDir(), "missing", "audit.log")
+
+		ws, err := NewWebServer(args)
+		assert.True(t, check.IfNil(ws))
+		assert.NotNil(t, err)
+	})
+	t.Run("invalid address should error", func(t *testing.T) {
+		args := createMockArgsWebServer()
+		args.NetworkAddress = "invalid address"
+
+		ws, err := NewWebServer(args)
+		assert.True(t, check.IfNil(ws))
+		assert.NotNil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		args := createMockArgsWebServer()
+
+		ws, err := NewWebServer(args)
+		assert.False(t, check.IfNil(ws))
+		assert.Nil(t, err)
+
+		assert.Nil(t, ws.Close())
+	})
+}
+
+func TestWebServer_Authorization(t *testing.T) {
+	t.Parallel()
+
+	ws, _ := NewWebServer(createMockArgsWebServer())
+	defer func() {
+		_ = ws.Close()
+	}()
+	url := fmt.Sprintf("http://%s/alarms", ws.Address())
+
+	t.Run("missing token should return unauthorized", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodGet, url, "")
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, codeUnauthorized, response.Code)
+		assert.Equal(t, errUnauthorized.Error(), response.Error)
+	})
+	t.Run("wrong token should return unauthorized", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodGet, url, "wrong token")
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, codeUnauthorized, response.Code)
+	})
+	t.Run("correct token should work", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodGet, url, testToken)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, codeSuccess, response.Code)
+	})
+	t.Run("wrong access token query parameter should return unauthorized", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodGet, url+"?access_token=wrong", "")
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+	t.Run("access token query parameter should not be accepted outside the events streams", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodGet, url+"?access_token=test%20token", "")
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+	t.Run("access token query parameter should not be accepted on the events streams non GET requests", func(t *testing.T) {
+		eventsUrl := fmt.Sprintf("http://%s/events?access_token=test%%20token", ws.Address())
+		status, _ := doRequest(t, http.MethodPost, eventsUrl, "")
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+}
+
+func TestWebServer_Alarms(t *testing.T) {
+	t.Parallel()
+
+	queryTime := time.Date(2022, 07, 06, 12, 0, 0, 0, time.UTC)
+	args := createMockArgsWebServer()
+	args.AlarmsStatusHandler = &mocks.AlarmsStatusHandlerStub{
+		AlarmsStatusCalled: func() []data.AlarmStatus {
+			return []data.AlarmStatus{
+				{
+					Identifier:    "alarm 1",
+					State:         data.StateAlerting,
+					LastQueryTime: queryTime,
+					LastResponse: data.AlarmResponse{
+						Identifier: "alarm 1",
+						Level:      data.Error,
+						Data:       "message",
+					},
+					NextQueryTime: queryTime.Add(time.Minute),
+				},
+				{
+					Identifier: "alarm 2",
+					State:      data.StatePending,
+				},
+			}
+		},
+	}
+	ws, _ := NewWebServer(args)
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	t.Run("wrong method should error", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms", ws.Address()), testToken)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+		assert.Equal(t, errMethodNotAllowed.Error(), response.Error)
+	})
+	t.Run("should work", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms", ws.Address()), testToken)
+		assert.Equal(t, http.StatusOK, status)
+
+		buff, _ := json.Marshal(response.Data)
+		statuses := make([]data.AlarmStatus, 0)
+		err := json.Unmarshal(buff, &statuses)
+		assert.Nil(t, err)
+		assert.Equal(t, args.AlarmsStatusHandler.AlarmsStatus(), statuses)
+	})
+}
+
+func TestWebServer_AlarmInfo(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsWebServer()
+	args.AlarmsStatusHandler = &mocks.AlarmsStatusHandlerStub{
+		QueryAlarmInfoCalled: func(ctx context.Context, identifier string) (string, error) {
+			switch identifier {
+			case "testnet - rating":
+				return "all good", nil
+			case "failing":
+				return "", fmt.Errorf("expected error")
+			default:
+				return "", fmt.Errorf("%w for identifier %s", poll.ErrAlarmNotFound, identifier)
+			}
+		},
+	}
+	ws, _ := NewWebServer(args)
+	defer func() {
+		_ = ws.Close()
+	}()
+
+	t.Run("unknown action should error", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms/alarm/unknown", ws.Address()), testToken)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, codeNotFound, response.Code)
+	})
+	t.Run("missing identifier should error", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms/info", ws.Address()), testToken)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, codeNotFound, response.Code)
+	})
+	t.Run("wrong method should error", func(t *testing.T) {
+		status, _ := doRequest(t, http.MethodPost, fmt.Sprintf("http://%s/alarms/alarm/info", ws.Address()), testToken)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+	})
+	t.Run("unknown alarm should return not found", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms/missing/info", ws.Address()), testToken)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Contains(t, response.Error, poll.ErrAlarmNotFound.Error())
+	})
+	t.Run("query info errors should return internal issue", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms/failing/info", ws.Address()), testToken)
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.Equal(t, codeInternalIssue, response.Code)
+		assert.Equal(t, "expected error", response.Error)
+	})
+	t.Run("should work with URL encoded identifier", func(t *testing.T) {
+		status, response := doRequest(t, http.MethodGet, fmt.Sprintf("http://%s/alarms/testnet%%20-%%20rating/info", ws.Address()), testToken)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, map[string]interface{}{"identifier": "testnet - rating", "info": "all good"}, response.Data)
+	})
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/urfave/cli"
+)
+
+const controlRequestTimeout = time.Minute
+
+var errMissingIdentifier = errors.New("missing alarm identifier argument")
+
+type apiResponse struct {
+	Data  json.RawMessage `json:"data"`
+	Error string          `json:"error"`
+	Code  string          `json:"code"`
+}
+
+func createControlCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "status",
+			Usage:  "lists the current status of all alarms",
+			Action: createControlAction(http.MethodGet, func(_ *cli.Context) (string, error) { return "/alarms", nil }),
+		},
+		{
+			Name:      "pause",
+			Usage:     "pauses the alarm with the provided identifier",
+			ArgsUsage: "<identifier>",
+			Action:    createControlAction(http.MethodPost, alarmPath("pause")),
+		},
+		{
+			Name:      "resume",
+			Usage:     "resumes the alarm with the provided identifier",
+			ArgsUsage: "<identifier>",
+			Action:    createControlAction(http.MethodPost, alarmPath("resume")),
+		},
+		{
+			Name:      "query",
+			Usage:     "queries the alarm with the provided identifier right away and notifies the result",
+			ArgsUsage: "<identifier>",
+			Action:    createControlAction(http.MethodPost, alarmPath("query")),
+		},
+		{
+			Name:   "report",
+			Usage:  "sends the info report right away",
+			Action: createControlAction(http.MethodPost, func(_ *cli.Context) (string, error) { return "/report", nil }),
+		},
+		{
+			Name:   "reload",
+			Usage:  "reloads the configuration file of the running process",
+			Action: createControlAction(http.MethodPost, func(_ *cli.Context) (string, error) { return "/reload", nil }),
+		},
+	}
+}
+
+func alarmPath(action string) func(ctx *cli.Context) (string, error) {
+	return func(ctx *cli.Context) (string, error) {
+		identifier := ctx.Args().First()
+		if len(identifier) == 0 {
+			return "", errMissingIdentifier
+		}
+
+		return fmt.Sprintf("/alarms/%s/%s", url.PathEscape(identifier), action), nil
+	}
+}
+
+func createControlAction(method string, pathHandler func(ctx *cli.Context) (string, error)) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		path, err := pathHandler(ctx)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.LoadConfig(ctx.GlobalString(configFile.Name))
+		if err != nil {
+			return err
+		}
+
+		response, err := callControlEndpoint(method, fmt.Sprintf("http://%s%s", cfg.Api.NetworkAddress, path), cfg.Api.BearerToken)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(response))
+
+		return nil
+	}
+}
+
+func callControlEndpoint(method string, endpoint string, token string) ([]byte, error) {
+	req, err := http.NewRequest(method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := &http.Client{Timeout: controlRequestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	buff, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	response := apiResponse{}
+	err = json.Unmarshal(buff, &response)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding the response with status %s", err, resp.Status)
+	}
+	if len(response.Error) > 0 {
+		return nil, fmt.Errorf("%s: %s", response.Code, response.Error)
+	}
+
+	return json.MarshalIndent(response.Data, "", "  ")
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/api"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+// PollingHandler defines the operations of the polling handler used by the main process
+type PollingHandler interface {
+	api.AlarmsStatusHandler
+	api.ControlHandler
+	AcknowledgeAlarm(identifier string) error
+	SilenceAlarm(identifier string, duration time.Duration) error
+	UpdateComponents(alarms []poll.AlarmHandler, notifiers []poll.NotifierHandler) error
+	Close() error
+}
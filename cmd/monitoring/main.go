@@ -1,5 +1,190 @@
 package main
 
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/api"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/events"
+	"github.com/iulianpascalau/node-monitoring/factory"
+	"github.com/iulianpascalau/node-monitoring/poll"
+	"github.com/iulianpascalau/node-monitoring/reload"
+	"github.com/urfave/cli"
+)
+
+var log = logger.GetOrCreate("main")
+
+var (
+	configFile = cli.StringFlag{
+		Name:  "config",
+		Usage: "The path for the main configuration file",
+		Value: "./config/config.toml",
+	}
+	logLevel = cli.StringFlag{
+		Name: "log-level",
+		Usage: "This flag specifies the logger `level(s)`. It can contain multiple comma-separated value. For example" +
+			", if set to *:INFO the logs for all packages will have the INFO level. However, if set to *:INFO,api:DEBUG" +
+			" the logs for all packages will have the INFO level, excepting the api package which will receive a DEBUG" +
+			" log level.",
+		Value: "*:" + logger.LogInfo.String(),
+	}
+)
+
 func main() {
+	app := cli.NewApp()
+	app.Name = "Node monitoring tool"
+	app.Usage = "Monitors the Elrond nodes and sends notifications when something goes wrong"
+	app.Flags = []cli.Flag{
+		configFile,
+		logLevel,
+	}
+	app.Action = startMonitoring
+	app.Commands = append(createControlCommands(), createPreviewCommand())
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func startMonitoring(ctx *cli.Context) error {
+	err := logger.SetLogLevel(ctx.GlobalString(logLevel.Name))
+	if err != nil {
+		return err
+	}
+
+	configPath := ctx.GlobalString(configFile.Name)
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	components, err := factory.CreateComponents(cfg)
+	if err != nil {
+		return err
+	}
+
+	eventsBroker, err := events.NewEventsBroker(cfg.Api.EventsBufferSize)
+	if err != nil {
+		return err
+	}
+
+	pollingHandler, err := createPollingHandler(cfg, components, eventsBroker)
+	if err != nil {
+		return err
+	}
+
+	closers := []io.Closer{pollingHandler}
+	defer func() {
+		log.Info("application closing, calling Close on all subcomponents...")
+		// the components are closed in the reverse order of their creation so none of them is closed while still
+		// being used by a component created after it (e.g. the polling handler by the web server or the Telegram commands)
+		for i := len(closers) - 1; i >= 0; i-- {
+			errClose := closers[i].Close()
+			if errClose != nil {
+				log.Warn("error closing component", "error", errClose.Error())
+			}
+		}
+	}()
+
+	commandsHandlers, err := factory.CreateTelegramCommandsHandlers(cfg.Notifiers, pollingHandler)
+	if err != nil {
+		return err
+	}
+	closers = append(closers, commandsHandlers...)
+
+	configReloader, err := reload.NewConfigReloader(reload.ArgsConfigReloader{
+		ConfigPath:        configPath,
+		Config:            cfg,
+		Components:        components,
+		ComponentsUpdater: pollingHandler,
+	})
+	if err != nil {
+		return err
+	}
+	// the reloader closes the alarms and notifiers in use, such as the syslog connections and the opened files, so it
+	// is placed first to be closed last, after the polling handler stopped using them
+	closers = append([]io.Closer{configReloader}, closers...)
+
+	if cfg.ConfigReload.WatchFile {
+		fileWatcher, errCreate := reload.NewFileWatcher(reload.ArgsFileWatcher{
+			FilePath:      configPath,
+			CheckInterval: time.Duration(cfg.ConfigReload.WatchIntervalInSeconds) * time.Second,
+			OnChange: func() {
+				_ = configReloader.Reload()
+			},
+		})
+		if errCreate != nil {
+			return errCreate
+		}
+
+		closers = append(closers, fileWatcher)
+	}
+
+	if cfg.Api.Enabled {
+		webServer, errCreate := api.NewWebServer(api.ArgsWebServer{
+			NetworkAddress:           cfg.Api.NetworkAddress,
+			BearerToken:              cfg.Api.BearerToken,
+			AuditLogFile:             cfg.Api.AuditLogFile,
+			DashboardRefreshInterval: time.Duration(cfg.Api.DashboardRefreshIntervalInSeconds) * time.Second,
+			AlarmsStatusHandler:      pollingHandler,
+			ControlHandler:           pollingHandler,
+			ConfigReloader:           configReloader,
+			EventsSubscriber:         eventsBroker,
+		})
+		if errCreate != nil {
+			return errCreate
+		}
+
+		closers = append(closers, webServer)
+	}
+
+	log.Info("node monitoring tool started", "config", configPath)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigs {
+		if sig != syscall.SIGHUP {
+			break
+		}
+
+		log.Info("SIGHUP received, reloading the configuration")
+		_ = configReloader.Reload()
+	}
+
+	return nil
+}
+
+func createPollingHandler(cfg config.GeneralConfig, components *factory.Components, publisher poll.EventsPublisher) (PollingHandler, error) {
+	timeOfDay, err := factory.ParseTimeOfDay(cfg.InfoTimeOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	networksInfo, err := factory.CreateNetworksInfo(cfg.Networks)
+	if err != nil {
+		return nil, err
+	}
+
+	pollingHandler, err := poll.NewPollingHandler(poll.ArgsPollingHandler{
+		Alarms:         components.Alarms(),
+		Notifiers:      components.Notifiers(),
+		Publisher:      publisher,
+		SendInfo:       timeOfDay.Active,
+		SendInfoHour:   timeOfDay.Hour,
+		SendInfoMinute: timeOfDay.Minute,
+		SendInfoSecond: timeOfDay.Second,
+		NetworksInfo:   networksInfo,
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	return pollingHandler, nil
 }
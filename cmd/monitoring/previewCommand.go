@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/factory"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/urfave/cli"
+)
+
+var errInvalidSampleLevel = errors.New("invalid sample level")
+
+var (
+	notifierType = cli.StringFlag{
+		Name: "notifier",
+		Usage: "The `type` of the notifier whose templates are previewed (e.g. Slack). Without it, the built-in" +
+			" templates are previewed",
+	}
+	notifierIndex = cli.IntFlag{
+		Name:  "index",
+		Usage: "The `index` of the notifier among the ones of the same type defined in the configuration file",
+	}
+	sampleLevel = cli.StringFlag{
+		Name:  "level",
+		Usage: "The `level` of the built-in sample response: Error or Info (the info report)",
+		Value: string(data.Error),
+	}
+	sampleFile = cli.StringFlag{
+		Name:  "sample",
+		Usage: "The `path` of a JSON file holding the alarm response to be rendered instead of the built-in sample",
+	}
+)
+
+func createPreviewCommand() cli.Command {
+	return cli.Command{
+		Name:   "preview",
+		Usage:  "renders the message templates of a notifier against a sample alarm response",
+		Flags:  []cli.Flag{notifierType, notifierIndex, sampleLevel, sampleFile},
+		Action: previewTemplates,
+	}
+}
+
+func previewTemplates(ctx *cli.Context) error {
+	templates := config.NotifierTemplates{}
+	if len(ctx.String(notifierType.Name)) > 0 {
+		cfg, err := config.LoadConfig(ctx.GlobalString(configFile.Name))
+		if err != nil {
+			return err
+		}
+
+		templates, err = factory.GetNotifierTemplates(cfg.Notifiers, ctx.String(notifierType.Name), ctx.Int(notifierIndex.Name))
+		if err != nil {
+			return err
+		}
+	}
+
+	response, err := loadSampleResponse(ctx.String(sampleFile.Name), data.EventLevel(ctx.String(sampleLevel.Name)))
+	if err != nil {
+		return err
+	}
+
+	renderer, err := factory.CreateMessageRenderer(templates)
+	if err != nil {
+		return err
+	}
+
+	message, err := renderer.Render(response)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Title:\n%s\n\nBody:\n%s\n\nHTML body:\n%s\n", message.Title, message.Body, message.HTMLBody)
+
+	return nil
+}
+
+func loadSampleResponse(path string, level data.EventLevel) (data.AlarmResponse, error) {
+	if len(path) == 0 {
+		if level != data.Error && level != data.Info {
+			return data.AlarmResponse{}, fmt.Errorf("%w %q, allowed: %s, %s", errInvalidSampleLevel, level, data.Error, data.Info)
+		}
+
+		return templating.SampleResponse(level), nil
+	}
+
+	buff, err := ioutil.ReadFile(path)
+	if err != nil {
+		return data.AlarmResponse{}, err
+	}
+
+	response := data.AlarmResponse{}
+	err = json.Unmarshal(buff, &response)
+	if err != nil {
+		return data.AlarmResponse{}, fmt.Errorf("%w while decoding the sample response %s", err, path)
+	}
+
+	return response, nil
+}
@@ -3,30 +3,81 @@ package config
 // GeneralConfig will hold the configs
 type GeneralConfig struct {
 	Alarms        AlarmsConfig
+	Networks      []NetworkConfig
 	Notifiers     NotifiersConfig
+	Inventory     InventoryConfig
+	Api           ApiConfig
+	ConfigReload  ConfigReloadConfig
 	InfoTimeOfDay string
 }
 
 // AlarmsConfig defines the alarms config
 type AlarmsConfig struct {
-	NodeRating []NodeRatingAlarmConfig
-	NodeNonce  []NodeNonceAlarmConfig
+	NodeRating    []NodeRatingAlarmConfig
+	NodeNonce     []NodeNonceAlarmConfig
+	KeysDiscovery []KeysDiscoveryAlarmConfig
+}
+
+// NetworkConfig defines a network profile (e.g. mainnet, testnet, devnet) grouping its alarms. The alarms missing
+// the API url, the rating threshold or the nonce difference use the network's values, their responses are labeled
+// with the network's name and are sent only to the referenced notifiers ("Slack" for all the Slack notifiers,
+// "Slack:1" for the second one). No referenced notifiers means all the notifiers. The optional InfoTimeOfDay sends
+// a separate info report for the network's alarms
+type NetworkConfig struct {
+	Name            string
+	ApiUrl          string
+	DiscoveryApiUrl string
+	RatingThreshold float64
+	NonceDifference int
+	Notifiers       []string
+	InfoTimeOfDay   string
+	Alarms          AlarmsConfig
 }
 
 // NotifiersConfig defines the implemented notifiers configs
 type NotifiersConfig struct {
-	Pushover []PushoverNotifier
+	Pushover     []PushoverNotifier
+	Telegram     []TelegramNotifier
+	Slack        []SlackNotifier
+	Discord      []DiscordNotifier
+	Email        []EmailNotifier
+	Webhook      []WebhookNotifier
+	PagerDuty    []PagerDutyNotifier
+	Opsgenie     []OpsgenieNotifier
+	Matrix       []MatrixNotifier
+	Teams        []TeamsNotifier
+	Ntfy         []NtfyNotifier
+	Gotify       []GotifyNotifier
+	Script       []ScriptNotifier
+	Syslog       []SyslogNotifier
+	File         []FileNotifier
+	SMS          []SMSNotifier
+	Alertmanager []AlertmanagerNotifier
 }
 
-// NodeRatingAlarmConfig the node rating config struct
+// NodeRatingAlarmConfig the node rating config struct. The PublicKeysPaths can hold validatorKey.pem or
+// allValidatorsKeys.pem files and directories with such files, their keys being added to the PublicKeys together
+// with the discovered ones
 type NodeRatingAlarmConfig struct {
 	Identifier           string
 	Threshold            float64
 	ApiUrl               string
 	PublicKeys           []string
+	PublicKeysPaths      []string
+	KeysDiscovery        KeysDiscoveryConfig
 	PollingTimeInSeconds int
 }
 
+// KeysDiscoveryConfig defines the delegation contracts (staking providers) and owners whose nodes' public keys are
+// discovered through the Elrond API
+type KeysDiscoveryConfig struct {
+	ApiUrl                   string
+	ContractAddresses        []string
+	OwnerAddresses           []string
+	RefreshIntervalInSeconds int
+	RequestTimeoutInSeconds  int
+}
+
 // NodeNonceAlarmConfig the node's nonce alarm config
 type NodeNonceAlarmConfig struct {
 	Identifier           string
@@ -35,8 +86,270 @@ type NodeNonceAlarmConfig struct {
 	PollingTimeInSeconds int
 }
 
+// KeysDiscoveryAlarmConfig the keys discovery alarm config, reporting the public keys added and removed from the
+// delegation contracts (staking providers) and owners
+type KeysDiscoveryAlarmConfig struct {
+	Identifier              string
+	ApiUrl                  string
+	ContractAddresses       []string
+	OwnerAddresses          []string
+	PollingTimeInSeconds    int
+	RequestTimeoutInSeconds int
+}
+
+// InventoryConfig defines the known nodes, their details being added to the alarm responses referring to them
+type InventoryConfig struct {
+	Nodes []InventoryNodeConfig
+}
+
+// InventoryNodeConfig defines a node (or a group of keys run together) by its public keys and API urls
+type InventoryNodeConfig struct {
+	Name       string
+	Owner      string
+	Provider   string
+	Tags       []string
+	PublicKeys []string
+	ApiUrls    []string
+}
+
+// NotifierTemplates defines the templates rendering a notifier's messages. The templates of a level take precedence
+// over the Default ones, the empty templates falling back to the built-in ones
+type NotifierTemplates struct {
+	Default MessageTemplate
+	Error   MessageTemplate
+	Info    MessageTemplate
+}
+
+// MessageTemplate defines the templates of a message, the HTML body being used only by the notifiers supporting it
+type MessageTemplate struct {
+	Title    string
+	Body     string
+	HTMLBody string
+}
+
 // PushoverNotifier pushover's config struct
 type PushoverNotifier struct {
 	Token string
 	User  string
 }
+
+// TelegramNotifier Telegram bot's config struct
+type TelegramNotifier struct {
+	ApiUrl                  string
+	Token                   string
+	ChatIDs                 []int64
+	RequestTimeoutInSeconds int
+	Commands                TelegramCommandsConfig
+	Templates               NotifierTemplates
+}
+
+// TelegramCommandsConfig defines the Telegram bot commands config
+type TelegramCommandsConfig struct {
+	Enabled                     bool
+	AllowedUserIDs              []int64
+	LongPollingTimeoutInSeconds int
+}
+
+// SlackNotifier Slack or Mattermost incoming webhook config struct
+type SlackNotifier struct {
+	WebhookUrl              string
+	Format                  string
+	RequestTimeoutInSeconds int
+	Templates               NotifierTemplates
+}
+
+// DiscordNotifier Discord webhook config struct
+type DiscordNotifier struct {
+	WebhookUrl              string
+	Username                string
+	MaxRetries              int
+	MaxRetryAfterInSeconds  int
+	RequestTimeoutInSeconds int
+	Templates               NotifierTemplates
+}
+
+// EmailNotifier SMTP email config struct
+type EmailNotifier struct {
+	Host                    string
+	Port                    int
+	Security                string
+	Username                string
+	Password                string
+	InsecureSkipVerify      bool
+	From                    string
+	ErrorRecipients         []string
+	InfoRecipients          []string
+	RequestTimeoutInSeconds int
+	Templates               NotifierTemplates
+}
+
+// WebhookNotifier generic templated webhook config struct
+type WebhookNotifier struct {
+	Url                     string
+	Method                  string
+	Headers                 map[string]string
+	Body                    string
+	SigningSecret           string
+	SignatureHeader         string
+	ExpectedStatusCodes     []int
+	RequestTimeoutInSeconds int
+}
+
+// PagerDutyNotifier PagerDuty Events API v2 config struct
+type PagerDutyNotifier struct {
+	EventsUrl               string
+	RoutingKey              string
+	ErrorSeverity           string
+	InfoSeverity            string
+	Source                  string
+	ResolveOnStartup        bool
+	RequestTimeoutInSeconds int
+}
+
+// OpsgenieNotifier Opsgenie Alert API config struct
+type OpsgenieNotifier struct {
+	ApiKey                  string
+	Region                  string
+	ApiUrl                  string
+	ErrorPriority           string
+	InfoPriority            string
+	CloseOnStartup          bool
+	RequestTimeoutInSeconds int
+	Templates               NotifierTemplates
+}
+
+// MatrixNotifier Matrix client-server API config struct
+type MatrixNotifier struct {
+	HomeserverUrl           string
+	AccessToken             string
+	RoomIDs                 []string
+	MaxRetries              int
+	RetryDelayInSeconds     int
+	RequestTimeoutInSeconds int
+	Templates               NotifierTemplates
+}
+
+// TeamsNotifier Microsoft Teams workflow webhook config struct
+type TeamsNotifier struct {
+	WebhookUrl              string
+	RequestTimeoutInSeconds int
+	Templates               NotifierTemplates
+}
+
+// NtfyNotifier ntfy topic config struct
+type NtfyNotifier struct {
+	ServerUrl               string
+	Topic                   string
+	Token                   string
+	Username                string
+	Password                string
+	ErrorPriority           int
+	InfoPriority            int
+	Tags                    []string
+	RequestTimeoutInSeconds int
+	Templates               NotifierTemplates
+}
+
+// GotifyNotifier Gotify application config struct
+type GotifyNotifier struct {
+	ServerUrl               string
+	AppToken                string
+	ErrorPriority           int
+	InfoPriority            int
+	RequestTimeoutInSeconds int
+	Templates               NotifierTemplates
+}
+
+// ScriptNotifier external executable config struct
+type ScriptNotifier struct {
+	Command          string
+	Args             []string
+	WorkingDirectory string
+	Environment      map[string]string
+	TimeoutInSeconds int
+}
+
+// SyslogNotifier RFC 5424 syslog config struct
+type SyslogNotifier struct {
+	Network          string
+	Address          string
+	Facility         string
+	AppName          string
+	Hostname         string
+	EnterpriseNumber int
+	TimeoutInSeconds int
+}
+
+// FileNotifier JSON lines file config struct
+type FileNotifier struct {
+	FilePath                string
+	MaxSizeInMB             int
+	RotationIntervalInHours int
+	MaxBackups              int
+	Compress                bool
+	SyncEachWrite           bool
+	IncludeNoEvent          bool
+}
+
+// SMSNotifier SMS config struct, the Provider selecting which of the Twilio or Gateway configs is used
+type SMSNotifier struct {
+	Provider                string
+	Recipients              []string
+	MaxSegments             int
+	DailyLimitPerRecipient  int
+	SendInfo                bool
+	RequestTimeoutInSeconds int
+	Twilio                  SMSTwilioConfig
+	Gateway                 SMSGatewayConfig
+	Templates               NotifierTemplates
+}
+
+// SMSTwilioConfig defines the Twilio Messages API config
+type SMSTwilioConfig struct {
+	ApiUrl              string
+	AccountSID          string
+	AuthToken           string
+	From                string
+	MessagingServiceSID string
+}
+
+// SMSGatewayConfig defines the templated HTTP SMS gateway config
+type SMSGatewayConfig struct {
+	Url                 string
+	Method              string
+	Headers             map[string]string
+	Body                string
+	From                string
+	ExpectedStatusCodes []int
+}
+
+// AlertmanagerNotifier Prometheus Alertmanager config struct
+type AlertmanagerNotifier struct {
+	Urls                    []string
+	Username                string
+	Password                string
+	AlertName               string
+	Severity                string
+	StaticLabels            map[string]string
+	GeneratorUrl            string
+	AlertTimeoutInSeconds   int
+	ResendIntervalInSeconds int
+	RequestTimeoutInSeconds int
+}
+
+// ApiConfig defines the REST API config
+type ApiConfig struct {
+	Enabled        bool
+	NetworkAddress string
+	BearerToken    string
+	AuditLogFile   string
+
+	DashboardRefreshIntervalInSeconds int
+	EventsBufferSize                  int
+}
+
+// ConfigReloadConfig defines the config reload settings
+type ConfigReloadConfig struct {
+	WatchFile              bool
+	WatchIntervalInSeconds int
+}
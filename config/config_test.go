@@ -12,10 +12,18 @@ func generateMockConfigStruct() GeneralConfig {
 	alarmsConfig := AlarmsConfig{
 		NodeRating: []NodeRatingAlarmConfig{
 			{
-				Identifier:           "testnet - rating",
-				Threshold:            1,
-				ApiUrl:               "http://testnet",
-				PublicKeys:           []string{"pk1", "pk2"},
+				Identifier:      "testnet - rating",
+				Threshold:       1,
+				ApiUrl:          "http://testnet",
+				PublicKeys:      []string{"pk1", "pk2"},
+				PublicKeysPaths: []string{"keys/validatorKey.pem", "keys/testnet"},
+				KeysDiscovery: KeysDiscoveryConfig{
+					ApiUrl:                   "https://testnet-api.elrond.com",
+					ContractAddresses:        []string{"erd1contract"},
+					OwnerAddresses:           []string{"erd1owner"},
+					RefreshIntervalInSeconds: 600,
+					RequestTimeoutInSeconds:  10,
+				},
 				PollingTimeInSeconds: 5,
 			},
 			{
@@ -40,6 +48,36 @@ func generateMockConfigStruct() GeneralConfig {
 				PollingTimeInSeconds: 4,
 			},
 		},
+		KeysDiscovery: []KeysDiscoveryAlarmConfig{
+			{
+				Identifier:              "testnet - keys",
+				ApiUrl:                  "https://testnet-api.elrond.com",
+				ContractAddresses:       []string{"erd1contract"},
+				PollingTimeInSeconds:    600,
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+	}
+
+	networksConfig := []NetworkConfig{
+		{
+			Name:            "mainnet",
+			ApiUrl:          "https://gateway.elrond.com",
+			DiscoveryApiUrl: "https://api.elrond.com",
+			RatingThreshold: 95,
+			NonceDifference: 5,
+			Notifiers:       []string{"Slack", "Telegram:1"},
+			InfoTimeOfDay:   "11:30:00",
+			Alarms: AlarmsConfig{
+				NodeRating: []NodeRatingAlarmConfig{
+					{
+						Identifier:           "mainnet - rating",
+						PublicKeys:           []string{"pubkey1"},
+						PollingTimeInSeconds: 60,
+					},
+				},
+			},
+		},
 	}
 
 	notifiersConfig := NotifiersConfig{
@@ -53,11 +91,248 @@ func generateMockConfigStruct() GeneralConfig {
 				User:  "user2",
 			},
 		},
+		Telegram: []TelegramNotifier{
+			{
+				ApiUrl:                  "https://api.telegram.org",
+				Token:                   "bot token",
+				ChatIDs:                 []int64{-1001, 1002},
+				RequestTimeoutInSeconds: 10,
+				Commands: TelegramCommandsConfig{
+					Enabled:                     true,
+					AllowedUserIDs:              []int64{1002},
+					LongPollingTimeoutInSeconds: 30,
+				},
+			},
+		},
+		Slack: []SlackNotifier{
+			{
+				WebhookUrl:              "https://hooks.slack.com/services/T000/B000/XXXX",
+				Format:                  "slack",
+				RequestTimeoutInSeconds: 10,
+			},
+			{
+				WebhookUrl:              "https://mattermost.local/hooks/xxx",
+				Format:                  "mattermost",
+				RequestTimeoutInSeconds: 5,
+				Templates: NotifierTemplates{
+					Default: MessageTemplate{
+						Title: "{{.Level}} | {{.Identifier}}",
+					},
+					Error: MessageTemplate{
+						Body: "{{.Labels.pubkey | shortenPubkey}}\n{{.Data}}",
+					},
+				},
+			},
+		},
+		Discord: []DiscordNotifier{
+			{
+				WebhookUrl:              "https://discord.com/api/webhooks/1/token",
+				Username:                "monitoring",
+				MaxRetries:              3,
+				MaxRetryAfterInSeconds:  30,
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+		Email: []EmailNotifier{
+			{
+				Host:                    "smtp.example.com",
+				Port:                    587,
+				Security:                "starttls",
+				Username:                "monitor",
+				Password:                "secret",
+				From:                    "Monitoring <monitor@example.com>",
+				ErrorRecipients:         []string{"oncall@example.com", "team@example.com"},
+				InfoRecipients:          []string{"team@example.com"},
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+		Webhook: []WebhookNotifier{
+			{
+				Url:    "https://incidents.local/api/{{.Identifier}}",
+				Method: "PUT",
+				Headers: map[string]string{
+					"X-Alarm": "{{.Identifier}}",
+				},
+				Body:                    `{"text": {{json .Data}}}`,
+				SigningSecret:           "secret",
+				SignatureHeader:         "X-Hub-Signature-256",
+				ExpectedStatusCodes:     []int{200, 201},
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+		PagerDuty: []PagerDutyNotifier{
+			{
+				EventsUrl:               "https://events.pagerduty.com/v2/enqueue",
+				RoutingKey:              "routing key",
+				ErrorSeverity:           "critical",
+				InfoSeverity:            "warning",
+				Source:                  "monitoring host",
+				ResolveOnStartup:        true,
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+		Opsgenie: []OpsgenieNotifier{
+			{
+				ApiKey:                  "team api key",
+				Region:                  "eu",
+				ErrorPriority:           "P2",
+				InfoPriority:            "P5",
+				CloseOnStartup:          true,
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+		Matrix: []MatrixNotifier{
+			{
+				HomeserverUrl:           "https://matrix.local",
+				AccessToken:             "access token",
+				RoomIDs:                 []string{"!room:matrix.local"},
+				MaxRetries:              3,
+				RetryDelayInSeconds:     2,
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+		Teams: []TeamsNotifier{
+			{
+				WebhookUrl:              "https://prod.westeurope.logic.azure.com/workflows/xxx",
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+		Ntfy: []NtfyNotifier{
+			{
+				ServerUrl:               "https://ntfy.local",
+				Topic:                   "node-alerts",
+				Token:                   "tk_token",
+				ErrorPriority:           5,
+				InfoPriority:            3,
+				Tags:                    []string{"validator"},
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+		Gotify: []GotifyNotifier{
+			{
+				ServerUrl:               "https://gotify.local",
+				AppToken:                "app token",
+				ErrorPriority:           8,
+				InfoPriority:            4,
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+		Script: []ScriptNotifier{
+			{
+				Command:          "/usr/local/bin/notify.sh",
+				Args:             []string{"--channel", "ops"},
+				WorkingDirectory: "/tmp",
+				Environment: map[string]string{
+					"API_KEY": "secret",
+				},
+				TimeoutInSeconds: 30,
+			},
+		},
+		Syslog: []SyslogNotifier{
+			{
+				Network:          "tcp",
+				Address:          "siem.local:6514",
+				Facility:         "local0",
+				AppName:          "node-monitoring",
+				EnterpriseNumber: 32473,
+				TimeoutInSeconds: 5,
+			},
+		},
+		File: []FileNotifier{
+			{
+				FilePath:                "audit/alarms.jsonl",
+				MaxSizeInMB:             100,
+				RotationIntervalInHours: 24,
+				MaxBackups:              30,
+				Compress:                true,
+				SyncEachWrite:           true,
+			},
+		},
+		SMS: []SMSNotifier{
+			{
+				Provider:                "twilio",
+				Recipients:              []string{"+40712345678"},
+				MaxSegments:             2,
+				DailyLimitPerRecipient:  20,
+				RequestTimeoutInSeconds: 10,
+				Twilio: SMSTwilioConfig{
+					AccountSID: "AC123",
+					AuthToken:  "auth token",
+					From:       "+15005550006",
+				},
+			},
+			{
+				Provider:                "template",
+				Recipients:              []string{"+40712345678", "+40787654321"},
+				SendInfo:                true,
+				RequestTimeoutInSeconds: 10,
+				Gateway: SMSGatewayConfig{
+					Url:    "https://sms.local/send",
+					Method: "POST",
+					Headers: map[string]string{
+						"X-Api-Key": "key",
+					},
+					Body:                `{"to": {{json .To}}, "text": {{json .Message}}}`,
+					From:                "NodeMon",
+					ExpectedStatusCodes: []int{200, 202},
+				},
+			},
+		},
+		Alertmanager: []AlertmanagerNotifier{
+			{
+				Urls:      []string{"http://am-0:9093", "http://am-1:9093"},
+				AlertName: "NodeAlarm",
+				Severity:  "page",
+				StaticLabels: map[string]string{
+					"team": "validators",
+				},
+				GeneratorUrl:            "https://monitoring.local",
+				AlertTimeoutInSeconds:   900,
+				ResendIntervalInSeconds: 60,
+				RequestTimeoutInSeconds: 10,
+			},
+		},
+	}
+
+	inventoryConfig := InventoryConfig{
+		Nodes: []InventoryNodeConfig{
+			{
+				Name:       "validator-01",
+				Owner:      "ops",
+				Provider:   "hetzner",
+				Tags:       []string{"mainnet", "shard-1"},
+				PublicKeys: []string{"pubkey1", "pubkey2"},
+				ApiUrls:    []string{"http://192.168.169.110:8080"},
+			},
+			{
+				Name:    "observer-01",
+				ApiUrls: []string{"http://192.168.169.111:8080"},
+			},
+		},
+	}
+
+	apiConfig := ApiConfig{
+		Enabled:        true,
+		NetworkAddress: "127.0.0.1:8080",
+		BearerToken:    "secret token",
+		AuditLogFile:   "audit.log",
+
+		DashboardRefreshIntervalInSeconds: 5,
+		EventsBufferSize:                  100,
+	}
+
+	configReloadConfig := ConfigReloadConfig{
+		WatchFile:              true,
+		WatchIntervalInSeconds: 5,
 	}
 
 	return GeneralConfig{
 		Alarms:        alarmsConfig,
+		Networks:      networksConfig,
 		Notifiers:     notifiersConfig,
+		Inventory:     inventoryConfig,
+		Api:           apiConfig,
+		ConfigReload:  configReloadConfig,
 		InfoTimeOfDay: "11:00:00",
 	}
 }
@@ -78,6 +353,14 @@ func TestUnmarshal(t *testing.T) {
 InfoTimeOfDay = "11:00:00"
 
 [Alarms]
+
+  [[Alarms.KeysDiscovery]]
+    ApiUrl = "https://testnet-api.elrond.com"
+    ContractAddresses = ["erd1contract"]
+    Identifier = "testnet - keys"
+    PollingTimeInSeconds = 600
+    RequestTimeoutInSeconds = 10
+
   [[Alarms.NodeNonce]]
     ApiUrls = ["http://n1", "http://n2"]
     Identifier = "testnet - nonce"
@@ -95,7 +378,14 @@ InfoTimeOfDay = "11:00:00"
     Identifier = "testnet - rating"
     PollingTimeInSeconds = 5
     PublicKeys = ["pk1", "pk2"]
+    PublicKeysPaths = ["keys/validatorKey.pem", "keys/testnet"]
     Threshold = 1.0
+    [Alarms.NodeRating.KeysDiscovery]
+      ApiUrl = "https://testnet-api.elrond.com"
+      ContractAddresses = ["erd1contract"]
+      OwnerAddresses = ["erd1owner"]
+      RefreshIntervalInSeconds = 600
+      RequestTimeoutInSeconds = 10
 
   [[Alarms.NodeRating]]
     ApiUrl = "http://devnet"
@@ -104,6 +394,21 @@ InfoTimeOfDay = "11:00:00"
     PublicKeys = ["pk3", "pk4"]
     Threshold = 2.0
 
+[[Networks]]
+  ApiUrl = "https://gateway.elrond.com"
+  DiscoveryApiUrl = "https://api.elrond.com"
+  InfoTimeOfDay = "11:30:00"
+  Name = "mainnet"
+  NonceDifference = 5
+  Notifiers = ["Slack", "Telegram:1"]
+  RatingThreshold = 95.0
+  [Networks.Alarms]
+
+    [[Networks.Alarms.NodeRating]]
+      Identifier = "mainnet - rating"
+      PollingTimeInSeconds = 60
+      PublicKeys = ["pubkey1"]
+
 [Notifiers]
   [[Notifiers.Pushover]]
     Token = "token1"
@@ -112,6 +417,206 @@ InfoTimeOfDay = "11:00:00"
   [[Notifiers.Pushover]]
     Token = "token2"
     User = "user2"
+
+  [[Notifiers.Telegram]]
+    ApiUrl = "https://api.telegram.org"
+    ChatIDs = [-1001, 1002]
+    RequestTimeoutInSeconds = 10
+    Token = "bot token"
+    [Notifiers.Telegram.Commands]
+      AllowedUserIDs = [1002]
+      Enabled = true
+      LongPollingTimeoutInSeconds = 30
+
+  [[Notifiers.Slack]]
+    Format = "slack"
+    RequestTimeoutInSeconds = 10
+    WebhookUrl = "https://hooks.slack.com/services/T000/B000/XXXX"
+
+  [[Notifiers.Slack]]
+    Format = "mattermost"
+    RequestTimeoutInSeconds = 5
+    WebhookUrl = "https://mattermost.local/hooks/xxx"
+    [Notifiers.Slack.Templates.Default]
+      Title = "{{.Level}} | {{.Identifier}}"
+    [Notifiers.Slack.Templates.Error]
+      Body = """
+{{.Labels.pubkey | shortenPubkey}}
+{{.Data}}"""
+
+  [[Notifiers.Discord]]
+    MaxRetries = 3
+    MaxRetryAfterInSeconds = 30
+    RequestTimeoutInSeconds = 10
+    Username = "monitoring"
+    WebhookUrl = "https://discord.com/api/webhooks/1/token"
+
+  [[Notifiers.Email]]
+    ErrorRecipients = ["oncall@example.com", "team@example.com"]
+    From = "Monitoring <monitor@example.com>"
+    Host = "smtp.example.com"
+    InfoRecipients = ["team@example.com"]
+    InsecureSkipVerify = false
+    Password = "secret"
+    Port = 587
+    RequestTimeoutInSeconds = 10
+    Security = "starttls"
+    Username = "monitor"
+
+  [[Notifiers.Webhook]]
+    Body = '{"text": {{json .Data}}}'
+    ExpectedStatusCodes = [200, 201]
+    Method = "PUT"
+    RequestTimeoutInSeconds = 10
+    SignatureHeader = "X-Hub-Signature-256"
+    SigningSecret = "secret"
+    Url = "https://incidents.local/api/{{.Identifier}}"
+
+    [Notifiers.Webhook.Headers]
+      X-Alarm = "{{.Identifier}}"
+
+  [[Notifiers.PagerDuty]]
+    ErrorSeverity = "critical"
+    EventsUrl = "https://events.pagerduty.com/v2/enqueue"
+    InfoSeverity = "warning"
+    RequestTimeoutInSeconds = 10
+    ResolveOnStartup = true
+    RoutingKey = "routing key"
+    Source = "monitoring host"
+
+  [[Notifiers.Opsgenie]]
+    ApiKey = "team api key"
+    ApiUrl = ""
+    CloseOnStartup = true
+    ErrorPriority = "P2"
+    InfoPriority = "P5"
+    Region = "eu"
+    RequestTimeoutInSeconds = 10
+
+  [[Notifiers.Matrix]]
+    AccessToken = "access token"
+    HomeserverUrl = "https://matrix.local"
+    MaxRetries = 3
+    RequestTimeoutInSeconds = 10
+    RetryDelayInSeconds = 2
+    RoomIDs = ["!room:matrix.local"]
+
+  [[Notifiers.Teams]]
+    RequestTimeoutInSeconds = 10
+    WebhookUrl = "https://prod.westeurope.logic.azure.com/workflows/xxx"
+
+  [[Notifiers.Ntfy]]
+    ErrorPriority = 5
+    InfoPriority = 3
+    Password = ""
+    RequestTimeoutInSeconds = 10
+    ServerUrl = "https://ntfy.local"
+    Tags = ["validator"]
+    Token = "tk_token"
+    Topic = "node-alerts"
+    Username = ""
+
+  [[Notifiers.Gotify]]
+    AppToken = "app token"
+    ErrorPriority = 8
+    InfoPriority = 4
+    RequestTimeoutInSeconds = 10
+    ServerUrl = "https://gotify.local"
+
+  [[Notifiers.Script]]
+    Args = ["--channel", "ops"]
+    Command = "/usr/local/bin/notify.sh"
+    TimeoutInSeconds = 30
+    WorkingDirectory = "/tmp"
+    [Notifiers.Script.Environment]
+      API_KEY = "secret"
+
+  [[Notifiers.Syslog]]
+    Address = "siem.local:6514"
+    AppName = "node-monitoring"
+    EnterpriseNumber = 32473
+    Facility = "local0"
+    Hostname = ""
+    Network = "tcp"
+    TimeoutInSeconds = 5
+
+  [[Notifiers.File]]
+    Compress = true
+    FilePath = "audit/alarms.jsonl"
+    IncludeNoEvent = false
+    MaxBackups = 30
+    MaxSizeInMB = 100
+    RotationIntervalInHours = 24
+    SyncEachWrite = true
+
+  [[Notifiers.SMS]]
+    DailyLimitPerRecipient = 20
+    MaxSegments = 2
+    Provider = "twilio"
+    Recipients = ["+40712345678"]
+    RequestTimeoutInSeconds = 10
+    SendInfo = false
+    [Notifiers.SMS.Twilio]
+      AccountSID = "AC123"
+      ApiUrl = ""
+      AuthToken = "auth token"
+      From = "+15005550006"
+      MessagingServiceSID = ""
+
+  [[Notifiers.SMS]]
+    DailyLimitPerRecipient = 0
+    MaxSegments = 0
+    Provider = "template"
+    Recipients = ["+40712345678", "+40787654321"]
+    RequestTimeoutInSeconds = 10
+    SendInfo = true
+    [Notifiers.SMS.Gateway]
+      Body = '{"to": {{json .To}}, "text": {{json .Message}}}'
+      ExpectedStatusCodes = [200, 202]
+      From = "NodeMon"
+      Method = "POST"
+      Url = "https://sms.local/send"
+      [Notifiers.SMS.Gateway.Headers]
+        X-Api-Key = "key"
+
+  [[Notifiers.Alertmanager]]
+    AlertName = "NodeAlarm"
+    AlertTimeoutInSeconds = 900
+    GeneratorUrl = "https://monitoring.local"
+    Password = ""
+    RequestTimeoutInSeconds = 10
+    ResendIntervalInSeconds = 60
+    Severity = "page"
+    Urls = ["http://am-0:9093", "http://am-1:9093"]
+    Username = ""
+    [Notifiers.Alertmanager.StaticLabels]
+      team = "validators"
+
+[Inventory]
+
+  [[Inventory.Nodes]]
+    ApiUrls = ["http://192.168.169.110:8080"]
+    Name = "validator-01"
+    Owner = "ops"
+    Provider = "hetzner"
+    PublicKeys = ["pubkey1", "pubkey2"]
+    Tags = ["mainnet", "shard-1"]
+
+  [[Inventory.Nodes]]
+    ApiUrls = ["http://192.168.169.111:8080"]
+    Name = "observer-01"
+
+[Api]
+  AuditLogFile = "audit.log"
+  BearerToken = "secret token"
+  DashboardRefreshIntervalInSeconds = 5
+  Enabled = true
+  EventsBufferSize = 100
+  NetworkAddress = "127.0.0.1:8080"
+
+[ConfigReload]
+  WatchFile = true
+  WatchIntervalInSeconds = 5
 `
 
 	result := GeneralConfig{}
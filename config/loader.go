@@ -0,0 +1,18 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/ElrondNetwork/elrond-go-core/core"
+)
+
+// LoadConfig loads the general config from the provided toml file
+func LoadConfig(filepath string) (GeneralConfig, error) {
+	cfg := GeneralConfig{}
+	err := core.LoadTomlFile(&cfg, filepath)
+	if err != nil {
+		return GeneralConfig{}, fmt.Errorf("%w while loading config file %s", err, filepath)
+	}
+
+	return cfg, nil
+}
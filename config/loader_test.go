@@ -0,0 +1,37 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file should error", func(t *testing.T) {
+		cfg, err := LoadConfig(filepath.Join(t.TempDir(), "missing.toml"))
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "while loading config file"))
+		assert.Equal(t, GeneralConfig{}, cfg)
+	})
+	t.Run("invalid file should error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		_ = ioutil.WriteFile(path, []byte("InfoTimeOfDay = "), 0644)
+
+		cfg, err := LoadConfig(path)
+		assert.NotNil(t, err)
+		assert.Equal(t, GeneralConfig{}, cfg)
+	})
+	t.Run("should work", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		_ = ioutil.WriteFile(path, []byte(`InfoTimeOfDay = "11:00:00"`), 0644)
+
+		cfg, err := LoadConfig(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "11:00:00", cfg.InfoTimeOfDay)
+	})
+}
@@ -1,8 +1,64 @@
 package data
 
-// AlarmResponse is the DTO that is generated by an alarm
+import "time"
+
+// AlarmResponse is the DTO that is generated by an alarm. The Metrics field can optionally hold numeric values
+// (e.g. rating, nonce) that will be kept in the alarm's history
 type AlarmResponse struct {
-	Identifier string
-	Level      EventLevel
-	Data       string
+	Identifier string             `json:"identifier"`
+	Level      EventLevel         `json:"level"`
+	Data       string             `json:"data"`
+	Metrics    map[string]float64 `json:"metrics,omitempty"`
+	Labels     map[string]string  `json:"labels,omitempty"`
+	Report     []ReportEntry      `json:"report,omitempty"`
+}
+
+// ReportEntry is the DTO that holds the status of an alarm in the info report. The paused alarms are not queried,
+// their entries having the NoEvent level
+type ReportEntry struct {
+	Identifier string     `json:"identifier"`
+	Level      EventLevel `json:"level"`
+	Status     string     `json:"status"`
+	Paused     bool       `json:"paused"`
+}
+
+// AlarmStatus is the DTO that holds the current status of an alarm
+type AlarmStatus struct {
+	Identifier    string        `json:"identifier"`
+	State         AlarmState    `json:"state"`
+	LastQueryTime time.Time     `json:"lastQueryTime"`
+	LastResponse  AlarmResponse `json:"lastResponse"`
+	LastError     string        `json:"lastError"`
+	NextQueryTime time.Time     `json:"nextQueryTime"`
+	Acknowledged  bool          `json:"acknowledged"`
+	SilencedUntil time.Time     `json:"silencedUntil"`
+}
+
+// AlarmHistoryPoint is the DTO that holds the outcome of an alarm's query at a point in time
+type AlarmHistoryPoint struct {
+	Timestamp time.Time          `json:"timestamp"`
+	Level     EventLevel         `json:"level"`
+	Metrics   map[string]float64 `json:"metrics,omitempty"`
+}
+
+// NotifierStatus is the DTO that holds the health information of a notifier
+type NotifierStatus struct {
+	Name          string    `json:"name"`
+	Healthy       bool      `json:"healthy"`
+	NumSent       uint64    `json:"numSent"`
+	NumErrors     uint64    `json:"numErrors"`
+	LastSendTime  time.Time `json:"lastSendTime"`
+	LastError     string    `json:"lastError"`
+	LastErrorTime time.Time `json:"lastErrorTime"`
+}
+
+// AlarmEvent is the DTO published for each alarm response or alarm state transition
+type AlarmEvent struct {
+	Type          AlarmEventType `json:"type"`
+	Timestamp     time.Time      `json:"timestamp"`
+	Identifier    string         `json:"identifier"`
+	Level         EventLevel     `json:"level"`
+	Response      *AlarmResponse `json:"response,omitempty"`
+	PreviousState AlarmState     `json:"previousState,omitempty"`
+	State         AlarmState     `json:"state,omitempty"`
 }
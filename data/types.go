@@ -11,3 +11,58 @@ const (
 	// Error specify that an error event was triggered
 	Error EventLevel = "Error"
 )
+
+// SystemIdentifier is the identifier of the responses created by the monitoring tool itself, like the info report
+const SystemIdentifier = "system"
+
+const (
+	// UptimeMetric is the info report metric holding the monitoring tool's uptime in seconds
+	UptimeMetric = "uptimeSeconds"
+	// ProcessingErrorsMetric is the info report metric holding the number of processing errors since the last report
+	ProcessingErrorsMetric = "processingErrors"
+	// AlarmsWithErrorMetric is the info report metric holding the number of alarms with errors since the last report
+	AlarmsWithErrorMetric = "alarmsWithError"
+)
+
+const (
+	// PubkeyLabel is the label holding the BLS public key of the node an alarm response refers to
+	PubkeyLabel = "pubkey"
+	// ApiUrlLabel is the label holding the API url of the node an alarm response refers to
+	ApiUrlLabel = "apiUrl"
+	// NodeLabel is the label holding the inventory name of the node an alarm response refers to
+	NodeLabel = "node"
+	// OwnerLabel is the label holding the inventory owner of the node
+	OwnerLabel = "owner"
+	// ProviderLabel is the label holding the inventory hosting provider of the node
+	ProviderLabel = "provider"
+	// TagsLabel is the label holding the comma separated inventory tags of the node
+	TagsLabel = "tags"
+	// NetworkLabel is the label holding the name of the network profile an alarm response belongs to
+	NetworkLabel = "network"
+)
+
+// AlarmState represents the current state of an alarm as seen by the polling handler
+type AlarmState string
+
+const (
+	// StatePending specify that the alarm was not queried yet
+	StatePending AlarmState = "Pending"
+	// StateOk specify that the last alarm's response did not contain an error
+	StateOk AlarmState = "Ok"
+	// StateAlerting specify that the last alarm's response had the Error level
+	StateAlerting AlarmState = "Alerting"
+	// StateQueryError specify that the last alarm's query failed
+	StateQueryError AlarmState = "Query error"
+	// StatePaused specify that the alarm was paused and will not be queried until resumed
+	StatePaused AlarmState = "Paused"
+)
+
+// AlarmEventType represents the type of the published alarm event
+type AlarmEventType string
+
+const (
+	// ResponseEvent specify that the event holds an alarm response
+	ResponseEvent AlarmEventType = "response"
+	// StateTransitionEvent specify that the event holds an alarm state transition
+	StateTransitionEvent AlarmEventType = "stateTransition"
+)
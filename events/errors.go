@@ -0,0 +1,5 @@
+package events
+
+import "errors"
+
+var errInvalidValue = errors.New("invalid value")
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+const minBufferSize = 1
+
+// eventsBroker will dispatch the published events to all subscribers. The publisher is never blocked: each
+// subscriber has its own buffer and, if the buffer is full, the events are dropped and counted for that subscriber
+type eventsBroker struct {
+	mut           sync.RWMutex
+	bufferSize    int
+	nextID        uint64
+	subscriptions map[uint64]*Subscription
+}
+
+// NewEventsBroker creates a new events broker instance
+func NewEventsBroker(bufferSize int) (*eventsBroker, error) {
+	if bufferSize < minBufferSize {
+		return nil, fmt.Errorf("%w for bufferSize, provided: %d, minimum: %d", errInvalidValue, bufferSize, minBufferSize)
+	}
+
+	return &eventsBroker{
+		bufferSize:    bufferSize,
+		subscriptions: make(map[uint64]*Subscription),
+	}, nil
+}
+
+// Publish will dispatch the event to all matching subscribers
+func (broker *eventsBroker) Publish(event data.AlarmEvent) {
+	broker.mut.RLock()
+	defer broker.mut.RUnlock()
+
+	for _, subscription := range broker.subscriptions {
+		subscription.push(event)
+	}
+}
+
+// Subscribe will register a new subscriber that will receive the events matching the provided filter
+func (broker *eventsBroker) Subscribe(filter Filter) *Subscription {
+	broker.mut.Lock()
+	defer broker.mut.Unlock()
+
+	subscription := &Subscription{
+		id:     broker.nextID,
+		filter: filter,
+		events: make(chan data.AlarmEvent, broker.bufferSize),
+	}
+	broker.nextID++
+	broker.subscriptions[subscription.id] = subscription
+
+	return subscription
+}
+
+// Unsubscribe will remove the subscriber and will close its events channel
+func (broker *eventsBroker) Unsubscribe(subscription *Subscription) {
+	broker.mut.Lock()
+	defer broker.mut.Unlock()
+
+	_, found := broker.subscriptions[subscription.id]
+	if !found {
+		return
+	}
+
+	delete(broker.subscriptions, subscription.id)
+	close(subscription.events)
+}
+
+// NumSubscribers returns the number of active subscribers
+func (broker *eventsBroker) NumSubscribers() int {
+	broker.mut.RLock()
+	defer broker.mut.RUnlock()
+
+	return len(broker.subscriptions)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (broker *eventsBroker) IsInterfaceNil() bool {
+	return broker == nil
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEventsBroker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid buffer size should error", func(t *testing.T) {
+		broker, err := NewEventsBroker(0)
+		assert.True(t, check.IfNil(broker))
+		assert.True(t, errors.Is(err, errInvalidValue))
+	})
+	t.Run("should work", func(t *testing.T) {
+		broker, err := NewEventsBroker(1)
+		assert.False(t, check.IfNil(broker))
+		assert.Nil(t, err)
+		assert.Equal(t, 0, broker.NumSubscribers())
+	})
+}
+
+func TestEventsBroker_PublishShouldDispatchToAllSubscribers(t *testing.T) {
+	t.Parallel()
+
+	broker, _ := NewEventsBroker(10)
+	subscription1 := broker.Subscribe(Filter{})
+	subscription2 := broker.Subscribe(Filter{})
+	assert.Equal(t, 2, broker.NumSubscribers())
+
+	event := data.AlarmEvent{Type: data.ResponseEvent, Identifier: "alarm"}
+	broker.Publish(event)
+
+	assert.Equal(t, event, <-subscription1.Events())
+	assert.Equal(t, event, <-subscription2.Events())
+}
+
+func TestEventsBroker_PublishShouldApplyTheFilter(t *testing.T) {
+	t.Parallel()
+
+	broker, _ := NewEventsBroker(10)
+	subscription := broker.Subscribe(Filter{
+		Levels:      []data.EventLevel{data.Error},
+		Identifiers: []string{"alarm1", "alarm2"},
+	})
+
+	broker.Publish(data.AlarmEvent{Identifier: "alarm1", Level: data.Info})
+	broker.Publish(data.AlarmEvent{Identifier: "alarm3", Level: data.Error})
+	broker.Publish(data.AlarmEvent{Identifier: "alarm2", Level: data.Error})
+
+	assert.Equal(t, 1, len(subscription.Events()))
+	assert.Equal(t, "alarm2", (<-subscription.Events()).Identifier)
+}
+
+func TestEventsBroker_SlowSubscriberShouldNotBlockThePublisher(t *testing.T) {
+	t.Parallel()
+
+	broker, _ := NewEventsBroker(2)
+	slowSubscription := broker.Subscribe(Filter{})
+	for i := 0; i < 5; i++ {
+		broker.Publish(data.AlarmEvent{Identifier: "alarm"})
+	}
+
+	assert.Equal(t, 2, len(slowSubscription.Events()))
+	assert.Equal(t, uint64(3), slowSubscription.TakeNumDropped())
+	assert.Equal(t, uint64(0), slowSubscription.TakeNumDropped())
+}
+
+func TestEventsBroker_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	broker, _ := NewEventsBroker(2)
+	subscription := broker.Subscribe(Filter{})
+	broker.Unsubscribe(subscription)
+	broker.Unsubscribe(subscription)
+	assert.Equal(t, 0, broker.NumSubscribers())
+
+	broker.Publish(data.AlarmEvent{Identifier: "alarm"})
+	_, ok := <-subscription.Events()
+	assert.False(t, ok)
+}
@@ -0,0 +1,39 @@
+package events
+
+import "github.com/iulianpascalau/node-monitoring/data"
+
+// Filter defines the criteria used to select the published events. An empty field will match everything
+type Filter struct {
+	Levels      []data.EventLevel
+	Identifiers []string
+}
+
+func (filter Filter) matches(event data.AlarmEvent) bool {
+	return matchesLevel(filter.Levels, event.Level) && matchesIdentifier(filter.Identifiers, event.Identifier)
+}
+
+func matchesLevel(levels []data.EventLevel, level data.EventLevel) bool {
+	if len(levels) == 0 {
+		return true
+	}
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesIdentifier(identifiers []string, identifier string) bool {
+	if len(identifiers) == 0 {
+		return true
+	}
+	for _, id := range identifiers {
+		if id == identifier {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"sync/atomic"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+// Subscription holds the events channel of a subscriber
+type Subscription struct {
+	id         uint64
+	filter     Filter
+	events     chan data.AlarmEvent
+	numDropped uint64
+}
+
+// Events returns the channel on which the matching events are delivered. The channel is closed on unsubscribe
+func (subscription *Subscription) Events() <-chan data.AlarmEvent {
+	return subscription.events
+}
+
+// TakeNumDropped returns the number of events dropped since the last call because the subscriber was too slow
+func (subscription *Subscription) TakeNumDropped() uint64 {
+	return atomic.SwapUint64(&subscription.numDropped, 0)
+}
+
+func (subscription *Subscription) push(event data.AlarmEvent) {
+	if !subscription.filter.matches(event) {
+		return
+	}
+
+	select {
+	case subscription.events <- event:
+	default:
+		atomic.AddUint64(&subscription.numDropped, 1)
+	}
+}
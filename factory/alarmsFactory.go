@@ -0,0 +1,92 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/alarms"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/keys"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+// alarmsRequestTimeout is the timeout of the requests done by the NodeRating and NodeNonce alarms to the nodes' API
+const alarmsRequestTimeout = 10 * time.Second
+
+type alarmDefinition struct {
+	identifier string
+	config     interface{}
+	create     func() (poll.AlarmHandler, error)
+}
+
+func createAlarmDefinitions(cfg config.AlarmsConfig, discoverers keys.SharedDiscoverers) []alarmDefinition {
+	definitions := make([]alarmDefinition, 0, len(cfg.NodeRating)+len(cfg.NodeNonce)+len(cfg.KeysDiscovery))
+	for _, alarmConfig := range cfg.NodeRating {
+		ratingConfig := alarmConfig
+		definitions = append(definitions, alarmDefinition{
+			identifier: ratingConfig.Identifier,
+			config:     ratingConfig,
+			create: func() (poll.AlarmHandler, error) {
+				return createNodeRatingAlarm(ratingConfig, discoverers)
+			},
+		})
+	}
+	for _, alarmConfig := range cfg.NodeNonce {
+		nonceConfig := alarmConfig
+		definitions = append(definitions, alarmDefinition{
+			identifier: nonceConfig.Identifier,
+			config:     nonceConfig,
+			create: func() (poll.AlarmHandler, error) {
+				return createNodeNonceAlarm(nonceConfig)
+			},
+		})
+	}
+	for _, alarmConfig := range cfg.KeysDiscovery {
+		discoveryConfig := alarmConfig
+		definitions = append(definitions, alarmDefinition{
+			identifier: discoveryConfig.Identifier,
+			config:     discoveryConfig,
+			create: func() (poll.AlarmHandler, error) {
+				return createKeysDiscoveryAlarm(discoveryConfig, discoverers)
+			},
+		})
+	}
+
+	return definitions
+}
+
+func createNodeRatingAlarm(cfg config.NodeRatingAlarmConfig, discoverers keys.SharedDiscoverers) (poll.AlarmHandler, error) {
+	publicKeysProvider, err := createPublicKeysProvider(cfg, discoverers)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := http.NewHTTPClientWrapper(alarmsRequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return alarms.NewNodeRatingAlarm(alarms.ArgsNodeRatingAlarm{
+		Identifier:         cfg.Identifier,
+		HTTPClient:         httpClient,
+		ApiUrl:             cfg.ApiUrl,
+		Threshold:          cfg.Threshold,
+		PublicKeysProvider: publicKeysProvider,
+		PollingInterval:    time.Duration(cfg.PollingTimeInSeconds) * time.Second,
+	})
+}
+
+func createNodeNonceAlarm(cfg config.NodeNonceAlarmConfig) (poll.AlarmHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(alarmsRequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return alarms.NewNodeNonceAlarm(alarms.ArgsNodeNonceAlarm{
+		Identifier:      cfg.Identifier,
+		HTTPClient:      httpClient,
+		ApiUrls:         cfg.ApiUrls,
+		NonceDifference: cfg.NonceDifference,
+		PollingInterval: time.Duration(cfg.PollingTimeInSeconds) * time.Second,
+	})
+}
@@ -0,0 +1,36 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/alertmanager"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createAlertmanagerNotifier(cfg config.AlertmanagerNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Alertmanager notifier", err)
+	}
+
+	notifier, err := alertmanager.NewAlertmanagerNotifier(alertmanager.ArgsAlertmanagerNotifier{
+		HTTPClient:     httpClient,
+		Urls:           cfg.Urls,
+		Username:       cfg.Username,
+		Password:       cfg.Password,
+		AlertName:      cfg.AlertName,
+		Severity:       cfg.Severity,
+		StaticLabels:   cfg.StaticLabels,
+		GeneratorUrl:   cfg.GeneratorUrl,
+		AlertTimeout:   time.Duration(cfg.AlertTimeoutInSeconds) * time.Second,
+		ResendInterval: time.Duration(cfg.ResendIntervalInSeconds) * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Alertmanager notifier", err)
+	}
+
+	return notifier, nil
+}
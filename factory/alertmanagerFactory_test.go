@@ -0,0 +1,43 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestAlertmanagerConfig() config.AlertmanagerNotifier {
+	return config.AlertmanagerNotifier{
+		Urls:                    []string{"http://127.0.0.1:9093"},
+		RequestTimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateAlertmanagerNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestAlertmanagerConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createAlertmanagerNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Alertmanager notifier"))
+	})
+	t.Run("no URLs should error", func(t *testing.T) {
+		cfg := createTestAlertmanagerConfig()
+		cfg.Urls = nil
+
+		notifier, err := createAlertmanagerNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Alertmanager notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createAlertmanagerNotifier(createTestAlertmanagerConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,247 @@
+package factory
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/keys"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+type alarmInstance struct {
+	definition alarmDefinition
+	handler    poll.AlarmHandler
+}
+
+type notifierInstance struct {
+	definition notifierDefinition
+	handler    poll.NotifierHandler
+}
+
+// ComponentsDiff holds the differences between two sets of components
+type ComponentsDiff struct {
+	AddedAlarms      []string
+	RemovedAlarms    []string
+	UpdatedAlarms    []string
+	UnchangedAlarms  []string
+	AddedNotifiers   int
+	RemovedNotifiers int
+}
+
+// Components holds the alarms and notifiers created from a config. The instance is immutable, an Update call
+// will return a new instance
+type Components struct {
+	alarms      []alarmInstance
+	notifiers   []notifierInstance
+	created     []interface{}
+	replaced    []interface{}
+	discoverers keys.SharedDiscoverers
+}
+
+// CreateComponents creates all the alarms and notifiers defined in the provided config
+func CreateComponents(cfg config.GeneralConfig) (*Components, error) {
+	components := &Components{
+		discoverers: keys.NewSharedDiscoverers(),
+	}
+	newComponents, _, err := components.Update(cfg)
+
+	return newComponents, err
+}
+
+// Update creates a new Components instance for the provided config. The alarms and notifiers whose config did not
+// change are reused so their internal state is kept, an inventory change recreating all the alarms. The networks'
+// alarms are labeled with the network's name and the notifiers are routed to their networks. The current instance
+// is not altered. The keys discoverers are shared by all the instances so the alarms watching the same addresses
+// discover the keys once, whether they were reused or not
+func (components *Components) Update(cfg config.GeneralConfig) (*Components, ComponentsDiff, error) {
+	alarmDefinitions, err := enrichAlarmDefinitions(createAlarmDefinitions(cfg.Alarms, components.discoverers), cfg.Inventory)
+	if err != nil {
+		return nil, ComponentsDiff{}, err
+	}
+
+	networkAlarmDefinitions, err := createNetworkAlarmDefinitions(cfg.Networks, cfg.Inventory, components.discoverers)
+	if err != nil {
+		return nil, ComponentsDiff{}, err
+	}
+
+	notifierDefinitions, err := routeNotifierDefinitions(createNotifierDefinitions(cfg.Notifiers), cfg.Networks)
+	if err != nil {
+		return nil, ComponentsDiff{}, err
+	}
+
+	return components.update(append(alarmDefinitions, networkAlarmDefinitions...), notifierDefinitions)
+}
+
+func (components *Components) update(
+	alarmDefinitions []alarmDefinition,
+	notifierDefinitions []notifierDefinition,
+) (*Components, ComponentsDiff, error) {
+	diff := ComponentsDiff{}
+	newComponents := &Components{
+		discoverers: components.discoverers,
+	}
+
+	err := checkAlarmDefinitions(alarmDefinitions)
+	if err != nil {
+		return nil, ComponentsDiff{}, err
+	}
+
+	existingAlarms := make(map[string]alarmInstance)
+	for _, instance := range components.alarms {
+		existingAlarms[instance.definition.identifier] = instance
+	}
+	for _, definition := range alarmDefinitions {
+		existing, found := existingAlarms[definition.identifier]
+		delete(existingAlarms, definition.identifier)
+		if found && reflect.DeepEqual(existing.definition.config, definition.config) {
+			newComponents.alarms = append(newComponents.alarms, existing)
+			diff.UnchangedAlarms = append(diff.UnchangedAlarms, definition.identifier)
+			continue
+		}
+
+		handler, errCreate := definition.create()
+		if errCreate != nil {
+			_ = newComponents.Discard()
+			return nil, ComponentsDiff{}, fmt.Errorf("%w for alarm %s", errCreate, definition.identifier)
+		}
+
+		newComponents.alarms = append(newComponents.alarms, alarmInstance{definition: definition, handler: handler})
+		newComponents.created = append(newComponents.created, handler)
+		if found {
+			newComponents.replaced = append(newComponents.replaced, existing.handler)
+			diff.UpdatedAlarms = append(diff.UpdatedAlarms, definition.identifier)
+		} else {
+			diff.AddedAlarms = append(diff.AddedAlarms, definition.identifier)
+		}
+	}
+	for _, instance := range components.alarms {
+		_, removed := existingAlarms[instance.definition.identifier]
+		if removed {
+			newComponents.replaced = append(newComponents.replaced, instance.handler)
+			diff.RemovedAlarms = append(diff.RemovedAlarms, instance.definition.identifier)
+		}
+	}
+
+	existingNotifiers := make(map[string][]notifierInstance)
+	for _, instance := range components.notifiers {
+		key := instance.definition.key
+		existingNotifiers[key] = append(existingNotifiers[key], instance)
+	}
+	for _, definition := range notifierDefinitions {
+		instances := existingNotifiers[definition.key]
+		if len(instances) > 0 {
+			newComponents.notifiers = append(newComponents.notifiers, instances[0])
+			existingNotifiers[definition.key] = instances[1:]
+			continue
+		}
+
+		handler, errCreate := definition.create()
+		if errCreate != nil {
+			_ = newComponents.Discard()
+			return nil, ComponentsDiff{}, errCreate
+		}
+
+		newComponents.notifiers = append(newComponents.notifiers, notifierInstance{definition: definition, handler: handler})
+		newComponents.created = append(newComponents.created, handler)
+		diff.AddedNotifiers++
+	}
+	for _, instances := range existingNotifiers {
+		for _, instance := range instances {
+			newComponents.replaced = append(newComponents.replaced, instance.handler)
+		}
+		diff.RemovedNotifiers += len(instances)
+	}
+
+	return newComponents, diff, nil
+}
+
+func checkAlarmDefinitions(definitions []alarmDefinition) error {
+	identifiers := make(map[string]struct{})
+	for _, definition := range definitions {
+		if len(definition.identifier) == 0 {
+			return errEmptyAlarmIdentifier
+		}
+
+		_, found := identifiers[definition.identifier]
+		if found {
+			return fmt.Errorf("%w: %s", errDuplicatedAlarmIdentifier, definition.identifier)
+		}
+		identifiers[definition.identifier] = struct{}{}
+	}
+
+	return nil
+}
+
+// CloseReplaced closes the alarms and notifiers of the previous instance that were removed or replaced by the
+// Update call that returned this instance. It should be called once this instance is in use
+func (components *Components) CloseReplaced() error {
+	return closeHandlers(components.replaced)
+}
+
+// Discard closes the alarms and notifiers created by the Update call that returned this instance, the reused ones
+// being kept open. It should be called if this instance is rejected
+func (components *Components) Discard() error {
+	return closeHandlers(components.created)
+}
+
+// Close closes all the alarms and notifiers of this instance
+func (components *Components) Close() error {
+	handlers := make([]interface{}, 0, len(components.alarms)+len(components.notifiers))
+	for _, instance := range components.alarms {
+		handlers = append(handlers, instance.handler)
+	}
+	for _, instance := range components.notifiers {
+		handlers = append(handlers, instance.handler)
+	}
+
+	return closeHandlers(handlers)
+}
+
+// closeHandlers closes all the handlers (or the handlers wrapped by them) implementing io.Closer, returning the
+// last error encountered
+func closeHandlers(handlers []interface{}) error {
+	var lastErr error
+	for _, handler := range handlers {
+		err := closeHandler(handler)
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+func closeHandler(handler interface{}) error {
+	switch wrapper := handler.(type) {
+	case io.Closer:
+		return wrapper.Close()
+	case interface{ Unwrap() poll.NotifierHandler }:
+		return closeHandler(wrapper.Unwrap())
+	case interface{ Unwrap() poll.AlarmHandler }:
+		return closeHandler(wrapper.Unwrap())
+	default:
+		return nil
+	}
+}
+
+// Alarms returns the created alarms
+func (components *Components) Alarms() []poll.AlarmHandler {
+	alarms := make([]poll.AlarmHandler, 0, len(components.alarms))
+	for _, instance := range components.alarms {
+		alarms = append(alarms, instance.handler)
+	}
+
+	return alarms
+}
+
+// Notifiers returns the created notifiers
+func (components *Components) Notifiers() []poll.NotifierHandler {
+	notifiers := make([]poll.NotifierHandler, 0, len(components.notifiers))
+	for _, instance := range components.notifiers {
+		notifiers = append(notifiers, instance.handler)
+	}
+
+	return notifiers
+}
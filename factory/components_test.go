@@ -0,0 +1,438 @@
+package factory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/networks"
+	"github.com/iulianpascalau/node-monitoring/poll"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestAlarmDefinition(identifier string, cfg interface{}, numCreated *int) alarmDefinition {
+	return alarmDefinition{
+		identifier: identifier,
+		config:     cfg,
+		create: func() (poll.AlarmHandler, error) {
+			*numCreated++
+			return &mocks.AlarmHandlerStub{}, nil
+		},
+	}
+}
+
+func createTestNotifierDefinition(cfg interface{}, numCreated *int) notifierDefinition {
+	return notifierDefinition{
+		key:    createNotifierKey("test", cfg),
+		config: cfg,
+		create: func() (poll.NotifierHandler, error) {
+			*numCreated++
+			return &mocks.NotifierHandlerStub{}, nil
+		},
+	}
+}
+
+func TestCreateComponents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid alarm should error", func(t *testing.T) {
+		cfg := config.GeneralConfig{
+			Alarms: config.AlarmsConfig{
+				NodeRating: []config.NodeRatingAlarmConfig{{Identifier: "rating", PublicKeys: []string{"pk1"}}},
+			},
+		}
+
+		components, err := CreateComponents(cfg)
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "for alarm rating"))
+		assert.Nil(t, components)
+	})
+	t.Run("missing public keys file should error", func(t *testing.T) {
+		cfg := config.GeneralConfig{
+			Alarms: config.AlarmsConfig{
+				NodeRating: []config.NodeRatingAlarmConfig{
+					{
+						Identifier:      "rating",
+						PublicKeysPaths: []string{filepath.Join(t.TempDir(), "validatorKey.pem")},
+					},
+				},
+			},
+		}
+
+		components, err := CreateComponents(cfg)
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+		assert.True(t, strings.Contains(err.Error(), "for alarm rating"))
+		assert.Nil(t, components)
+	})
+	t.Run("invalid notifier should error", func(t *testing.T) {
+		cfg := config.GeneralConfig{
+			Notifiers: config.NotifiersConfig{
+				Pushover: []config.PushoverNotifier{{Token: "", User: "user"}},
+			},
+		}
+
+		components, err := CreateComponents(cfg)
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "for Pushover notifier"))
+		assert.Nil(t, components)
+	})
+	t.Run("duplicated identifiers should error", func(t *testing.T) {
+		cfg := config.GeneralConfig{
+			Alarms: config.AlarmsConfig{
+				NodeRating: []config.NodeRatingAlarmConfig{{Identifier: "alarm"}},
+				NodeNonce:  []config.NodeNonceAlarmConfig{{Identifier: "alarm"}},
+			},
+		}
+
+		components, err := CreateComponents(cfg)
+		assert.True(t, errors.Is(err, errDuplicatedAlarmIdentifier))
+		assert.Nil(t, components)
+	})
+	t.Run("empty identifier should error", func(t *testing.T) {
+		cfg := config.GeneralConfig{
+			Alarms: config.AlarmsConfig{
+				NodeNonce: []config.NodeNonceAlarmConfig{{Identifier: ""}},
+			},
+		}
+
+		components, err := CreateComponents(cfg)
+		assert.Equal(t, errEmptyAlarmIdentifier, err)
+		assert.Nil(t, components)
+	})
+	t.Run("valid config should work", func(t *testing.T) {
+		cfg := config.GeneralConfig{
+			Alarms: config.AlarmsConfig{
+				NodeRating: []config.NodeRatingAlarmConfig{
+					{
+						Identifier:           "rating",
+						Threshold:            1.0,
+						ApiUrl:               "http://127.0.0.1:8080",
+						PublicKeys:           []string{"pk1", "pk2"},
+						PollingTimeInSeconds: 60,
+					},
+				},
+				NodeNonce: []config.NodeNonceAlarmConfig{
+					{
+						Identifier:           "nonce",
+						ApiUrls:              []string{"http://127.0.0.1:8080", "http://127.0.0.1:8081"},
+						NonceDifference:      5,
+						PollingTimeInSeconds: 60,
+					},
+				},
+			},
+			Notifiers: config.NotifiersConfig{
+				Pushover: []config.PushoverNotifier{{Token: "token", User: "user"}},
+			},
+		}
+
+		components, err := CreateComponents(cfg)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(components.Alarms()))
+		assert.Equal(t, "rating", components.Alarms()[0].Identifier())
+		assert.Equal(t, "nonce", components.Alarms()[1].Identifier())
+		assert.Equal(t, 1, len(components.Notifiers()))
+	})
+	t.Run("duplicated identifiers in networks should error", func(t *testing.T) {
+		cfg := config.GeneralConfig{
+			Networks: []config.NetworkConfig{createTestNetworkConfig("testnet")},
+		}
+		cfg.Alarms.KeysDiscovery = cfg.Networks[0].Alarms.KeysDiscovery
+
+		components, err := CreateComponents(cfg)
+		assert.True(t, errors.Is(err, errDuplicatedAlarmIdentifier))
+		assert.Nil(t, components)
+	})
+	t.Run("missing network notifier should error", func(t *testing.T) {
+		network := createTestNetworkConfig("testnet")
+		network.Notifiers = []string{"Webhook"}
+		cfg := config.GeneralConfig{
+			Networks: []config.NetworkConfig{network},
+		}
+
+		components, err := CreateComponents(cfg)
+		assert.True(t, errors.Is(err, errNotifierNotFound))
+		assert.Nil(t, components)
+	})
+	t.Run("networks should work", func(t *testing.T) {
+		testnet := createTestNetworkConfig("testnet")
+		testnet.Notifiers = []string{"Webhook:1"}
+		cfg := config.GeneralConfig{
+			Networks: []config.NetworkConfig{createTestNetworkConfig("mainnet"), testnet},
+			Notifiers: config.NotifiersConfig{
+				Webhook: []config.WebhookNotifier{
+					{Url: "http://127.0.0.1:8080/mainnet", RequestTimeoutInSeconds: 10},
+					{Url: "http://127.0.0.1:8080/testnet", RequestTimeoutInSeconds: 10},
+				},
+			},
+		}
+
+		components, err := CreateComponents(cfg)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(components.Alarms()))
+		assert.Equal(t, "testnet keys", components.Alarms()[1].Identifier())
+		assert.Equal(t, 2, len(components.Notifiers()))
+
+		newComponents, diff, err := components.Update(cfg)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"mainnet keys", "testnet keys"}, diff.UnchangedAlarms)
+		assert.Equal(t, 0, diff.AddedNotifiers)
+		assert.True(t, components.Notifiers()[0] == newComponents.Notifiers()[0])
+
+		testnet.Notifiers = []string{"Webhook"}
+		cfg.Networks[1] = testnet
+		_, diff, err = components.Update(cfg)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, diff.AddedNotifiers)
+		assert.Equal(t, 1, diff.RemovedNotifiers)
+	})
+	t.Run("shipped config should work", func(t *testing.T) {
+		cfg, err := config.LoadConfig(filepath.Join("..", "cmd", "monitoring", "config", "config.toml"))
+		require.Nil(t, err)
+
+		components, err := CreateComponents(cfg)
+		assert.Nil(t, err)
+		assert.Equal(t, len(cfg.Alarms.NodeRating), len(components.Alarms()))
+	})
+	t.Run("empty config should work", func(t *testing.T) {
+		components, err := CreateComponents(config.GeneralConfig{})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(components.Alarms()))
+		assert.Equal(t, 0, len(components.Notifiers()))
+	})
+}
+
+func TestComponents_Update(t *testing.T) {
+	t.Parallel()
+
+	numAlarmsCreated := 0
+	numNotifiersCreated := 0
+	components, _, err := (&Components{}).update(
+		[]alarmDefinition{
+			createTestAlarmDefinition("unchanged", 1, &numAlarmsCreated),
+			createTestAlarmDefinition("updated", 2, &numAlarmsCreated),
+			createTestAlarmDefinition("removed", 3, &numAlarmsCreated),
+		},
+		[]notifierDefinition{
+			createTestNotifierDefinition("notifier 1", &numNotifiersCreated),
+			createTestNotifierDefinition("notifier 2", &numNotifiersCreated),
+		},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, numAlarmsCreated)
+	assert.Equal(t, 2, numNotifiersCreated)
+	initialAlarms := components.Alarms()
+	initialNotifiers := components.Notifiers()
+
+	t.Run("create error should not alter the current instance", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		newComponents, _, errUpdate := components.update(
+			[]alarmDefinition{
+				{
+					identifier: "failing",
+					create: func() (poll.AlarmHandler, error) {
+						return nil, expectedErr
+					},
+				},
+			},
+			nil,
+		)
+		assert.True(t, errors.Is(errUpdate, expectedErr))
+		assert.Nil(t, newComponents)
+		assert.Equal(t, initialAlarms, components.Alarms())
+		assert.Equal(t, initialNotifiers, components.Notifiers())
+	})
+	t.Run("should reuse the unchanged components", func(t *testing.T) {
+		numAlarmsCreated = 0
+		numNotifiersCreated = 0
+		newComponents, diff, errUpdate := components.update(
+			[]alarmDefinition{
+				createTestAlarmDefinition("unchanged", 1, &numAlarmsCreated),
+				createTestAlarmDefinition("updated", 20, &numAlarmsCreated),
+				createTestAlarmDefinition("added", 4, &numAlarmsCreated),
+			},
+			[]notifierDefinition{
+				createTestNotifierDefinition("notifier 2", &numNotifiersCreated),
+				createTestNotifierDefinition("notifier 3", &numNotifiersCreated),
+			},
+		)
+		assert.Nil(t, errUpdate)
+		assert.Equal(t, 2, numAlarmsCreated)
+		assert.Equal(t, 1, numNotifiersCreated)
+
+		expectedDiff := ComponentsDiff{
+			AddedAlarms:      []string{"added"},
+			RemovedAlarms:    []string{"removed"},
+			UpdatedAlarms:    []string{"updated"},
+			UnchangedAlarms:  []string{"unchanged"},
+			AddedNotifiers:   1,
+			RemovedNotifiers: 1,
+		}
+		assert.Equal(t, expectedDiff, diff)
+
+		newAlarms := newComponents.Alarms()
+		assert.Equal(t, 3, len(newAlarms))
+		assert.True(t, newAlarms[0] == initialAlarms[0])
+		assert.False(t, newAlarms[1] == initialAlarms[1])
+
+		newNotifiers := newComponents.Notifiers()
+		assert.Equal(t, 2, len(newNotifiers))
+		assert.True(t, newNotifiers[0] == initialNotifiers[1])
+	})
+}
+
+func createClosableAlarmDefinition(identifier string, cfg interface{}, closed map[string]int) alarmDefinition {
+	return alarmDefinition{
+		identifier: identifier,
+		config:     cfg,
+		create: func() (poll.AlarmHandler, error) {
+			return &mocks.AlarmHandlerStub{
+				CloseCalled: func() error {
+					closed[identifier]++
+					return nil
+				},
+			}, nil
+		},
+	}
+}
+
+func createClosableNotifierDefinition(name string, closed map[string]int) notifierDefinition {
+	return notifierDefinition{
+		key:    createNotifierKey("test", name),
+		config: name,
+		create: func() (poll.NotifierHandler, error) {
+			return &mocks.NotifierHandlerStub{
+				CloseCalled: func() error {
+					closed[name]++
+					return nil
+				},
+			}, nil
+		},
+	}
+}
+
+func TestComponents_CloseReplaced(t *testing.T) {
+	t.Parallel()
+
+	closed := make(map[string]int)
+	components, _, err := (&Components{}).update(
+		[]alarmDefinition{
+			createClosableAlarmDefinition("unchanged", 1, closed),
+			createClosableAlarmDefinition("updated", 2, closed),
+			createClosableAlarmDefinition("removed", 3, closed),
+		},
+		[]notifierDefinition{
+			createClosableNotifierDefinition("kept notifier", closed),
+			createClosableNotifierDefinition("removed notifier", closed),
+		},
+	)
+	require.Nil(t, err)
+	assert.Nil(t, components.CloseReplaced())
+	assert.Equal(t, 0, len(closed))
+
+	newComponents, _, err := components.update(
+		[]alarmDefinition{
+			createClosableAlarmDefinition("unchanged", 1, closed),
+			createClosableAlarmDefinition("updated", 20, closed),
+		},
+		[]notifierDefinition{
+			createClosableNotifierDefinition("kept notifier", closed),
+		},
+	)
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(closed))
+
+	assert.Nil(t, newComponents.CloseReplaced())
+	assert.Equal(t, map[string]int{"updated": 1, "removed": 1, "removed notifier": 1}, closed)
+}
+
+func TestComponents_Discard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create error should close the components created so far", func(t *testing.T) {
+		closed := make(map[string]int)
+		components, _, err := (&Components{}).update(
+			[]alarmDefinition{createClosableAlarmDefinition("existing", 1, closed)},
+			nil,
+		)
+		require.Nil(t, err)
+
+		expectedErr := errors.New("expected error")
+		newComponents, _, err := components.update(
+			[]alarmDefinition{
+				createClosableAlarmDefinition("existing", 1, closed),
+				createClosableAlarmDefinition("added", 2, closed),
+			},
+			[]notifierDefinition{
+				createClosableNotifierDefinition("notifier", closed),
+				{
+					key: createNotifierKey("test", "failing"),
+					create: func() (poll.NotifierHandler, error) {
+						return nil, expectedErr
+					},
+				},
+			},
+		)
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, newComponents)
+		assert.Equal(t, map[string]int{"added": 1, "notifier": 1}, closed)
+	})
+	t.Run("should close only the created components", func(t *testing.T) {
+		closed := make(map[string]int)
+		components, _, err := (&Components{}).update(
+			[]alarmDefinition{createClosableAlarmDefinition("existing", 1, closed)},
+			nil,
+		)
+		require.Nil(t, err)
+
+		newComponents, _, err := components.update(
+			[]alarmDefinition{
+				createClosableAlarmDefinition("existing", 1, closed),
+				createClosableAlarmDefinition("added", 2, closed),
+			},
+			nil,
+		)
+		require.Nil(t, err)
+
+		assert.Nil(t, newComponents.Discard())
+		assert.Equal(t, map[string]int{"added": 1}, closed)
+	})
+}
+
+func TestCloseHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not closable handler should not error", func(t *testing.T) {
+		assert.Nil(t, closeHandler(struct{}{}))
+	})
+	t.Run("wrapped handlers should be closed", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		notifier := &mocks.NotifierHandlerStub{
+			CloseCalled: func() error {
+				return expectedErr
+			},
+		}
+		wrapper, err := networks.NewNetworkFilteredNotifier(notifier, []string{"mainnet"})
+		require.Nil(t, err)
+
+		assert.Equal(t, expectedErr, closeHandler(wrapper))
+	})
+}
+
+func TestComponents_Close(t *testing.T) {
+	t.Parallel()
+
+	closed := make(map[string]int)
+	components, _, err := (&Components{}).update(
+		[]alarmDefinition{createClosableAlarmDefinition("alarm", 1, closed)},
+		[]notifierDefinition{createClosableNotifierDefinition("notifier", closed)},
+	)
+	require.Nil(t, err)
+
+	assert.Nil(t, components.Close())
+	assert.Equal(t, map[string]int{"alarm": 1, "notifier": 1}, closed)
+}
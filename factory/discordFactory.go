@@ -0,0 +1,37 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/discord"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createDiscordNotifier(cfg config.DiscordNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Discord notifier", err)
+	}
+
+	renderer, err := createMessageRenderer(cfg.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Discord notifier", err)
+	}
+
+	notifier, err := discord.NewWebhookNotifier(discord.ArgsWebhookNotifier{
+		HTTPClient:    httpClient,
+		WebhookUrl:    cfg.WebhookUrl,
+		Username:      cfg.Username,
+		MaxRetries:    cfg.MaxRetries,
+		MaxRetryAfter: time.Duration(cfg.MaxRetryAfterInSeconds) * time.Second,
+		Renderer:      renderer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Discord notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,45 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestDiscordConfig() config.DiscordNotifier {
+	return config.DiscordNotifier{
+		WebhookUrl:              "http://localhost",
+		MaxRetries:              1,
+		MaxRetryAfterInSeconds:  1,
+		RequestTimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateDiscordNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestDiscordConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createDiscordNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Discord notifier"))
+	})
+	t.Run("invalid max retry after should error", func(t *testing.T) {
+		cfg := createTestDiscordConfig()
+		cfg.MaxRetryAfterInSeconds = 0
+
+		notifier, err := createDiscordNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Discord notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createDiscordNotifier(createTestDiscordConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
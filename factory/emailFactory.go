@@ -0,0 +1,50 @@
+package factory
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/email"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createEmailNotifier(cfg config.EmailNotifier) (poll.NotifierHandler, error) {
+	smtpClient, err := email.NewSMTPClient(email.ArgsSMTPClient{
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		Security: email.Security(cfg.Security),
+		Username: cfg.Username,
+		Password: cfg.Password,
+		TLSConfig: &tls.Config{
+			ServerName:         cfg.Host,
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		},
+		Timeout: time.Duration(cfg.RequestTimeoutInSeconds) * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Email notifier", err)
+	}
+
+	renderer, err := createMessageRenderer(cfg.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Email notifier", err)
+	}
+
+	notifier, err := email.NewEmailNotifier(email.ArgsEmailNotifier{
+		Sender: smtpClient,
+		From:   cfg.From,
+		Recipients: map[data.EventLevel][]string{
+			data.Error: cfg.ErrorRecipients,
+			data.Info:  cfg.InfoRecipients,
+		},
+		Renderer: renderer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Email notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,47 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestEmailConfig() config.EmailNotifier {
+	return config.EmailNotifier{
+		Host:                    "localhost",
+		Port:                    587,
+		Security:                "starttls",
+		From:                    "monitor@localhost",
+		ErrorRecipients:         []string{"oncall@localhost"},
+		RequestTimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateEmailNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid SMTP config should error", func(t *testing.T) {
+		cfg := createTestEmailConfig()
+		cfg.Security = "ssl"
+
+		notifier, err := createEmailNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Email notifier"))
+	})
+	t.Run("no recipients should error", func(t *testing.T) {
+		cfg := createTestEmailConfig()
+		cfg.ErrorRecipients = nil
+
+		notifier, err := createEmailNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Email notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createEmailNotifier(createTestEmailConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
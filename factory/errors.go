@@ -0,0 +1,13 @@
+package factory
+
+import "errors"
+
+var errInvalidTimeOfDay = errors.New("invalid time of day")
+var errEmptyAlarmIdentifier = errors.New("empty alarm identifier")
+var errDuplicatedAlarmIdentifier = errors.New("duplicated alarm identifier")
+var errUnknownSMSProvider = errors.New("unknown SMS provider")
+var errUnsupportedTemplates = errors.New("templates not supported by notifier")
+var errNotifierNotFound = errors.New("notifier not found")
+var errEmptyNetworkName = errors.New("empty network name")
+var errDuplicatedNetworkName = errors.New("duplicated network name")
+var errInvalidNotifierReference = errors.New("invalid notifier reference")
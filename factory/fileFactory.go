@@ -0,0 +1,37 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/notifiers/file"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+const bytesInMB = 1024 * 1024
+
+func createFileNotifier(cfg config.FileNotifier) (poll.NotifierHandler, error) {
+	writer, err := file.NewRotatingFile(file.ArgsRotatingFile{
+		FilePath:         cfg.FilePath,
+		MaxSize:          int64(cfg.MaxSizeInMB) * bytesInMB,
+		RotationInterval: time.Duration(cfg.RotationIntervalInHours) * time.Hour,
+		MaxBackups:       cfg.MaxBackups,
+		Compress:         cfg.Compress,
+		SyncEachWrite:    cfg.SyncEachWrite,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for File notifier", err)
+	}
+
+	notifier, err := file.NewFileNotifier(file.ArgsFileNotifier{
+		Writer:         writer,
+		IncludeNoEvent: cfg.IncludeNoEvent,
+	})
+	if err != nil {
+		_ = writer.Close()
+		return nil, fmt.Errorf("%w for File notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,42 @@
+package factory
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateFileNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty file path should error", func(t *testing.T) {
+		notifier, err := createFileNotifier(config.FileNotifier{})
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for File notifier"))
+	})
+	t.Run("invalid maximum size should error", func(t *testing.T) {
+		cfg := config.FileNotifier{
+			FilePath:    filepath.Join(t.TempDir(), "alarms.jsonl"),
+			MaxSizeInMB: -1,
+		}
+
+		notifier, err := createFileNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for File notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		cfg := config.FileNotifier{
+			FilePath:                filepath.Join(t.TempDir(), "alarms.jsonl"),
+			MaxSizeInMB:             1,
+			RotationIntervalInHours: 24,
+		}
+
+		notifier, err := createFileNotifier(cfg)
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,37 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/gotify"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createGotifyNotifier(cfg config.GotifyNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Gotify notifier", err)
+	}
+
+	renderer, err := createMessageRenderer(cfg.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Gotify notifier", err)
+	}
+
+	notifier, err := gotify.NewGotifyNotifier(gotify.ArgsGotifyNotifier{
+		HTTPClient:    httpClient,
+		ServerUrl:     cfg.ServerUrl,
+		AppToken:      cfg.AppToken,
+		ErrorPriority: cfg.ErrorPriority,
+		InfoPriority:  cfg.InfoPriority,
+		Renderer:      renderer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Gotify notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,44 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestGotifyConfig() config.GotifyNotifier {
+	return config.GotifyNotifier{
+		ServerUrl:               "http://localhost",
+		AppToken:                "token",
+		RequestTimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateGotifyNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestGotifyConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createGotifyNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Gotify notifier"))
+	})
+	t.Run("empty application token should error", func(t *testing.T) {
+		cfg := createTestGotifyConfig()
+		cfg.AppToken = ""
+
+		notifier, err := createGotifyNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Gotify notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createGotifyNotifier(createTestGotifyConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
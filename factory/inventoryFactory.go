@@ -0,0 +1,64 @@
+package factory
+
+import (
+	"fmt"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/inventory"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+// inventoryAlarmConfig binds the alarm's config to the inventory so the alarms are recreated when the inventory changes
+type inventoryAlarmConfig struct {
+	alarm     interface{}
+	inventory config.InventoryConfig
+}
+
+func createInventory(cfg config.InventoryConfig) (inventory.ResponseEnricher, error) {
+	nodes := make([]inventory.Node, 0, len(cfg.Nodes))
+	for _, nodeConfig := range cfg.Nodes {
+		nodes = append(nodes, inventory.Node(nodeConfig))
+	}
+
+	inv, err := inventory.NewInventory(inventory.ArgsInventory{Nodes: nodes})
+	if err != nil {
+		return nil, fmt.Errorf("%w in inventory", err)
+	}
+
+	return inv, nil
+}
+
+// enrichAlarmDefinitions wraps the created alarms so their responses hold the inventory details. The definitions
+// are returned unaltered if the inventory is empty
+func enrichAlarmDefinitions(definitions []alarmDefinition, cfg config.InventoryConfig) ([]alarmDefinition, error) {
+	if len(cfg.Nodes) == 0 {
+		return definitions, nil
+	}
+
+	enricher, err := createInventory(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	enrichedDefinitions := make([]alarmDefinition, 0, len(definitions))
+	for _, definition := range definitions {
+		create := definition.create
+		enrichedDefinitions = append(enrichedDefinitions, alarmDefinition{
+			identifier: definition.identifier,
+			config: inventoryAlarmConfig{
+				alarm:     definition.config,
+				inventory: cfg,
+			},
+			create: func() (poll.AlarmHandler, error) {
+				handler, errCreate := create()
+				if errCreate != nil {
+					return nil, errCreate
+				}
+
+				return inventory.NewEnrichedAlarmHandler(handler, enricher)
+			},
+		})
+	}
+
+	return enrichedDefinitions, nil
+}
@@ -0,0 +1,93 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/poll"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestInventoryConfig() config.InventoryConfig {
+	return config.InventoryConfig{
+		Nodes: []config.InventoryNodeConfig{
+			{
+				Name:       "validator-01",
+				Owner:      "ops",
+				PublicKeys: []string{"pubkey1"},
+			},
+		},
+	}
+}
+
+func TestEnrichAlarmDefinitions(t *testing.T) {
+	t.Parallel()
+
+	createDefinition := func() alarmDefinition {
+		return alarmDefinition{
+			identifier: "node rating",
+			config:     1,
+			create: func() (poll.AlarmHandler, error) {
+				return &mocks.AlarmHandlerStub{
+					QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+						return data.AlarmResponse{
+							Identifier: "node rating",
+							Level:      data.Error,
+							Labels:     map[string]string{data.PubkeyLabel: "pubkey1"},
+						}, nil
+					},
+				}, nil
+			},
+		}
+	}
+
+	t.Run("empty inventory should not alter the definitions", func(t *testing.T) {
+		definitions := []alarmDefinition{createDefinition()}
+
+		result, err := enrichAlarmDefinitions(definitions, config.InventoryConfig{})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, 1, result[0].config)
+	})
+	t.Run("invalid inventory should error", func(t *testing.T) {
+		cfg := createTestInventoryConfig()
+		cfg.Nodes = append(cfg.Nodes, cfg.Nodes[0])
+
+		result, err := enrichAlarmDefinitions([]alarmDefinition{createDefinition()}, cfg)
+		assert.Nil(t, result)
+		assert.Equal(t, "duplicated node name: validator-01 in inventory", err.Error())
+	})
+	t.Run("create error should error", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		definition := createDefinition()
+		definition.create = func() (poll.AlarmHandler, error) {
+			return nil, expectedErr
+		}
+
+		result, err := enrichAlarmDefinitions([]alarmDefinition{definition}, createTestInventoryConfig())
+		require.Nil(t, err)
+
+		handler, err := result[0].create()
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, handler)
+	})
+	t.Run("should wrap the created alarms", func(t *testing.T) {
+		cfg := createTestInventoryConfig()
+		result, err := enrichAlarmDefinitions([]alarmDefinition{createDefinition()}, cfg)
+		require.Nil(t, err)
+		assert.Equal(t, "node rating", result[0].identifier)
+		assert.Equal(t, inventoryAlarmConfig{alarm: 1, inventory: cfg}, result[0].config)
+
+		handler, err := result[0].create()
+		require.Nil(t, err)
+		response, err := handler.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "validator-01", response.Labels[data.NodeLabel])
+		assert.Equal(t, "ops", response.Labels[data.OwnerLabel])
+	})
+}
@@ -0,0 +1,111 @@
+package factory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/keys"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createPublicKeysProvider(
+	cfg config.NodeRatingAlarmConfig,
+	discoverers keys.SharedDiscoverers,
+) (keys.PublicKeysProvider, error) {
+	args := keys.ArgsPublicKeysProvider{
+		PublicKeys:        cfg.PublicKeys,
+		Paths:             cfg.PublicKeysPaths,
+		DiscoveryInterval: time.Duration(cfg.KeysDiscovery.RefreshIntervalInSeconds) * time.Second,
+	}
+
+	discoveryConfig := cfg.KeysDiscovery
+	if len(discoveryConfig.ContractAddresses)+len(discoveryConfig.OwnerAddresses) > 0 {
+		discoveryInterval := args.DiscoveryInterval
+		if discoveryInterval == 0 {
+			discoveryInterval = keys.DefaultDiscoveryInterval
+		}
+
+		discoverer, err := getSharedKeysDiscoverer(discoverers, discoveryConfig.ApiUrl, discoveryConfig.ContractAddresses,
+			discoveryConfig.OwnerAddresses, discoveryConfig.RequestTimeoutInSeconds, discoveryInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		args.Discoverer = discoverer
+	}
+
+	return keys.NewPublicKeysProvider(args)
+}
+
+func createKeysDiscoveryAlarm(
+	cfg config.KeysDiscoveryAlarmConfig,
+	discoverers keys.SharedDiscoverers,
+) (poll.AlarmHandler, error) {
+	pollingInterval := time.Duration(cfg.PollingTimeInSeconds) * time.Second
+	discoverer, err := getSharedKeysDiscoverer(discoverers, cfg.ApiUrl, cfg.ContractAddresses, cfg.OwnerAddresses,
+		cfg.RequestTimeoutInSeconds, pollingInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	return keys.NewKeysDiscoveryAlarm(keys.ArgsKeysDiscoveryAlarm{
+		Identifier:      cfg.Identifier,
+		Discoverer:      discoverer,
+		PollingInterval: pollingInterval,
+	})
+}
+
+// getSharedKeysDiscoverer returns the discoverer of the provided addresses shared by the node rating alarms' public
+// keys providers and the keys discovery alarms, so the keys are fetched once for all of them and the keys discovery
+// alarms report the same changes the node rating alarms start monitoring
+func getSharedKeysDiscoverer(
+	discoverers keys.SharedDiscoverers,
+	apiUrl string,
+	contractAddresses []string,
+	ownerAddresses []string,
+	requestTimeoutInSeconds int,
+	refreshInterval time.Duration,
+) (keys.KeysDiscoverer, error) {
+	key := fmt.Sprintf("%s|%s|%s|%d", apiUrl, joinSorted(contractAddresses), joinSorted(ownerAddresses),
+		requestTimeoutInSeconds)
+
+	return discoverers.Discoverer(key, refreshInterval, func() (keys.KeysDiscoverer, error) {
+		return createKeysDiscoverer(apiUrl, contractAddresses, ownerAddresses, requestTimeoutInSeconds)
+	})
+}
+
+func joinSorted(values []string) string {
+	sorted := make([]string, len(values))
+	copy(sorted, values)
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, ",")
+}
+
+func createKeysDiscoverer(
+	apiUrl string,
+	contractAddresses []string,
+	ownerAddresses []string,
+	requestTimeoutInSeconds int,
+) (keys.KeysDiscoverer, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(requestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for keys discovery", err)
+	}
+
+	discoverer, err := keys.NewStakingProviderDiscoverer(keys.ArgsStakingProviderDiscoverer{
+		HTTPClient:        httpClient,
+		ApiUrl:            apiUrl,
+		ContractAddresses: contractAddresses,
+		OwnerAddresses:    ownerAddresses,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for keys discovery", err)
+	}
+
+	return discoverer, nil
+}
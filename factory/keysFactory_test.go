@@ -0,0 +1,176 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContractAddress = "erd1qqqqqqqqqqqqqpgqxwakt2g7u9atsnr03gqcgmhcv38pt7mkd94q6shuwt"
+
+func createTestKeysDiscoveryAlarmConfig() config.KeysDiscoveryAlarmConfig {
+	return config.KeysDiscoveryAlarmConfig{
+		Identifier:              "staking provider keys",
+		ContractAddresses:       []string{testContractAddress},
+		PollingTimeInSeconds:    600,
+		RequestTimeoutInSeconds: 10,
+	}
+}
+
+func TestCreateKeysDiscoveryAlarm(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestKeysDiscoveryAlarmConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		alarm, err := createKeysDiscoveryAlarm(cfg, keys.NewSharedDiscoverers())
+		assert.True(t, check.IfNil(alarm))
+		assert.True(t, strings.HasSuffix(err.Error(), "for keys discovery"))
+	})
+	t.Run("invalid address should error", func(t *testing.T) {
+		cfg := createTestKeysDiscoveryAlarmConfig()
+		cfg.OwnerAddresses = []string{"erd1short"}
+
+		alarm, err := createKeysDiscoveryAlarm(cfg, keys.NewSharedDiscoverers())
+		assert.True(t, check.IfNil(alarm))
+		assert.True(t, strings.Contains(err.Error(), "invalid address"))
+	})
+	t.Run("invalid polling time should error", func(t *testing.T) {
+		cfg := createTestKeysDiscoveryAlarmConfig()
+		cfg.PollingTimeInSeconds = 0
+
+		alarm, err := createKeysDiscoveryAlarm(cfg, keys.NewSharedDiscoverers())
+		assert.True(t, check.IfNil(alarm))
+		assert.NotNil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		alarm, err := createKeysDiscoveryAlarm(createTestKeysDiscoveryAlarmConfig(), keys.NewSharedDiscoverers())
+		assert.False(t, check.IfNil(alarm))
+		assert.Nil(t, err)
+		assert.Equal(t, "staking provider keys", alarm.Identifier())
+	})
+	t.Run("should be created from the general config", func(t *testing.T) {
+		components, err := CreateComponents(config.GeneralConfig{
+			Alarms: config.AlarmsConfig{
+				KeysDiscovery: []config.KeysDiscoveryAlarmConfig{createTestKeysDiscoveryAlarmConfig()},
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(components.Alarms()))
+	})
+}
+
+func TestCreateComponents_SharedKeysDiscovery(t *testing.T) {
+	t.Parallel()
+
+	discoveredKey := createTestPemPublicKey("d")
+	numDiscoveries := uint32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/nodes" {
+			atomic.AddUint32(&numDiscoveries, 1)
+			_ = json.NewEncoder(writer).Encode([]map[string]string{{"bls": discoveredKey}})
+			return
+		}
+
+		_ = json.NewEncoder(writer).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"statistics": map[string]interface{}{discoveredKey: map[string]interface{}{"tempRating": 100}},
+			},
+			"code": "successful",
+		})
+	}))
+	defer server.Close()
+
+	discoveryAlarmConfig := createTestKeysDiscoveryAlarmConfig()
+	discoveryAlarmConfig.ApiUrl = server.URL
+	components, err := CreateComponents(config.GeneralConfig{
+		Alarms: config.AlarmsConfig{
+			NodeRating: []config.NodeRatingAlarmConfig{
+				{
+					Identifier: "rating",
+					ApiUrl:     server.URL,
+					Threshold:  90,
+					KeysDiscovery: config.KeysDiscoveryConfig{
+						ApiUrl:                   server.URL,
+						ContractAddresses:        []string{testContractAddress},
+						RefreshIntervalInSeconds: 600,
+						RequestTimeoutInSeconds:  10,
+					},
+					PollingTimeInSeconds: 60,
+				},
+			},
+			KeysDiscovery: []config.KeysDiscoveryAlarmConfig{discoveryAlarmConfig},
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(components.Alarms()))
+
+	response, err := components.Alarms()[0].Query(context.Background())
+	require.Nil(t, err)
+	assert.True(t, strings.Contains(response.Data, discoveredKey+": rating 100.00"))
+	response, err = components.Alarms()[1].Query(context.Background())
+	require.Nil(t, err)
+	assert.Equal(t, "1 public key(s) discovered", response.Data)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numDiscoveries))
+
+	// the reloaded components keep sharing the discovery
+	discoveryAlarmConfig.Identifier = "renamed keys"
+	updated, _, err := components.Update(config.GeneralConfig{
+		Alarms: config.AlarmsConfig{
+			NodeRating:    []config.NodeRatingAlarmConfig{components.alarms[0].definition.config.(config.NodeRatingAlarmConfig)},
+			KeysDiscovery: []config.KeysDiscoveryAlarmConfig{discoveryAlarmConfig},
+		},
+	})
+	require.Nil(t, err)
+
+	_, err = updated.Alarms()[1].Query(context.Background())
+	require.Nil(t, err)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numDiscoveries))
+}
+
+func TestCreatePublicKeysProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid discovery config should error", func(t *testing.T) {
+		cfg := config.NodeRatingAlarmConfig{
+			Identifier: "rating",
+			KeysDiscovery: config.KeysDiscoveryConfig{
+				ContractAddresses:       []string{testContractAddress},
+				RequestTimeoutInSeconds: 0,
+			},
+		}
+
+		provider, err := createPublicKeysProvider(cfg, keys.NewSharedDiscoverers())
+		assert.True(t, check.IfNil(provider))
+		assert.True(t, strings.HasSuffix(err.Error(), "for keys discovery"))
+	})
+	t.Run("no public keys should error", func(t *testing.T) {
+		provider, err := createPublicKeysProvider(config.NodeRatingAlarmConfig{Identifier: "rating"}, keys.NewSharedDiscoverers())
+		assert.True(t, check.IfNil(provider))
+		assert.NotNil(t, err)
+	})
+	t.Run("discovery only should work", func(t *testing.T) {
+		cfg := config.NodeRatingAlarmConfig{
+			Identifier: "rating",
+			KeysDiscovery: config.KeysDiscoveryConfig{
+				OwnerAddresses:          []string{testContractAddress},
+				RequestTimeoutInSeconds: 10,
+			},
+		}
+
+		provider, err := createPublicKeysProvider(cfg, keys.NewSharedDiscoverers())
+		assert.False(t, check.IfNil(provider))
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,38 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/matrix"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createMatrixNotifier(cfg config.MatrixNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Matrix notifier", err)
+	}
+
+	renderer, err := createMessageRenderer(cfg.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Matrix notifier", err)
+	}
+
+	notifier, err := matrix.NewMatrixNotifier(matrix.ArgsMatrixNotifier{
+		HTTPClient:    httpClient,
+		HomeserverUrl: cfg.HomeserverUrl,
+		AccessToken:   cfg.AccessToken,
+		RoomIDs:       cfg.RoomIDs,
+		MaxRetries:    cfg.MaxRetries,
+		RetryDelay:    time.Duration(cfg.RetryDelayInSeconds) * time.Second,
+		Renderer:      renderer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Matrix notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,47 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestMatrixConfig() config.MatrixNotifier {
+	return config.MatrixNotifier{
+		HomeserverUrl:           "http://localhost",
+		AccessToken:             "token",
+		RoomIDs:                 []string{"!room:localhost"},
+		MaxRetries:              1,
+		RetryDelayInSeconds:     1,
+		RequestTimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateMatrixNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestMatrixConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createMatrixNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Matrix notifier"))
+	})
+	t.Run("invalid retry delay should error", func(t *testing.T) {
+		cfg := createTestMatrixConfig()
+		cfg.RetryDelayInSeconds = 0
+
+		notifier, err := createMatrixNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Matrix notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createMatrixNotifier(createTestMatrixConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,234 @@
+package factory
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/keys"
+	"github.com/iulianpascalau/node-monitoring/networks"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+const notifierReferenceSeparator = ":"
+
+// networkAlarmConfig binds the alarm's config to the network so the alarms are recreated when moved to another network
+type networkAlarmConfig struct {
+	alarm   interface{}
+	network string
+}
+
+func checkNetworks(networksConfig []config.NetworkConfig) error {
+	names := make(map[string]struct{})
+	for _, network := range networksConfig {
+		if len(network.Name) == 0 {
+			return errEmptyNetworkName
+		}
+
+		_, found := names[network.Name]
+		if found {
+			return fmt.Errorf("%w: %s", errDuplicatedNetworkName, network.Name)
+		}
+		names[network.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// createNetworkAlarmDefinitions creates the alarms of all the networks, filled with the networks' defaults, enriched
+// with the inventory details and labeled with the network's name
+func createNetworkAlarmDefinitions(
+	networksConfig []config.NetworkConfig,
+	inventoryConfig config.InventoryConfig,
+	discoverers keys.SharedDiscoverers,
+) ([]alarmDefinition, error) {
+	err := checkNetworks(networksConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make([]alarmDefinition, 0)
+	for _, network := range networksConfig {
+		alarmDefinitions := createAlarmDefinitions(applyNetworkDefaults(network), discoverers)
+		networkDefinitions, errEnrich := enrichAlarmDefinitions(alarmDefinitions, inventoryConfig)
+		if errEnrich != nil {
+			return nil, errEnrich
+		}
+
+		for _, definition := range networkDefinitions {
+			create := definition.create
+			name := network.Name
+			definitions = append(definitions, alarmDefinition{
+				identifier: definition.identifier,
+				config: networkAlarmConfig{
+					alarm:   definition.config,
+					network: name,
+				},
+				create: func() (poll.AlarmHandler, error) {
+					handler, errCreate := create()
+					if errCreate != nil {
+						return nil, errCreate
+					}
+
+					return networks.NewNetworkAlarmHandler(handler, name)
+				},
+			})
+		}
+	}
+
+	return definitions, nil
+}
+
+// applyNetworkDefaults returns the network's alarms, the missing API urls, rating threshold and nonce difference
+// being replaced by the network's values
+func applyNetworkDefaults(network config.NetworkConfig) config.AlarmsConfig {
+	alarms := config.AlarmsConfig{
+		NodeRating:    make([]config.NodeRatingAlarmConfig, 0, len(network.Alarms.NodeRating)),
+		NodeNonce:     make([]config.NodeNonceAlarmConfig, 0, len(network.Alarms.NodeNonce)),
+		KeysDiscovery: make([]config.KeysDiscoveryAlarmConfig, 0, len(network.Alarms.KeysDiscovery)),
+	}
+	for _, alarmConfig := range network.Alarms.NodeRating {
+		if len(alarmConfig.ApiUrl) == 0 {
+			alarmConfig.ApiUrl = network.ApiUrl
+		}
+		if alarmConfig.Threshold == 0 {
+			alarmConfig.Threshold = network.RatingThreshold
+		}
+		if len(alarmConfig.KeysDiscovery.ApiUrl) == 0 {
+			alarmConfig.KeysDiscovery.ApiUrl = network.DiscoveryApiUrl
+		}
+		alarms.NodeRating = append(alarms.NodeRating, alarmConfig)
+	}
+	for _, alarmConfig := range network.Alarms.NodeNonce {
+		if len(alarmConfig.ApiUrls) == 0 && len(network.ApiUrl) > 0 {
+			alarmConfig.ApiUrls = []string{network.ApiUrl}
+		}
+		if alarmConfig.NonceDifference == 0 {
+			alarmConfig.NonceDifference = network.NonceDifference
+		}
+		alarms.NodeNonce = append(alarms.NodeNonce, alarmConfig)
+	}
+	for _, alarmConfig := range network.Alarms.KeysDiscovery {
+		if len(alarmConfig.ApiUrl) == 0 {
+			alarmConfig.ApiUrl = network.DiscoveryApiUrl
+		}
+		alarms.KeysDiscovery = append(alarms.KeysDiscovery, alarmConfig)
+	}
+
+	return alarms
+}
+
+// routeNotifierDefinitions wraps the notifiers so they ignore the responses of the networks that do not reference
+// them. The networks without referenced notifiers use all the notifiers, the notifiers that do not need filtering
+// being returned unaltered
+func routeNotifierDefinitions(
+	definitions []notifierDefinition,
+	networksConfig []config.NetworkConfig,
+) ([]notifierDefinition, error) {
+	rejectedNetworks := make([][]string, len(definitions))
+	for _, network := range networksConfig {
+		if len(network.Notifiers) == 0 {
+			continue
+		}
+
+		referenced := make([]bool, len(definitions))
+		for _, reference := range network.Notifiers {
+			err := markReferencedNotifiers(definitions, reference, referenced)
+			if err != nil {
+				return nil, fmt.Errorf("%w in network %s", err, network.Name)
+			}
+		}
+
+		for idx := range definitions {
+			if !referenced[idx] {
+				rejectedNetworks[idx] = append(rejectedNetworks[idx], network.Name)
+			}
+		}
+	}
+
+	routedDefinitions := make([]notifierDefinition, 0, len(definitions))
+	for idx, definition := range definitions {
+		rejected := rejectedNetworks[idx]
+		if len(rejected) == 0 {
+			routedDefinitions = append(routedDefinitions, definition)
+			continue
+		}
+
+		sort.Strings(rejected)
+		create := definition.create
+		routedDefinitions = append(routedDefinitions, notifierDefinition{
+			notifierType: definition.notifierType,
+			key:          definition.key + "-" + strings.Join(rejected, ","),
+			config:       definition.config,
+			create: func() (poll.NotifierHandler, error) {
+				handler, err := create()
+				if err != nil {
+					return nil, err
+				}
+
+				return networks.NewNetworkFilteredNotifier(handler, rejected)
+			},
+		})
+	}
+
+	return routedDefinitions, nil
+}
+
+// markReferencedNotifiers marks the notifiers matching the reference, either a notifier type (e.g. Slack) selecting
+// all the notifiers of that type or a type and an index in the configuration file (e.g. Slack:1)
+func markReferencedNotifiers(definitions []notifierDefinition, reference string, referenced []bool) error {
+	notifierType := reference
+	index := -1
+	separatorIndex := strings.Index(reference, notifierReferenceSeparator)
+	if separatorIndex >= 0 {
+		notifierType = reference[:separatorIndex]
+		value, err := strconv.Atoi(reference[separatorIndex+len(notifierReferenceSeparator):])
+		if err != nil || value < 0 {
+			return fmt.Errorf("%w %s, expected format Type or Type:index", errInvalidNotifierReference, reference)
+		}
+		index = value
+	}
+
+	found := false
+	typeIndex := 0
+	for idx, definition := range definitions {
+		if definition.notifierType != notifierType {
+			continue
+		}
+		if index < 0 || index == typeIndex {
+			referenced[idx] = true
+			found = true
+		}
+		typeIndex++
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", errNotifierNotFound, reference)
+	}
+
+	return nil
+}
+
+// CreateNetworksInfo returns the polling handler's arguments for the networks that have their own info report
+func CreateNetworksInfo(networksConfig []config.NetworkConfig) ([]poll.ArgsNetworkInfo, error) {
+	networksInfo := make([]poll.ArgsNetworkInfo, 0)
+	for _, network := range networksConfig {
+		timeOfDay, err := ParseTimeOfDay(network.InfoTimeOfDay)
+		if err != nil {
+			return nil, fmt.Errorf("%w in network %s", err, network.Name)
+		}
+		if !timeOfDay.Active {
+			continue
+		}
+
+		networksInfo = append(networksInfo, poll.ArgsNetworkInfo{
+			Network:        network.Name,
+			SendInfoHour:   timeOfDay.Hour,
+			SendInfoMinute: timeOfDay.Minute,
+			SendInfoSecond: timeOfDay.Second,
+		})
+	}
+
+	return networksInfo, nil
+}
@@ -0,0 +1,263 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/keys"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/poll"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestNetworkConfig(name string) config.NetworkConfig {
+	return config.NetworkConfig{
+		Name:            name,
+		DiscoveryApiUrl: "https://" + name + "-api.elrond.com",
+		Alarms: config.AlarmsConfig{
+			KeysDiscovery: []config.KeysDiscoveryAlarmConfig{
+				{
+					Identifier:              name + " keys",
+					ContractAddresses:       []string{testContractAddress},
+					PollingTimeInSeconds:    60,
+					RequestTimeoutInSeconds: 10,
+				},
+			},
+		},
+	}
+}
+
+func createTestTypedNotifierDefinition(notifierType string, cfg interface{}) notifierDefinition {
+	return notifierDefinition{
+		notifierType: notifierType,
+		key:          createNotifierKey(notifierType, cfg),
+		config:       cfg,
+		create: func() (poll.NotifierHandler, error) {
+			return &mocks.NotifierHandlerStub{}, nil
+		},
+	}
+}
+
+func TestCreateNetworkAlarmDefinitions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty network name should error", func(t *testing.T) {
+		networksConfig := []config.NetworkConfig{createTestNetworkConfig("")}
+
+		definitions, err := createNetworkAlarmDefinitions(networksConfig, config.InventoryConfig{}, keys.NewSharedDiscoverers())
+		assert.Nil(t, definitions)
+		assert.Equal(t, errEmptyNetworkName, err)
+	})
+	t.Run("duplicated network name should error", func(t *testing.T) {
+		networksConfig := []config.NetworkConfig{createTestNetworkConfig("testnet"), createTestNetworkConfig("testnet")}
+
+		definitions, err := createNetworkAlarmDefinitions(networksConfig, config.InventoryConfig{}, keys.NewSharedDiscoverers())
+		assert.Nil(t, definitions)
+		assert.True(t, errors.Is(err, errDuplicatedNetworkName))
+		assert.Equal(t, "duplicated network name: testnet", err.Error())
+	})
+	t.Run("should create the labeled alarms of all the networks", func(t *testing.T) {
+		networksConfig := []config.NetworkConfig{createTestNetworkConfig("mainnet"), createTestNetworkConfig("testnet")}
+
+		definitions, err := createNetworkAlarmDefinitions(networksConfig, createTestInventoryConfig(), keys.NewSharedDiscoverers())
+		require.Nil(t, err)
+		require.Equal(t, 2, len(definitions))
+		assert.Equal(t, "mainnet keys", definitions[0].identifier)
+		assert.Equal(t, "testnet keys", definitions[1].identifier)
+
+		expectedAlarmConfig := networksConfig[1].Alarms.KeysDiscovery[0]
+		expectedAlarmConfig.ApiUrl = "https://testnet-api.elrond.com"
+		assert.Equal(t, networkAlarmConfig{
+			alarm: inventoryAlarmConfig{
+				alarm:     expectedAlarmConfig,
+				inventory: createTestInventoryConfig(),
+			},
+			network: "testnet",
+		}, definitions[1].config)
+
+		handler, err := definitions[1].create()
+		require.Nil(t, err)
+		assert.Equal(t, "testnet keys", handler.Identifier())
+		assert.Equal(t, "testnet", handler.(interface{ Network() string }).Network())
+	})
+}
+
+func TestApplyNetworkDefaults(t *testing.T) {
+	t.Parallel()
+
+	network := config.NetworkConfig{
+		Name:            "devnet",
+		ApiUrl:          "https://devnet-gateway.elrond.com",
+		DiscoveryApiUrl: "https://devnet-api.elrond.com",
+		RatingThreshold: 90,
+		NonceDifference: 5,
+		Alarms: config.AlarmsConfig{
+			NodeRating: []config.NodeRatingAlarmConfig{
+				{Identifier: "default rating"},
+				{
+					Identifier:    "custom rating",
+					ApiUrl:        "http://127.0.0.1:8080",
+					Threshold:     95,
+					KeysDiscovery: config.KeysDiscoveryConfig{ApiUrl: "http://127.0.0.1:3001"},
+				},
+			},
+			NodeNonce: []config.NodeNonceAlarmConfig{
+				{Identifier: "default nonce"},
+				{
+					Identifier:      "custom nonce",
+					ApiUrls:         []string{"http://127.0.0.1:8080", "http://127.0.0.1:8081"},
+					NonceDifference: 10,
+				},
+			},
+			KeysDiscovery: []config.KeysDiscoveryAlarmConfig{
+				{Identifier: "default keys"},
+				{Identifier: "custom keys", ApiUrl: "http://127.0.0.1:3001"},
+			},
+		},
+	}
+
+	alarms := applyNetworkDefaults(network)
+	assert.Equal(t, config.AlarmsConfig{
+		NodeRating: []config.NodeRatingAlarmConfig{
+			{
+				Identifier:    "default rating",
+				ApiUrl:        "https://devnet-gateway.elrond.com",
+				Threshold:     90,
+				KeysDiscovery: config.KeysDiscoveryConfig{ApiUrl: "https://devnet-api.elrond.com"},
+			},
+			network.Alarms.NodeRating[1],
+		},
+		NodeNonce: []config.NodeNonceAlarmConfig{
+			{
+				Identifier:      "default nonce",
+				ApiUrls:         []string{"https://devnet-gateway.elrond.com"},
+				NonceDifference: 5,
+			},
+			network.Alarms.NodeNonce[1],
+		},
+		KeysDiscovery: []config.KeysDiscoveryAlarmConfig{
+			{Identifier: "default keys", ApiUrl: "https://devnet-api.elrond.com"},
+			network.Alarms.KeysDiscovery[1],
+		},
+	}, alarms)
+	assert.Equal(t, "", network.Alarms.NodeRating[0].ApiUrl)
+	assert.Nil(t, network.Alarms.NodeNonce[0].ApiUrls)
+
+	t.Run("network without API url should not default the node nonce API urls", func(t *testing.T) {
+		t.Parallel()
+
+		networkWithoutApiUrl := config.NetworkConfig{
+			Name: "devnet",
+			Alarms: config.AlarmsConfig{
+				NodeNonce: []config.NodeNonceAlarmConfig{{Identifier: "default nonce"}},
+			},
+		}
+
+		result := applyNetworkDefaults(networkWithoutApiUrl)
+		assert.Equal(t, 1, len(result.NodeNonce))
+		assert.Equal(t, 0, len(result.NodeNonce[0].ApiUrls))
+	})
+}
+
+func TestRouteNotifierDefinitions(t *testing.T) {
+	t.Parallel()
+
+	createDefinitions := func() []notifierDefinition {
+		return []notifierDefinition{
+			createTestTypedNotifierDefinition("Slack", 0),
+			createTestTypedNotifierDefinition("Slack", 1),
+			createTestTypedNotifierDefinition("Email", 0),
+		}
+	}
+
+	t.Run("no routing should not alter the definitions", func(t *testing.T) {
+		definitions := createDefinitions()
+		networksConfig := []config.NetworkConfig{createTestNetworkConfig("mainnet")}
+
+		result, err := routeNotifierDefinitions(definitions, networksConfig)
+		assert.Nil(t, err)
+		assert.Equal(t, len(definitions), len(result))
+		for idx := range definitions {
+			assert.Equal(t, definitions[idx].key, result[idx].key)
+		}
+	})
+	t.Run("invalid reference should error", func(t *testing.T) {
+		network := createTestNetworkConfig("testnet")
+		network.Notifiers = []string{"Slack:first"}
+
+		result, err := routeNotifierDefinitions(createDefinitions(), []config.NetworkConfig{network})
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, errInvalidNotifierReference))
+		assert.Equal(t, "invalid notifier reference Slack:first, expected format Type or Type:index in network testnet", err.Error())
+	})
+	t.Run("missing notifier should error", func(t *testing.T) {
+		network := createTestNetworkConfig("testnet")
+		network.Notifiers = []string{"Slack:2"}
+
+		result, err := routeNotifierDefinitions(createDefinitions(), []config.NetworkConfig{network})
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, errNotifierNotFound))
+		assert.Equal(t, "notifier not found: Slack:2 in network testnet", err.Error())
+	})
+	t.Run("should route the notifiers", func(t *testing.T) {
+		definitions := createDefinitions()
+		mainnet := createTestNetworkConfig("mainnet")
+		mainnet.Notifiers = []string{"Slack", "Email"}
+		testnet := createTestNetworkConfig("testnet")
+		testnet.Notifiers = []string{"Slack:1"}
+		devnet := createTestNetworkConfig("devnet")
+		devnet.Notifiers = []string{"Slack:1"}
+		networksConfig := []config.NetworkConfig{mainnet, testnet, devnet, createTestNetworkConfig("localnet")}
+
+		result, err := routeNotifierDefinitions(definitions, networksConfig)
+		require.Nil(t, err)
+		require.Equal(t, 3, len(result))
+		assert.Equal(t, definitions[0].key+"-devnet,testnet", result[0].key)
+		assert.Equal(t, definitions[1].key, result[1].key)
+		assert.Equal(t, definitions[2].key+"-devnet,testnet", result[2].key)
+
+		processed := make([]string, 0)
+		handler, err := result[0].create()
+		require.Nil(t, err)
+		handler.(interface{ Unwrap() poll.NotifierHandler }).Unwrap().(*mocks.NotifierHandlerStub).ProcessAlarmResponseCalled =
+			func(ctx context.Context, response data.AlarmResponse) error {
+				processed = append(processed, response.Labels[data.NetworkLabel])
+				return nil
+			}
+		for _, network := range []string{"mainnet", "testnet", "devnet", "localnet", ""} {
+			err = handler.ProcessAlarmResponse(context.Background(), data.AlarmResponse{
+				Labels: map[string]string{data.NetworkLabel: network},
+			})
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, []string{"mainnet", "localnet", ""}, processed)
+	})
+}
+
+func TestCreateNetworksInfo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid time of day should error", func(t *testing.T) {
+		network := createTestNetworkConfig("testnet")
+		network.InfoTimeOfDay = "11:00"
+
+		networksInfo, err := CreateNetworksInfo([]config.NetworkConfig{network})
+		assert.Nil(t, networksInfo)
+		assert.True(t, errors.Is(err, errInvalidTimeOfDay))
+		assert.Equal(t, "invalid time of day 11:00, expected format hh:mm:ss in network testnet", err.Error())
+	})
+	t.Run("should return only the networks with info reports", func(t *testing.T) {
+		testnet := createTestNetworkConfig("testnet")
+		testnet.InfoTimeOfDay = "12:30:15"
+
+		networksInfo, err := CreateNetworksInfo([]config.NetworkConfig{createTestNetworkConfig("mainnet"), testnet})
+		assert.Nil(t, err)
+		assert.Equal(t, []poll.ArgsNetworkInfo{
+			{Network: "testnet", SendInfoHour: 12, SendInfoMinute: 30, SendInfoSecond: 15},
+		}, networksInfo)
+	})
+}
@@ -0,0 +1,177 @@
+package factory
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+type notifierDefinition struct {
+	notifierType string
+	key          string
+	config       interface{}
+	create       func() (poll.NotifierHandler, error)
+}
+
+// notifiersOfType holds the configs of all the notifiers of one type together with the function creating a notifier
+// from one of these configs
+type notifiersOfType struct {
+	notifierType string
+	configs      interface{}
+	create       func(notifierConfig interface{}) (poll.NotifierHandler, error)
+}
+
+func createNotifiersOfType(cfg config.NotifiersConfig) []notifiersOfType {
+	return []notifiersOfType{
+		{
+			notifierType: "Pushover",
+			configs:      cfg.Pushover,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createPushoverNotifier(notifierConfig.(config.PushoverNotifier))
+			},
+		},
+		{
+			notifierType: "Telegram",
+			configs:      cfg.Telegram,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createTelegramNotifier(notifierConfig.(config.TelegramNotifier))
+			},
+		},
+		{
+			notifierType: "Slack",
+			configs:      cfg.Slack,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createSlackNotifier(notifierConfig.(config.SlackNotifier))
+			},
+		},
+		{
+			notifierType: "Discord",
+			configs:      cfg.Discord,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createDiscordNotifier(notifierConfig.(config.DiscordNotifier))
+			},
+		},
+		{
+			notifierType: "Email",
+			configs:      cfg.Email,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createEmailNotifier(notifierConfig.(config.EmailNotifier))
+			},
+		},
+		{
+			notifierType: "Webhook",
+			configs:      cfg.Webhook,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createWebhookNotifier(notifierConfig.(config.WebhookNotifier))
+			},
+		},
+		{
+			notifierType: "PagerDuty",
+			configs:      cfg.PagerDuty,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createPagerDutyNotifier(notifierConfig.(config.PagerDutyNotifier))
+			},
+		},
+		{
+			notifierType: "Opsgenie",
+			configs:      cfg.Opsgenie,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createOpsgenieNotifier(notifierConfig.(config.OpsgenieNotifier))
+			},
+		},
+		{
+			notifierType: "Matrix",
+			configs:      cfg.Matrix,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createMatrixNotifier(notifierConfig.(config.MatrixNotifier))
+			},
+		},
+		{
+			notifierType: "Teams",
+			configs:      cfg.Teams,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createTeamsNotifier(notifierConfig.(config.TeamsNotifier))
+			},
+		},
+		{
+			notifierType: "Ntfy",
+			configs:      cfg.Ntfy,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createNtfyNotifier(notifierConfig.(config.NtfyNotifier))
+			},
+		},
+		{
+			notifierType: "Gotify",
+			configs:      cfg.Gotify,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createGotifyNotifier(notifierConfig.(config.GotifyNotifier))
+			},
+		},
+		{
+			notifierType: "Script",
+			configs:      cfg.Script,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createScriptNotifier(notifierConfig.(config.ScriptNotifier))
+			},
+		},
+		{
+			notifierType: "Syslog",
+			configs:      cfg.Syslog,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createSyslogNotifier(notifierConfig.(config.SyslogNotifier))
+			},
+		},
+		{
+			notifierType: "File",
+			configs:      cfg.File,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createFileNotifier(notifierConfig.(config.FileNotifier))
+			},
+		},
+		{
+			notifierType: "SMS",
+			configs:      cfg.SMS,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createSMSNotifier(notifierConfig.(config.SMSNotifier))
+			},
+		},
+		{
+			notifierType: "Alertmanager",
+			configs:      cfg.Alertmanager,
+			create: func(notifierConfig interface{}) (poll.NotifierHandler, error) {
+				return createAlertmanagerNotifier(notifierConfig.(config.AlertmanagerNotifier))
+			},
+		},
+	}
+}
+
+func createNotifierDefinitions(cfg config.NotifiersConfig) []notifierDefinition {
+	definitions := make([]notifierDefinition, 0)
+	for _, notifiers := range createNotifiersOfType(cfg) {
+		create := notifiers.create
+		configs := reflect.ValueOf(notifiers.configs)
+		for i := 0; i < configs.Len(); i++ {
+			notifierConfig := configs.Index(i).Interface()
+			definitions = append(definitions, notifierDefinition{
+				notifierType: notifiers.notifierType,
+				key:          createNotifierKey(notifiers.notifierType, notifierConfig),
+				config:       notifierConfig,
+				create: func() (poll.NotifierHandler, error) {
+					return create(notifierConfig)
+				},
+			})
+		}
+	}
+
+	return definitions
+}
+
+// createNotifierKey generates a key that uniquely identifies a notifier's config. As the notifiers do not have
+// identifiers, this is the only way to tell if a notifier's config remained the same between two reloads
+func createNotifierKey(notifierType string, notifierConfig interface{}) string {
+	buff, _ := json.Marshal(notifierConfig)
+
+	return notifierType + string(buff)
+}
@@ -0,0 +1,59 @@
+package factory
+
+import (
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateNotifierDefinitions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty config should return no definitions", func(t *testing.T) {
+		definitions := createNotifierDefinitions(config.NotifiersConfig{})
+		assert.Equal(t, 0, len(definitions))
+	})
+	t.Run("should create one definition for each notifier config", func(t *testing.T) {
+		cfg := config.NotifiersConfig{
+			Pushover: []config.PushoverNotifier{
+				{Token: "token", User: "user1"},
+				{Token: "token", User: "user2"},
+			},
+			File: []config.FileNotifier{
+				{FilePath: "alarms.log"},
+			},
+		}
+
+		definitions := createNotifierDefinitions(cfg)
+		require.Equal(t, 3, len(definitions))
+		assert.Equal(t, "Pushover", definitions[0].notifierType)
+		assert.Equal(t, cfg.Pushover[0], definitions[0].config)
+		assert.Equal(t, createNotifierKey("Pushover", cfg.Pushover[0]), definitions[0].key)
+		assert.Equal(t, "Pushover", definitions[1].notifierType)
+		assert.Equal(t, cfg.Pushover[1], definitions[1].config)
+		assert.NotEqual(t, definitions[0].key, definitions[1].key)
+		assert.Equal(t, "File", definitions[2].notifierType)
+		assert.Equal(t, cfg.File[0], definitions[2].config)
+	})
+	t.Run("each definition should create the notifier from its own config", func(t *testing.T) {
+		cfg := config.NotifiersConfig{
+			Pushover: []config.PushoverNotifier{
+				{Token: "token", User: "user"},
+				{Token: "", User: "user"},
+			},
+		}
+
+		definitions := createNotifierDefinitions(cfg)
+		require.Equal(t, 2, len(definitions))
+
+		notifier, err := definitions[0].create()
+		assert.Nil(t, err)
+		assert.NotNil(t, notifier)
+
+		notifier, err = definitions[1].create()
+		assert.NotNil(t, err)
+		assert.Nil(t, notifier)
+	})
+}
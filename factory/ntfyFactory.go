@@ -0,0 +1,41 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/ntfy"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createNtfyNotifier(cfg config.NtfyNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Ntfy notifier", err)
+	}
+
+	renderer, err := createMessageRenderer(cfg.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Ntfy notifier", err)
+	}
+
+	notifier, err := ntfy.NewNtfyNotifier(ntfy.ArgsNtfyNotifier{
+		HTTPClient:    httpClient,
+		ServerUrl:     cfg.ServerUrl,
+		Topic:         cfg.Topic,
+		Token:         cfg.Token,
+		Username:      cfg.Username,
+		Password:      cfg.Password,
+		ErrorPriority: cfg.ErrorPriority,
+		InfoPriority:  cfg.InfoPriority,
+		Tags:          cfg.Tags,
+		Renderer:      renderer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Ntfy notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,43 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestNtfyConfig() config.NtfyNotifier {
+	return config.NtfyNotifier{
+		Topic:                   "alerts",
+		RequestTimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateNtfyNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestNtfyConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createNtfyNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Ntfy notifier"))
+	})
+	t.Run("empty topic should error", func(t *testing.T) {
+		cfg := createTestNtfyConfig()
+		cfg.Topic = ""
+
+		notifier, err := createNtfyNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Ntfy notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createNtfyNotifier(createTestNtfyConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,39 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/opsgenie"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createOpsgenieNotifier(cfg config.OpsgenieNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Opsgenie notifier", err)
+	}
+
+	renderer, err := createMessageRenderer(cfg.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Opsgenie notifier", err)
+	}
+
+	notifier, err := opsgenie.NewOpsgenieNotifier(opsgenie.ArgsOpsgenieNotifier{
+		HTTPClient:     httpClient,
+		ApiKey:         cfg.ApiKey,
+		Region:         cfg.Region,
+		ApiUrl:         cfg.ApiUrl,
+		ErrorPriority:  cfg.ErrorPriority,
+		InfoPriority:   cfg.InfoPriority,
+		CloseOnStartup: cfg.CloseOnStartup,
+		Renderer:       renderer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Opsgenie notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,44 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestOpsgenieConfig() config.OpsgenieNotifier {
+	return config.OpsgenieNotifier{
+		ApiKey:                  "api key",
+		Region:                  "eu",
+		RequestTimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateOpsgenieNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestOpsgenieConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createOpsgenieNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Opsgenie notifier"))
+	})
+	t.Run("unknown region should error", func(t *testing.T) {
+		cfg := createTestOpsgenieConfig()
+		cfg.Region = "asia"
+
+		notifier, err := createOpsgenieNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Opsgenie notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createOpsgenieNotifier(createTestOpsgenieConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
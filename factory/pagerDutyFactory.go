@@ -0,0 +1,33 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/pagerduty"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createPagerDutyNotifier(cfg config.PagerDutyNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for PagerDuty notifier", err)
+	}
+
+	notifier, err := pagerduty.NewPagerDutyNotifier(pagerduty.ArgsPagerDutyNotifier{
+		HTTPClient:       httpClient,
+		EventsUrl:        cfg.EventsUrl,
+		RoutingKey:       cfg.RoutingKey,
+		ErrorSeverity:    cfg.ErrorSeverity,
+		InfoSeverity:     cfg.InfoSeverity,
+		Source:           cfg.Source,
+		ResolveOnStartup: cfg.ResolveOnStartup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for PagerDuty notifier", err)
+	}
+
+	return notifier, nil
+}
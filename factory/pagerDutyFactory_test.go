@@ -0,0 +1,44 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestPagerDutyConfig() config.PagerDutyNotifier {
+	return config.PagerDutyNotifier{
+		EventsUrl:               "http://localhost",
+		RoutingKey:              "routing key",
+		RequestTimeoutInSeconds: 1,
+	}
+}
+
+func TestCreatePagerDutyNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestPagerDutyConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createPagerDutyNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for PagerDuty notifier"))
+	})
+	t.Run("invalid severity should error", func(t *testing.T) {
+		cfg := createTestPagerDutyConfig()
+		cfg.ErrorSeverity = "fatal"
+
+		notifier, err := createPagerDutyNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for PagerDuty notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createPagerDutyNotifier(createTestPagerDutyConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,33 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/pushover"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+// pushoverRequestTimeout is the timeout of the requests done by the Pushover notifiers
+const pushoverRequestTimeout = 10 * time.Second
+
+func createPushoverNotifier(cfg config.PushoverNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(pushoverRequestTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Pushover notifier", err)
+	}
+
+	notifier, err := pushover.NewPushoverNotifier(pushover.ArgsPushoverNotifier{
+		HTTPClient: httpClient,
+		ApiUrl:     pushover.DefaultApiUrl,
+		Token:      cfg.Token,
+		User:       cfg.User,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Pushover notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,25 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/notifiers/script"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createScriptNotifier(cfg config.ScriptNotifier) (poll.NotifierHandler, error) {
+	notifier, err := script.NewScriptNotifier(script.ArgsScriptNotifier{
+		Command:          cfg.Command,
+		Args:             cfg.Args,
+		WorkingDirectory: cfg.WorkingDirectory,
+		Environment:      cfg.Environment,
+		Timeout:          time.Duration(cfg.TimeoutInSeconds) * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Script notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,44 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestScriptConfig() config.ScriptNotifier {
+	return config.ScriptNotifier{
+		Command:          "sh",
+		Args:             []string{"-c", "cat > /dev/null"},
+		TimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateScriptNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid timeout should error", func(t *testing.T) {
+		cfg := createTestScriptConfig()
+		cfg.TimeoutInSeconds = 0
+
+		notifier, err := createScriptNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Script notifier"))
+	})
+	t.Run("empty command should error", func(t *testing.T) {
+		cfg := createTestScriptConfig()
+		cfg.Command = ""
+
+		notifier, err := createScriptNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Script notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createScriptNotifier(createTestScriptConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,35 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/slack"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createSlackNotifier(cfg config.SlackNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Slack notifier", err)
+	}
+
+	renderer, err := createMessageRenderer(cfg.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Slack notifier", err)
+	}
+
+	notifier, err := slack.NewWebhookNotifier(slack.ArgsWebhookNotifier{
+		HTTPClient: httpClient,
+		WebhookUrl: cfg.WebhookUrl,
+		Format:     slack.PayloadFormat(cfg.Format),
+		Renderer:   renderer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Slack notifier", err)
+	}
+
+	return notifier, nil
+}
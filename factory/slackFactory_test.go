@@ -0,0 +1,49 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSlackNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		notifier, err := createSlackNotifier(config.SlackNotifier{WebhookUrl: "http://localhost"})
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Slack notifier"))
+	})
+	t.Run("invalid format should error", func(t *testing.T) {
+		notifier, err := createSlackNotifier(config.SlackNotifier{
+			WebhookUrl:              "http://localhost",
+			Format:                  "teams",
+			RequestTimeoutInSeconds: 1,
+		})
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Slack notifier"))
+	})
+	t.Run("invalid template should error", func(t *testing.T) {
+		notifier, err := createSlackNotifier(config.SlackNotifier{
+			WebhookUrl:              "http://localhost",
+			RequestTimeoutInSeconds: 1,
+			Templates: config.NotifierTemplates{
+				Error: config.MessageTemplate{Title: "{{.Identifier"},
+			},
+		})
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Slack notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createSlackNotifier(config.SlackNotifier{
+			WebhookUrl:              "http://localhost",
+			Format:                  "mattermost",
+			RequestTimeoutInSeconds: 1,
+		})
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
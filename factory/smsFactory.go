@@ -0,0 +1,69 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/sms"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createSMSNotifier(cfg config.SMSNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for SMS notifier", err)
+	}
+
+	gateway, err := createSMSGateway(cfg, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("%w for SMS notifier", err)
+	}
+
+	renderer, err := createMessageRenderer(cfg.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w for SMS notifier", err)
+	}
+
+	notifier, err := sms.NewSMSNotifier(sms.ArgsSMSNotifier{
+		Gateway:     gateway,
+		Recipients:  cfg.Recipients,
+		MaxSegments: cfg.MaxSegments,
+		DailyLimit:  cfg.DailyLimitPerRecipient,
+		SendInfo:    cfg.SendInfo,
+		Renderer:    renderer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for SMS notifier", err)
+	}
+
+	return notifier, nil
+}
+
+func createSMSGateway(cfg config.SMSNotifier, httpClient sms.HTTPClient) (sms.Gateway, error) {
+	switch sms.Provider(cfg.Provider) {
+	case sms.TwilioProvider:
+		return sms.NewTwilioGateway(sms.ArgsTwilioGateway{
+			HTTPClient:          httpClient,
+			ApiUrl:              cfg.Twilio.ApiUrl,
+			AccountSID:          cfg.Twilio.AccountSID,
+			AuthToken:           cfg.Twilio.AuthToken,
+			From:                cfg.Twilio.From,
+			MessagingServiceSID: cfg.Twilio.MessagingServiceSID,
+		})
+	case sms.TemplatedProvider:
+		return sms.NewTemplatedGateway(sms.ArgsTemplatedGateway{
+			HTTPClient:          httpClient,
+			Url:                 cfg.Gateway.Url,
+			Method:              cfg.Gateway.Method,
+			Headers:             cfg.Gateway.Headers,
+			Body:                cfg.Gateway.Body,
+			From:                cfg.Gateway.From,
+			ExpectedStatusCodes: cfg.Gateway.ExpectedStatusCodes,
+		})
+	default:
+		return nil, fmt.Errorf("%w %q, supported providers: %s, %s", errUnknownSMSProvider, cfg.Provider,
+			sms.TwilioProvider, sms.TemplatedProvider)
+	}
+}
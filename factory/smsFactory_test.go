@@ -0,0 +1,78 @@
+package factory
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestSMSConfig() config.SMSNotifier {
+	return config.SMSNotifier{
+		Provider:                "twilio",
+		Recipients:              []string{"+40712345678"},
+		RequestTimeoutInSeconds: 1,
+		Twilio: config.SMSTwilioConfig{
+			AccountSID: "AC123",
+			AuthToken:  "token",
+			From:       "+15005550006",
+		},
+		Gateway: config.SMSGatewayConfig{
+			Url: "http://127.0.0.1/send",
+		},
+	}
+}
+
+func TestCreateSMSNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestSMSConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createSMSNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for SMS notifier"))
+	})
+	t.Run("unknown provider should error", func(t *testing.T) {
+		cfg := createTestSMSConfig()
+		cfg.Provider = "carrier pigeon"
+
+		notifier, err := createSMSNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errUnknownSMSProvider))
+		assert.True(t, strings.Contains(err.Error(), "for SMS notifier"))
+	})
+	t.Run("invalid Twilio config should error", func(t *testing.T) {
+		cfg := createTestSMSConfig()
+		cfg.Twilio.AuthToken = ""
+
+		notifier, err := createSMSNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for SMS notifier"))
+	})
+	t.Run("invalid recipient should error", func(t *testing.T) {
+		cfg := createTestSMSConfig()
+		cfg.Recipients = []string{"0712345678"}
+
+		notifier, err := createSMSNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for SMS notifier"))
+	})
+	t.Run("should work with Twilio", func(t *testing.T) {
+		notifier, err := createSMSNotifier(createTestSMSConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+	t.Run("should work with the templated gateway", func(t *testing.T) {
+		cfg := createTestSMSConfig()
+		cfg.Provider = "template"
+
+		notifier, err := createSMSNotifier(cfg)
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
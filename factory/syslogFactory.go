@@ -0,0 +1,27 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/notifiers/syslog"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createSyslogNotifier(cfg config.SyslogNotifier) (poll.NotifierHandler, error) {
+	notifier, err := syslog.NewSyslogNotifier(syslog.ArgsSyslogNotifier{
+		Network:          cfg.Network,
+		Address:          cfg.Address,
+		Facility:         cfg.Facility,
+		AppName:          cfg.AppName,
+		Hostname:         cfg.Hostname,
+		EnterpriseNumber: cfg.EnterpriseNumber,
+		Timeout:          time.Duration(cfg.TimeoutInSeconds) * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Syslog notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,44 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestSyslogConfig() config.SyslogNotifier {
+	return config.SyslogNotifier{
+		Network:          "udp",
+		Address:          "127.0.0.1:514",
+		TimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateSyslogNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid timeout should error", func(t *testing.T) {
+		cfg := createTestSyslogConfig()
+		cfg.TimeoutInSeconds = 0
+
+		notifier, err := createSyslogNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Syslog notifier"))
+	})
+	t.Run("invalid facility should error", func(t *testing.T) {
+		cfg := createTestSyslogConfig()
+		cfg.Facility = "local8"
+
+		notifier, err := createSyslogNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Syslog notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createSyslogNotifier(createTestSyslogConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,34 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/teams"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createTeamsNotifier(cfg config.TeamsNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Teams notifier", err)
+	}
+
+	renderer, err := createMessageRenderer(cfg.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Teams notifier", err)
+	}
+
+	notifier, err := teams.NewWebhookNotifier(teams.ArgsWebhookNotifier{
+		HTTPClient: httpClient,
+		WebhookUrl: cfg.WebhookUrl,
+		Renderer:   renderer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Teams notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,43 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestTeamsConfig() config.TeamsNotifier {
+	return config.TeamsNotifier{
+		WebhookUrl:              "http://localhost",
+		RequestTimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateTeamsNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestTeamsConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createTeamsNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Teams notifier"))
+	})
+	t.Run("empty webhook URL should error", func(t *testing.T) {
+		cfg := createTestTeamsConfig()
+		cfg.WebhookUrl = ""
+
+		notifier, err := createTeamsNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Teams notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createTeamsNotifier(createTestTeamsConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,89 @@
+package factory
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/telegram"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createTelegramBotClient(cfg config.TelegramNotifier, requestTimeout time.Duration) (telegram.BotClient, error) {
+	httpClient, err := http.NewHTTPClientWrapper(requestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return telegram.NewBotClient(telegram.ArgsBotClient{
+		HTTPClient: httpClient,
+		ApiUrl:     cfg.ApiUrl,
+		Token:      cfg.Token,
+	})
+}
+
+func createTelegramNotifier(cfg config.TelegramNotifier) (poll.NotifierHandler, error) {
+	botClient, err := createTelegramBotClient(cfg, time.Duration(cfg.RequestTimeoutInSeconds)*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Telegram notifier", err)
+	}
+
+	renderer, err := createMessageRenderer(cfg.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Telegram notifier", err)
+	}
+
+	notifier, err := telegram.NewTelegramNotifier(telegram.ArgsTelegramNotifier{
+		BotClient: botClient,
+		ChatIDs:   cfg.ChatIDs,
+		Renderer:  renderer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Telegram notifier", err)
+	}
+
+	return notifier, nil
+}
+
+// CreateTelegramCommandsHandlers creates the commands handlers for all Telegram notifiers that have the commands
+// enabled. The returned handlers should be closed when the application stops
+func CreateTelegramCommandsHandlers(cfg config.NotifiersConfig, controller telegram.AlarmsController) ([]io.Closer, error) {
+	handlers := make([]io.Closer, 0)
+	for _, notifierConfig := range cfg.Telegram {
+		if !notifierConfig.Commands.Enabled {
+			continue
+		}
+
+		longPollingTimeout := time.Duration(notifierConfig.Commands.LongPollingTimeoutInSeconds) * time.Second
+		// the long polling request must not be interrupted by the HTTP client
+		requestTimeout := longPollingTimeout + time.Duration(notifierConfig.RequestTimeoutInSeconds)*time.Second
+		botClient, err := createTelegramBotClient(notifierConfig, requestTimeout)
+		if err != nil {
+			closeAll(handlers)
+			return nil, fmt.Errorf("%w for Telegram commands handler", err)
+		}
+
+		handler, err := telegram.NewCommandsHandler(telegram.ArgsCommandsHandler{
+			BotClient:          botClient,
+			AlarmsController:   controller,
+			AllowedUserIDs:     notifierConfig.Commands.AllowedUserIDs,
+			LongPollingTimeout: longPollingTimeout,
+		})
+		if err != nil {
+			closeAll(handlers)
+			return nil, fmt.Errorf("%w for Telegram commands handler", err)
+		}
+
+		handlers = append(handlers, handler)
+	}
+
+	return handlers, nil
+}
+
+func closeAll(closers []io.Closer) {
+	for _, closer := range closers {
+		_ = closer.Close()
+	}
+}
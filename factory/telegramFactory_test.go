@@ -0,0 +1,89 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestTelegramConfig() config.TelegramNotifier {
+	return config.TelegramNotifier{
+		ApiUrl:                  "http://127.0.0.1:1",
+		Token:                   "token",
+		ChatIDs:                 []int64{1},
+		RequestTimeoutInSeconds: 1,
+		Commands: config.TelegramCommandsConfig{
+			Enabled:                     true,
+			AllowedUserIDs:              []int64{1},
+			LongPollingTimeoutInSeconds: 1,
+		},
+	}
+}
+
+func TestCreateTelegramNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestTelegramConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createTelegramNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Telegram notifier"))
+	})
+	t.Run("no chat IDs should error", func(t *testing.T) {
+		cfg := createTestTelegramConfig()
+		cfg.ChatIDs = nil
+
+		notifier, err := createTelegramNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.NotNil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createTelegramNotifier(createTestTelegramConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
+
+func TestCreateTelegramCommandsHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled commands should not create handlers", func(t *testing.T) {
+		cfg := createTestTelegramConfig()
+		cfg.Commands.Enabled = false
+
+		handlers, err := CreateTelegramCommandsHandlers(config.NotifiersConfig{Telegram: []config.TelegramNotifier{cfg}}, &mocks.AlarmsControllerStub{})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(handlers))
+	})
+	t.Run("invalid config should error", func(t *testing.T) {
+		cfg := createTestTelegramConfig()
+		invalidCfg := createTestTelegramConfig()
+		invalidCfg.Commands.AllowedUserIDs = nil
+
+		notifiersConfig := config.NotifiersConfig{Telegram: []config.TelegramNotifier{cfg, invalidCfg}}
+		handlers, err := CreateTelegramCommandsHandlers(notifiersConfig, &mocks.AlarmsControllerStub{})
+		assert.Nil(t, handlers)
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "for Telegram commands handler"))
+	})
+	t.Run("nil controller should error", func(t *testing.T) {
+		notifiersConfig := config.NotifiersConfig{Telegram: []config.TelegramNotifier{createTestTelegramConfig()}}
+		handlers, err := CreateTelegramCommandsHandlers(notifiersConfig, nil)
+		assert.Nil(t, handlers)
+		assert.NotNil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifiersConfig := config.NotifiersConfig{Telegram: []config.TelegramNotifier{createTestTelegramConfig()}}
+		handlers, err := CreateTelegramCommandsHandlers(notifiersConfig, &mocks.AlarmsControllerStub{})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(handlers))
+
+		closeAll(handlers)
+	})
+}
@@ -0,0 +1,97 @@
+package factory
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+// TemplatedNotifiers lists the notifier types supporting the message templates. The other notifiers send structured
+// payloads (Webhook with its own request templates, PagerDuty, Alertmanager, Syslog, File and Script) which are not
+// rendered from the message templates, while Pushover sends its own plain text messages
+var TemplatedNotifiers = []string{
+	"Telegram", "Slack", "Discord", "Teams", "Email", "Matrix", "Ntfy", "Gotify", "SMS", "Opsgenie",
+}
+
+// CreateMessageRenderer creates the component that renders the messages from the provided templates, the missing
+// ones being replaced by the built-in templates
+func CreateMessageRenderer(cfg config.NotifierTemplates) (templating.MessageRenderer, error) {
+	return templating.NewMessageRenderer(templating.ArgsMessageRenderer{
+		Default: templating.MessageTemplate(cfg.Default),
+		Levels: map[data.EventLevel]templating.MessageTemplate{
+			data.Error: templating.MessageTemplate(cfg.Error),
+			data.Info:  templating.MessageTemplate(cfg.Info),
+		},
+	})
+}
+
+// createMessageRenderer returns a nil renderer if no template was configured, so the notifier keeps its built-in
+// layout
+func createMessageRenderer(cfg config.NotifierTemplates) (templating.MessageRenderer, error) {
+	if cfg == (config.NotifierTemplates{}) {
+		return nil, nil
+	}
+
+	return CreateMessageRenderer(cfg)
+}
+
+// GetNotifierTemplates returns the templates of the notifier with the provided type (e.g. Slack) and index in the
+// configuration file
+func GetNotifierTemplates(cfg config.NotifiersConfig, notifierType string, index int) (config.NotifierTemplates, error) {
+	templates := make([]config.NotifierTemplates, 0)
+	switch notifierType {
+	case "Telegram":
+		for _, notifierConfig := range cfg.Telegram {
+			templates = append(templates, notifierConfig.Templates)
+		}
+	case "Slack":
+		for _, notifierConfig := range cfg.Slack {
+			templates = append(templates, notifierConfig.Templates)
+		}
+	case "Discord":
+		for _, notifierConfig := range cfg.Discord {
+			templates = append(templates, notifierConfig.Templates)
+		}
+	case "Teams":
+		for _, notifierConfig := range cfg.Teams {
+			templates = append(templates, notifierConfig.Templates)
+		}
+	case "Email":
+		for _, notifierConfig := range cfg.Email {
+			templates = append(templates, notifierConfig.Templates)
+		}
+	case "Matrix":
+		for _, notifierConfig := range cfg.Matrix {
+			templates = append(templates, notifierConfig.Templates)
+		}
+	case "Ntfy":
+		for _, notifierConfig := range cfg.Ntfy {
+			templates = append(templates, notifierConfig.Templates)
+		}
+	case "Gotify":
+		for _, notifierConfig := range cfg.Gotify {
+			templates = append(templates, notifierConfig.Templates)
+		}
+	case "SMS":
+		for _, notifierConfig := range cfg.SMS {
+			templates = append(templates, notifierConfig.Templates)
+		}
+	case "Opsgenie":
+		for _, notifierConfig := range cfg.Opsgenie {
+			templates = append(templates, notifierConfig.Templates)
+		}
+	default:
+		return config.NotifierTemplates{}, fmt.Errorf("%w %q, supported notifiers: %s", errUnsupportedTemplates,
+			notifierType, strings.Join(TemplatedNotifiers, ", "))
+	}
+
+	if index < 0 || index >= len(templates) {
+		return config.NotifierTemplates{}, fmt.Errorf("%w, %d %s notifier(s) defined, provided index: %d",
+			errNotifierNotFound, len(templates), notifierType, index)
+	}
+
+	return templates[index], nil
+}
@@ -0,0 +1,104 @@
+package factory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMessageRenderer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no templates should not create a renderer", func(t *testing.T) {
+		renderer, err := createMessageRenderer(config.NotifierTemplates{})
+		assert.Nil(t, renderer)
+		assert.Nil(t, err)
+	})
+	t.Run("invalid template should error", func(t *testing.T) {
+		renderer, err := createMessageRenderer(config.NotifierTemplates{
+			Info: config.MessageTemplate{Body: "{{.Data | missing}}"},
+		})
+		assert.True(t, check.IfNil(renderer))
+		assert.NotNil(t, err)
+	})
+	t.Run("should use the level templates", func(t *testing.T) {
+		renderer, err := createMessageRenderer(config.NotifierTemplates{
+			Default: config.MessageTemplate{Title: "default {{.Identifier}}"},
+			Error:   config.MessageTemplate{Title: "error {{.Identifier}}"},
+		})
+		require.False(t, check.IfNil(renderer))
+		require.Nil(t, err)
+
+		message, err := renderer.Render(templating.SampleResponse(data.Error))
+		assert.Nil(t, err)
+		assert.Equal(t, "error node rating", message.Title)
+
+		message, err = renderer.Render(templating.SampleResponse(data.Info))
+		assert.Nil(t, err)
+		assert.Equal(t, "default system", message.Title)
+	})
+	t.Run("exported function should always create a renderer", func(t *testing.T) {
+		renderer, err := CreateMessageRenderer(config.NotifierTemplates{})
+		require.False(t, check.IfNil(renderer))
+		require.Nil(t, err)
+
+		message, err := renderer.Render(templating.SampleResponse(data.Error))
+		assert.Nil(t, err)
+		assert.Equal(t, "🚨 ERROR | node rating", message.Title)
+	})
+}
+
+func TestGetNotifierTemplates(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.NotifiersConfig{
+		Slack: []config.SlackNotifier{
+			{},
+			{Templates: config.NotifierTemplates{Default: config.MessageTemplate{Title: "slack"}}},
+		},
+		SMS: []config.SMSNotifier{
+			{Templates: config.NotifierTemplates{Error: config.MessageTemplate{Body: "sms"}}},
+		},
+		Teams: []config.TeamsNotifier{
+			{Templates: config.NotifierTemplates{Default: config.MessageTemplate{Title: "teams"}}},
+		},
+		Opsgenie: []config.OpsgenieNotifier{
+			{Templates: config.NotifierTemplates{Info: config.MessageTemplate{Body: "opsgenie"}}},
+		},
+	}
+
+	t.Run("unsupported notifier should error", func(t *testing.T) {
+		_, err := GetNotifierTemplates(cfg, "Webhook", 0)
+		assert.True(t, errors.Is(err, errUnsupportedTemplates))
+	})
+	t.Run("index out of range should error", func(t *testing.T) {
+		_, err := GetNotifierTemplates(cfg, "Slack", 2)
+		assert.True(t, errors.Is(err, errNotifierNotFound))
+
+		_, err = GetNotifierTemplates(cfg, "Telegram", 0)
+		assert.True(t, errors.Is(err, errNotifierNotFound))
+	})
+	t.Run("should work", func(t *testing.T) {
+		templates, err := GetNotifierTemplates(cfg, "Slack", 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "slack", templates.Default.Title)
+
+		templates, err = GetNotifierTemplates(cfg, "SMS", 0)
+		assert.Nil(t, err)
+		assert.Equal(t, "sms", templates.Error.Body)
+
+		templates, err = GetNotifierTemplates(cfg, "Teams", 0)
+		assert.Nil(t, err)
+		assert.Equal(t, "teams", templates.Default.Title)
+
+		templates, err = GetNotifierTemplates(cfg, "Opsgenie", 0)
+		assert.Nil(t, err)
+		assert.Equal(t, "opsgenie", templates.Info.Body)
+	})
+}
@@ -0,0 +1,35 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+)
+
+const timeOfDayLayout = "15:04:05"
+
+// TimeOfDay holds the parsed InfoTimeOfDay config value
+type TimeOfDay struct {
+	Active bool
+	Hour   int
+	Minute int
+	Second int
+}
+
+// ParseTimeOfDay parses a value in the hh:mm:ss format. An empty value means that the info message is disabled
+func ParseTimeOfDay(value string) (TimeOfDay, error) {
+	if len(value) == 0 {
+		return TimeOfDay{}, nil
+	}
+
+	t, err := time.Parse(timeOfDayLayout, value)
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("%w %s, expected format hh:mm:ss", errInvalidTimeOfDay, value)
+	}
+
+	return TimeOfDay{
+		Active: true,
+		Hour:   t.Hour(),
+		Minute: t.Minute(),
+		Second: t.Second(),
+	}, nil
+}
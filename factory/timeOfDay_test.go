@@ -0,0 +1,31 @@
+package factory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty value should return inactive", func(t *testing.T) {
+		result, err := ParseTimeOfDay("")
+		assert.Nil(t, err)
+		assert.Equal(t, TimeOfDay{}, result)
+	})
+	t.Run("invalid value should error", func(t *testing.T) {
+		result, err := ParseTimeOfDay("25:00:00")
+		assert.True(t, errors.Is(err, errInvalidTimeOfDay))
+		assert.Equal(t, TimeOfDay{}, result)
+
+		_, err = ParseTimeOfDay("11:00")
+		assert.True(t, errors.Is(err, errInvalidTimeOfDay))
+	})
+	t.Run("should work", func(t *testing.T) {
+		result, err := ParseTimeOfDay("11:22:33")
+		assert.Nil(t, err)
+		assert.Equal(t, TimeOfDay{Active: true, Hour: 11, Minute: 22, Second: 33}, result)
+	})
+}
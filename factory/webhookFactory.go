@@ -0,0 +1,34 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/webhook"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+func createWebhookNotifier(cfg config.WebhookNotifier) (poll.NotifierHandler, error) {
+	httpClient, err := http.NewHTTPClientWrapper(time.Duration(cfg.RequestTimeoutInSeconds) * time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w for Webhook notifier", err)
+	}
+
+	notifier, err := webhook.NewWebhookNotifier(webhook.ArgsWebhookNotifier{
+		HTTPClient:          httpClient,
+		Url:                 cfg.Url,
+		Method:              cfg.Method,
+		Headers:             cfg.Headers,
+		Body:                cfg.Body,
+		SigningSecret:       cfg.SigningSecret,
+		SignatureHeader:     cfg.SignatureHeader,
+		ExpectedStatusCodes: cfg.ExpectedStatusCodes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w for Webhook notifier", err)
+	}
+
+	return notifier, nil
+}
@@ -0,0 +1,45 @@
+package factory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestWebhookConfig() config.WebhookNotifier {
+	return config.WebhookNotifier{
+		Url:                     "http://localhost/{{.Identifier}}",
+		Headers:                 map[string]string{"X-Alarm": "{{.Identifier}}"},
+		Body:                    "{{json .Data}}",
+		RequestTimeoutInSeconds: 1,
+	}
+}
+
+func TestCreateWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid request timeout should error", func(t *testing.T) {
+		cfg := createTestWebhookConfig()
+		cfg.RequestTimeoutInSeconds = 0
+
+		notifier, err := createWebhookNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Webhook notifier"))
+	})
+	t.Run("invalid template should error", func(t *testing.T) {
+		cfg := createTestWebhookConfig()
+		cfg.Body = "{{json .Data"
+
+		notifier, err := createWebhookNotifier(cfg)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Webhook notifier"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := createWebhookNotifier(createTestWebhookConfig())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
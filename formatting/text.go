@@ -0,0 +1,27 @@
+package formatting
+
+// TruncateSuffix is appended to the truncated texts
+const TruncateSuffix = "…"
+
+const shortenedPubkeyEdge = 6
+
+// Truncate will limit the text to the provided number of characters (runes), replacing the last one with
+// TruncateSuffix if the text was longer
+func Truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+
+	return string(runes[:maxLength-1]) + TruncateSuffix
+}
+
+// ShortenPubkey keeps only the edges of a long key, e.g. "c26a1d…f80f0c"
+func ShortenPubkey(pubkey string) string {
+	runes := []rune(pubkey)
+	if len(runes) <= 2*shortenedPubkeyEdge+1 {
+		return pubkey
+	}
+
+	return string(runes[:shortenedPubkeyEdge]) + TruncateSuffix + string(runes[len(runes)-shortenedPubkeyEdge:])
+}
@@ -0,0 +1,23 @@
+package formatting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "abc", Truncate("abc", 3))
+	assert.Equal(t, "ab…", Truncate("abcd", 3))
+	assert.Equal(t, "ăî…", Truncate("ăîșț", 3))
+}
+
+func TestShortenPubkey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", ShortenPubkey(""))
+	assert.Equal(t, "0123456789abc", ShortenPubkey("0123456789abc"))
+	assert.Equal(t, "012345…89abcd", ShortenPubkey("0123456789abcd"))
+}
@@ -15,9 +15,10 @@ import (
 var log = logger.GetOrCreate("http/client")
 
 const (
-	minRequestTimeout = time.Second
-	userAgent         = "Elrond Node Monitoring / 1.0.0 <Requesting data from api>"
-	applicationType   = "application/json"
+	maxErrorBodyLength = 512
+	minRequestTimeout  = time.Second
+	userAgent          = "Elrond Node Monitoring / 1.0.0 <Requesting data from api>"
+	applicationType    = "application/json"
 )
 
 type httpClientWrapper struct {
@@ -30,11 +31,11 @@ func NewHTTPClientWrapper(requestTimeout time.Duration) (*httpClientWrapper, err
 		return nil, fmt.Errorf("%w, provided: %v, minimum: %v", errInvalidValue, requestTimeout, minRequestTimeout)
 	}
 
-	httpClient := http.DefaultClient
-	httpClient.Timeout = requestTimeout
-
+	// each wrapper has its own client as the timeouts might differ (e.g. long polling requests)
 	return &httpClientWrapper{
-		httpClient: httpClient,
+		httpClient: &http.Client{
+			Timeout: requestTimeout,
+		},
 	}, nil
 }
 
@@ -61,30 +62,98 @@ func (hcw *httpClientWrapper) CallGetRestEndPoint(ctx context.Context, url strin
 	return ioutil.ReadAll(resp.Body)
 }
 
-// CallPostRestEndPoint calls an external end point
+// CallPostRestEndPoint calls an external end point. A response status code other than 2xx is returned as error
 func (hcw *httpClientWrapper) CallPostRestEndPoint(ctx context.Context, url string, data interface{}) error {
-	buff, err := json.Marshal(data)
+	statusCode, buff, err := hcw.post(ctx, url, data)
 	if err != nil {
 		return err
 	}
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		if len(buff) > maxErrorBodyLength {
+			buff = buff[:maxErrorBodyLength]
+		}
+
+		return fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, statusCode, buff)
+	}
+
+	return nil
+}
+
+// CallPostRestEndPointWithResponse calls an external end point and returns the response body regardless
+// of the response status code
+func (hcw *httpClientWrapper) CallPostRestEndPointWithResponse(ctx context.Context, url string, data interface{}) ([]byte, error) {
+	_, buff, err := hcw.post(ctx, url, data)
+
+	return buff, err
+}
+
+func (hcw *httpClientWrapper) post(ctx context.Context, url string, data interface{}) (int, []byte, error) {
+	buff, err := json.Marshal(data)
+	if err != nil {
+		return 0, nil, err
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(buff))
 	if err != nil {
-		return err
+		return 0, nil, err
 	}
 
 	applyPostHeaders(req)
 	resp, err := hcw.httpClient.Do(req)
 	if err != nil {
-		return err
+		return 0, nil, err
 	}
 
-	errNotCritical := resp.Body.Close()
-	if errNotCritical != nil {
-		log.Warn("base process GET: close body", "error", errNotCritical.Error())
+	defer func() {
+		errNotCritical := resp.Body.Close()
+		if errNotCritical != nil {
+			log.Warn("base process POST: close body", "error", errNotCritical.Error())
+		}
+	}()
+
+	buff, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
 	}
 
-	return nil
+	return resp.StatusCode, buff, nil
+}
+
+// CallEndPoint sends the provided request and returns the response regardless of its status code. The provided
+// headers override the default ones
+func (hcw *httpClientWrapper) CallEndPoint(ctx context.Context, request Request) (Response, error) {
+	req, err := http.NewRequestWithContext(ctx, request.Method, request.Url, bytes.NewReader(request.Body))
+	if err != nil {
+		return Response{}, err
+	}
+
+	applyPostHeaders(req)
+	for key, value := range request.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := hcw.httpClient.Do(req)
+	if err != nil {
+		return Response{}, err
+	}
+
+	defer func() {
+		errNotCritical := resp.Body.Close()
+		if errNotCritical != nil {
+			log.Warn("base process request: close body", "method", request.Method, "error", errNotCritical.Error())
+		}
+	}()
+
+	buff, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Response{}, err
+	}
+
+	return Response{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Body:       buff,
+	}, nil
 }
 
 func applyGetHeaders(request *http.Request) {
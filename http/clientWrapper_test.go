@@ -142,3 +142,125 @@ func TestHttpClientWrapper_CallPostRestEndPoint(t *testing.T) {
 		assert.Equal(t, string(expectedBuff), string(result))
 	})
 }
+
+func TestHttpClientWrapper_CallPostRestEndPointShouldCheckTheStatusCode(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("invalid_payload"))
+	}))
+	defer svr.Close()
+
+	client, _ := NewHTTPClientWrapper(time.Second)
+
+	err := client.CallPostRestEndPoint(context.Background(), svr.URL+testUrl, "test")
+
+	assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+	assert.True(t, strings.Contains(err.Error(), "400: invalid_payload"))
+}
+
+func TestHttpClientWrapper_CallPostRestEndPointWithResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid url should error", func(t *testing.T) {
+		client, _ := NewHTTPClientWrapper(time.Second)
+
+		buff, err := client.CallPostRestEndPointWithResponse(context.Background(), "invalid url", "test")
+
+		assert.Nil(t, buff)
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "unsupported protocol scheme"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		buffToSend := []byte("response")
+
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, r.Method, http.MethodPost)
+			assert.Equal(t, applicationType, r.Header.Get("Content-Type"))
+
+			buff, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			assert.Equal(t, `"test"`, string(buff))
+
+			w.WriteHeader(http.StatusBadRequest)
+			_, err = w.Write(buffToSend)
+			assert.Nil(t, err)
+		}))
+		defer svr.Close()
+
+		client, _ := NewHTTPClientWrapper(time.Second)
+
+		buff, err := client.CallPostRestEndPointWithResponse(context.Background(), svr.URL+testUrl, "test")
+
+		assert.Nil(t, err)
+		assert.Equal(t, buffToSend, buff)
+	})
+}
+
+func TestNewHTTPClientWrapper_ShouldNotShareTheTimeout(t *testing.T) {
+	t.Parallel()
+
+	client1, _ := NewHTTPClientWrapper(time.Second)
+	client2, _ := NewHTTPClientWrapper(time.Minute)
+
+	assert.Equal(t, time.Second, client1.httpClient.Timeout)
+	assert.Equal(t, time.Minute, client2.httpClient.Timeout)
+	assert.Equal(t, time.Duration(0), http.DefaultClient.Timeout)
+}
+
+func TestHttpClientWrapper_CallEndPoint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid method should error", func(t *testing.T) {
+		client, _ := NewHTTPClientWrapper(time.Second)
+
+		response, err := client.CallEndPoint(context.Background(), Request{Method: "bad method", Url: "http://localhost"})
+
+		assert.Equal(t, Response{}, response)
+		assert.NotNil(t, err)
+	})
+	t.Run("invalid url should error", func(t *testing.T) {
+		client, _ := NewHTTPClientWrapper(time.Second)
+
+		_, err := client.CallEndPoint(context.Background(), Request{Method: http.MethodPut, Url: "invalid url"})
+
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "unsupported protocol scheme"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, testUrl, r.URL.Path)
+			assert.Equal(t, userAgent, r.Header.Get("User-Agent"))
+			assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+			assert.Equal(t, "value", r.Header.Get("X-Custom"))
+
+			buff, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			assert.Equal(t, "body", string(buff))
+
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte("slow down"))
+		}))
+		defer svr.Close()
+
+		client, _ := NewHTTPClientWrapper(time.Second)
+
+		response, err := client.CallEndPoint(context.Background(), Request{
+			Method: http.MethodPut,
+			Url:    svr.URL + testUrl,
+			Headers: map[string]string{
+				"Content-Type": "text/plain",
+				"X-Custom":     "value",
+			},
+			Body: []byte("body"),
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+		assert.Equal(t, "2", response.Headers.Get("Retry-After"))
+		assert.Equal(t, "slow down", string(response.Body))
+	})
+}
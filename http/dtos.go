@@ -0,0 +1,18 @@
+package http
+
+import "net/http"
+
+// Request holds the data of a generic HTTP request
+type Request struct {
+	Method  string
+	Url     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// Response holds the data of a generic HTTP response
+type Response struct {
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+}
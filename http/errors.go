@@ -3,3 +3,4 @@ package http
 import "errors"
 
 var errInvalidValue = errors.New("invalid value")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
@@ -0,0 +1,60 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+type enrichedAlarmHandler struct {
+	poll.AlarmHandler
+	enricher ResponseEnricher
+}
+
+// NewEnrichedAlarmHandler wraps the provided alarm so its responses and info texts are enriched with the inventory
+// details, all the other operations being forwarded to the alarm
+func NewEnrichedAlarmHandler(handler poll.AlarmHandler, enricher ResponseEnricher) (*enrichedAlarmHandler, error) {
+	if check.IfNil(handler) {
+		return nil, errNilAlarmHandler
+	}
+	if check.IfNil(enricher) {
+		return nil, errNilResponseEnricher
+	}
+
+	return &enrichedAlarmHandler{
+		AlarmHandler: handler,
+		enricher:     enricher,
+	}, nil
+}
+
+// Query queries the wrapped alarm and enriches its response
+func (handler *enrichedAlarmHandler) Query(ctx context.Context) (data.AlarmResponse, error) {
+	response, err := handler.AlarmHandler.Query(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	return handler.enricher.EnrichResponse(response), nil
+}
+
+// QueryInfo queries the wrapped alarm's info and enriches the returned text
+func (handler *enrichedAlarmHandler) QueryInfo(ctx context.Context) (string, error) {
+	info, err := handler.AlarmHandler.QueryInfo(ctx)
+	if err != nil {
+		return info, err
+	}
+
+	return handler.enricher.EnrichText(info), nil
+}
+
+// Unwrap returns the wrapped alarm
+func (handler *enrichedAlarmHandler) Unwrap() poll.AlarmHandler {
+	return handler.AlarmHandler
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *enrichedAlarmHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
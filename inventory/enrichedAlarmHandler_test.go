@@ -0,0 +1,101 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEnrichedAlarmHandler(t *testing.T) {
+	t.Parallel()
+
+	inv, _ := NewInventory(createMockArgsInventory())
+	t.Run("nil alarm handler should error", func(t *testing.T) {
+		handler, err := NewEnrichedAlarmHandler(nil, inv)
+		assert.True(t, check.IfNil(handler))
+		assert.Equal(t, errNilAlarmHandler, err)
+	})
+	t.Run("nil response enricher should error", func(t *testing.T) {
+		handler, err := NewEnrichedAlarmHandler(&mocks.AlarmHandlerStub{}, nil)
+		assert.True(t, check.IfNil(handler))
+		assert.Equal(t, errNilResponseEnricher, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		alarm := &mocks.AlarmHandlerStub{}
+		handler, err := NewEnrichedAlarmHandler(alarm, inv)
+		assert.False(t, check.IfNil(handler))
+		assert.Nil(t, err)
+		assert.True(t, handler.Unwrap() == alarm)
+	})
+}
+
+func TestEnrichedAlarmHandler_Query(t *testing.T) {
+	t.Parallel()
+
+	inv, _ := NewInventory(createMockArgsInventory())
+	t.Run("query error should not enrich", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		handler, _ := NewEnrichedAlarmHandler(&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				return data.AlarmResponse{}, expectedErr
+			},
+		}, inv)
+
+		response, err := handler.Query(context.Background())
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, data.AlarmResponse{}, response)
+	})
+	t.Run("should enrich the response", func(t *testing.T) {
+		handler, _ := NewEnrichedAlarmHandler(&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				return data.AlarmResponse{
+					Identifier: "node nonce",
+					Level:      data.Error,
+					Labels:     map[string]string{data.ApiUrlLabel: "http://192.168.169.111:8080"},
+				}, nil
+			},
+			IdentifierCalled: func() string {
+				return "node nonce"
+			},
+		}, inv)
+
+		response, err := handler.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "observer-01", response.Labels[data.NodeLabel])
+		assert.Equal(t, "node nonce", handler.Identifier())
+	})
+}
+
+func TestEnrichedAlarmHandler_QueryInfo(t *testing.T) {
+	t.Parallel()
+
+	inv, _ := NewInventory(createMockArgsInventory())
+	t.Run("query error should not enrich", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		handler, _ := NewEnrichedAlarmHandler(&mocks.AlarmHandlerStub{
+			QueryInfoCalled: func(ctx context.Context) (string, error) {
+				return "", expectedErr
+			},
+		}, inv)
+
+		info, err := handler.QueryInfo(context.Background())
+		assert.Equal(t, expectedErr, err)
+		assert.Empty(t, info)
+	})
+	t.Run("should replace the known public keys", func(t *testing.T) {
+		handler, _ := NewEnrichedAlarmHandler(&mocks.AlarmHandlerStub{
+			QueryInfoCalled: func(ctx context.Context) (string, error) {
+				return testPubkey + ": rating 100", nil
+			},
+		}, inv)
+
+		info, err := handler.QueryInfo(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "validator-01 (c26a1d…f80f0c): rating 100", info)
+	})
+}
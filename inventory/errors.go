@@ -0,0 +1,13 @@
+package inventory
+
+import "errors"
+
+var errEmptyNodeName = errors.New("empty node name")
+var errDuplicatedNodeName = errors.New("duplicated node name")
+var errNodeWithoutKeysOrUrls = errors.New("node without public keys or API urls")
+var errEmptyPublicKey = errors.New("empty public key")
+var errEmptyApiUrl = errors.New("empty API url")
+var errDuplicatedPublicKey = errors.New("duplicated public key")
+var errDuplicatedApiUrl = errors.New("duplicated API url")
+var errNilAlarmHandler = errors.New("nil alarm handler")
+var errNilResponseEnricher = errors.New("nil response enricher")
@@ -0,0 +1,10 @@
+package inventory
+
+import "github.com/iulianpascalau/node-monitoring/data"
+
+// ResponseEnricher defines the operations of a component able to add details to the alarm responses
+type ResponseEnricher interface {
+	EnrichResponse(response data.AlarmResponse) data.AlarmResponse
+	EnrichText(text string) string
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,187 @@
+package inventory
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+)
+
+const tagsSeparator = ","
+
+// Node holds the human readable details of a node, identified by its public keys and API urls
+type Node struct {
+	Name       string
+	Owner      string
+	Provider   string
+	Tags       []string
+	PublicKeys []string
+	ApiUrls    []string
+}
+
+// ArgsInventory represents the arguments DTO for the inventory constructor
+type ArgsInventory struct {
+	Nodes []Node
+}
+
+type inventory struct {
+	nodesByPublicKey map[string]*Node
+	nodesByApiUrl    map[string]*Node
+	keysReplacer     *strings.Replacer
+}
+
+// NewInventory creates a new inventory able to add the node's name, owner, hosting provider and tags to the alarm
+// responses referring to one of its public keys or API urls. The public keys found in the texts are replaced with
+// the node's name followed by the shortened key
+func NewInventory(args ArgsInventory) (*inventory, error) {
+	inv := &inventory{
+		nodesByPublicKey: make(map[string]*Node),
+		nodesByApiUrl:    make(map[string]*Node),
+	}
+
+	names := make(map[string]struct{}, len(args.Nodes))
+	replacements := make([]string, 0)
+	for idx := range args.Nodes {
+		node := args.Nodes[idx]
+		err := checkNode(node, names)
+		if err != nil {
+			return nil, err
+		}
+		names[node.Name] = struct{}{}
+
+		for _, pubkey := range node.PublicKeys {
+			pubkey = normalizePublicKey(pubkey)
+			_, found := inv.nodesByPublicKey[pubkey]
+			if found {
+				return nil, fmt.Errorf("%w %s for node %s", errDuplicatedPublicKey, pubkey, node.Name)
+			}
+			inv.nodesByPublicKey[pubkey] = &node
+			replacements = append(replacements, pubkey, fmt.Sprintf("%s (%s)", node.Name, formatting.ShortenPubkey(pubkey)))
+		}
+		for _, apiUrl := range node.ApiUrls {
+			apiUrl = normalizeApiUrl(apiUrl)
+			_, found := inv.nodesByApiUrl[apiUrl]
+			if found {
+				return nil, fmt.Errorf("%w %s for node %s", errDuplicatedApiUrl, apiUrl, node.Name)
+			}
+			inv.nodesByApiUrl[apiUrl] = &node
+		}
+	}
+	inv.keysReplacer = strings.NewReplacer(replacements...)
+
+	return inv, nil
+}
+
+func checkNode(node Node, names map[string]struct{}) error {
+	if len(strings.TrimSpace(node.Name)) == 0 {
+		return errEmptyNodeName
+	}
+	_, found := names[node.Name]
+	if found {
+		return fmt.Errorf("%w: %s", errDuplicatedNodeName, node.Name)
+	}
+	if len(node.PublicKeys) == 0 && len(node.ApiUrls) == 0 {
+		return fmt.Errorf("%w: %s", errNodeWithoutKeysOrUrls, node.Name)
+	}
+	for _, pubkey := range node.PublicKeys {
+		if len(normalizePublicKey(pubkey)) == 0 {
+			return fmt.Errorf("%w for node %s", errEmptyPublicKey, node.Name)
+		}
+	}
+	for _, apiUrl := range node.ApiUrls {
+		if len(normalizeApiUrl(apiUrl)) == 0 {
+			return fmt.Errorf("%w for node %s", errEmptyApiUrl, node.Name)
+		}
+	}
+
+	return nil
+}
+
+func normalizePublicKey(pubkey string) string {
+	return strings.ToLower(strings.TrimSpace(pubkey))
+}
+
+func normalizeApiUrl(apiUrl string) string {
+	return strings.TrimSuffix(strings.TrimSpace(apiUrl), "/")
+}
+
+// NodeByPublicKey returns the node owning the provided public key
+func (inv *inventory) NodeByPublicKey(pubkey string) (Node, bool) {
+	node, found := inv.nodesByPublicKey[normalizePublicKey(pubkey)]
+	if !found {
+		return Node{}, false
+	}
+
+	return *node, true
+}
+
+// NodeByApiUrl returns the node reachable on the provided API url
+func (inv *inventory) NodeByApiUrl(apiUrl string) (Node, bool) {
+	node, found := inv.nodesByApiUrl[normalizeApiUrl(apiUrl)]
+	if !found {
+		return Node{}, false
+	}
+
+	return *node, true
+}
+
+// EnrichResponse returns a copy of the response having the node labels added, if the response refers to a node
+// from the inventory, and the known public keys from the data replaced. The labels already set by the alarm are kept
+func (inv *inventory) EnrichResponse(response data.AlarmResponse) data.AlarmResponse {
+	response.Data = inv.EnrichText(response.Data)
+
+	node, found := inv.findNode(response.Labels)
+	if !found {
+		return response
+	}
+
+	labels := make(map[string]string, len(response.Labels)+4)
+	addLabel(labels, data.NodeLabel, node.Name)
+	addLabel(labels, data.OwnerLabel, node.Owner)
+	addLabel(labels, data.ProviderLabel, node.Provider)
+	addLabel(labels, data.TagsLabel, strings.Join(node.Tags, tagsSeparator))
+	for name, value := range response.Labels {
+		labels[name] = value
+	}
+	response.Labels = labels
+
+	return response
+}
+
+func (inv *inventory) findNode(labels map[string]string) (Node, bool) {
+	pubkey := labels[data.PubkeyLabel]
+	if len(pubkey) > 0 {
+		node, found := inv.NodeByPublicKey(pubkey)
+		if found {
+			return node, true
+		}
+	}
+
+	apiUrl := labels[data.ApiUrlLabel]
+	if len(apiUrl) > 0 {
+		return inv.NodeByApiUrl(apiUrl)
+	}
+
+	return Node{}, false
+}
+
+func addLabel(labels map[string]string, name string, value string) {
+	if len(value) > 0 {
+		labels[name] = value
+	}
+}
+
+// EnrichText replaces the known public keys from the text with the node's name followed by the shortened key
+func (inv *inventory) EnrichText(text string) string {
+	if len(inv.nodesByPublicKey) == 0 {
+		return text
+	}
+
+	return inv.keysReplacer.Replace(text)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (inv *inventory) IsInterfaceNil() bool {
+	return inv == nil
+}
@@ -0,0 +1,178 @@
+package inventory
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPubkey = "c26a1d7dcbbd5b7ac75f3a06d30bd42bd3b4d1ae2cde1d4a1b5a6e1c0e4d1b7f2c1f8a7f0d2e5b6c3a9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f80f0c"
+const otherPubkey = "225e1792d9fc44fcc1288111c79f43b59dd2ab019ab99d675b3c73439ec8417e94ee0071372e0faf0f061ebbe420ca0fae40e6bce620d0a23e4b6d0ae5ffb61a"
+
+func createMockArgsInventory() ArgsInventory {
+	return ArgsInventory{
+		Nodes: []Node{
+			{
+				Name:       "validator-01",
+				Owner:      "ops",
+				Provider:   "hetzner",
+				Tags:       []string{"mainnet", "shard-1"},
+				PublicKeys: []string{testPubkey},
+				ApiUrls:    []string{"http://192.168.169.110:8080/"},
+			},
+			{
+				Name:    "observer-01",
+				ApiUrls: []string{"http://192.168.169.111:8080"},
+			},
+		},
+	}
+}
+
+func TestNewInventory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty node name should error", func(t *testing.T) {
+		args := createMockArgsInventory()
+		args.Nodes[1].Name = " "
+
+		inv, err := NewInventory(args)
+		assert.True(t, check.IfNil(inv))
+		assert.Equal(t, errEmptyNodeName, err)
+	})
+	t.Run("duplicated node name should error", func(t *testing.T) {
+		args := createMockArgsInventory()
+		args.Nodes[1].Name = "validator-01"
+
+		inv, err := NewInventory(args)
+		assert.True(t, check.IfNil(inv))
+		assert.True(t, errors.Is(err, errDuplicatedNodeName))
+	})
+	t.Run("node without public keys or API urls should error", func(t *testing.T) {
+		args := createMockArgsInventory()
+		args.Nodes[1].ApiUrls = nil
+
+		inv, err := NewInventory(args)
+		assert.True(t, check.IfNil(inv))
+		assert.True(t, errors.Is(err, errNodeWithoutKeysOrUrls))
+		assert.True(t, strings.Contains(err.Error(), "observer-01"))
+	})
+	t.Run("empty public key should error", func(t *testing.T) {
+		args := createMockArgsInventory()
+		args.Nodes[0].PublicKeys = append(args.Nodes[0].PublicKeys, "")
+
+		inv, err := NewInventory(args)
+		assert.True(t, check.IfNil(inv))
+		assert.True(t, errors.Is(err, errEmptyPublicKey))
+	})
+	t.Run("empty API url should error", func(t *testing.T) {
+		args := createMockArgsInventory()
+		args.Nodes[1].ApiUrls = []string{"/"}
+
+		inv, err := NewInventory(args)
+		assert.True(t, check.IfNil(inv))
+		assert.True(t, errors.Is(err, errEmptyApiUrl))
+	})
+	t.Run("duplicated public key should error", func(t *testing.T) {
+		args := createMockArgsInventory()
+		args.Nodes[1].PublicKeys = []string{strings.ToUpper(testPubkey)}
+
+		inv, err := NewInventory(args)
+		assert.True(t, check.IfNil(inv))
+		assert.True(t, errors.Is(err, errDuplicatedPublicKey))
+	})
+	t.Run("duplicated API url should error", func(t *testing.T) {
+		args := createMockArgsInventory()
+		args.Nodes[1].ApiUrls = []string{"http://192.168.169.110:8080"}
+
+		inv, err := NewInventory(args)
+		assert.True(t, check.IfNil(inv))
+		assert.True(t, errors.Is(err, errDuplicatedApiUrl))
+	})
+	t.Run("should work", func(t *testing.T) {
+		inv, err := NewInventory(createMockArgsInventory())
+		assert.False(t, check.IfNil(inv))
+		assert.Nil(t, err)
+
+		node, found := inv.NodeByPublicKey(" " + strings.ToUpper(testPubkey))
+		assert.True(t, found)
+		assert.Equal(t, "validator-01", node.Name)
+		node, found = inv.NodeByApiUrl("http://192.168.169.110:8080")
+		assert.True(t, found)
+		assert.Equal(t, "validator-01", node.Name)
+		node, found = inv.NodeByApiUrl("http://192.168.169.111:8080/")
+		assert.True(t, found)
+		assert.Equal(t, "observer-01", node.Name)
+		_, found = inv.NodeByPublicKey(otherPubkey)
+		assert.False(t, found)
+	})
+	t.Run("empty inventory should work", func(t *testing.T) {
+		inv, err := NewInventory(ArgsInventory{})
+		assert.False(t, check.IfNil(inv))
+		assert.Nil(t, err)
+		assert.Equal(t, "text with "+testPubkey, inv.EnrichText("text with "+testPubkey))
+	})
+}
+
+func TestInventory_EnrichResponse(t *testing.T) {
+	t.Parallel()
+
+	inv, _ := NewInventory(createMockArgsInventory())
+
+	t.Run("response referring a known public key should be enriched", func(t *testing.T) {
+		t.Parallel()
+
+		labels := map[string]string{data.PubkeyLabel: testPubkey, "shard": "1"}
+		response := data.AlarmResponse{
+			Identifier: "node rating",
+			Level:      data.Error,
+			Data:       "rating of " + testPubkey + " dropped, " + otherPubkey + " is fine",
+			Labels:     labels,
+		}
+
+		result := inv.EnrichResponse(response)
+		expectedLabels := map[string]string{
+			data.PubkeyLabel:   testPubkey,
+			"shard":            "1",
+			data.NodeLabel:     "validator-01",
+			data.OwnerLabel:    "ops",
+			data.ProviderLabel: "hetzner",
+			data.TagsLabel:     "mainnet,shard-1",
+		}
+		assert.Equal(t, expectedLabels, result.Labels)
+		assert.Equal(t, "rating of validator-01 (c26a1d…f80f0c) dropped, "+otherPubkey+" is fine", result.Data)
+		assert.Equal(t, 2, len(labels))
+	})
+	t.Run("response referring a known API url should be enriched without overriding the alarm's labels", func(t *testing.T) {
+		t.Parallel()
+
+		response := data.AlarmResponse{
+			Identifier: "node nonce",
+			Level:      data.Error,
+			Labels:     map[string]string{data.ApiUrlLabel: "http://192.168.169.111:8080", data.OwnerLabel: "alarm owner"},
+		}
+
+		result := inv.EnrichResponse(response)
+		expectedLabels := map[string]string{
+			data.ApiUrlLabel: "http://192.168.169.111:8080",
+			data.NodeLabel:   "observer-01",
+			data.OwnerLabel:  "alarm owner",
+		}
+		assert.Equal(t, expectedLabels, result.Labels)
+	})
+	t.Run("unknown node should not alter the labels", func(t *testing.T) {
+		t.Parallel()
+
+		response := data.AlarmResponse{
+			Identifier: "node rating",
+			Level:      data.Error,
+			Data:       "rating dropped",
+			Labels:     map[string]string{data.PubkeyLabel: otherPubkey, data.ApiUrlLabel: "http://localhost:8080"},
+		}
+
+		assert.Equal(t, response, inv.EnrichResponse(response))
+	})
+}
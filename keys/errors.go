@@ -0,0 +1,17 @@
+package keys
+
+import "errors"
+
+var errNoPublicKeys = errors.New("no public keys provided")
+var errEmptyPath = errors.New("empty path")
+var errInvalidPemHeader = errors.New("invalid PEM header")
+var errInvalidPublicKey = errors.New("invalid public key")
+var errNoPublicKeysInFile = errors.New("no public keys found in file")
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errNoAddresses = errors.New("no contract or owner addresses provided")
+var errInvalidAddress = errors.New("invalid address")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
+var errNilKeysDiscoverer = errors.New("nil keys discoverer")
+var errEmptyIdentifier = errors.New("empty identifier")
+var errInvalidPollingInterval = errors.New("invalid polling interval")
+var errInvalidDiscoveryInterval = errors.New("invalid discovery interval")
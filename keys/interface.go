@@ -0,0 +1,33 @@
+package keys
+
+import (
+	"context"
+	"time"
+
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+// PublicKeysProvider defines the operations of a component providing the up to date public keys to be monitored
+type PublicKeysProvider interface {
+	PublicKeys(ctx context.Context) []string
+	IsInterfaceNil() bool
+}
+
+// KeysDiscoverer defines the operations of a component able to fetch the public keys owned by some addresses
+type KeysDiscoverer interface {
+	DiscoverPublicKeys(ctx context.Context) ([]string, error)
+	IsInterfaceNil() bool
+}
+
+// SharedDiscoverers defines the operations of a registry providing the discoverers shared by the components watching
+// the same addresses
+type SharedDiscoverers interface {
+	Discoverer(key string, refreshInterval time.Duration, create func() (KeysDiscoverer, error)) (KeysDiscoverer, error)
+	IsInterfaceNil() bool
+}
+
+// HTTPClient defines the operations of the HTTP client used to call the Elrond API
+type HTTPClient interface {
+	CallEndPoint(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error)
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,174 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+const (
+	// KeysMetric is the metric holding the number of discovered public keys
+	KeysMetric = "keys"
+	// AddedKeysMetric is the metric holding the number of public keys added since the previous discovery
+	AddedKeysMetric = "addedKeys"
+	// RemovedKeysMetric is the metric holding the number of public keys removed since the previous discovery
+	RemovedKeysMetric = "removedKeys"
+)
+
+// ArgsKeysDiscoveryAlarm represents the arguments DTO for the keysDiscoveryAlarm constructor
+type ArgsKeysDiscoveryAlarm struct {
+	Identifier      string
+	Discoverer      KeysDiscoverer
+	PollingInterval time.Duration
+}
+
+type keysDiscoveryAlarm struct {
+	identifier      string
+	discoverer      KeysDiscoverer
+	pollingInterval time.Duration
+	getTime         func() time.Time
+
+	mutKeys       sync.RWMutex
+	nextQueryTime time.Time
+	discovered    bool
+	publicKeys    []string
+}
+
+// NewKeysDiscoveryAlarm creates an alarm that periodically discovers the public keys owned by the configured
+// addresses and reports the added and removed keys as Info responses. The first discovery only reports the number
+// of keys, with no event
+func NewKeysDiscoveryAlarm(args ArgsKeysDiscoveryAlarm) (*keysDiscoveryAlarm, error) {
+	if len(strings.TrimSpace(args.Identifier)) == 0 {
+		return nil, errEmptyIdentifier
+	}
+	if check.IfNil(args.Discoverer) {
+		return nil, errNilKeysDiscoverer
+	}
+	if args.PollingInterval <= 0 {
+		return nil, fmt.Errorf("%w, provided: %v", errInvalidPollingInterval, args.PollingInterval)
+	}
+
+	return &keysDiscoveryAlarm{
+		identifier:      args.Identifier,
+		discoverer:      args.Discoverer,
+		pollingInterval: args.PollingInterval,
+		getTime:         time.Now,
+	}, nil
+}
+
+// ShouldQuery returns true if the polling interval elapsed since the last query
+func (alarm *keysDiscoveryAlarm) ShouldQuery() bool {
+	return !alarm.getTime().Before(alarm.NextQueryTime())
+}
+
+// Query discovers the public keys and compares them with the previously discovered ones
+func (alarm *keysDiscoveryAlarm) Query(ctx context.Context) (data.AlarmResponse, error) {
+	alarm.mutKeys.Lock()
+	alarm.nextQueryTime = alarm.getTime().Add(alarm.pollingInterval)
+	alarm.mutKeys.Unlock()
+
+	publicKeys, err := alarm.discoverer.DiscoverPublicKeys(ctx)
+	if err != nil {
+		return data.AlarmResponse{}, err
+	}
+
+	alarm.mutKeys.Lock()
+	previousKeys := alarm.publicKeys
+	discovered := alarm.discovered
+	alarm.publicKeys = publicKeys
+	alarm.discovered = true
+	alarm.mutKeys.Unlock()
+
+	response := data.AlarmResponse{
+		Identifier: alarm.identifier,
+		Level:      data.NoEvent,
+		Data:       fmt.Sprintf("%d public key(s) discovered", len(publicKeys)),
+		Metrics:    map[string]float64{KeysMetric: float64(len(publicKeys))},
+	}
+	if !discovered {
+		return response, nil
+	}
+
+	added, removed := DiffKeys(previousKeys, publicKeys)
+	response.Metrics[AddedKeysMetric] = float64(len(added))
+	response.Metrics[RemovedKeysMetric] = float64(len(removed))
+	if len(added)+len(removed) == 0 {
+		return response, nil
+	}
+
+	response.Level = data.Info
+	response.Data = formatChanges(len(publicKeys), added, removed)
+
+	return response, nil
+}
+
+func formatChanges(numKeys int, added []string, removed []string) string {
+	lines := []string{fmt.Sprintf("public keys changed, %d key(s) discovered", numKeys)}
+	if len(added) > 0 {
+		lines = append(lines, fmt.Sprintf("added %d key(s):", len(added)))
+		lines = append(lines, added...)
+	}
+	if len(removed) > 0 {
+		lines = append(lines, fmt.Sprintf("removed %d key(s):", len(removed)))
+		lines = append(lines, removed...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// QueryInfo returns the last discovered public keys, discovering them if no query was done yet
+func (alarm *keysDiscoveryAlarm) QueryInfo(ctx context.Context) (string, error) {
+	publicKeys := alarm.PublicKeys()
+	if !alarm.isDiscovered() {
+		var err error
+		publicKeys, err = alarm.discoverer.DiscoverPublicKeys(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	lines := append([]string{fmt.Sprintf("%d public key(s) discovered", len(publicKeys))}, publicKeys...)
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (alarm *keysDiscoveryAlarm) isDiscovered() bool {
+	alarm.mutKeys.RLock()
+	defer alarm.mutKeys.RUnlock()
+
+	return alarm.discovered
+}
+
+// PublicKeys returns the last discovered public keys
+func (alarm *keysDiscoveryAlarm) PublicKeys() []string {
+	alarm.mutKeys.RLock()
+	defer alarm.mutKeys.RUnlock()
+
+	publicKeys := make([]string, len(alarm.publicKeys))
+	copy(publicKeys, alarm.publicKeys)
+
+	return publicKeys
+}
+
+// NextQueryTime returns the time of the next discovery
+func (alarm *keysDiscoveryAlarm) NextQueryTime() time.Time {
+	alarm.mutKeys.RLock()
+	defer alarm.mutKeys.RUnlock()
+
+	return alarm.nextQueryTime
+}
+
+// Identifier returns the alarm's identifier
+func (alarm *keysDiscoveryAlarm) Identifier() string {
+	return alarm.identifier
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (alarm *keysDiscoveryAlarm) IsInterfaceNil() bool {
+	return alarm == nil
+}
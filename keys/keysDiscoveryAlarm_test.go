@@ -0,0 +1,162 @@
+package keys
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type keysDiscovererStub struct {
+	DiscoverPublicKeysCalled func(ctx context.Context) ([]string, error)
+}
+
+// DiscoverPublicKeys -
+func (stub *keysDiscovererStub) DiscoverPublicKeys(ctx context.Context) ([]string, error) {
+	if stub.DiscoverPublicKeysCalled != nil {
+		return stub.DiscoverPublicKeysCalled(ctx)
+	}
+
+	return nil, nil
+}
+
+// IsInterfaceNil -
+func (stub *keysDiscovererStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createMockArgsKeysDiscoveryAlarm() ArgsKeysDiscoveryAlarm {
+	return ArgsKeysDiscoveryAlarm{
+		Identifier:      "staking provider keys",
+		Discoverer:      &keysDiscovererStub{},
+		PollingInterval: time.Minute,
+	}
+}
+
+func TestNewKeysDiscoveryAlarm(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty identifier should error", func(t *testing.T) {
+		args := createMockArgsKeysDiscoveryAlarm()
+		args.Identifier = ""
+
+		alarm, err := NewKeysDiscoveryAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.Equal(t, errEmptyIdentifier, err)
+	})
+	t.Run("nil discoverer should error", func(t *testing.T) {
+		args := createMockArgsKeysDiscoveryAlarm()
+		args.Discoverer = nil
+
+		alarm, err := NewKeysDiscoveryAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.Equal(t, errNilKeysDiscoverer, err)
+	})
+	t.Run("invalid polling interval should error", func(t *testing.T) {
+		args := createMockArgsKeysDiscoveryAlarm()
+		args.PollingInterval = 0
+
+		alarm, err := NewKeysDiscoveryAlarm(args)
+		assert.True(t, check.IfNil(alarm))
+		assert.True(t, errors.Is(err, errInvalidPollingInterval))
+	})
+	t.Run("should work", func(t *testing.T) {
+		alarm, err := NewKeysDiscoveryAlarm(createMockArgsKeysDiscoveryAlarm())
+		assert.False(t, check.IfNil(alarm))
+		assert.Nil(t, err)
+		assert.Equal(t, "staking provider keys", alarm.Identifier())
+		assert.True(t, alarm.ShouldQuery())
+	})
+}
+
+func TestKeysDiscoveryAlarm_Query(t *testing.T) {
+	t.Parallel()
+
+	t.Run("discovery error should error and postpone the next query", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		args := createMockArgsKeysDiscoveryAlarm()
+		args.Discoverer = &keysDiscovererStub{
+			DiscoverPublicKeysCalled: func(ctx context.Context) ([]string, error) {
+				return nil, expectedErr
+			},
+		}
+		alarm, _ := NewKeysDiscoveryAlarm(args)
+		currentTime := time.Date(2022, 4, 5, 10, 0, 0, 0, time.UTC)
+		alarm.getTime = func() time.Time {
+			return currentTime
+		}
+
+		response, err := alarm.Query(context.Background())
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, data.AlarmResponse{}, response)
+		assert.Equal(t, currentTime.Add(time.Minute), alarm.NextQueryTime())
+		assert.False(t, alarm.ShouldQuery())
+
+		currentTime = currentTime.Add(time.Minute)
+		assert.True(t, alarm.ShouldQuery())
+	})
+	t.Run("should report the added and removed keys", func(t *testing.T) {
+		discoveredKeys := []string{createTestPublicKey("a"), createTestPublicKey("b")}
+		args := createMockArgsKeysDiscoveryAlarm()
+		args.Discoverer = &keysDiscovererStub{
+			DiscoverPublicKeysCalled: func(ctx context.Context) ([]string, error) {
+				return discoveredKeys, nil
+			},
+		}
+		alarm, _ := NewKeysDiscoveryAlarm(args)
+
+		response, err := alarm.Query(context.Background())
+		require.Nil(t, err)
+		assert.Equal(t, data.AlarmResponse{
+			Identifier: "staking provider keys",
+			Level:      data.NoEvent,
+			Data:       "2 public key(s) discovered",
+			Metrics:    map[string]float64{KeysMetric: 2},
+		}, response)
+
+		response, err = alarm.Query(context.Background())
+		require.Nil(t, err)
+		assert.Equal(t, data.NoEvent, response.Level)
+		assert.Equal(t, map[string]float64{KeysMetric: 2, AddedKeysMetric: 0, RemovedKeysMetric: 0}, response.Metrics)
+
+		discoveredKeys = []string{createTestPublicKey("b"), createTestPublicKey("c"), createTestPublicKey("d")}
+		response, err = alarm.Query(context.Background())
+		require.Nil(t, err)
+		assert.Equal(t, data.Info, response.Level)
+		assert.Equal(t, "public keys changed, 3 key(s) discovered\n"+
+			"added 2 key(s):\n"+createTestPublicKey("c")+"\n"+createTestPublicKey("d")+"\n"+
+			"removed 1 key(s):\n"+createTestPublicKey("a"), response.Data)
+		assert.Equal(t, map[string]float64{KeysMetric: 3, AddedKeysMetric: 2, RemovedKeysMetric: 1}, response.Metrics)
+		assert.Equal(t, discoveredKeys, alarm.PublicKeys())
+	})
+}
+
+func TestKeysDiscoveryAlarm_QueryInfo(t *testing.T) {
+	t.Parallel()
+
+	numCalls := 0
+	args := createMockArgsKeysDiscoveryAlarm()
+	args.Discoverer = &keysDiscovererStub{
+		DiscoverPublicKeysCalled: func(ctx context.Context) ([]string, error) {
+			numCalls++
+			return []string{createTestPublicKey("a")}, nil
+		},
+	}
+	alarm, _ := NewKeysDiscoveryAlarm(args)
+
+	info, err := alarm.QueryInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "1 public key(s) discovered\n"+createTestPublicKey("a"), info)
+	assert.Equal(t, 1, numCalls)
+
+	_, _ = alarm.Query(context.Background())
+	info, err = alarm.QueryInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "1 public key(s) discovered\n"+createTestPublicKey("a"), info)
+	assert.Equal(t, 2, numCalls)
+}
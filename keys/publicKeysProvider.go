@@ -0,0 +1,261 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+)
+
+// DefaultDiscoveryInterval is the interval at which the public keys are discovered again when none is configured
+const DefaultDiscoveryInterval = 10 * time.Minute
+
+var log = logger.GetOrCreate("keys")
+
+// ArgsPublicKeysProvider represents the arguments DTO for the publicKeysProvider constructor
+type ArgsPublicKeysProvider struct {
+	PublicKeys        []string
+	Paths             []string
+	Discoverer        KeysDiscoverer
+	DiscoveryInterval time.Duration
+}
+
+type publicKeysProvider struct {
+	staticKeys        []string
+	paths             []string
+	discoverer        KeysDiscoverer
+	discoveryInterval time.Duration
+	getTime           func() time.Time
+
+	mutKeys           sync.Mutex
+	fingerprint       string
+	fileKeys          []string
+	nextDiscoveryTime time.Time
+	discoveredKeys    []string
+}
+
+// NewPublicKeysProvider creates a provider of the configured public keys together with the ones read from the
+// provided PEM files and directories and the ones found by the optional discoverer. The files are read again
+// whenever one of them changes while the keys are discovered again at each discovery interval
+func NewPublicKeysProvider(args ArgsPublicKeysProvider) (*publicKeysProvider, error) {
+	for _, path := range args.Paths {
+		if len(strings.TrimSpace(path)) == 0 {
+			return nil, errEmptyPath
+		}
+	}
+	if args.DiscoveryInterval < 0 {
+		return nil, fmt.Errorf("%w, provided: %v", errInvalidDiscoveryInterval, args.DiscoveryInterval)
+	}
+
+	provider := &publicKeysProvider{
+		staticKeys:        args.PublicKeys,
+		paths:             args.Paths,
+		discoveryInterval: args.DiscoveryInterval,
+		getTime:           time.Now,
+	}
+	if !check.IfNil(args.Discoverer) {
+		provider.discoverer = args.Discoverer
+	}
+	if provider.discoveryInterval == 0 {
+		provider.discoveryInterval = DefaultDiscoveryInterval
+	}
+
+	fingerprint, err := computeFingerprint(provider.paths)
+	if err != nil {
+		return nil, err
+	}
+	fileKeys, err := provider.readFiles()
+	if err != nil {
+		return nil, err
+	}
+	// the discovered keys are fetched on the first call, an unreachable API not preventing the start
+	if len(mergeKeys(provider.staticKeys, fileKeys)) == 0 && provider.discoverer == nil {
+		return nil, errNoPublicKeys
+	}
+
+	provider.fingerprint = fingerprint
+	provider.fileKeys = fileKeys
+
+	return provider, nil
+}
+
+// PublicKeys returns the current public keys, reading the files again if one of them changed and discovering the
+// keys again, with the provided context, if the discovery interval elapsed. A failed refresh keeps the previous keys
+func (provider *publicKeysProvider) PublicKeys(ctx context.Context) []string {
+	provider.mutKeys.Lock()
+	provider.refreshFiles()
+	shouldDiscover := provider.shouldDiscover()
+	provider.mutKeys.Unlock()
+
+	// the discovery is done without holding the mutex so a slow API does not block the other callers
+	if shouldDiscover {
+		provider.discoverKeys(ctx)
+	}
+
+	provider.mutKeys.Lock()
+	defer provider.mutKeys.Unlock()
+
+	return mergeKeys(provider.staticKeys, provider.fileKeys, provider.discoveredKeys)
+}
+
+func (provider *publicKeysProvider) refreshFiles() {
+	if len(provider.paths) == 0 {
+		return
+	}
+
+	fingerprint, err := computeFingerprint(provider.paths)
+	if err != nil {
+		log.Warn("error checking the public keys files", "error", err.Error())
+		return
+	}
+	if fingerprint == provider.fingerprint {
+		return
+	}
+
+	// the new fingerprint is kept even if the reload fails so the error is not logged on each call
+	provider.fingerprint = fingerprint
+	fileKeys, err := provider.readFiles()
+	if err != nil {
+		log.Warn("error reloading the public keys, keeping the previous ones", "error", err.Error())
+		return
+	}
+	if len(fileKeys) == 0 {
+		log.Warn("no public keys found after reloading the files, keeping the previous ones", "paths", provider.paths)
+		return
+	}
+
+	log.Info("public keys reloaded", "paths", provider.paths, "num keys", len(fileKeys),
+		"previous num keys", len(provider.fileKeys))
+	provider.fileKeys = fileKeys
+}
+
+// shouldDiscover returns true if the discovery interval elapsed, scheduling the next discovery. Must be called
+// under mutKeys
+func (provider *publicKeysProvider) shouldDiscover() bool {
+	now := provider.getTime()
+	if provider.discoverer == nil || now.Before(provider.nextDiscoveryTime) {
+		return false
+	}
+
+	provider.nextDiscoveryTime = now.Add(provider.discoveryInterval)
+
+	return true
+}
+
+func (provider *publicKeysProvider) discoverKeys(ctx context.Context) {
+	discoveredKeys, err := provider.discoverer.DiscoverPublicKeys(ctx)
+	if err != nil {
+		log.Warn("error discovering the public keys, keeping the previous ones", "error", err.Error())
+		return
+	}
+
+	provider.mutKeys.Lock()
+	defer provider.mutKeys.Unlock()
+
+	added, removed := DiffKeys(provider.discoveredKeys, discoveredKeys)
+	if len(added)+len(removed) > 0 {
+		log.Info("discovered public keys changed", "num keys", len(discoveredKeys),
+			"num added", len(added), "num removed", len(removed))
+	}
+	provider.discoveredKeys = discoveredKeys
+}
+
+func (provider *publicKeysProvider) readFiles() ([]string, error) {
+	fileKeys := make([]string, 0)
+	for _, path := range provider.paths {
+		pathKeys, err := ReadPublicKeys(path)
+		if err != nil {
+			return nil, err
+		}
+
+		fileKeys = append(fileKeys, pathKeys...)
+	}
+
+	return fileKeys, nil
+}
+
+// mergeKeys returns the provided public keys in order, without duplicates and empty values
+func mergeKeys(keysLists ...[]string) []string {
+	publicKeys := make([]string, 0)
+	existing := make(map[string]struct{})
+	for _, keys := range keysLists {
+		for _, key := range keys {
+			key = strings.TrimSpace(key)
+			_, found := existing[strings.ToLower(key)]
+			if found || len(key) == 0 {
+				continue
+			}
+
+			existing[strings.ToLower(key)] = struct{}{}
+			publicKeys = append(publicKeys, key)
+		}
+	}
+
+	return publicKeys
+}
+
+// DiffKeys returns the public keys found only in the current keys (added) and only in the previous ones (removed)
+func DiffKeys(previous []string, current []string) ([]string, []string) {
+	return subtractKeys(current, previous), subtractKeys(previous, current)
+}
+
+func subtractKeys(keys []string, toRemove []string) []string {
+	removed := make(map[string]struct{}, len(toRemove))
+	for _, key := range toRemove {
+		removed[key] = struct{}{}
+	}
+
+	result := make([]string, 0)
+	for _, key := range keys {
+		_, found := removed[key]
+		if !found {
+			result = append(result, key)
+		}
+	}
+
+	return result
+}
+
+// computeFingerprint describes the modification time and size of all the files that would be read, so any change
+// (including the added or removed files of a directory) is detected without reading them
+func computeFingerprint(paths []string) (string, error) {
+	builder := strings.Builder{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		writeFileInfo(&builder, path, info)
+		if !info.IsDir() {
+			continue
+		}
+
+		files, err := listPemFiles(path)
+		if err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			fileInfo, errStat := os.Stat(file)
+			if errStat != nil {
+				return "", errStat
+			}
+			writeFileInfo(&builder, file, fileInfo)
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func writeFileInfo(builder *strings.Builder, path string, info os.FileInfo) {
+	builder.WriteString(fmt.Sprintf("%s|%d|%d\n", path, info.ModTime().UnixNano(), info.Size()))
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (provider *publicKeysProvider) IsInterfaceNil() bool {
+	return provider == nil
+}
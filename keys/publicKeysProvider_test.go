@@ -0,0 +1,173 @@
+package keys
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func touchTestFile(t *testing.T, path string, content string, modTime time.Time) {
+	writeTestFile(t, path, content)
+	err := os.Chtimes(path, modTime, modTime)
+	require.Nil(t, err)
+}
+
+func TestNewPublicKeysProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty path should error", func(t *testing.T) {
+		provider, err := NewPublicKeysProvider(ArgsPublicKeysProvider{Paths: []string{""}})
+		assert.True(t, check.IfNil(provider))
+		assert.Equal(t, errEmptyPath, err)
+	})
+	t.Run("no public keys should error", func(t *testing.T) {
+		provider, err := NewPublicKeysProvider(ArgsPublicKeysProvider{Paths: []string{t.TempDir()}})
+		assert.True(t, check.IfNil(provider))
+		assert.Equal(t, errNoPublicKeys, err)
+	})
+	t.Run("invalid discovery interval should error", func(t *testing.T) {
+		provider, err := NewPublicKeysProvider(ArgsPublicKeysProvider{
+			PublicKeys:        []string{"pk1"},
+			DiscoveryInterval: -time.Second,
+		})
+		assert.True(t, check.IfNil(provider))
+		assert.True(t, errors.Is(err, errInvalidDiscoveryInterval))
+	})
+	t.Run("only a discoverer should work", func(t *testing.T) {
+		provider, err := NewPublicKeysProvider(ArgsPublicKeysProvider{Discoverer: &keysDiscovererStub{}})
+		assert.False(t, check.IfNil(provider))
+		assert.Nil(t, err)
+		assert.Equal(t, DefaultDiscoveryInterval, provider.discoveryInterval)
+	})
+	t.Run("missing file should error", func(t *testing.T) {
+		provider, err := NewPublicKeysProvider(ArgsPublicKeysProvider{
+			Paths: []string{filepath.Join(t.TempDir(), "validatorKey.pem")},
+		})
+		assert.True(t, check.IfNil(provider))
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+	t.Run("should merge the configured keys with the ones from the files", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "validatorKey.pem")
+		writeTestFile(t, path, createPemBlock(createTestPublicKey("a"))+createPemBlock(createTestPublicKey("b")))
+
+		provider, err := NewPublicKeysProvider(ArgsPublicKeysProvider{
+			PublicKeys: []string{"pk1", createTestPublicKey("a")},
+			Paths:      []string{path, dir},
+		})
+		assert.False(t, check.IfNil(provider))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"pk1", createTestPublicKey("a"), createTestPublicKey("b")}, provider.PublicKeys(context.Background()))
+	})
+}
+
+func TestPublicKeysProvider_PublicKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should reload the changed files", func(t *testing.T) {
+		dir := t.TempDir()
+		modTime := time.Now().Add(-time.Hour)
+		touchTestFile(t, filepath.Join(dir, "node-0.pem"), createPemBlock(createTestPublicKey("a")), modTime)
+
+		provider, _ := NewPublicKeysProvider(ArgsPublicKeysProvider{Paths: []string{dir}})
+		assert.Equal(t, []string{createTestPublicKey("a")}, provider.PublicKeys(context.Background()))
+
+		touchTestFile(t, filepath.Join(dir, "node-1.pem"), createPemBlock(createTestPublicKey("b")), modTime)
+		assert.Equal(t, []string{createTestPublicKey("a"), createTestPublicKey("b")}, provider.PublicKeys(context.Background()))
+
+		touchTestFile(t, filepath.Join(dir, "node-0.pem"), createPemBlock(createTestPublicKey("c")), modTime.Add(time.Minute))
+		assert.Equal(t, []string{createTestPublicKey("c"), createTestPublicKey("b")}, provider.PublicKeys(context.Background()))
+	})
+	t.Run("failed reload should keep the previous keys", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "validatorKey.pem")
+		modTime := time.Now().Add(-time.Hour)
+		touchTestFile(t, path, createPemBlock(createTestPublicKey("a")), modTime)
+
+		provider, _ := NewPublicKeysProvider(ArgsPublicKeysProvider{Paths: []string{path}})
+
+		touchTestFile(t, path, createPemBlock("invalid"), modTime.Add(time.Minute))
+		assert.Equal(t, []string{createTestPublicKey("a")}, provider.PublicKeys(context.Background()))
+
+		require.Nil(t, os.Remove(path))
+		assert.Equal(t, []string{createTestPublicKey("a")}, provider.PublicKeys(context.Background()))
+
+		touchTestFile(t, path, createPemBlock(createTestPublicKey("b")), modTime.Add(2*time.Minute))
+		assert.Equal(t, []string{createTestPublicKey("b")}, provider.PublicKeys(context.Background()))
+	})
+	t.Run("should discover the keys at each discovery interval", func(t *testing.T) {
+		numCalls := 0
+		discoveredKeys := []string{createTestPublicKey("a"), "pk1"}
+		var discoveryErr error
+		provider, _ := NewPublicKeysProvider(ArgsPublicKeysProvider{
+			PublicKeys: []string{"pk1"},
+			Discoverer: &keysDiscovererStub{
+				DiscoverPublicKeysCalled: func(ctx context.Context) ([]string, error) {
+					numCalls++
+					return discoveredKeys, discoveryErr
+				},
+			},
+			DiscoveryInterval: time.Minute,
+		})
+		currentTime := time.Date(2022, 4, 5, 10, 0, 0, 0, time.UTC)
+		provider.getTime = func() time.Time {
+			return currentTime
+		}
+
+		assert.Equal(t, []string{"pk1", createTestPublicKey("a")}, provider.PublicKeys(context.Background()))
+		discoveredKeys = []string{createTestPublicKey("b")}
+		currentTime = currentTime.Add(time.Second)
+		assert.Equal(t, []string{"pk1", createTestPublicKey("a")}, provider.PublicKeys(context.Background()))
+		assert.Equal(t, 1, numCalls)
+
+		currentTime = currentTime.Add(time.Minute)
+		assert.Equal(t, []string{"pk1", createTestPublicKey("b")}, provider.PublicKeys(context.Background()))
+		assert.Equal(t, 2, numCalls)
+
+		discoveryErr = errors.New("expected error")
+		currentTime = currentTime.Add(time.Minute)
+		assert.Equal(t, []string{"pk1", createTestPublicKey("b")}, provider.PublicKeys(context.Background()))
+		assert.Equal(t, 3, numCalls)
+	})
+	t.Run("should discover with the provided context without blocking the other callers", func(t *testing.T) {
+		type contextKey string
+		ctx := context.WithValue(context.Background(), contextKey("key"), "value")
+		discoveryStarted := make(chan struct{})
+		releaseDiscovery := make(chan struct{})
+		provider, _ := NewPublicKeysProvider(ArgsPublicKeysProvider{
+			PublicKeys: []string{"pk1"},
+			Discoverer: &keysDiscovererStub{
+				DiscoverPublicKeysCalled: func(providedCtx context.Context) ([]string, error) {
+					assert.Equal(t, "value", providedCtx.Value(contextKey("key")))
+					close(discoveryStarted)
+					<-releaseDiscovery
+					return []string{"pk2"}, nil
+				},
+			},
+			DiscoveryInterval: time.Minute,
+		})
+
+		publicKeysChan := make(chan []string)
+		go func() {
+			publicKeysChan <- provider.PublicKeys(ctx)
+		}()
+
+		<-discoveryStarted
+		assert.Equal(t, []string{"pk1"}, provider.PublicKeys(context.Background()))
+		close(releaseDiscovery)
+		assert.Equal(t, []string{"pk1", "pk2"}, <-publicKeysChan)
+	})
+	t.Run("returned keys should not alter the provider", func(t *testing.T) {
+		provider, _ := NewPublicKeysProvider(ArgsPublicKeysProvider{PublicKeys: []string{"pk1"}})
+
+		publicKeys := provider.PublicKeys(context.Background())
+		publicKeys[0] = "pk2"
+		assert.Equal(t, []string{"pk1"}, provider.PublicKeys(context.Background()))
+	})
+}
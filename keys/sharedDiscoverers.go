@@ -0,0 +1,155 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+)
+
+type discovery struct {
+	done       chan struct{}
+	publicKeys []string
+	err        error
+}
+
+// sharedDiscoverer wraps a discoverer used by more components (the public keys providers and the keys discovery
+// alarms watching the same addresses) so the keys are fetched only once for all of them: the keys discovered less
+// than a refresh interval ago are returned as they are and the concurrent calls wait for the same discovery
+type sharedDiscoverer struct {
+	discoverer KeysDiscoverer
+	getTime    func() time.Time
+
+	mut               sync.Mutex
+	refreshInterval   time.Duration
+	discovered        bool
+	lastDiscoveryTime time.Time
+	publicKeys        []string
+	inProgress        *discovery
+}
+
+// DiscoverPublicKeys returns the keys discovered less than a refresh interval ago or discovers them again
+func (shared *sharedDiscoverer) DiscoverPublicKeys(ctx context.Context) ([]string, error) {
+	shared.mut.Lock()
+	if shared.discovered && shared.getTime().Sub(shared.lastDiscoveryTime) < shared.refreshInterval {
+		publicKeys := copyKeys(shared.publicKeys)
+		shared.mut.Unlock()
+		return publicKeys, nil
+	}
+
+	current := shared.inProgress
+	if current != nil {
+		shared.mut.Unlock()
+		return waitDiscovery(ctx, current)
+	}
+
+	current = &discovery{done: make(chan struct{})}
+	shared.inProgress = current
+	shared.mut.Unlock()
+
+	current.publicKeys, current.err = shared.discoverer.DiscoverPublicKeys(ctx)
+
+	shared.mut.Lock()
+	shared.inProgress = nil
+	if current.err == nil {
+		shared.discovered = true
+		shared.lastDiscoveryTime = shared.getTime()
+		shared.publicKeys = current.publicKeys
+	}
+	shared.mut.Unlock()
+	close(current.done)
+
+	return copyKeys(current.publicKeys), current.err
+}
+
+func waitDiscovery(ctx context.Context, current *discovery) ([]string, error) {
+	select {
+	case <-current.done:
+		return copyKeys(current.publicKeys), current.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (shared *sharedDiscoverer) useRefreshInterval(refreshInterval time.Duration) {
+	shared.mut.Lock()
+	defer shared.mut.Unlock()
+
+	if refreshInterval < shared.refreshInterval {
+		shared.refreshInterval = refreshInterval
+	}
+}
+
+func copyKeys(publicKeys []string) []string {
+	if publicKeys == nil {
+		return nil
+	}
+
+	result := make([]string, len(publicKeys))
+	copy(result, publicKeys)
+
+	return result
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (shared *sharedDiscoverer) IsInterfaceNil() bool {
+	return shared == nil
+}
+
+type sharedDiscoverers struct {
+	mut         sync.Mutex
+	discoverers map[string]*sharedDiscoverer
+}
+
+// NewSharedDiscoverers creates the registry of the discoverers shared by the components watching the same addresses
+func NewSharedDiscoverers() *sharedDiscoverers {
+	return &sharedDiscoverers{
+		discoverers: make(map[string]*sharedDiscoverer),
+	}
+}
+
+// Discoverer returns the discoverer registered for the provided key, creating it if needed. The keys are discovered
+// again after the shortest refresh interval of all the callers, a caller with a longer interval using the keys
+// discovered for the others
+func (registry *sharedDiscoverers) Discoverer(
+	key string,
+	refreshInterval time.Duration,
+	create func() (KeysDiscoverer, error),
+) (KeysDiscoverer, error) {
+	if refreshInterval <= 0 {
+		return nil, fmt.Errorf("%w, provided: %v", errInvalidDiscoveryInterval, refreshInterval)
+	}
+
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	shared, found := registry.discoverers[key]
+	if found {
+		shared.useRefreshInterval(refreshInterval)
+		return shared, nil
+	}
+
+	discoverer, err := create()
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(discoverer) {
+		return nil, errNilKeysDiscoverer
+	}
+
+	shared = &sharedDiscoverer{
+		discoverer:      discoverer,
+		getTime:         time.Now,
+		refreshInterval: refreshInterval,
+	}
+	registry.discoverers[key] = shared
+
+	return shared, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (registry *sharedDiscoverers) IsInterfaceNil() bool {
+	return registry == nil
+}
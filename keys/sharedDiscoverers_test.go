@@ -0,0 +1,194 @@
+package keys
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDiscovererFunc(discoverer KeysDiscoverer) func() (KeysDiscoverer, error) {
+	return func() (KeysDiscoverer, error) {
+		return discoverer, nil
+	}
+}
+
+func TestSharedDiscoverers_Discoverer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid refresh interval should error", func(t *testing.T) {
+		registry := NewSharedDiscoverers()
+		discoverer, err := registry.Discoverer("key", 0, createDiscovererFunc(&keysDiscovererStub{}))
+		assert.True(t, check.IfNil(discoverer))
+		assert.True(t, errors.Is(err, errInvalidDiscoveryInterval))
+	})
+	t.Run("create error should error", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		registry := NewSharedDiscoverers()
+		discoverer, err := registry.Discoverer("key", time.Minute, func() (KeysDiscoverer, error) {
+			return nil, expectedErr
+		})
+		assert.True(t, check.IfNil(discoverer))
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("nil created discoverer should error", func(t *testing.T) {
+		registry := NewSharedDiscoverers()
+		discoverer, err := registry.Discoverer("key", time.Minute, createDiscovererFunc(nil))
+		assert.True(t, check.IfNil(discoverer))
+		assert.Equal(t, errNilKeysDiscoverer, err)
+	})
+	t.Run("same key should return the same discoverer", func(t *testing.T) {
+		registry := NewSharedDiscoverers()
+		numCreated := 0
+		create := func() (KeysDiscoverer, error) {
+			numCreated++
+			return &keysDiscovererStub{}, nil
+		}
+
+		discoverer1, err := registry.Discoverer("key", time.Minute, create)
+		require.Nil(t, err)
+		discoverer2, err := registry.Discoverer("key", time.Hour, create)
+		require.Nil(t, err)
+		discoverer3, err := registry.Discoverer("other key", time.Minute, create)
+		require.Nil(t, err)
+
+		assert.True(t, discoverer1 == discoverer2)
+		assert.False(t, discoverer1 == discoverer3)
+		assert.Equal(t, 2, numCreated)
+		assert.Equal(t, time.Minute, discoverer1.(*sharedDiscoverer).refreshInterval)
+	})
+	t.Run("the shortest refresh interval should be used", func(t *testing.T) {
+		registry := NewSharedDiscoverers()
+		discoverer, _ := registry.Discoverer("key", time.Hour, createDiscovererFunc(&keysDiscovererStub{}))
+		_, _ = registry.Discoverer("key", time.Minute, createDiscovererFunc(&keysDiscovererStub{}))
+
+		assert.Equal(t, time.Minute, discoverer.(*sharedDiscoverer).refreshInterval)
+	})
+}
+
+func TestSharedDiscoverer_DiscoverPublicKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should discover again only after the refresh interval", func(t *testing.T) {
+		numCalls := 0
+		discoveredKeys := []string{"pk1"}
+		var discoveryErr error
+		registry := NewSharedDiscoverers()
+		discoverer, _ := registry.Discoverer("key", time.Minute, createDiscovererFunc(&keysDiscovererStub{
+			DiscoverPublicKeysCalled: func(ctx context.Context) ([]string, error) {
+				numCalls++
+				return discoveredKeys, discoveryErr
+			},
+		}))
+		currentTime := time.Date(2022, 4, 5, 10, 0, 0, 0, time.UTC)
+		discoverer.(*sharedDiscoverer).getTime = func() time.Time {
+			return currentTime
+		}
+
+		publicKeys, err := discoverer.DiscoverPublicKeys(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"pk1"}, publicKeys)
+
+		discoveredKeys = []string{"pk2"}
+		currentTime = currentTime.Add(time.Second)
+		publicKeys, _ = discoverer.DiscoverPublicKeys(context.Background())
+		assert.Equal(t, []string{"pk1"}, publicKeys)
+		assert.Equal(t, 1, numCalls)
+
+		currentTime = currentTime.Add(time.Minute)
+		publicKeys, _ = discoverer.DiscoverPublicKeys(context.Background())
+		assert.Equal(t, []string{"pk2"}, publicKeys)
+		assert.Equal(t, 2, numCalls)
+
+		discoveryErr = errors.New("expected error")
+		currentTime = currentTime.Add(time.Minute)
+		publicKeys, err = discoverer.DiscoverPublicKeys(context.Background())
+		assert.Equal(t, discoveryErr, err)
+		assert.Equal(t, 3, numCalls)
+
+		// a failed discovery is retried on the next call
+		discoveryErr = nil
+		discoveredKeys = []string{"pk3"}
+		publicKeys, err = discoverer.DiscoverPublicKeys(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"pk3"}, publicKeys)
+		assert.Equal(t, 4, numCalls)
+	})
+	t.Run("concurrent calls should wait for the same discovery", func(t *testing.T) {
+		numCalls := 0
+		discoveryStarted := make(chan struct{})
+		releaseDiscovery := make(chan struct{})
+		registry := NewSharedDiscoverers()
+		discoverer, _ := registry.Discoverer("key", time.Minute, createDiscovererFunc(&keysDiscovererStub{
+			DiscoverPublicKeysCalled: func(ctx context.Context) ([]string, error) {
+				numCalls++
+				close(discoveryStarted)
+				<-releaseDiscovery
+				return []string{"pk1"}, nil
+			},
+		}))
+
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			publicKeys, err := discoverer.DiscoverPublicKeys(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"pk1"}, publicKeys)
+		}()
+		<-discoveryStarted
+		go func() {
+			defer wg.Done()
+			publicKeys, err := discoverer.DiscoverPublicKeys(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"pk1"}, publicKeys)
+		}()
+
+		time.Sleep(time.Millisecond * 50)
+		close(releaseDiscovery)
+		wg.Wait()
+		assert.Equal(t, 1, numCalls)
+	})
+	t.Run("a waiting call should return when its context is done", func(t *testing.T) {
+		discoveryStarted := make(chan struct{})
+		releaseDiscovery := make(chan struct{})
+		defer close(releaseDiscovery)
+		registry := NewSharedDiscoverers()
+		discoverer, _ := registry.Discoverer("key", time.Minute, createDiscovererFunc(&keysDiscovererStub{
+			DiscoverPublicKeysCalled: func(ctx context.Context) ([]string, error) {
+				close(discoveryStarted)
+				<-releaseDiscovery
+				return []string{"pk1"}, nil
+			},
+		}))
+
+		go func() {
+			_, _ = discoverer.DiscoverPublicKeys(context.Background())
+		}()
+		<-discoveryStarted
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		publicKeys, err := discoverer.DiscoverPublicKeys(ctx)
+		assert.Nil(t, publicKeys)
+		assert.Equal(t, context.Canceled, err)
+	})
+	t.Run("returned keys should not alter the discoverer", func(t *testing.T) {
+		registry := NewSharedDiscoverers()
+		discoverer, _ := registry.Discoverer("key", time.Minute, createDiscovererFunc(&keysDiscovererStub{
+			DiscoverPublicKeysCalled: func(ctx context.Context) ([]string, error) {
+				return []string{"pk1"}, nil
+			},
+		}))
+
+		publicKeys, _ := discoverer.DiscoverPublicKeys(context.Background())
+		publicKeys[0] = "pk2"
+		publicKeys, _ = discoverer.DiscoverPublicKeys(context.Background())
+		assert.Equal(t, []string{"pk1"}, publicKeys)
+	})
+}
@@ -0,0 +1,164 @@
+package keys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+const (
+	// DefaultApiUrl is the Elrond API used when none is configured
+	DefaultApiUrl = "https://api.elrond.com"
+
+	nodesPath          = "/nodes"
+	pageSize           = 500
+	addressPrefix      = "erd1"
+	addressLength      = 62
+	maxErrorBodyLength = 512
+)
+
+// ArgsStakingProviderDiscoverer represents the arguments DTO for the stakingProviderDiscoverer constructor
+type ArgsStakingProviderDiscoverer struct {
+	HTTPClient        HTTPClient
+	ApiUrl            string
+	ContractAddresses []string
+	OwnerAddresses    []string
+}
+
+type nodeDTO struct {
+	Bls string `json:"bls"`
+}
+
+type stakingProviderDiscoverer struct {
+	httpClient HTTPClient
+	queries    []string
+}
+
+// NewStakingProviderDiscoverer creates a discoverer fetching from the Elrond API the BLS public keys of the nodes
+// managed by the provided delegation contracts (staking providers) and owned by the provided addresses
+func NewStakingProviderDiscoverer(args ArgsStakingProviderDiscoverer) (*stakingProviderDiscoverer, error) {
+	err := checkDiscovererArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	apiUrl := strings.TrimSuffix(args.ApiUrl, "/")
+	if len(apiUrl) == 0 {
+		apiUrl = DefaultApiUrl
+	}
+
+	discoverer := &stakingProviderDiscoverer{
+		httpClient: args.HTTPClient,
+		queries:    make([]string, 0, len(args.ContractAddresses)+len(args.OwnerAddresses)),
+	}
+	for _, address := range args.ContractAddresses {
+		discoverer.queries = append(discoverer.queries, apiUrl+nodesPath+"?provider="+url.QueryEscape(address))
+	}
+	for _, address := range args.OwnerAddresses {
+		discoverer.queries = append(discoverer.queries, apiUrl+nodesPath+"?owner="+url.QueryEscape(address))
+	}
+
+	return discoverer, nil
+}
+
+func checkDiscovererArgs(args ArgsStakingProviderDiscoverer) error {
+	if check.IfNil(args.HTTPClient) {
+		return errNilHTTPClient
+	}
+	if len(args.ContractAddresses)+len(args.OwnerAddresses) == 0 {
+		return errNoAddresses
+	}
+	err := checkAddresses(args.ContractAddresses)
+	if err != nil {
+		return err
+	}
+
+	return checkAddresses(args.OwnerAddresses)
+}
+
+func checkAddresses(addresses []string) error {
+	for _, address := range addresses {
+		if !strings.HasPrefix(address, addressPrefix) || len(address) != addressLength {
+			return fmt.Errorf("%w: %q", errInvalidAddress, address)
+		}
+	}
+
+	return nil
+}
+
+// DiscoverPublicKeys fetches the public keys of all the configured addresses, without duplicates
+func (discoverer *stakingProviderDiscoverer) DiscoverPublicKeys(ctx context.Context) ([]string, error) {
+	publicKeys := make([]string, 0)
+	existing := make(map[string]struct{})
+	for _, query := range discoverer.queries {
+		queryKeys, err := discoverer.fetchAllPages(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, publicKey := range queryKeys {
+			_, found := existing[publicKey]
+			if found {
+				continue
+			}
+
+			existing[publicKey] = struct{}{}
+			publicKeys = append(publicKeys, publicKey)
+		}
+	}
+
+	return publicKeys, nil
+}
+
+func (discoverer *stakingProviderDiscoverer) fetchAllPages(ctx context.Context, query string) ([]string, error) {
+	publicKeys := make([]string, 0)
+	for from := 0; ; from += pageSize {
+		nodes, err := discoverer.fetchPage(ctx, fmt.Sprintf("%s&from=%d&size=%d", query, from, pageSize))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range nodes {
+			if len(node.Bls) > 0 {
+				publicKeys = append(publicKeys, strings.ToLower(node.Bls))
+			}
+		}
+		if len(nodes) < pageSize {
+			return publicKeys, nil
+		}
+	}
+}
+
+func (discoverer *stakingProviderDiscoverer) fetchPage(ctx context.Context, pageUrl string) ([]nodeDTO, error) {
+	response, err := discoverer.httpClient.CallEndPoint(ctx, httpWrapper.Request{
+		Method: http.MethodGet,
+		Url:    pageUrl,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w %d for %s: %s", errUnexpectedStatusCode, response.StatusCode, pageUrl,
+			formatting.Truncate(strings.TrimSpace(string(response.Body)), maxErrorBodyLength))
+	}
+
+	nodes := make([]nodeDTO, 0)
+	err = json.Unmarshal(response.Body, &nodes)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding the nodes from %s", err, pageUrl)
+	}
+
+	return nodes, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (discoverer *stakingProviderDiscoverer) IsInterfaceNil() bool {
+	return discoverer == nil
+}
@@ -0,0 +1,189 @@
+package keys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContractAddress = "erd1qqqqqqqqqqqqqpgqxwakt2g7u9atsnr03gqcgmhcv38pt7mkd94q6shuwt"
+const testOwnerAddress = "erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx"
+
+type httpClientStub struct {
+	CallEndPointCalled func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error)
+}
+
+// CallEndPoint -
+func (stub *httpClientStub) CallEndPoint(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+	if stub.CallEndPointCalled != nil {
+		return stub.CallEndPointCalled(ctx, request)
+	}
+
+	return httpWrapper.Response{StatusCode: http.StatusOK, Body: []byte("[]")}, nil
+}
+
+// IsInterfaceNil -
+func (stub *httpClientStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createMockArgsStakingProviderDiscoverer() ArgsStakingProviderDiscoverer {
+	return ArgsStakingProviderDiscoverer{
+		HTTPClient:        &httpClientStub{},
+		ApiUrl:            "https://testnet-api.elrond.com/",
+		ContractAddresses: []string{testContractAddress},
+	}
+}
+
+func createNodesResponse(t *testing.T, publicKeys ...string) httpWrapper.Response {
+	nodes := make([]nodeDTO, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		nodes = append(nodes, nodeDTO{Bls: publicKey})
+	}
+
+	buff, err := json.Marshal(nodes)
+	require.Nil(t, err)
+
+	return httpWrapper.Response{StatusCode: http.StatusOK, Body: buff}
+}
+
+func TestNewStakingProviderDiscoverer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsStakingProviderDiscoverer()
+		args.HTTPClient = nil
+
+		discoverer, err := NewStakingProviderDiscoverer(args)
+		assert.True(t, check.IfNil(discoverer))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("no addresses should error", func(t *testing.T) {
+		args := createMockArgsStakingProviderDiscoverer()
+		args.ContractAddresses = nil
+
+		discoverer, err := NewStakingProviderDiscoverer(args)
+		assert.True(t, check.IfNil(discoverer))
+		assert.Equal(t, errNoAddresses, err)
+	})
+	t.Run("invalid address should error", func(t *testing.T) {
+		args := createMockArgsStakingProviderDiscoverer()
+		args.OwnerAddresses = []string{"erd1short"}
+
+		discoverer, err := NewStakingProviderDiscoverer(args)
+		assert.True(t, check.IfNil(discoverer))
+		assert.True(t, errors.Is(err, errInvalidAddress))
+		assert.True(t, strings.Contains(err.Error(), "erd1short"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		discoverer, err := NewStakingProviderDiscoverer(createMockArgsStakingProviderDiscoverer())
+		assert.False(t, check.IfNil(discoverer))
+		assert.Nil(t, err)
+	})
+}
+
+func TestStakingProviderDiscoverer_DiscoverPublicKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("request error should error", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		args := createMockArgsStakingProviderDiscoverer()
+		args.HTTPClient = &httpClientStub{
+			CallEndPointCalled: func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+				return httpWrapper.Response{}, expectedErr
+			},
+		}
+		discoverer, _ := NewStakingProviderDiscoverer(args)
+
+		publicKeys, err := discoverer.DiscoverPublicKeys(context.Background())
+		assert.Nil(t, publicKeys)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("unexpected status code should error", func(t *testing.T) {
+		args := createMockArgsStakingProviderDiscoverer()
+		args.HTTPClient = &httpClientStub{
+			CallEndPointCalled: func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+				return httpWrapper.Response{StatusCode: http.StatusBadRequest, Body: []byte("invalid provider\n")}, nil
+			},
+		}
+		discoverer, _ := NewStakingProviderDiscoverer(args)
+
+		publicKeys, err := discoverer.DiscoverPublicKeys(context.Background())
+		assert.Nil(t, publicKeys)
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.True(t, strings.HasSuffix(err.Error(), ": invalid provider"))
+	})
+	t.Run("invalid response should error", func(t *testing.T) {
+		args := createMockArgsStakingProviderDiscoverer()
+		args.HTTPClient = &httpClientStub{
+			CallEndPointCalled: func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+				return httpWrapper.Response{StatusCode: http.StatusOK, Body: []byte("{}")}, nil
+			},
+		}
+		discoverer, _ := NewStakingProviderDiscoverer(args)
+
+		publicKeys, err := discoverer.DiscoverPublicKeys(context.Background())
+		assert.Nil(t, publicKeys)
+		assert.NotNil(t, err)
+	})
+	t.Run("should fetch all the pages of all the addresses", func(t *testing.T) {
+		firstPage := make([]string, 0, pageSize)
+		for i := 0; i < pageSize; i++ {
+			firstPage = append(firstPage, fmt.Sprintf("%0192x", i))
+		}
+
+		requestedUrls := make([]string, 0)
+		args := createMockArgsStakingProviderDiscoverer()
+		args.OwnerAddresses = []string{testOwnerAddress}
+		args.HTTPClient = &httpClientStub{
+			CallEndPointCalled: func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+				assert.Equal(t, http.MethodGet, request.Method)
+				requestedUrls = append(requestedUrls, request.Url)
+				switch len(requestedUrls) {
+				case 1:
+					return createNodesResponse(t, firstPage...), nil
+				case 2:
+					return createNodesResponse(t, strings.ToUpper(createTestPublicKey("a")), ""), nil
+				default:
+					return createNodesResponse(t, createTestPublicKey("a"), createTestPublicKey("b")), nil
+				}
+			},
+		}
+		discoverer, _ := NewStakingProviderDiscoverer(args)
+
+		publicKeys, err := discoverer.DiscoverPublicKeys(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, append(firstPage, createTestPublicKey("a"), createTestPublicKey("b")), publicKeys)
+		assert.Equal(t, []string{
+			"https://testnet-api.elrond.com/nodes?provider=" + testContractAddress + "&from=0&size=500",
+			"https://testnet-api.elrond.com/nodes?provider=" + testContractAddress + "&from=500&size=500",
+			"https://testnet-api.elrond.com/nodes?owner=" + testOwnerAddress + "&from=0&size=500",
+		}, requestedUrls)
+	})
+	t.Run("should use the default API", func(t *testing.T) {
+		var requestedUrl string
+		args := createMockArgsStakingProviderDiscoverer()
+		args.ApiUrl = ""
+		args.HTTPClient = &httpClientStub{
+			CallEndPointCalled: func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+				requestedUrl = request.Url
+				return createNodesResponse(t), nil
+			},
+		}
+		discoverer, _ := NewStakingProviderDiscoverer(args)
+
+		publicKeys, err := discoverer.DiscoverPublicKeys(context.Background())
+		assert.Nil(t, err)
+		assert.Empty(t, publicKeys)
+		assert.True(t, strings.HasPrefix(requestedUrl, DefaultApiUrl+"/nodes?provider="))
+	})
+}
@@ -2,16 +2,19 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/iulianpascalau/node-monitoring/data"
 )
 
 // AlarmHandlerStub -
 type AlarmHandlerStub struct {
-	ShouldQueryCalled func() bool
-	QueryCalled       func(ctx context.Context) (data.AlarmResponse, error)
-	QueryInfoCalled   func(ctx context.Context) (string, error)
-	IdentifierCalled  func() string
+	ShouldQueryCalled   func() bool
+	QueryCalled         func(ctx context.Context) (data.AlarmResponse, error)
+	QueryInfoCalled     func(ctx context.Context) (string, error)
+	NextQueryTimeCalled func() time.Time
+	IdentifierCalled    func() string
+	CloseCalled         func() error
 }
 
 // ShouldQuery -
@@ -41,6 +44,15 @@ func (stub *AlarmHandlerStub) QueryInfo(ctx context.Context) (string, error) {
 	return "", nil
 }
 
+// NextQueryTime -
+func (stub *AlarmHandlerStub) NextQueryTime() time.Time {
+	if stub.NextQueryTimeCalled != nil {
+		return stub.NextQueryTimeCalled()
+	}
+
+	return time.Time{}
+}
+
 // Identifier -
 func (stub *AlarmHandlerStub) Identifier() string {
 	if stub.IdentifierCalled != nil {
@@ -50,6 +62,15 @@ func (stub *AlarmHandlerStub) Identifier() string {
 	return ""
 }
 
+// Close -
+func (stub *AlarmHandlerStub) Close() error {
+	if stub.CloseCalled != nil {
+		return stub.CloseCalled()
+	}
+
+	return nil
+}
+
 // IsInterfaceNil -
 func (stub *AlarmHandlerStub) IsInterfaceNil() bool {
 	return stub == nil
@@ -0,0 +1,77 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+// AlarmsControllerStub -
+type AlarmsControllerStub struct {
+	AlarmsStatusCalled     func() []data.AlarmStatus
+	QueryAlarmInfoCalled   func(ctx context.Context, identifier string) (string, error)
+	PauseAlarmCalled       func(identifier string) error
+	ResumeAlarmCalled      func(identifier string) error
+	AcknowledgeAlarmCalled func(identifier string) error
+	SilenceAlarmCalled     func(identifier string, duration time.Duration) error
+}
+
+// AlarmsStatus -
+func (stub *AlarmsControllerStub) AlarmsStatus() []data.AlarmStatus {
+	if stub.AlarmsStatusCalled != nil {
+		return stub.AlarmsStatusCalled()
+	}
+
+	return nil
+}
+
+// QueryAlarmInfo -
+func (stub *AlarmsControllerStub) QueryAlarmInfo(ctx context.Context, identifier string) (string, error) {
+	if stub.QueryAlarmInfoCalled != nil {
+		return stub.QueryAlarmInfoCalled(ctx, identifier)
+	}
+
+	return "", nil
+}
+
+// PauseAlarm -
+func (stub *AlarmsControllerStub) PauseAlarm(identifier string) error {
+	if stub.PauseAlarmCalled != nil {
+		return stub.PauseAlarmCalled(identifier)
+	}
+
+	return nil
+}
+
+// ResumeAlarm -
+func (stub *AlarmsControllerStub) ResumeAlarm(identifier string) error {
+	if stub.ResumeAlarmCalled != nil {
+		return stub.ResumeAlarmCalled(identifier)
+	}
+
+	return nil
+}
+
+// AcknowledgeAlarm -
+func (stub *AlarmsControllerStub) AcknowledgeAlarm(identifier string) error {
+	if stub.AcknowledgeAlarmCalled != nil {
+		return stub.AcknowledgeAlarmCalled(identifier)
+	}
+
+	return nil
+}
+
+// SilenceAlarm -
+func (stub *AlarmsControllerStub) SilenceAlarm(identifier string, duration time.Duration) error {
+	if stub.SilenceAlarmCalled != nil {
+		return stub.SilenceAlarmCalled(identifier, duration)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *AlarmsControllerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
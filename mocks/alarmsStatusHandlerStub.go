@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+// AlarmsStatusHandlerStub -
+type AlarmsStatusHandlerStub struct {
+	AlarmsStatusCalled    func() []data.AlarmStatus
+	AlarmsHistoryCalled   func() map[string][]data.AlarmHistoryPoint
+	NotifiersStatusCalled func() []data.NotifierStatus
+	QueryAlarmInfoCalled  func(ctx context.Context, identifier string) (string, error)
+}
+
+// AlarmsStatus -
+func (stub *AlarmsStatusHandlerStub) AlarmsStatus() []data.AlarmStatus {
+	if stub.AlarmsStatusCalled != nil {
+		return stub.AlarmsStatusCalled()
+	}
+
+	return nil
+}
+
+// AlarmsHistory -
+func (stub *AlarmsStatusHandlerStub) AlarmsHistory() map[string][]data.AlarmHistoryPoint {
+	if stub.AlarmsHistoryCalled != nil {
+		return stub.AlarmsHistoryCalled()
+	}
+
+	return nil
+}
+
+// NotifiersStatus -
+func (stub *AlarmsStatusHandlerStub) NotifiersStatus() []data.NotifierStatus {
+	if stub.NotifiersStatusCalled != nil {
+		return stub.NotifiersStatusCalled()
+	}
+
+	return nil
+}
+
+// QueryAlarmInfo -
+func (stub *AlarmsStatusHandlerStub) QueryAlarmInfo(ctx context.Context, identifier string) (string, error) {
+	if stub.QueryAlarmInfoCalled != nil {
+		return stub.QueryAlarmInfoCalled(ctx, identifier)
+	}
+
+	return "", nil
+}
+
+// IsInterfaceNil -
+func (stub *AlarmsStatusHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
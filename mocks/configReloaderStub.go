@@ -0,0 +1,20 @@
+package mocks
+
+// ConfigReloaderStub -
+type ConfigReloaderStub struct {
+	ReloadCalled func() error
+}
+
+// Reload -
+func (stub *ConfigReloaderStub) Reload() error {
+	if stub.ReloadCalled != nil {
+		return stub.ReloadCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *ConfigReloaderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,77 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+// ControlHandlerStub -
+type ControlHandlerStub struct {
+	PauseAlarmCalled         func(identifier string) error
+	ResumeAlarmCalled        func(identifier string) error
+	AcknowledgeAlarmCalled   func(identifier string) error
+	SilenceAlarmCalled       func(identifier string, duration time.Duration) error
+	TriggerAlarmQueryCalled  func(ctx context.Context, identifier string) (data.AlarmResponse, error)
+	TriggerInfoMessageCalled func(ctx context.Context) data.AlarmResponse
+}
+
+// PauseAlarm -
+func (stub *ControlHandlerStub) PauseAlarm(identifier string) error {
+	if stub.PauseAlarmCalled != nil {
+		return stub.PauseAlarmCalled(identifier)
+	}
+
+	return nil
+}
+
+// ResumeAlarm -
+func (stub *ControlHandlerStub) ResumeAlarm(identifier string) error {
+	if stub.ResumeAlarmCalled != nil {
+		return stub.ResumeAlarmCalled(identifier)
+	}
+
+	return nil
+}
+
+// AcknowledgeAlarm -
+func (stub *ControlHandlerStub) AcknowledgeAlarm(identifier string) error {
+	if stub.AcknowledgeAlarmCalled != nil {
+		return stub.AcknowledgeAlarmCalled(identifier)
+	}
+
+	return nil
+}
+
+// SilenceAlarm -
+func (stub *ControlHandlerStub) SilenceAlarm(identifier string, duration time.Duration) error {
+	if stub.SilenceAlarmCalled != nil {
+		return stub.SilenceAlarmCalled(identifier, duration)
+	}
+
+	return nil
+}
+
+// TriggerAlarmQuery -
+func (stub *ControlHandlerStub) TriggerAlarmQuery(ctx context.Context, identifier string) (data.AlarmResponse, error) {
+	if stub.TriggerAlarmQueryCalled != nil {
+		return stub.TriggerAlarmQueryCalled(ctx, identifier)
+	}
+
+	return data.AlarmResponse{}, nil
+}
+
+// TriggerInfoMessage -
+func (stub *ControlHandlerStub) TriggerInfoMessage(ctx context.Context) data.AlarmResponse {
+	if stub.TriggerInfoMessageCalled != nil {
+		return stub.TriggerInfoMessageCalled(ctx)
+	}
+
+	return data.AlarmResponse{}
+}
+
+// IsInterfaceNil -
+func (stub *ControlHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
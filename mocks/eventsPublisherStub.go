@@ -0,0 +1,20 @@
+package mocks
+
+import "github.com/iulianpascalau/node-monitoring/data"
+
+// EventsPublisherStub -
+type EventsPublisherStub struct {
+	PublishCalled func(event data.AlarmEvent)
+}
+
+// Publish -
+func (stub *EventsPublisherStub) Publish(event data.AlarmEvent) {
+	if stub.PublishCalled != nil {
+		stub.PublishCalled(event)
+	}
+}
+
+// IsInterfaceNil -
+func (stub *EventsPublisherStub) IsInterfaceNil() bool {
+	return stub == nil
+}
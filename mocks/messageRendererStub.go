@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+// MessageRendererStub -
+type MessageRendererStub struct {
+	RenderCalled func(response data.AlarmResponse) (templating.Message, error)
+}
+
+// Render -
+func (stub *MessageRendererStub) Render(response data.AlarmResponse) (templating.Message, error) {
+	if stub.RenderCalled != nil {
+		return stub.RenderCalled(response)
+	}
+
+	return templating.Message{}, nil
+}
+
+// IsInterfaceNil -
+func (stub *MessageRendererStub) IsInterfaceNil() bool {
+	return stub == nil
+}
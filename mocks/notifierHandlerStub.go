@@ -9,6 +9,7 @@ import (
 // NotifierHandlerStub -
 type NotifierHandlerStub struct {
 	ProcessAlarmResponseCalled func(ctx context.Context, response data.AlarmResponse) error
+	CloseCalled                func() error
 }
 
 // ProcessAlarmResponse -
@@ -20,6 +21,15 @@ func (stub *NotifierHandlerStub) ProcessAlarmResponse(ctx context.Context, respo
 	return nil
 }
 
+// Close -
+func (stub *NotifierHandlerStub) Close() error {
+	if stub.CloseCalled != nil {
+		return stub.CloseCalled()
+	}
+
+	return nil
+}
+
 // IsInterfaceNil -
 func (stub *NotifierHandlerStub) IsInterfaceNil() bool {
 	return stub == nil
@@ -0,0 +1,7 @@
+package networks
+
+import "errors"
+
+var errNilAlarmHandler = errors.New("nil alarm handler")
+var errNilNotifier = errors.New("nil notifier")
+var errEmptyNetworkName = errors.New("empty network name")
@@ -0,0 +1,62 @@
+package networks
+
+import (
+	"context"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+type networkAlarmHandler struct {
+	poll.AlarmHandler
+	network string
+}
+
+// NewNetworkAlarmHandler wraps the provided alarm so its responses are labeled with the network's name, all the
+// other operations being forwarded to the alarm
+func NewNetworkAlarmHandler(handler poll.AlarmHandler, network string) (*networkAlarmHandler, error) {
+	if check.IfNil(handler) {
+		return nil, errNilAlarmHandler
+	}
+	if len(network) == 0 {
+		return nil, errEmptyNetworkName
+	}
+
+	return &networkAlarmHandler{
+		AlarmHandler: handler,
+		network:      network,
+	}, nil
+}
+
+// Query queries the wrapped alarm and labels its response with the network's name
+func (handler *networkAlarmHandler) Query(ctx context.Context) (data.AlarmResponse, error) {
+	response, err := handler.AlarmHandler.Query(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	labels := make(map[string]string, len(response.Labels)+1)
+	for name, value := range response.Labels {
+		labels[name] = value
+	}
+	labels[data.NetworkLabel] = handler.network
+	response.Labels = labels
+
+	return response, nil
+}
+
+// Network returns the name of the network the alarm belongs to
+func (handler *networkAlarmHandler) Network() string {
+	return handler.network
+}
+
+// Unwrap returns the wrapped alarm
+func (handler *networkAlarmHandler) Unwrap() poll.AlarmHandler {
+	return handler.AlarmHandler
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *networkAlarmHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
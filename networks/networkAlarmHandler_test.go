@@ -0,0 +1,69 @@
+package networks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNetworkAlarmHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil alarm handler should error", func(t *testing.T) {
+		handler, err := NewNetworkAlarmHandler(nil, "mainnet")
+		assert.True(t, check.IfNil(handler))
+		assert.Equal(t, errNilAlarmHandler, err)
+	})
+	t.Run("empty network name should error", func(t *testing.T) {
+		handler, err := NewNetworkAlarmHandler(&mocks.AlarmHandlerStub{}, "")
+		assert.True(t, check.IfNil(handler))
+		assert.Equal(t, errEmptyNetworkName, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		alarm := &mocks.AlarmHandlerStub{}
+		handler, err := NewNetworkAlarmHandler(alarm, "mainnet")
+		assert.False(t, check.IfNil(handler))
+		assert.Nil(t, err)
+		assert.Equal(t, "mainnet", handler.Network())
+		assert.True(t, handler.Unwrap() == alarm)
+	})
+}
+
+func TestNetworkAlarmHandler_Query(t *testing.T) {
+	t.Parallel()
+
+	t.Run("query error should not label", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		handler, _ := NewNetworkAlarmHandler(&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				return data.AlarmResponse{}, expectedErr
+			},
+		}, "mainnet")
+
+		response, err := handler.Query(context.Background())
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, data.AlarmResponse{}, response)
+	})
+	t.Run("should label the response without altering the alarm's labels", func(t *testing.T) {
+		labels := map[string]string{data.NodeLabel: "validator-0"}
+		handler, _ := NewNetworkAlarmHandler(&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				return data.AlarmResponse{
+					Identifier: "node rating",
+					Level:      data.Error,
+					Labels:     labels,
+				}, nil
+			},
+		}, "testnet")
+
+		response, err := handler.Query(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{data.NodeLabel: "validator-0", data.NetworkLabel: "testnet"}, response.Labels)
+		assert.Equal(t, map[string]string{data.NodeLabel: "validator-0"}, labels)
+	})
+}
@@ -0,0 +1,58 @@
+package networks
+
+import (
+	"context"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/poll"
+)
+
+type networkFilteredNotifier struct {
+	notifier         poll.NotifierHandler
+	rejectedNetworks map[string]struct{}
+}
+
+// NewNetworkFilteredNotifier wraps the provided notifier so it ignores the responses labeled with one of the
+// rejected networks. The responses without a network label are always forwarded
+func NewNetworkFilteredNotifier(notifier poll.NotifierHandler, rejectedNetworks []string) (*networkFilteredNotifier, error) {
+	if check.IfNil(notifier) {
+		return nil, errNilNotifier
+	}
+
+	filtered := &networkFilteredNotifier{
+		notifier:         notifier,
+		rejectedNetworks: make(map[string]struct{}, len(rejectedNetworks)),
+	}
+	for _, network := range rejectedNetworks {
+		filtered.rejectedNetworks[network] = struct{}{}
+	}
+
+	return filtered, nil
+}
+
+// AcceptsResponse returns true if the response does not belong to a rejected network
+func (filtered *networkFilteredNotifier) AcceptsResponse(response data.AlarmResponse) bool {
+	_, rejected := filtered.rejectedNetworks[response.Labels[data.NetworkLabel]]
+
+	return !rejected
+}
+
+// ProcessAlarmResponse forwards the accepted responses to the wrapped notifier
+func (filtered *networkFilteredNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if !filtered.AcceptsResponse(response) {
+		return nil
+	}
+
+	return filtered.notifier.ProcessAlarmResponse(ctx, response)
+}
+
+// Unwrap returns the wrapped notifier
+func (filtered *networkFilteredNotifier) Unwrap() poll.NotifierHandler {
+	return filtered.notifier
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (filtered *networkFilteredNotifier) IsInterfaceNil() bool {
+	return filtered == nil
+}
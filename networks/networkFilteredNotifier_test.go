@@ -0,0 +1,58 @@
+package networks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNetworkFilteredNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil notifier should error", func(t *testing.T) {
+		notifier, err := NewNetworkFilteredNotifier(nil, []string{"testnet"})
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilNotifier, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		wrapped := &mocks.NotifierHandlerStub{}
+		notifier, err := NewNetworkFilteredNotifier(wrapped, []string{"testnet"})
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.True(t, notifier.Unwrap() == wrapped)
+	})
+}
+
+func TestNetworkFilteredNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	processed := make([]string, 0)
+	notifier, _ := NewNetworkFilteredNotifier(&mocks.NotifierHandlerStub{
+		ProcessAlarmResponseCalled: func(ctx context.Context, response data.AlarmResponse) error {
+			processed = append(processed, response.Identifier)
+			return expectedErr
+		},
+	}, []string{"testnet", "devnet"})
+
+	responses := []data.AlarmResponse{
+		{Identifier: "mainnet rating", Labels: map[string]string{data.NetworkLabel: "mainnet"}},
+		{Identifier: "testnet rating", Labels: map[string]string{data.NetworkLabel: "testnet"}},
+		{Identifier: "devnet rating", Labels: map[string]string{data.NetworkLabel: "devnet"}},
+		{Identifier: data.SystemIdentifier},
+	}
+	errs := make([]error, 0, len(responses))
+	for _, response := range responses {
+		errs = append(errs, notifier.ProcessAlarmResponse(context.Background(), response))
+	}
+
+	assert.Equal(t, []string{"mainnet rating", data.SystemIdentifier}, processed)
+	assert.Equal(t, []error{expectedErr, nil, nil, expectedErr}, errs)
+	assert.True(t, notifier.AcceptsResponse(responses[0]))
+	assert.False(t, notifier.AcceptsResponse(responses[1]))
+}
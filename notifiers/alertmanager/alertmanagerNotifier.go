@@ -0,0 +1,286 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+var log = logger.GetOrCreate("notifiers/alertmanager")
+
+const (
+	// DefaultAlertName is the alertname label used when none is configured
+	DefaultAlertName = "NodeMonitoringAlarm"
+	// DefaultSeverity is the severity label used when neither the config nor the alarm provides one
+	DefaultSeverity = "critical"
+	// DefaultAlertTimeout is the time after which Alertmanager resolves a firing alert that was not sent again
+	DefaultAlertTimeout = 15 * time.Minute
+	// DefaultResendInterval is the interval at which a firing alert is sent again
+	DefaultResendInterval = time.Minute
+
+	alertsPath          = "/api/v2/alerts"
+	authorizationHeader = "Authorization"
+	maxErrorBodyLength  = 512
+)
+
+// ArgsAlertmanagerNotifier represents the arguments DTO for the alertmanagerNotifier constructor
+type ArgsAlertmanagerNotifier struct {
+	HTTPClient     HTTPClient
+	Urls           []string
+	Username       string
+	Password       string
+	AlertName      string
+	Severity       string
+	StaticLabels   map[string]string
+	GeneratorUrl   string
+	AlertTimeout   time.Duration
+	ResendInterval time.Duration
+}
+
+type alertState struct {
+	firing   bool
+	startsAt time.Time
+	labels   map[string]string
+	lastSent time.Time
+}
+
+type alertmanagerNotifier struct {
+	httpClient     HTTPClient
+	alertsUrls     []string
+	authorization  string
+	alertName      string
+	severity       string
+	staticLabels   map[string]string
+	generatorUrl   string
+	alertTimeout   time.Duration
+	resendInterval time.Duration
+	getTime        func() time.Time
+
+	mutStates sync.Mutex
+	states    map[string]*alertState
+}
+
+// NewAlertmanagerNotifier creates a new notifier that pushes the alarms in error as firing alerts to all the provided
+// Alertmanager instances (e.g. the members of a cluster) and resolves them when the alarms recover. A firing alert is
+// sent again at most every resend interval, with the end time set to the alert timeout, so Alertmanager resolves it
+// by itself if the monitoring tool stops. The alert timeout should exceed the alarms polling interval
+func NewAlertmanagerNotifier(args ArgsAlertmanagerNotifier) (*alertmanagerNotifier, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	notifier := &alertmanagerNotifier{
+		httpClient:     args.HTTPClient,
+		alertsUrls:     make([]string, 0, len(args.Urls)),
+		alertName:      args.AlertName,
+		severity:       args.Severity,
+		staticLabels:   make(map[string]string, len(args.StaticLabels)),
+		generatorUrl:   args.GeneratorUrl,
+		alertTimeout:   args.AlertTimeout,
+		resendInterval: args.ResendInterval,
+		getTime:        time.Now,
+		states:         make(map[string]*alertState),
+	}
+	for _, url := range args.Urls {
+		notifier.alertsUrls = append(notifier.alertsUrls, strings.TrimSuffix(url, "/")+alertsPath)
+	}
+	for name, value := range args.StaticLabels {
+		notifier.staticLabels[name] = value
+	}
+	if len(args.Username) > 0 {
+		credentials := base64.StdEncoding.EncodeToString([]byte(args.Username + ":" + args.Password))
+		notifier.authorization = "Basic " + credentials
+	}
+	if len(notifier.alertName) == 0 {
+		notifier.alertName = DefaultAlertName
+	}
+	if len(notifier.severity) == 0 {
+		notifier.severity = DefaultSeverity
+	}
+	if notifier.alertTimeout == 0 {
+		notifier.alertTimeout = DefaultAlertTimeout
+	}
+	if notifier.resendInterval == 0 {
+		notifier.resendInterval = DefaultResendInterval
+	}
+	if notifier.resendInterval >= notifier.alertTimeout {
+		return nil, fmt.Errorf("%w, the resend interval %v should be lower than the alert timeout %v",
+			errInvalidResendInterval, notifier.resendInterval, notifier.alertTimeout)
+	}
+
+	return notifier, nil
+}
+
+func checkArgs(args ArgsAlertmanagerNotifier) error {
+	if check.IfNil(args.HTTPClient) {
+		return errNilHTTPClient
+	}
+	if len(args.Urls) == 0 {
+		return errNoUrls
+	}
+	for _, url := range args.Urls {
+		if len(strings.TrimSpace(url)) == 0 {
+			return errEmptyUrl
+		}
+	}
+	for name := range args.StaticLabels {
+		if !labelNameRegex.MatchString(name) {
+			return fmt.Errorf("%w: %q", errInvalidLabelName, name)
+		}
+	}
+	if args.AlertTimeout < 0 {
+		return fmt.Errorf("%w, provided: %v", errInvalidAlertTimeout, args.AlertTimeout)
+	}
+	if args.ResendInterval < 0 {
+		return fmt.Errorf("%w, provided: %v", errInvalidResendInterval, args.ResendInterval)
+	}
+
+	return nil
+}
+
+// ProcessAlarmResponse will push a firing alert while the alarm is in error and a resolved alert once the alarm
+// reports any other level. The info reports are not sent as they are not actionable
+func (notifier *alertmanagerNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Identifier == data.SystemIdentifier {
+		return nil
+	}
+
+	now := notifier.getTime()
+	state := notifier.getState(response.Identifier)
+	if response.Level == data.Error {
+		if state.firing && now.Sub(state.lastSent) < notifier.resendInterval {
+			return nil
+		}
+
+		newState := alertState{
+			firing:   true,
+			startsAt: now,
+			labels:   createLabels(notifier.staticLabels, notifier.alertName, notifier.severity, response),
+			lastSent: now,
+		}
+		if state.firing {
+			newState.startsAt = state.startsAt
+		}
+
+		return notifier.postAlert(ctx, response, newState, now.Add(notifier.alertTimeout))
+	}
+
+	// the unknown state is resolved as well, so the alerts left firing by a previous run are resolved
+	if state.resolved() {
+		return nil
+	}
+
+	newState := alertState{
+		startsAt: state.startsAt,
+		labels:   state.labels,
+		lastSent: now,
+	}
+	if len(newState.labels) == 0 {
+		newState.labels = createLabels(notifier.staticLabels, notifier.alertName, notifier.severity, response)
+	}
+
+	return notifier.postAlert(ctx, response, newState, now)
+}
+
+// postAlert will send the alert to all the Alertmanager instances and, only if all of them accepted it, will store
+// the new alert state so the failed alerts are sent again on the next response
+func (notifier *alertmanagerNotifier) postAlert(
+	ctx context.Context,
+	response data.AlarmResponse,
+	newState alertState,
+	endsAt time.Time,
+) error {
+	alert := postableAlert{
+		Labels:       newState.labels,
+		Annotations:  createAnnotations(response),
+		EndsAt:       formatTime(endsAt),
+		GeneratorURL: notifier.generatorUrl,
+	}
+	if !newState.startsAt.IsZero() {
+		alert.StartsAt = formatTime(newState.startsAt)
+	}
+
+	buff, err := json.Marshal([]postableAlert{alert})
+	if err != nil {
+		return err
+	}
+
+	for _, url := range notifier.alertsUrls {
+		err = notifier.post(ctx, url, buff)
+		if err != nil {
+			return fmt.Errorf("%w for %s", err, url)
+		}
+	}
+
+	log.Debug("Alertmanager alert sent", "identifier", response.Identifier, "firing", newState.firing,
+		"starts at", alert.StartsAt, "ends at", alert.EndsAt)
+	notifier.setState(response.Identifier, newState)
+
+	return nil
+}
+
+func (notifier *alertmanagerNotifier) post(ctx context.Context, url string, body []byte) error {
+	request := httpWrapper.Request{
+		Method: http.MethodPost,
+		Url:    url,
+		Body:   body,
+	}
+	if len(notifier.authorization) > 0 {
+		request.Headers = map[string]string{authorizationHeader: notifier.authorization}
+	}
+
+	result, err := notifier.httpClient.CallEndPoint(ctx, request)
+	if err != nil {
+		return err
+	}
+	if result.StatusCode < http.StatusOK || result.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, result.StatusCode,
+			formatting.Truncate(strings.TrimSpace(string(result.Body)), maxErrorBodyLength))
+	}
+
+	return nil
+}
+
+func (notifier *alertmanagerNotifier) getState(identifier string) alertState {
+	notifier.mutStates.Lock()
+	defer notifier.mutStates.Unlock()
+
+	state, found := notifier.states[identifier]
+	if !found {
+		return alertState{}
+	}
+
+	return *state
+}
+
+func (notifier *alertmanagerNotifier) setState(identifier string, state alertState) {
+	notifier.mutStates.Lock()
+	notifier.states[identifier] = &state
+	notifier.mutStates.Unlock()
+}
+
+// resolved returns true if a resolved alert was already sent, the zero value meaning an unknown state
+func (state alertState) resolved() bool {
+	return !state.firing && !state.lastSent.IsZero()
+}
+
+func formatTime(timestamp time.Time) string {
+	return timestamp.UTC().Format(time.RFC3339Nano)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *alertmanagerNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
@@ -0,0 +1,311 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAlertmanager records the received alerts and answers as the Alertmanager API v2, the queued status codes
+// being used first
+type fakeAlertmanager struct {
+	*httptest.Server
+	mut            sync.Mutex
+	alerts         []postableAlert
+	authorizations []string
+	statusCodes    []int
+}
+
+func newFakeAlertmanager() *fakeAlertmanager {
+	am := &fakeAlertmanager{}
+	am.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != alertsPath || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		buff, _ := ioutil.ReadAll(r.Body)
+		alerts := make([]postableAlert, 0)
+		_ = json.Unmarshal(buff, &alerts)
+
+		am.mut.Lock()
+		statusCode := http.StatusOK
+		if len(am.statusCodes) > 0 {
+			statusCode = am.statusCodes[0]
+			am.statusCodes = am.statusCodes[1:]
+		}
+		if statusCode == http.StatusOK {
+			am.alerts = append(am.alerts, alerts...)
+			am.authorizations = append(am.authorizations, r.Header.Get(authorizationHeader))
+		}
+		am.mut.Unlock()
+
+		w.WriteHeader(statusCode)
+		if statusCode != http.StatusOK {
+			_, _ = w.Write([]byte(`{"code":400,"message":"start time must be before end time"}`))
+		}
+	}))
+
+	return am
+}
+
+func (am *fakeAlertmanager) receivedAlerts() []postableAlert {
+	am.mut.Lock()
+	defer am.mut.Unlock()
+
+	return append(make([]postableAlert, 0, len(am.alerts)), am.alerts...)
+}
+
+func createMockArgsAlertmanagerNotifier(urls ...string) ArgsAlertmanagerNotifier {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second)
+
+	return ArgsAlertmanagerNotifier{
+		HTTPClient:   httpClient,
+		Urls:         urls,
+		StaticLabels: map[string]string{"team": "validators"},
+		GeneratorUrl: "https://monitoring.local",
+	}
+}
+
+func createErrorResponse() data.AlarmResponse {
+	return data.AlarmResponse{
+		Identifier: "node-0",
+		Level:      data.Error,
+		Data:       "node is offline\nlast nonce: 123",
+		Labels:     map[string]string{"shard": "0", "node-url": "http://127.0.0.1:8080"},
+		Metrics:    map[string]float64{"rating": 99.5},
+	}
+}
+
+func TestNewAlertmanagerNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsAlertmanagerNotifier("http://127.0.0.1:9093")
+		args.HTTPClient = nil
+
+		notifier, err := NewAlertmanagerNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("no URLs should error", func(t *testing.T) {
+		notifier, err := NewAlertmanagerNotifier(createMockArgsAlertmanagerNotifier())
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNoUrls, err)
+	})
+	t.Run("empty URL should error", func(t *testing.T) {
+		notifier, err := NewAlertmanagerNotifier(createMockArgsAlertmanagerNotifier("http://127.0.0.1:9093", " "))
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyUrl, err)
+	})
+	t.Run("invalid static label name should error", func(t *testing.T) {
+		args := createMockArgsAlertmanagerNotifier("http://127.0.0.1:9093")
+		args.StaticLabels = map[string]string{"node-team": "validators"}
+
+		notifier, err := NewAlertmanagerNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidLabelName))
+	})
+	t.Run("invalid alert timeout should error", func(t *testing.T) {
+		args := createMockArgsAlertmanagerNotifier("http://127.0.0.1:9093")
+		args.AlertTimeout = -time.Second
+
+		notifier, err := NewAlertmanagerNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidAlertTimeout))
+	})
+	t.Run("resend interval not lower than the alert timeout should error", func(t *testing.T) {
+		args := createMockArgsAlertmanagerNotifier("http://127.0.0.1:9093")
+		args.AlertTimeout = time.Minute
+		args.ResendInterval = time.Minute
+
+		notifier, err := NewAlertmanagerNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidResendInterval))
+	})
+	t.Run("should apply the defaults", func(t *testing.T) {
+		notifier, err := NewAlertmanagerNotifier(createMockArgsAlertmanagerNotifier("http://127.0.0.1:9093/"))
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"http://127.0.0.1:9093/api/v2/alerts"}, notifier.alertsUrls)
+		assert.Equal(t, DefaultAlertName, notifier.alertName)
+		assert.Equal(t, DefaultSeverity, notifier.severity)
+		assert.Equal(t, DefaultAlertTimeout, notifier.alertTimeout)
+		assert.Equal(t, DefaultResendInterval, notifier.resendInterval)
+	})
+}
+
+func TestAlertmanagerNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	startTime := time.Date(2022, 4, 5, 10, 0, 0, 0, time.UTC)
+
+	t.Run("info report should not be sent", func(t *testing.T) {
+		t.Parallel()
+
+		am := newFakeAlertmanager()
+		defer am.Close()
+
+		notifier, _ := NewAlertmanagerNotifier(createMockArgsAlertmanagerNotifier(am.URL))
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{
+			Identifier: data.SystemIdentifier,
+			Level:      data.Info,
+		})
+		assert.Nil(t, err)
+		assert.Empty(t, am.receivedAlerts())
+	})
+	t.Run("first healthy response should resolve once", func(t *testing.T) {
+		t.Parallel()
+
+		am := newFakeAlertmanager()
+		defer am.Close()
+
+		notifier, _ := NewAlertmanagerNotifier(createMockArgsAlertmanagerNotifier(am.URL))
+		notifier.getTime = func() time.Time {
+			return startTime
+		}
+
+		response := createErrorResponse()
+		response.Level = data.NoEvent
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+
+		alerts := am.receivedAlerts()
+		require.Equal(t, 1, len(alerts))
+		assert.Empty(t, alerts[0].StartsAt)
+		assert.Equal(t, "2022-04-05T10:00:00Z", alerts[0].EndsAt)
+		assert.Equal(t, "node-0", alerts[0].Labels[identifierLabel])
+	})
+	t.Run("firing alert should be resent and then resolved", func(t *testing.T) {
+		t.Parallel()
+
+		am := newFakeAlertmanager()
+		defer am.Close()
+
+		args := createMockArgsAlertmanagerNotifier(am.URL)
+		args.AlertTimeout = 10 * time.Minute
+		args.ResendInterval = 2 * time.Minute
+		notifier, _ := NewAlertmanagerNotifier(args)
+		currentTime := startTime
+		notifier.getTime = func() time.Time {
+			return currentTime
+		}
+
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), createErrorResponse()))
+		currentTime = startTime.Add(time.Minute)
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), createErrorResponse()))
+		currentTime = startTime.Add(3 * time.Minute)
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), createErrorResponse()))
+		currentTime = startTime.Add(4 * time.Minute)
+		response := createErrorResponse()
+		response.Level = data.Info
+		response.Labels = nil
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+
+		alerts := am.receivedAlerts()
+		require.Equal(t, 3, len(alerts))
+		expectedLabels := map[string]string{
+			"alertname":  DefaultAlertName,
+			"identifier": "node-0",
+			"severity":   DefaultSeverity,
+			"team":       "validators",
+			"shard":      "0",
+			"node_url":   "http://127.0.0.1:8080",
+		}
+		assert.Equal(t, postableAlert{
+			Labels: expectedLabels,
+			Annotations: map[string]string{
+				"summary":       "node-0: node is offline",
+				"description":   "node is offline\nlast nonce: 123",
+				"metric_rating": "99.5",
+			},
+			StartsAt:     "2022-04-05T10:00:00Z",
+			EndsAt:       "2022-04-05T10:10:00Z",
+			GeneratorURL: "https://monitoring.local",
+		}, alerts[0])
+
+		assert.Equal(t, expectedLabels, alerts[1].Labels)
+		assert.Equal(t, "2022-04-05T10:00:00Z", alerts[1].StartsAt)
+		assert.Equal(t, "2022-04-05T10:13:00Z", alerts[1].EndsAt)
+
+		// the resolved alert has the same labels so Alertmanager matches it with the firing one
+		assert.Equal(t, expectedLabels, alerts[2].Labels)
+		assert.Equal(t, "2022-04-05T10:00:00Z", alerts[2].StartsAt)
+		assert.Equal(t, "2022-04-05T10:04:00Z", alerts[2].EndsAt)
+	})
+	t.Run("severity label should override the configured severity", func(t *testing.T) {
+		t.Parallel()
+
+		am := newFakeAlertmanager()
+		defer am.Close()
+
+		args := createMockArgsAlertmanagerNotifier(am.URL)
+		args.Severity = "warning"
+		args.AlertName = "ValidatorDown"
+		notifier, _ := NewAlertmanagerNotifier(args)
+
+		response := createErrorResponse()
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+		response.Identifier = "node-1"
+		response.Labels = map[string]string{"severity": "page", "alertname": "Other"}
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+
+		alerts := am.receivedAlerts()
+		require.Equal(t, 2, len(alerts))
+		assert.Equal(t, "warning", alerts[0].Labels[severityLabel])
+		assert.Equal(t, "page", alerts[1].Labels[severityLabel])
+		assert.Equal(t, "ValidatorDown", alerts[1].Labels[alertNameLabel])
+	})
+	t.Run("rejected alert should be sent again on the next response", func(t *testing.T) {
+		t.Parallel()
+
+		am := newFakeAlertmanager()
+		defer am.Close()
+		am.statusCodes = []int{http.StatusBadRequest}
+
+		notifier, _ := NewAlertmanagerNotifier(createMockArgsAlertmanagerNotifier(am.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createErrorResponse())
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.True(t, strings.Contains(err.Error(), "start time must be before end time"))
+		assert.True(t, strings.Contains(err.Error(), am.URL))
+
+		err = notifier.ProcessAlarmResponse(context.Background(), createErrorResponse())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(am.receivedAlerts()))
+	})
+	t.Run("should send to all the instances with basic auth", func(t *testing.T) {
+		t.Parallel()
+
+		am1 := newFakeAlertmanager()
+		defer am1.Close()
+		am2 := newFakeAlertmanager()
+		defer am2.Close()
+
+		args := createMockArgsAlertmanagerNotifier(am1.URL, am2.URL)
+		args.Username = "monitor"
+		args.Password = "secret"
+		notifier, _ := NewAlertmanagerNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createErrorResponse())
+		assert.Nil(t, err)
+
+		assert.Equal(t, 1, len(am1.receivedAlerts()))
+		assert.Equal(t, 1, len(am2.receivedAlerts()))
+		assert.Equal(t, []string{"Basic bW9uaXRvcjpzZWNyZXQ="}, am1.authorizations)
+		assert.Equal(t, []string{"Basic bW9uaXRvcjpzZWNyZXQ="}, am2.authorizations)
+	})
+}
@@ -0,0 +1,11 @@
+package alertmanager
+
+// postableAlert is an item of the Alertmanager API v2 POST /alerts request body. The times are RFC 3339 strings so
+// the empty ones are omitted and filled in by Alertmanager
+type postableAlert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     string            `json:"startsAt,omitempty"`
+	EndsAt       string            `json:"endsAt,omitempty"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
@@ -0,0 +1,11 @@
+package alertmanager
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errNoUrls = errors.New("no Alertmanager URLs")
+var errEmptyUrl = errors.New("empty URL")
+var errInvalidLabelName = errors.New("invalid label name")
+var errInvalidAlertTimeout = errors.New("invalid alert timeout")
+var errInvalidResendInterval = errors.New("invalid resend interval")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
@@ -0,0 +1,13 @@
+package alertmanager
+
+import (
+	"context"
+
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+// HTTPClient defines the operations of the HTTP client used to call the Alertmanager API
+type HTTPClient interface {
+	CallEndPoint(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error)
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,78 @@
+package alertmanager
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+)
+
+const (
+	alertNameLabel        = "alertname"
+	identifierLabel       = "identifier"
+	severityLabel         = "severity"
+	summaryAnnotation     = "summary"
+	descriptionAnnotation = "description"
+	metricPrefix          = "metric_"
+	maxSummaryLength      = 256
+)
+
+// the Prometheus label names format
+var labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// sanitizeLabelName replaces the characters not allowed in a Prometheus label name with _
+func sanitizeLabelName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	if len(sanitized) == 0 || (sanitized[0] >= '0' && sanitized[0] <= '9') {
+		sanitized = "_" + sanitized
+	}
+
+	return sanitized
+}
+
+// createLabels builds the alert's label set from the static labels, the default severity and the response's labels,
+// in this order of precedence. The alertname and identifier labels can not be overridden as they identify the alert
+func createLabels(staticLabels map[string]string, alertName string, severity string, response data.AlarmResponse) map[string]string {
+	labels := make(map[string]string, len(staticLabels)+len(response.Labels)+3)
+	for name, value := range staticLabels {
+		labels[name] = value
+	}
+	labels[severityLabel] = severity
+	for name, value := range response.Labels {
+		labels[sanitizeLabelName(name)] = value
+	}
+	labels[alertNameLabel] = alertName
+	labels[identifierLabel] = response.Identifier
+
+	return labels
+}
+
+func createAnnotations(response data.AlarmResponse) map[string]string {
+	annotations := make(map[string]string, len(response.Metrics)+2)
+
+	summary := response.Identifier
+	detail := firstLine(response.Data)
+	if len(detail) > 0 {
+		summary += ": " + detail
+	}
+	annotations[summaryAnnotation] = formatting.Truncate(summary, maxSummaryLength)
+	if len(response.Data) > 0 {
+		annotations[descriptionAnnotation] = response.Data
+	}
+	for name, value := range response.Metrics {
+		annotations[metricPrefix+sanitizeLabelName(name)] = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	return annotations
+}
+
+func firstLine(text string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+}
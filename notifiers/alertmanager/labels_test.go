@@ -0,0 +1,28 @@
+package alertmanager
+
+import (
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeLabelName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "shard", sanitizeLabelName("shard"))
+	assert.Equal(t, "node_url", sanitizeLabelName("node-url"))
+	assert.Equal(t, "_0x", sanitizeLabelName("0x"))
+	assert.Equal(t, "_", sanitizeLabelName(""))
+	assert.Equal(t, "_n_", sanitizeLabelName("(n)"))
+}
+
+func TestCreateAnnotations(t *testing.T) {
+	t.Parallel()
+
+	annotations := createAnnotations(data.AlarmResponse{
+		Identifier: "node-0",
+		Level:      data.Info,
+	})
+	assert.Equal(t, map[string]string{"summary": "node-0"}, annotations)
+}
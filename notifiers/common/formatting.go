@@ -0,0 +1,36 @@
+package common
+
+import (
+	"sort"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+)
+
+// SortedLabelKeys returns the keys of the provided labels in alphabetical order so the notifications have
+// a stable layout
+func SortedLabelKeys(labels map[string]string) []string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// DisplayLabels returns the labels as they should be displayed in the notifications: once the node's name is known,
+// the long public key is shortened. The provided labels are not altered
+func DisplayLabels(labels map[string]string) map[string]string {
+	if len(labels[data.NodeLabel]) == 0 || len(labels[data.PubkeyLabel]) == 0 {
+		return labels
+	}
+
+	displayLabels := make(map[string]string, len(labels))
+	for key, value := range labels {
+		displayLabels[key] = value
+	}
+	displayLabels[data.PubkeyLabel] = formatting.ShortenPubkey(labels[data.PubkeyLabel])
+
+	return displayLabels
+}
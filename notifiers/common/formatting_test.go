@@ -0,0 +1,39 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedLabelKeys(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{}, SortedLabelKeys(nil))
+	assert.Equal(t, []string{"network", "shard", "team"}, SortedLabelKeys(map[string]string{
+		"team":    "a",
+		"network": "b",
+		"shard":   "c",
+	}))
+}
+
+func TestDisplayLabels(t *testing.T) {
+	t.Parallel()
+
+	pubkey := "c26a1d7dcbbd5b7ac75f3a06d30bd42bd3b4d1ae2cde1d4a1b5a6e1c0e4d1b7f2c1f8a7f0d2e5b6c3a9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f80f0c"
+	t.Run("labels without the node name should not change", func(t *testing.T) {
+		t.Parallel()
+
+		labels := map[string]string{data.PubkeyLabel: pubkey, "shard": "1"}
+		assert.Equal(t, labels, DisplayLabels(labels))
+		assert.Nil(t, DisplayLabels(nil))
+	})
+	t.Run("public key should be shortened once the node name is known", func(t *testing.T) {
+		t.Parallel()
+
+		labels := map[string]string{data.PubkeyLabel: pubkey, data.NodeLabel: "validator-01"}
+		assert.Equal(t, map[string]string{data.PubkeyLabel: "c26a1d…f80f0c", data.NodeLabel: "validator-01"}, DisplayLabels(labels))
+		assert.Equal(t, pubkey, labels[data.PubkeyLabel])
+	})
+}
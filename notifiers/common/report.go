@@ -0,0 +1,43 @@
+package common
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+// SummaryField is a named value of the info report summary
+type SummaryField struct {
+	Name  string
+	Value string
+}
+
+var summaryMetrics = []struct {
+	metric string
+	name   string
+}{
+	{metric: data.UptimeMetric, name: "Uptime"},
+	{metric: data.ProcessingErrorsMetric, name: "Processing errors"},
+	{metric: data.AlarmsWithErrorMetric, name: "Alarms with error"},
+}
+
+// ReportSummary returns the human-readable summary of the info report metrics, in a fixed order, skipping the
+// missing ones
+func ReportSummary(metrics map[string]float64) []SummaryField {
+	summary := make([]SummaryField, 0, len(summaryMetrics))
+	for _, item := range summaryMetrics {
+		value, found := metrics[item.metric]
+		if !found {
+			continue
+		}
+
+		formatted := strconv.FormatFloat(value, 'f', -1, 64)
+		if item.metric == data.UptimeMetric {
+			formatted = (time.Duration(value) * time.Second).String()
+		}
+		summary = append(summary, SummaryField{Name: item.name, Value: formatted})
+	}
+
+	return summary
+}
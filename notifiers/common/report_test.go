@@ -0,0 +1,26 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportSummary(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, ReportSummary(nil))
+	assert.Equal(t, []SummaryField{
+		{Name: "Uptime", Value: "26h0m5s"},
+		{Name: "Processing errors", Value: "1"},
+		{Name: "Alarms with error", Value: "2"},
+	}, ReportSummary(map[string]float64{
+		data.AlarmsWithErrorMetric:  2,
+		data.UptimeMetric:           93605,
+		data.ProcessingErrorsMetric: 1,
+		"other":                     5,
+	}))
+	assert.Equal(t, []SummaryField{{Name: "Processing errors", Value: "0"}},
+		ReportSummary(map[string]float64{data.ProcessingErrorsMetric: 0}))
+}
@@ -0,0 +1,167 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+// the limits defined by the Discord API for the embeds
+const (
+	maxTitleLength       = 256
+	maxDescriptionLength = 4096
+	maxFieldNameLength   = 256
+	maxFieldValueLength  = 1024
+	maxFieldsInEmbed     = 25
+	maxEmbedsInMessage   = 10
+	maxCharsInMessage    = 6000
+	// the fields of the first embed must fit in the message together with the longest title and description
+	maxFieldsLength = maxCharsInMessage - maxTitleLength - maxDescriptionLength
+)
+
+const defaultColor = 0x9E9E9E
+
+var levelColors = map[data.EventLevel]int{
+	data.Error: 0xD32F2F,
+	data.Info:  0x1976D2,
+}
+
+var levelTitles = map[data.EventLevel]string{
+	data.Error: "🚨 ERROR",
+	data.Info:  "ℹ️ INFO",
+}
+
+// createEmbeds converts the alarm response in one or more embeds. The first embed holds the labels, the data is
+// split across as many embeds as required by the description limit
+func createEmbeds(response data.AlarmResponse, timestamp time.Time) []embed {
+	levelTitle, found := levelTitles[response.Level]
+	if !found {
+		levelTitle = string(response.Level)
+	}
+	title := fmt.Sprintf("%s | %s", levelTitle, response.Identifier)
+
+	embeds := createTextEmbeds(title, response.Data, levelColor(response.Level), timestamp)
+	embeds[0].Fields = createFields(common.DisplayLabels(response.Labels))
+
+	return embeds
+}
+
+// createRenderedEmbeds converts the texts rendered from the templates in one or more embeds, the body being split
+// across as many embeds as required by the description limit
+func createRenderedEmbeds(level data.EventLevel, message templating.Message, timestamp time.Time) []embed {
+	return createTextEmbeds(message.Title, message.Body, levelColor(level), timestamp)
+}
+
+func levelColor(level data.EventLevel) int {
+	color, found := levelColors[level]
+	if !found {
+		return defaultColor
+	}
+
+	return color
+}
+
+func createTextEmbeds(title string, text string, color int, timestamp time.Time) []embed {
+	title = formatting.Truncate(title, maxTitleLength)
+	chunks := splitText(text, maxDescriptionLength)
+	embeds := make([]embed, 0, len(chunks))
+	for idx, chunk := range chunks {
+		embedTitle := title
+		if idx > 0 {
+			suffix := fmt.Sprintf(" (%d/%d)", idx+1, len(chunks))
+			embedTitle = formatting.Truncate(title, maxTitleLength-len([]rune(suffix))) + suffix
+		}
+
+		embeds = append(embeds, embed{
+			Title:       embedTitle,
+			Description: chunk,
+			Color:       color,
+			Timestamp:   timestamp.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return embeds
+}
+
+func createFields(labels map[string]string) []embedField {
+	keys := common.SortedLabelKeys(labels)
+	if len(keys) > maxFieldsInEmbed {
+		keys = keys[:maxFieldsInEmbed]
+	}
+
+	fields := make([]embedField, 0, len(keys))
+	fieldsLength := 0
+	for _, key := range keys {
+		field := embedField{
+			Name:   formatting.Truncate(key, maxFieldNameLength),
+			Value:  formatting.Truncate(labels[key], maxFieldValueLength),
+			Inline: true,
+		}
+
+		fieldsLength += len([]rune(field.Name)) + len([]rune(field.Value))
+		if fieldsLength > maxFieldsLength {
+			log.Debug("Discord embed fields limit reached, the remaining labels are not sent", "num labels", len(labels))
+			break
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// splitText splits the text in chunks of at most maxLength characters, preferring to break at new lines. An empty
+// text will result in one empty chunk
+func splitText(text string, maxLength int) []string {
+	runes := []rune(text)
+	chunks := make([]string, 0, len(runes)/maxLength+1)
+	for len(runes) > maxLength {
+		end := maxLength
+		for idx := maxLength - 1; idx > maxLength/2; idx-- {
+			if runes[idx] == '\n' {
+				end = idx + 1
+				break
+			}
+		}
+
+		chunks = append(chunks, strings.TrimSuffix(string(runes[:end]), "\n"))
+		runes = runes[end:]
+	}
+
+	return append(chunks, string(runes))
+}
+
+func embedLength(e embed) int {
+	length := len([]rune(e.Title)) + len([]rune(e.Description))
+	for _, field := range e.Fields {
+		length += len([]rune(field.Name)) + len([]rune(field.Value))
+	}
+
+	return length
+}
+
+// groupEmbeds distributes the embeds in messages respecting the number of embeds and the total characters limits
+func groupEmbeds(embeds []embed) [][]embed {
+	groups := make([][]embed, 0, 1)
+	current := make([]embed, 0, maxEmbedsInMessage)
+	currentLength := 0
+	for _, e := range embeds {
+		length := embedLength(e)
+		exceedsLimits := len(current) == maxEmbedsInMessage || currentLength+length > maxCharsInMessage
+		if exceedsLimits && len(current) > 0 {
+			groups = append(groups, current)
+			current = make([]embed, 0, maxEmbedsInMessage)
+			currentLength = 0
+		}
+
+		current = append(current, e)
+		currentLength += length
+	}
+
+	return append(groups, current)
+}
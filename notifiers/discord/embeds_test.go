@@ -0,0 +1,129 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitText(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{""}, splitText("", 10))
+	assert.Equal(t, []string{"0123456789"}, splitText("0123456789", 10))
+	assert.Equal(t, []string{"0123456789", "abc"}, splitText("0123456789abc", 10))
+	assert.Equal(t, []string{"012345", "6789abc"}, splitText("012345\n6789abc", 10), "should break at new line")
+	assert.Equal(t, []string{"01\n3456789", "abc"}, splitText("01\n3456789abc", 10), "new line too early")
+	assert.Equal(t, []string{"ăîșțăîșțăî", "șț"}, splitText("ăîșțăîșțăîșț", 10), "should count characters")
+}
+
+func TestCreateEmbeds(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("short response should create one embed", func(t *testing.T) {
+		response := data.AlarmResponse{
+			Identifier: "testnet - rating",
+			Level:      data.Error,
+			Data:       "rating dropped",
+			Labels:     map[string]string{"team": "validators", "network": "testnet"},
+		}
+
+		embeds := createEmbeds(response, timestamp)
+		assert.Equal(t, []embed{
+			{
+				Title:       "🚨 ERROR | testnet - rating",
+				Description: "rating dropped",
+				Color:       0xD32F2F,
+				Fields: []embedField{
+					{Name: "network", Value: "testnet", Inline: true},
+					{Name: "team", Value: "validators", Inline: true},
+				},
+				Timestamp: "2022-01-02T03:04:05Z",
+			},
+		}, embeds)
+	})
+	t.Run("long data should be split in multiple embeds", func(t *testing.T) {
+		response := data.AlarmResponse{
+			Identifier: "system",
+			Level:      data.Info,
+			Data:       strings.Repeat("a", maxDescriptionLength*2+1),
+		}
+
+		embeds := createEmbeds(response, timestamp)
+		assert.Equal(t, 3, len(embeds))
+		assert.Equal(t, "ℹ️ INFO | system", embeds[0].Title)
+		assert.Equal(t, "ℹ️ INFO | system (2/3)", embeds[1].Title)
+		assert.Equal(t, "ℹ️ INFO | system (3/3)", embeds[2].Title)
+		assert.Equal(t, 0x1976D2, embeds[2].Color)
+		assert.Equal(t, "a", embeds[2].Description)
+	})
+	t.Run("labels should fit in the message limit", func(t *testing.T) {
+		response := data.AlarmResponse{
+			Level:  data.Error,
+			Data:   strings.Repeat("a", maxDescriptionLength),
+			Labels: make(map[string]string),
+		}
+		for i := 0; i < 30; i++ {
+			response.Labels[fmt.Sprintf("label%02d", i)] = strings.Repeat("v", 200)
+		}
+
+		embeds := createEmbeds(response, timestamp)
+		assert.True(t, len(embeds[0].Fields) < maxFieldsInEmbed)
+		assert.True(t, embedLength(embeds[0]) <= maxCharsInMessage)
+	})
+}
+
+func TestCreateRenderedEmbeds(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	message := templating.Message{
+		Title: "rendered title",
+		Body:  strings.Repeat("a", maxDescriptionLength+1),
+	}
+
+	embeds := createRenderedEmbeds(data.Info, message, timestamp)
+	assert.Equal(t, []embed{
+		{
+			Title:       "rendered title",
+			Description: strings.Repeat("a", maxDescriptionLength),
+			Color:       0x1976D2,
+			Timestamp:   "2022-01-02T03:04:05Z",
+		},
+		{
+			Title:       "rendered title (2/2)",
+			Description: "a",
+			Color:       0x1976D2,
+			Timestamp:   "2022-01-02T03:04:05Z",
+		},
+	}, embeds)
+}
+
+func TestGroupEmbeds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("small embeds should be grouped by the embeds limit", func(t *testing.T) {
+		embeds := make([]embed, maxEmbedsInMessage+1)
+
+		groups := groupEmbeds(embeds)
+		assert.Equal(t, 2, len(groups))
+		assert.Equal(t, maxEmbedsInMessage, len(groups[0]))
+		assert.Equal(t, 1, len(groups[1]))
+	})
+	t.Run("large embeds should be grouped by the characters limit", func(t *testing.T) {
+		large := embed{Description: strings.Repeat("a", maxDescriptionLength)}
+		small := embed{Description: "a"}
+
+		groups := groupEmbeds([]embed{large, small, large})
+		assert.Equal(t, 2, len(groups))
+		assert.Equal(t, []embed{large, small}, groups[0])
+		assert.Equal(t, []embed{large}, groups[1])
+	})
+}
@@ -0,0 +1,9 @@
+package discord
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errEmptyWebhookUrl = errors.New("empty webhook URL")
+var errInvalidValue = errors.New("invalid value")
+var errRateLimited = errors.New("rate limited")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
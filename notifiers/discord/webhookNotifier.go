@@ -0,0 +1,227 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+var log = logger.GetOrCreate("notifiers/discord")
+
+const (
+	defaultRetryAfter     = time.Second
+	retryAfterHeader      = "Retry-After"
+	rateLimitRemaining    = "X-RateLimit-Remaining"
+	rateLimitResetAfter   = "X-RateLimit-Reset-After"
+	maxErrorBodyLength    = 512
+	contentTypeHeader     = "Content-Type"
+	applicationJsonHeader = "application/json"
+)
+
+// ArgsWebhookNotifier represents the arguments DTO for the webhookNotifier constructor. The renderer is optional,
+// the built-in embeds being used without it
+type ArgsWebhookNotifier struct {
+	HTTPClient    HTTPClient
+	WebhookUrl    string
+	Username      string
+	MaxRetries    int
+	MaxRetryAfter time.Duration
+	Renderer      templating.MessageRenderer
+}
+
+type webhookNotifier struct {
+	httpClient    HTTPClient
+	webhookUrl    string
+	username      string
+	maxRetries    int
+	maxRetryAfter time.Duration
+	renderer      templating.MessageRenderer
+
+	mutRateLimit sync.Mutex
+	resumeTime   time.Time
+}
+
+// NewWebhookNotifier creates a new notifier that posts the alarm responses as embeds on a Discord webhook
+func NewWebhookNotifier(args ArgsWebhookNotifier) (*webhookNotifier, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(args.WebhookUrl) == 0 {
+		return nil, errEmptyWebhookUrl
+	}
+	if args.MaxRetries < 0 {
+		return nil, fmt.Errorf("%w for MaxRetries, provided: %d", errInvalidValue, args.MaxRetries)
+	}
+	if args.MaxRetryAfter < defaultRetryAfter {
+		return nil, fmt.Errorf("%w for MaxRetryAfter, provided: %v, minimum: %v", errInvalidValue, args.MaxRetryAfter, defaultRetryAfter)
+	}
+
+	return &webhookNotifier{
+		httpClient:    args.HTTPClient,
+		webhookUrl:    args.WebhookUrl,
+		username:      args.Username,
+		maxRetries:    args.MaxRetries,
+		maxRetryAfter: args.MaxRetryAfter,
+		renderer:      args.Renderer,
+	}, nil
+}
+
+// ProcessAlarmResponse will post the alarm response on the webhook, splitting it in multiple messages if it exceeds
+// the Discord limits. The responses without an event are ignored
+func (notifier *webhookNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	embeds, err := notifier.createEmbeds(response, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groupEmbeds(embeds) {
+		message := webhookMessage{
+			Username: notifier.username,
+			Embeds:   group,
+		}
+
+		err := notifier.send(ctx, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (notifier *webhookNotifier) createEmbeds(response data.AlarmResponse, timestamp time.Time) ([]embed, error) {
+	if check.IfNil(notifier.renderer) {
+		return createEmbeds(response, timestamp), nil
+	}
+
+	message, err := notifier.renderer.Render(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return createRenderedEmbeds(response.Level, message, timestamp), nil
+}
+
+// send will post the message, waiting and retrying when Discord responds with 429 Too Many Requests
+func (notifier *webhookNotifier) send(ctx context.Context, message webhookMessage) error {
+	buff, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	request := httpWrapper.Request{
+		Method:  http.MethodPost,
+		Url:     notifier.webhookUrl,
+		Headers: map[string]string{contentTypeHeader: applicationJsonHeader},
+		Body:    buff,
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = notifier.waitRateLimit(ctx)
+		if err != nil {
+			return err
+		}
+
+		response, errCall := notifier.httpClient.CallEndPoint(ctx, request)
+		if errCall != nil {
+			return errCall
+		}
+
+		notifier.updateRateLimit(response)
+		if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+			return nil
+		}
+		if response.StatusCode != http.StatusTooManyRequests {
+			return fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, response.StatusCode,
+				formatting.Truncate(string(response.Body), maxErrorBodyLength))
+		}
+
+		retryAfter := parseRetryAfter(response)
+		if attempt >= notifier.maxRetries || retryAfter > notifier.maxRetryAfter {
+			return fmt.Errorf("%w after %d attempt(s), retry after: %v", errRateLimited, attempt+1, retryAfter)
+		}
+
+		log.Debug("Discord webhook rate limited, retrying", "retry after", retryAfter, "attempt", attempt+1)
+		notifier.setResumeTime(time.Now().Add(retryAfter))
+	}
+}
+
+func (notifier *webhookNotifier) waitRateLimit(ctx context.Context) error {
+	notifier.mutRateLimit.Lock()
+	wait := time.Until(notifier.resumeTime)
+	notifier.mutRateLimit.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+func (notifier *webhookNotifier) setResumeTime(resumeTime time.Time) {
+	notifier.mutRateLimit.Lock()
+	if resumeTime.After(notifier.resumeTime) {
+		notifier.resumeTime = resumeTime
+	}
+	notifier.mutRateLimit.Unlock()
+}
+
+// updateRateLimit will delay the next request if the current response consumed the last request of the bucket
+func (notifier *webhookNotifier) updateRateLimit(response httpWrapper.Response) {
+	if response.Headers.Get(rateLimitRemaining) != "0" {
+		return
+	}
+
+	resetAfter, err := strconv.ParseFloat(response.Headers.Get(rateLimitResetAfter), 64)
+	if err != nil {
+		return
+	}
+
+	notifier.setResumeTime(time.Now().Add(secondsToDuration(resetAfter)))
+}
+
+// parseRetryAfter reads the retry_after value (in seconds) from the response body, falling back to the
+// Retry-After header
+func parseRetryAfter(response httpWrapper.Response) time.Duration {
+	rateLimit := rateLimitResponse{}
+	err := json.Unmarshal(response.Body, &rateLimit)
+	if err == nil && rateLimit.RetryAfter > 0 {
+		return secondsToDuration(rateLimit.RetryAfter)
+	}
+
+	seconds, err := strconv.ParseFloat(response.Headers.Get(retryAfterHeader), 64)
+	if err == nil && seconds > 0 {
+		return secondsToDuration(seconds)
+	}
+
+	return defaultRetryAfter
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *webhookNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
@@ -0,0 +1,320 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsWebhookNotifier() ArgsWebhookNotifier {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second * 5)
+
+	return ArgsWebhookNotifier{
+		HTTPClient:    httpClient,
+		WebhookUrl:    "https://discord.com/api/webhooks/1/token",
+		MaxRetries:    2,
+		MaxRetryAfter: time.Second,
+	}
+}
+
+// fakeWebhook records the received messages and answers with the queued responses, then with 204 No Content
+type fakeWebhook struct {
+	*httptest.Server
+	mut       sync.Mutex
+	messages  []webhookMessage
+	times     []time.Time
+	responses []func(w http.ResponseWriter)
+}
+
+func newFakeWebhook() *fakeWebhook {
+	webhook := &fakeWebhook{}
+	webhook.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buff, _ := ioutil.ReadAll(r.Body)
+		message := webhookMessage{}
+		_ = json.Unmarshal(buff, &message)
+
+		webhook.mut.Lock()
+		webhook.messages = append(webhook.messages, message)
+		webhook.times = append(webhook.times, time.Now())
+		var respond func(w http.ResponseWriter)
+		if len(webhook.responses) > 0 {
+			respond = webhook.responses[0]
+			webhook.responses = webhook.responses[1:]
+		}
+		webhook.mut.Unlock()
+
+		if respond != nil {
+			respond(w)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	return webhook
+}
+
+func (webhook *fakeWebhook) queueResponse(respond func(w http.ResponseWriter)) {
+	webhook.mut.Lock()
+	webhook.responses = append(webhook.responses, respond)
+	webhook.mut.Unlock()
+}
+
+func (webhook *fakeWebhook) getMessages() ([]webhookMessage, []time.Time) {
+	webhook.mut.Lock()
+	defer webhook.mut.Unlock()
+
+	return webhook.messages, webhook.times
+}
+
+func tooManyRequests(retryAfter string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": ` + retryAfter + `, "global": false}`))
+	}
+}
+
+func TestNewWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsWebhookNotifier()
+		args.HTTPClient = nil
+
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty webhook URL should error", func(t *testing.T) {
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = ""
+
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyWebhookUrl, err)
+	})
+	t.Run("negative max retries should error", func(t *testing.T) {
+		args := createMockArgsWebhookNotifier()
+		args.MaxRetries = -1
+
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidValue))
+	})
+	t.Run("invalid max retry after should error", func(t *testing.T) {
+		args := createMockArgsWebhookNotifier()
+		args.MaxRetryAfter = time.Millisecond
+
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidValue))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := NewWebhookNotifier(createMockArgsWebhookNotifier())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
+
+func TestWebhookNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	response := data.AlarmResponse{Identifier: "alarm", Level: data.Error, Data: "node down"}
+
+	t.Run("no event responses should be ignored", func(t *testing.T) {
+		webhook := newFakeWebhook()
+		defer webhook.Close()
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = webhook.URL
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.NoEvent})
+		assert.Nil(t, err)
+		messages, _ := webhook.getMessages()
+		assert.Equal(t, 0, len(messages))
+	})
+	t.Run("should post the embeds", func(t *testing.T) {
+		webhook := newFakeWebhook()
+		defer webhook.Close()
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = webhook.URL
+		args.Username = "monitoring"
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+
+		messages, _ := webhook.getMessages()
+		require.Equal(t, 1, len(messages))
+		assert.Equal(t, "monitoring", messages[0].Username)
+		assert.Equal(t, "node down", messages[0].Embeds[0].Description)
+	})
+	t.Run("should post the rendered texts", func(t *testing.T) {
+		webhook := newFakeWebhook()
+		defer webhook.Close()
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = webhook.URL
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{Title: "rendered title", Body: "rendered body"}, nil
+			},
+		}
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+
+		messages, _ := webhook.getMessages()
+		require.Equal(t, 1, len(messages))
+		assert.Equal(t, "rendered title", messages[0].Embeds[0].Title)
+		assert.Equal(t, "rendered body", messages[0].Embeds[0].Description)
+	})
+	t.Run("render error should error", func(t *testing.T) {
+		webhook := newFakeWebhook()
+		defer webhook.Close()
+
+		expectedErr := errors.New("expected error")
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = webhook.URL
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{}, expectedErr
+			},
+		}
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Equal(t, expectedErr, err)
+		messages, _ := webhook.getMessages()
+		assert.Equal(t, 0, len(messages))
+	})
+	t.Run("large response should be sent in multiple messages", func(t *testing.T) {
+		webhook := newFakeWebhook()
+		defer webhook.Close()
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = webhook.URL
+		notifier, _ := NewWebhookNotifier(args)
+
+		largeResponse := response
+		largeResponse.Data = strings.Repeat("a", maxDescriptionLength*3)
+		err := notifier.ProcessAlarmResponse(context.Background(), largeResponse)
+		assert.Nil(t, err)
+
+		messages, _ := webhook.getMessages()
+		assert.Equal(t, 3, len(messages))
+	})
+	t.Run("rate limited request should be retried after retry_after", func(t *testing.T) {
+		webhook := newFakeWebhook()
+		defer webhook.Close()
+		webhook.queueResponse(tooManyRequests("0.2"))
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = webhook.URL
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+
+		messages, times := webhook.getMessages()
+		assert.Equal(t, 2, len(messages))
+		assert.True(t, times[1].Sub(times[0]) >= time.Millisecond*200)
+	})
+	t.Run("too many rate limited attempts should error", func(t *testing.T) {
+		webhook := newFakeWebhook()
+		defer webhook.Close()
+		for i := 0; i < 3; i++ {
+			webhook.queueResponse(tooManyRequests("0.01"))
+		}
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = webhook.URL
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.True(t, errors.Is(err, errRateLimited))
+		messages, _ := webhook.getMessages()
+		assert.Equal(t, 3, len(messages))
+	})
+	t.Run("retry after longer than the maximum should error right away", func(t *testing.T) {
+		webhook := newFakeWebhook()
+		defer webhook.Close()
+		webhook.queueResponse(tooManyRequests("30"))
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = webhook.URL
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.True(t, errors.Is(err, errRateLimited))
+		messages, _ := webhook.getMessages()
+		assert.Equal(t, 1, len(messages))
+	})
+	t.Run("exhausted rate limit bucket should delay the next request", func(t *testing.T) {
+		webhook := newFakeWebhook()
+		defer webhook.Close()
+		webhook.queueResponse(func(w http.ResponseWriter) {
+			w.Header().Set(rateLimitRemaining, "0")
+			w.Header().Set(rateLimitResetAfter, "0.2")
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = webhook.URL
+		notifier, _ := NewWebhookNotifier(args)
+
+		_ = notifier.ProcessAlarmResponse(context.Background(), response)
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+
+		_, times := webhook.getMessages()
+		assert.Equal(t, 2, len(times))
+		assert.True(t, times[1].Sub(times[0]) >= time.Millisecond*200)
+	})
+	t.Run("rejected request should error", func(t *testing.T) {
+		webhook := newFakeWebhook()
+		defer webhook.Close()
+		webhook.queueResponse(func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message": "Invalid Form Body"}`))
+		})
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = webhook.URL
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.True(t, strings.Contains(err.Error(), "Invalid Form Body"))
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Millisecond*1500, parseRetryAfter(httpWrapper.Response{Body: []byte(`{"retry_after": 1.5}`)}))
+
+	headers := http.Header{}
+	headers.Set(retryAfterHeader, "3")
+	assert.Equal(t, time.Second*3, parseRetryAfter(httpWrapper.Response{Headers: headers, Body: []byte("not a json")}))
+
+	assert.Equal(t, defaultRetryAfter, parseRetryAfter(httpWrapper.Response{Headers: http.Header{}}))
+}
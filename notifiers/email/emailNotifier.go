@@ -0,0 +1,102 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+var log = logger.GetOrCreate("notifiers/email")
+
+// ArgsEmailNotifier represents the arguments DTO for the emailNotifier constructor. The renderer is optional, the
+// built-in subject and bodies being used without it
+type ArgsEmailNotifier struct {
+	Sender     MailSender
+	From       string
+	Recipients map[data.EventLevel][]string
+	Renderer   templating.MessageRenderer
+}
+
+type emailNotifier struct {
+	sender     MailSender
+	from       string
+	recipients map[data.EventLevel][]string
+	renderer   templating.MessageRenderer
+}
+
+// NewEmailNotifier creates a new notifier that sends the alarm responses as emails. Each level can have its own
+// recipients, the levels without recipients are not sent
+func NewEmailNotifier(args ArgsEmailNotifier) (*emailNotifier, error) {
+	if check.IfNil(args.Sender) {
+		return nil, errNilMailSender
+	}
+	if len(args.From) == 0 {
+		return nil, errEmptySender
+	}
+
+	recipients := make(map[data.EventLevel][]string)
+	for level, addresses := range args.Recipients {
+		if len(addresses) > 0 {
+			recipients[level] = append(make([]string, 0, len(addresses)), addresses...)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil, errNoRecipients
+	}
+
+	return &emailNotifier{
+		sender:     args.Sender,
+		from:       args.From,
+		recipients: recipients,
+		renderer:   args.Renderer,
+	}, nil
+}
+
+// ProcessAlarmResponse will send the alarm response as a multipart (plain text and HTML) email to the recipients
+// configured for the response level. The responses without an event are ignored
+func (notifier *emailNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	recipients := notifier.recipients[response.Level]
+	if len(recipients) == 0 {
+		log.Trace("no email recipients for level", "level", response.Level, "identifier", response.Identifier)
+		return nil
+	}
+
+	timestamp := time.Now()
+	rendered, err := notifier.renderMessage(response, timestamp)
+	if err != nil {
+		return err
+	}
+
+	message, err := buildMIMEMessage(notifier.from, recipients, rendered, timestamp)
+	if err != nil {
+		return err
+	}
+
+	return notifier.sender.Send(ctx, notifier.from, recipients, message)
+}
+
+func (notifier *emailNotifier) renderMessage(response data.AlarmResponse, timestamp time.Time) (renderedMessage, error) {
+	if check.IfNil(notifier.renderer) {
+		return renderMessage(response, timestamp)
+	}
+
+	message, err := notifier.renderer.Render(response)
+	if err != nil {
+		return renderedMessage{}, err
+	}
+
+	return createRenderedMessage(message), nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *emailNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
@@ -0,0 +1,243 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mailSenderStub -
+type mailSenderStub struct {
+	SendCalled func(ctx context.Context, from string, to []string, message []byte) error
+}
+
+// Send -
+func (stub *mailSenderStub) Send(ctx context.Context, from string, to []string, message []byte) error {
+	if stub.SendCalled != nil {
+		return stub.SendCalled(ctx, from, to, message)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *mailSenderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createMockArgsEmailNotifier() ArgsEmailNotifier {
+	return ArgsEmailNotifier{
+		Sender: &mailSenderStub{},
+		From:   "monitor@example.com",
+		Recipients: map[data.EventLevel][]string{
+			data.Error: {"oncall@example.com", "team@example.com"},
+			data.Info:  {"team@example.com"},
+		},
+	}
+}
+
+func TestNewEmailNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil sender should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEmailNotifier()
+		args.Sender = nil
+		notifier, err := NewEmailNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilMailSender, err)
+	})
+	t.Run("empty from should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEmailNotifier()
+		args.From = ""
+		notifier, err := NewEmailNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptySender, err)
+	})
+	t.Run("no recipients should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEmailNotifier()
+		args.Recipients = map[data.EventLevel][]string{
+			data.Error: nil,
+		}
+		notifier, err := NewEmailNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNoRecipients, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEmailNotifier()
+		args.Recipients[data.NoEvent] = make([]string, 0)
+		notifier, err := NewEmailNotifier(args)
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(notifier.recipients))
+	})
+}
+
+func TestEmailNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no event should not send", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEmailNotifier()
+		args.Sender = &mailSenderStub{
+			SendCalled: func(ctx context.Context, from string, to []string, message []byte) error {
+				assert.Fail(t, "should have not called send")
+				return nil
+			},
+		}
+		notifier, _ := NewEmailNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.NoEvent})
+		assert.Nil(t, err)
+	})
+	t.Run("level without recipients should not send", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEmailNotifier()
+		delete(args.Recipients, data.Info)
+		args.Sender = &mailSenderStub{
+			SendCalled: func(ctx context.Context, from string, to []string, message []byte) error {
+				assert.Fail(t, "should have not called send")
+				return nil
+			},
+		}
+		notifier, _ := NewEmailNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.Info})
+		assert.Nil(t, err)
+	})
+	t.Run("sender errors should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockArgsEmailNotifier()
+		args.Sender = &mailSenderStub{
+			SendCalled: func(ctx context.Context, from string, to []string, message []byte) error {
+				return expectedErr
+			},
+		}
+		notifier, _ := NewEmailNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.Error})
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("should send to the level recipients", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsEmailNotifier()
+		sentTo := make(map[data.EventLevel][]string)
+		level := data.Error
+		args.Sender = &mailSenderStub{
+			SendCalled: func(ctx context.Context, from string, to []string, message []byte) error {
+				assert.Equal(t, "monitor@example.com", from)
+				sentTo[level] = to
+				return nil
+			},
+		}
+		notifier, _ := NewEmailNotifier(args)
+
+		_ = notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: level})
+		level = data.Info
+		_ = notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: level})
+
+		assert.Equal(t, []string{"oncall@example.com", "team@example.com"}, sentTo[data.Error])
+		assert.Equal(t, []string{"team@example.com"}, sentTo[data.Info])
+	})
+	t.Run("should send the rendered texts", func(t *testing.T) {
+		t.Parallel()
+
+		var sentMessage string
+		args := createMockArgsEmailNotifier()
+		args.Sender = &mailSenderStub{
+			SendCalled: func(ctx context.Context, from string, to []string, message []byte) error {
+				sentMessage = string(message)
+				return nil
+			},
+		}
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{Title: "rendered\n title", Body: "rendered body", HTMLBody: "<p>rendered html</p>"}, nil
+			},
+		}
+		notifier, _ := NewEmailNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.Error})
+		assert.Nil(t, err)
+		assert.True(t, strings.Contains(sentMessage, "Subject: rendered title\r\n"))
+		assert.True(t, strings.Contains(sentMessage, "rendered body"))
+		assert.True(t, strings.Contains(sentMessage, "<p>rendered html</p>"))
+	})
+	t.Run("render error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockArgsEmailNotifier()
+		args.Sender = &mailSenderStub{
+			SendCalled: func(ctx context.Context, from string, to []string, message []byte) error {
+				assert.Fail(t, "should have not sent")
+				return nil
+			},
+		}
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{}, expectedErr
+			},
+		}
+		notifier, _ := NewEmailNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.Error})
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("info report through the fake SMTP server should work", func(t *testing.T) {
+		t.Parallel()
+
+		cert, pool := createTestCertificate(t)
+		server := newFakeSMTPServer(t, cert, false, true, true)
+		client, err := NewSMTPClient(ArgsSMTPClient{
+			Host:      "127.0.0.1",
+			Port:      server.port(),
+			Security:  StartTLSSecurity,
+			Username:  testUsername,
+			Password:  testPassword,
+			TLSConfig: &tls.Config{RootCAs: pool},
+			Timeout:   time.Second * 5,
+		})
+		require.Nil(t, err)
+
+		args := createMockArgsEmailNotifier()
+		args.Sender = client
+		notifier, _ := NewEmailNotifier(args)
+
+		err = notifier.ProcessAlarmResponse(context.Background(), createInfoReport())
+		require.Nil(t, err)
+
+		mails := server.receivedMails()
+		require.Equal(t, 1, len(mails))
+		assert.True(t, mails[0].tls)
+		assert.Equal(t, []string{"oncall@example.com", "team@example.com"}, mails[0].to)
+
+		headers, text, html := readMailBodies(t, mails[0])
+		assert.Equal(t, "oncall@example.com, team@example.com", headers.Get("To"))
+		assert.True(t, strings.Contains(headers.Get("Subject"), "Node monitoring info report"))
+		assert.True(t, strings.Contains(text, "  [ERROR] balance: error fetching status info: <timeout>"))
+		assert.True(t, strings.Contains(html, "error fetching status info: &lt;timeout&gt;"))
+	})
+}
@@ -0,0 +1,12 @@
+package email
+
+import "errors"
+
+var errEmptyHost = errors.New("empty SMTP host")
+var errInvalidValue = errors.New("invalid value")
+var errUnknownSecurity = errors.New("unknown SMTP security mode")
+var errStartTLSNotSupported = errors.New("SMTP server does not support STARTTLS")
+var errAuthNotSupported = errors.New("SMTP server does not support authentication")
+var errNilMailSender = errors.New("nil mail sender")
+var errEmptySender = errors.New("empty sender address")
+var errNoRecipients = errors.New("no recipients")
@@ -0,0 +1,236 @@
+package email
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUsername = "monitor"
+	testPassword = "secret"
+)
+
+// receivedMail holds a mail accepted by the fake SMTP server together with the session details. The data has the
+// line endings normalized to \n by the dot reader
+type receivedMail struct {
+	from          string
+	to            []string
+	data          string
+	tls           bool
+	authenticated bool
+}
+
+// fakeSMTPServer is an in-process SMTP server that understands just enough of the protocol for the net/smtp client:
+// EHLO/HELO, STARTTLS, AUTH PLAIN, MAIL, RCPT, DATA, RSET, NOOP and QUIT
+type fakeSMTPServer struct {
+	listener    net.Listener
+	tlsConfig   *tls.Config
+	implicitTLS bool
+	startTLS    bool
+	requireAuth bool
+
+	mut   sync.Mutex
+	mails []receivedMail
+}
+
+func createTestCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func newFakeSMTPServer(t *testing.T, cert tls.Certificate, implicitTLS bool, startTLS bool, requireAuth bool) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+
+	server := &fakeSMTPServer{
+		tlsConfig:   &tls.Config{Certificates: []tls.Certificate{cert}},
+		implicitTLS: implicitTLS,
+		startTLS:    startTLS,
+		requireAuth: requireAuth,
+	}
+	server.listener = listener
+	if implicitTLS {
+		server.listener = tls.NewListener(listener, server.tlsConfig)
+	}
+
+	go server.serve()
+	t.Cleanup(func() {
+		_ = server.listener.Close()
+	})
+
+	return server
+}
+
+func (server *fakeSMTPServer) port() int {
+	return server.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (server *fakeSMTPServer) receivedMails() []receivedMail {
+	server.mut.Lock()
+	defer server.mut.Unlock()
+
+	return append(make([]receivedMail, 0, len(server.mails)), server.mails...)
+}
+
+func (server *fakeSMTPServer) serve() {
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go server.handleConnection(conn)
+	}
+}
+
+func (server *fakeSMTPServer) handleConnection(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	text := textproto.NewConn(conn)
+	mail := receivedMail{tls: server.implicitTLS}
+	_ = text.PrintfLine("220 fake ESMTP ready")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		argument := strings.TrimSpace(strings.TrimPrefix(line, strings.SplitN(line, " ", 2)[0]))
+		switch command {
+		case "EHLO":
+			extensions := []string{"fake", "8BITMIME", "AUTH PLAIN"}
+			if server.startTLS && !mail.tls {
+				extensions = append(extensions, "STARTTLS")
+			}
+			for i, extension := range extensions {
+				separator := "-"
+				if i == len(extensions)-1 {
+					separator = " "
+				}
+				_ = text.PrintfLine("250%s%s", separator, extension)
+			}
+		case "HELO", "NOOP":
+			_ = text.PrintfLine("250 OK")
+		case "STARTTLS":
+			if !server.startTLS || mail.tls {
+				_ = text.PrintfLine("502 not supported")
+				continue
+			}
+			_ = text.PrintfLine("220 ready to start TLS")
+			tlsConn := tls.Server(conn, server.tlsConfig)
+			err = tlsConn.Handshake()
+			if err != nil {
+				return
+			}
+			conn = tlsConn
+			text = textproto.NewConn(tlsConn)
+			mail.tls = true
+		case "AUTH":
+			fields := strings.Fields(argument)
+			if len(fields) != 2 || strings.ToUpper(fields[0]) != "PLAIN" {
+				_ = text.PrintfLine("504 unrecognized authentication type")
+				continue
+			}
+			decoded, _ := base64.StdEncoding.DecodeString(fields[1])
+			if string(decoded) != "\x00"+testUsername+"\x00"+testPassword {
+				_ = text.PrintfLine("535 authentication failed")
+				continue
+			}
+			mail.authenticated = true
+			_ = text.PrintfLine("235 authentication successful")
+		case "MAIL":
+			if server.requireAuth && !mail.authenticated {
+				_ = text.PrintfLine("530 authentication required")
+				continue
+			}
+			mail.from = extractAddress(argument)
+			_ = text.PrintfLine("250 OK")
+		case "RCPT":
+			mail.to = append(mail.to, extractAddress(argument))
+			_ = text.PrintfLine("250 OK")
+		case "DATA":
+			_ = text.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+			buff, errRead := ioutil.ReadAll(text.DotReader())
+			if errRead != nil {
+				return
+			}
+			mail.data = string(buff)
+			server.mut.Lock()
+			server.mails = append(server.mails, mail)
+			server.mut.Unlock()
+			mail = receivedMail{tls: mail.tls, authenticated: mail.authenticated}
+			_ = text.PrintfLine("250 OK queued")
+		case "RSET":
+			mail = receivedMail{tls: mail.tls, authenticated: mail.authenticated}
+			_ = text.PrintfLine("250 OK")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("500 unknown command")
+		}
+	}
+}
+
+func extractAddress(argument string) string {
+	start := strings.Index(argument, "<")
+	end := strings.Index(argument, ">")
+	if start < 0 || end < start {
+		return argument
+	}
+
+	return argument[start+1 : end]
+}
+
+// readMailBodies parses the received multipart message returning its headers and the decoded plain text and
+// HTML bodies
+func readMailBodies(t *testing.T, mail receivedMail) (textproto.MIMEHeader, string, string) {
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(mail.data)))
+	headers, err := reader.ReadMIMEHeader()
+	require.Nil(t, err)
+
+	text, html := readMultipartBodies(t, headers.Get("Content-Type"), reader.R)
+
+	return headers, text, html
+}
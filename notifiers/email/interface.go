@@ -0,0 +1,9 @@
+package email
+
+import "context"
+
+// MailSender defines the operations of the component able to deliver an already built message
+type MailSender interface {
+	Send(ctx context.Context, from string, to []string, message []byte) error
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,299 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+const (
+	maxSubjectDetailLength = 80
+	reportTitle            = "Node monitoring info report"
+	defaultColor           = "#9e9e9e"
+	timestampLayout        = "2006-01-02 15:04:05 MST"
+	dateLayout             = "2006-01-02"
+	pausedLevelName        = "PAUSED"
+)
+
+var levelColors = map[data.EventLevel]string{
+	data.Error: "#d32f2f",
+	data.Info:  "#1976d2",
+}
+
+// the lighter colors used as background for the rows of the info report
+var levelBackgrounds = map[data.EventLevel]string{
+	data.Error:   "#fdecea",
+	data.NoEvent: "#f5f5f5",
+}
+
+type keyValue struct {
+	Key   string
+	Value string
+}
+
+type reportEntryView struct {
+	Identifier string
+	Status     string
+	LevelName  string
+	Color      string
+	Background string
+}
+
+type messageView struct {
+	Subject   string
+	Title     string
+	LevelName string
+	Color     string
+	Timestamp string
+	Summary   []keyValue
+	Report    []reportEntryView
+	Fields    []keyValue
+	Data      string
+}
+
+type renderedMessage struct {
+	subject string
+	text    string
+	html    string
+}
+
+func levelColor(level data.EventLevel) string {
+	color, found := levelColors[level]
+	if !found {
+		return defaultColor
+	}
+
+	return color
+}
+
+func levelName(level data.EventLevel) string {
+	return strings.ToUpper(string(level))
+}
+
+func reportEntryLevelName(entry data.ReportEntry) string {
+	if entry.Paused {
+		return pausedLevelName
+	}
+
+	return levelName(entry.Level)
+}
+
+// isInfoReport returns true if the response is the periodic info report generated by the monitoring tool
+func isInfoReport(response data.AlarmResponse) bool {
+	return response.Identifier == data.SystemIdentifier
+}
+
+func createView(response data.AlarmResponse, timestamp time.Time) messageView {
+	view := messageView{
+		Title:     response.Identifier,
+		LevelName: levelName(response.Level),
+		Color:     levelColor(response.Level),
+		Timestamp: timestamp.Format(timestampLayout),
+		Data:      response.Data,
+	}
+
+	labels := common.DisplayLabels(response.Labels)
+	for _, key := range common.SortedLabelKeys(labels) {
+		view.Fields = append(view.Fields, keyValue{Key: key, Value: labels[key]})
+	}
+
+	if !isInfoReport(response) {
+		view.Subject = fmt.Sprintf("[%s] %s", view.LevelName, response.Identifier)
+		detail := firstLine(response.Data)
+		if len(detail) > 0 {
+			view.Subject += ": " + formatting.Truncate(detail, maxSubjectDetailLength)
+		}
+		view.Fields = append(view.Fields, metricsFields(response.Metrics)...)
+
+		return view
+	}
+
+	view.Title = reportTitle
+	view.Subject = fmt.Sprintf("[%s] %s - %s", view.LevelName, reportTitle, timestamp.Format(dateLayout))
+	view.Summary = reportSummary(response.Metrics)
+	for _, entry := range response.Report {
+		view.Report = append(view.Report, reportEntryView{
+			Identifier: entry.Identifier,
+			Status:     entry.Status,
+			LevelName:  reportEntryLevelName(entry),
+			Color:      levelColor(entry.Level),
+			Background: levelBackgrounds[entry.Level],
+		})
+	}
+	if len(view.Report) > 0 {
+		// the data only duplicates the structured report
+		view.Data = ""
+	}
+
+	return view
+}
+
+func firstLine(text string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+}
+
+func metricsFields(metrics map[string]float64) []keyValue {
+	keys := make([]string, 0, len(metrics))
+	for key := range metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]keyValue, 0, len(metrics))
+	for _, key := range keys {
+		fields = append(fields, keyValue{Key: key, Value: strconv.FormatFloat(metrics[key], 'f', -1, 64)})
+	}
+
+	return fields
+}
+
+func reportSummary(metrics map[string]float64) []keyValue {
+	fields := common.ReportSummary(metrics)
+	summary := make([]keyValue, 0, len(fields))
+	for _, field := range fields {
+		summary = append(summary, keyValue{Key: field.Name, Value: field.Value})
+	}
+
+	return summary
+}
+
+func renderMessage(response data.AlarmResponse, timestamp time.Time) (renderedMessage, error) {
+	view := createView(response, timestamp)
+
+	htmlBuff := bytes.Buffer{}
+	err := htmlTemplates.Execute(&htmlBuff, view)
+	if err != nil {
+		return renderedMessage{}, err
+	}
+
+	return renderedMessage{
+		subject: view.Subject,
+		text:    renderText(view),
+		html:    htmlBuff.String(),
+	}, nil
+}
+
+// createRenderedMessage uses the texts rendered from the templates, the subject being kept on a single line
+func createRenderedMessage(message templating.Message) renderedMessage {
+	return renderedMessage{
+		subject: strings.Join(strings.Fields(message.Title), " "),
+		text:    message.Body + "\n",
+		html:    message.HTMLBody,
+	}
+}
+
+// renderText creates the plain text body, the sections being separated by empty lines
+func renderText(view messageView) string {
+	sections := []string{view.LevelName + ": " + view.Title + "\n" + view.Timestamp}
+	if len(view.Summary) > 0 {
+		sections = append(sections, joinKeyValues(view.Summary))
+	}
+	if len(view.Report) > 0 {
+		lines := []string{"Alarms:"}
+		for _, entry := range view.Report {
+			lines = append(lines, fmt.Sprintf("  [%s] %s: %s", entry.LevelName, entry.Identifier, entry.Status))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+	if len(view.Fields) > 0 {
+		sections = append(sections, joinKeyValues(view.Fields))
+	}
+	if len(view.Data) > 0 {
+		sections = append(sections, view.Data)
+	}
+
+	return strings.Join(sections, "\n\n") + "\n"
+}
+
+func joinKeyValues(values []keyValue) string {
+	lines := make([]string, 0, len(values))
+	for _, value := range values {
+		lines = append(lines, value.Key+": "+value.Value)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// buildMIMEMessage creates a multipart/alternative message holding the plain text and the HTML bodies, in this
+// order, so the clients will display the richest version they support
+func buildMIMEMessage(from string, to []string, message renderedMessage, timestamp time.Time) ([]byte, error) {
+	body := bytes.Buffer{}
+	writer := multipart.NewWriter(&body)
+
+	err := writeQuotedPrintablePart(writer, "text/plain; charset=utf-8", message.text)
+	if err != nil {
+		return nil, err
+	}
+	err = writeQuotedPrintablePart(writer, "text/html; charset=utf-8", message.html)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := []keyValue{
+		{Key: "From", Value: from},
+		{Key: "To", Value: strings.Join(to, ", ")},
+		{Key: "Subject", Value: mime.QEncoding.Encode("utf-8", message.subject)},
+		{Key: "Date", Value: timestamp.Format(time.RFC1123Z)},
+		{Key: "Message-ID", Value: createMessageID(from, timestamp)},
+		{Key: "MIME-Version", Value: "1.0"},
+		{Key: "Content-Type", Value: fmt.Sprintf("multipart/alternative; boundary=%q", writer.Boundary())},
+	}
+
+	buff := bytes.Buffer{}
+	for _, header := range headers {
+		buff.WriteString(header.Key + ": " + header.Value + "\r\n")
+	}
+	buff.WriteString("\r\n")
+	buff.Write(body.Bytes())
+
+	return buff.Bytes(), nil
+}
+
+func writeQuotedPrintablePart(writer *multipart.Writer, contentType string, content string) error {
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder := quotedprintable.NewWriter(part)
+	_, err = encoder.Write([]byte(content))
+	if err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+func createMessageID(from string, timestamp time.Time) string {
+	domain := "localhost"
+	idx := strings.LastIndex(from, "@")
+	if idx >= 0 && idx < len(from)-1 {
+		domain = strings.TrimSuffix(from[idx+1:], ">")
+	}
+
+	randomBytes := make([]byte, 8)
+	_, _ = rand.Read(randomBytes)
+
+	return fmt.Sprintf("<%d.%s@%s>", timestamp.UnixNano(), hex.EncodeToString(randomBytes), domain)
+}
@@ -0,0 +1,184 @@
+package email
+
+import (
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTimestamp = time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+func readMultipartBodies(t *testing.T, contentType string, body io.Reader) (string, string) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.Nil(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	text := ""
+	html := ""
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		// the quoted-printable parts are transparently decoded by the multipart reader
+		part, errPart := reader.NextPart()
+		if errPart == io.EOF {
+			break
+		}
+		require.Nil(t, errPart)
+
+		buff, errRead := ioutil.ReadAll(part)
+		require.Nil(t, errRead)
+		switch {
+		case strings.HasPrefix(part.Header.Get("Content-Type"), "text/plain"):
+			text = string(buff)
+		case strings.HasPrefix(part.Header.Get("Content-Type"), "text/html"):
+			html = string(buff)
+		}
+	}
+
+	return text, html
+}
+
+func createInfoReport() data.AlarmResponse {
+	return data.AlarmResponse{
+		Identifier: data.SystemIdentifier,
+		Level:      data.Error,
+		Data:       "System is running. Uptime: 1h0m0s.\nStatus for alarm rating: ok",
+		Metrics: map[string]float64{
+			data.UptimeMetric:           3600,
+			data.ProcessingErrorsMetric: 1,
+			data.AlarmsWithErrorMetric:  2,
+		},
+		Report: []data.ReportEntry{
+			{Identifier: "rating", Level: data.Info, Status: "rating is 100"},
+			{Identifier: "jailed", Level: data.NoEvent, Status: "paused", Paused: true},
+			{Identifier: "balance", Level: data.Error, Status: "error fetching status info: <timeout>"},
+		},
+	}
+}
+
+func TestRenderMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("alarm response", func(t *testing.T) {
+		t.Parallel()
+
+		response := data.AlarmResponse{
+			Identifier: "node rating",
+			Level:      data.Error,
+			Data:       "rating dropped below <90>\nsecond line",
+			Metrics:    map[string]float64{"rating": 89.5},
+			Labels:     map[string]string{"shard": "1", "node": "validator-0"},
+		}
+		message, err := renderMessage(response, testTimestamp)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "[ERROR] node rating: rating dropped below <90>", message.subject)
+		expectedText := "ERROR: node rating\n" +
+			"2026-10-18 09:30:00 UTC\n\n" +
+			"node: validator-0\n" +
+			"shard: 1\n" +
+			"rating: 89.5\n\n" +
+			"rating dropped below <90>\nsecond line\n"
+		assert.Equal(t, expectedText, message.text)
+
+		assert.True(t, strings.Contains(message.html, "background-color:#d32f2f"))
+		assert.True(t, strings.Contains(message.html, "rating dropped below &lt;90&gt;\nsecond line</pre>"))
+		assert.True(t, strings.Contains(message.html, ">shard</td><td style=\"border:1px solid #e0e0e0;\">1</td>"))
+		assert.False(t, strings.Contains(message.html, "Alarm</th>"))
+	})
+	t.Run("long first line should be truncated in subject", func(t *testing.T) {
+		t.Parallel()
+
+		response := data.AlarmResponse{
+			Identifier: "id",
+			Level:      data.Info,
+			Data:       strings.Repeat("a", 200),
+		}
+		message, err := renderMessage(response, testTimestamp)
+		assert.Nil(t, err)
+		assert.Equal(t, "[INFO] id: "+strings.Repeat("a", maxSubjectDetailLength-1)+"…", message.subject)
+	})
+	t.Run("info report", func(t *testing.T) {
+		t.Parallel()
+
+		message, err := renderMessage(createInfoReport(), testTimestamp)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "[ERROR] Node monitoring info report - 2026-10-18", message.subject)
+		expectedText := "ERROR: Node monitoring info report\n" +
+			"2026-10-18 09:30:00 UTC\n\n" +
+			"Uptime: 1h0m0s\n" +
+			"Processing errors: 1\n" +
+			"Alarms with error: 2\n\n" +
+			"Alarms:\n" +
+			"  [INFO] rating: rating is 100\n" +
+			"  [PAUSED] jailed: paused\n" +
+			"  [ERROR] balance: error fetching status info: <timeout>\n"
+		assert.Equal(t, expectedText, message.text)
+
+		assert.True(t, strings.Contains(message.html, "<div style=\"font-size:22px;font-weight:bold;\">1h0m0s</div>"))
+		assert.True(t, strings.Contains(message.html, "<tr style=\"background-color:#fdecea;\">"))
+		assert.True(t, strings.Contains(message.html, "border-left:4px solid #9e9e9e;font-weight:bold;white-space:nowrap;\">jailed</td>"))
+		assert.True(t, strings.Contains(message.html, "error fetching status info: &lt;timeout&gt;"))
+		assert.False(t, strings.Contains(message.html, "<pre"))
+	})
+	t.Run("info report without entries should keep the data", func(t *testing.T) {
+		t.Parallel()
+
+		response := createInfoReport()
+		response.Report = nil
+		message, err := renderMessage(response, testTimestamp)
+		assert.Nil(t, err)
+
+		assert.True(t, strings.Contains(message.text, "Status for alarm rating: ok"))
+		assert.True(t, strings.Contains(message.html, "Status for alarm rating: ok</pre>"))
+	})
+}
+
+func TestBuildMIMEMessage(t *testing.T) {
+	t.Parallel()
+
+	rendered := renderedMessage{
+		subject: "[ERROR] nodé rating",
+		text:    "plain text body with a very long line " + strings.Repeat("x", 100),
+		html:    "<p>html body é</p>",
+	}
+	buff, err := buildMIMEMessage("Monitor <monitor@example.com>", []string{"a@example.com", "b@example.com"}, rendered, testTimestamp)
+	require.Nil(t, err)
+
+	for _, line := range strings.Split(string(buff), "\r\n") {
+		assert.True(t, len(line) <= 998)
+	}
+
+	headers, text, html := readMailBodies(t, receivedMail{data: string(buff)})
+	assert.Equal(t, "Monitor <monitor@example.com>", headers.Get("From"))
+	assert.Equal(t, "a@example.com, b@example.com", headers.Get("To"))
+	assert.Equal(t, "1.0", headers.Get("MIME-Version"))
+	assert.Equal(t, testTimestamp.Format(time.RFC1123Z), headers.Get("Date"))
+	assert.True(t, strings.HasSuffix(headers.Get("Message-ID"), "@example.com>"))
+
+	decoder := mime.WordDecoder{}
+	subject, err := decoder.DecodeHeader(headers.Get("Subject"))
+	assert.Nil(t, err)
+	assert.Equal(t, rendered.subject, subject)
+	assert.Equal(t, rendered.text, text)
+	assert.Equal(t, rendered.html, html)
+}
+
+func TestCreateMessageID(t *testing.T) {
+	t.Parallel()
+
+	first := createMessageID("monitor@example.com", testTimestamp)
+	second := createMessageID("monitor@example.com", testTimestamp)
+	assert.NotEqual(t, first, second)
+	assert.True(t, strings.HasPrefix(first, "<"))
+	assert.True(t, strings.HasSuffix(first, "@example.com>"))
+	assert.True(t, strings.HasSuffix(createMessageID("monitor", testTimestamp), "@localhost>"))
+}
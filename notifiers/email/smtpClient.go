@@ -0,0 +1,188 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Security defines how the connection to the SMTP server is secured
+type Security string
+
+const (
+	// NoSecurity uses a plain text connection
+	NoSecurity Security = "none"
+	// StartTLSSecurity upgrades the plain text connection with the STARTTLS command
+	StartTLSSecurity Security = "starttls"
+	// ImplicitTLSSecurity establishes a TLS connection from the start (usually on port 465)
+	ImplicitTLSSecurity Security = "tls"
+)
+
+// ArgsSMTPClient represents the arguments DTO for the smtpClient constructor
+type ArgsSMTPClient struct {
+	Host      string
+	Port      int
+	Security  Security
+	Username  string
+	Password  string
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+}
+
+type smtpClient struct {
+	host      string
+	address   string
+	security  Security
+	auth      smtp.Auth
+	tlsConfig *tls.Config
+	timeout   time.Duration
+}
+
+// NewSMTPClient creates a new SMTP client. An empty security mode defaults to STARTTLS
+func NewSMTPClient(args ArgsSMTPClient) (*smtpClient, error) {
+	if len(args.Host) == 0 {
+		return nil, errEmptyHost
+	}
+	if args.Port <= 0 || args.Port > 65535 {
+		return nil, fmt.Errorf("%w for Port, provided: %d", errInvalidValue, args.Port)
+	}
+	if args.Timeout <= 0 {
+		return nil, fmt.Errorf("%w for Timeout, provided: %v", errInvalidValue, args.Timeout)
+	}
+
+	security := Security(strings.ToLower(string(args.Security)))
+	if len(security) == 0 {
+		security = StartTLSSecurity
+	}
+	switch security {
+	case NoSecurity, StartTLSSecurity, ImplicitTLSSecurity:
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownSecurity, args.Security)
+	}
+
+	tlsConfig := &tls.Config{ServerName: args.Host}
+	if args.TLSConfig != nil {
+		tlsConfig = args.TLSConfig.Clone()
+		if len(tlsConfig.ServerName) == 0 {
+			tlsConfig.ServerName = args.Host
+		}
+	}
+
+	client := &smtpClient{
+		host:      args.Host,
+		address:   net.JoinHostPort(args.Host, strconv.Itoa(args.Port)),
+		security:  security,
+		tlsConfig: tlsConfig,
+		timeout:   args.Timeout,
+	}
+	if len(args.Username) > 0 {
+		client.auth = smtp.PlainAuth("", args.Username, args.Password, args.Host)
+	}
+
+	return client, nil
+}
+
+// Send will deliver the message to the provided recipients in a new SMTP session
+func (client *smtpClient) Send(ctx context.Context, from string, to []string, message []byte) error {
+	conn, err := client.dial(ctx)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(client.timeout)
+	ctxDeadline, ok := ctx.Deadline()
+	if ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	session, err := smtp.NewClient(conn, client.host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer func() {
+		_ = session.Close()
+	}()
+
+	if client.security == StartTLSSecurity {
+		supported, _ := session.Extension("STARTTLS")
+		if !supported {
+			return errStartTLSNotSupported
+		}
+		err = session.StartTLS(client.tlsConfig)
+		if err != nil {
+			return fmt.Errorf("%w while starting TLS", err)
+		}
+	}
+
+	if client.auth != nil {
+		supported, _ := session.Extension("AUTH")
+		if !supported {
+			return errAuthNotSupported
+		}
+		err = session.Auth(client.auth)
+		if err != nil {
+			return fmt.Errorf("%w while authenticating", err)
+		}
+	}
+
+	return client.sendMessage(session, from, to, message)
+}
+
+func (client *smtpClient) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: client.timeout}
+	if client.security != ImplicitTLSSecurity {
+		return dialer.DialContext(ctx, "tcp", client.address)
+	}
+
+	tlsDialer := &tls.Dialer{
+		NetDialer: dialer,
+		Config:    client.tlsConfig,
+	}
+
+	return tlsDialer.DialContext(ctx, "tcp", client.address)
+}
+
+func (client *smtpClient) sendMessage(session *smtp.Client, from string, to []string, message []byte) error {
+	err := session.Mail(from)
+	if err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		err = session.Rcpt(recipient)
+		if err != nil {
+			return fmt.Errorf("%w for recipient %s", err, recipient)
+		}
+	}
+
+	writer, err := session.Data()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(message)
+	if err != nil {
+		_ = writer.Close()
+		return err
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	return session.Quit()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (client *smtpClient) IsInterfaceNil() bool {
+	return client == nil
+}
@@ -0,0 +1,234 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMessage = "Subject: test\r\n\r\ntest body\r\n"
+
+func createMockArgsSMTPClient() ArgsSMTPClient {
+	return ArgsSMTPClient{
+		Host:     "127.0.0.1",
+		Port:     25,
+		Security: NoSecurity,
+		Timeout:  time.Second * 5,
+	}
+}
+
+func TestNewSMTPClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty host should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSMTPClient()
+		args.Host = ""
+		client, err := NewSMTPClient(args)
+		assert.True(t, check.IfNil(client))
+		assert.Equal(t, errEmptyHost, err)
+	})
+	t.Run("invalid port should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSMTPClient()
+		args.Port = 0
+		client, err := NewSMTPClient(args)
+		assert.True(t, check.IfNil(client))
+		assert.True(t, errors.Is(err, errInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "Port"))
+
+		args.Port = 65536
+		client, err = NewSMTPClient(args)
+		assert.True(t, check.IfNil(client))
+		assert.True(t, errors.Is(err, errInvalidValue))
+	})
+	t.Run("invalid timeout should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSMTPClient()
+		args.Timeout = 0
+		client, err := NewSMTPClient(args)
+		assert.True(t, check.IfNil(client))
+		assert.True(t, errors.Is(err, errInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "Timeout"))
+	})
+	t.Run("unknown security should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSMTPClient()
+		args.Security = "ssl"
+		client, err := NewSMTPClient(args)
+		assert.True(t, check.IfNil(client))
+		assert.True(t, errors.Is(err, errUnknownSecurity))
+	})
+	t.Run("empty security should default to STARTTLS", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSMTPClient()
+		args.Security = ""
+		client, err := NewSMTPClient(args)
+		assert.False(t, check.IfNil(client))
+		assert.Nil(t, err)
+		assert.Equal(t, StartTLSSecurity, client.security)
+		assert.Equal(t, "127.0.0.1", client.tlsConfig.ServerName)
+		assert.Nil(t, client.auth)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSMTPClient()
+		args.Security = "TLS"
+		args.Username = testUsername
+		args.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+		client, err := NewSMTPClient(args)
+		assert.False(t, check.IfNil(client))
+		assert.Nil(t, err)
+		assert.Equal(t, ImplicitTLSSecurity, client.security)
+		assert.Equal(t, "127.0.0.1:25", client.address)
+		assert.NotNil(t, client.auth)
+		assert.Equal(t, "127.0.0.1", client.tlsConfig.ServerName)
+		assert.Empty(t, args.TLSConfig.ServerName)
+	})
+}
+
+func TestSmtpClient_Send(t *testing.T) {
+	t.Parallel()
+
+	cert, pool := createTestCertificate(t)
+	recipients := []string{"a@example.com", "b@example.com"}
+
+	t.Run("plain connection with authentication should work", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeSMTPServer(t, cert, false, false, true)
+		args := createMockArgsSMTPClient()
+		args.Port = server.port()
+		args.Username = testUsername
+		args.Password = testPassword
+		client, _ := NewSMTPClient(args)
+
+		err := client.Send(context.Background(), "monitor@example.com", recipients, []byte(testMessage))
+		require.Nil(t, err)
+
+		mails := server.receivedMails()
+		require.Equal(t, 1, len(mails))
+		assert.Equal(t, "monitor@example.com", mails[0].from)
+		assert.Equal(t, recipients, mails[0].to)
+		assert.Equal(t, strings.ReplaceAll(testMessage, "\r\n", "\n"), mails[0].data)
+		assert.True(t, mails[0].authenticated)
+		assert.False(t, mails[0].tls)
+	})
+	t.Run("STARTTLS with authentication should work", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeSMTPServer(t, cert, false, true, true)
+		args := createMockArgsSMTPClient()
+		args.Port = server.port()
+		args.Security = StartTLSSecurity
+		args.Username = testUsername
+		args.Password = testPassword
+		args.TLSConfig = &tls.Config{RootCAs: pool}
+		client, _ := NewSMTPClient(args)
+
+		err := client.Send(context.Background(), "monitor@example.com", recipients, []byte(testMessage))
+		require.Nil(t, err)
+
+		mails := server.receivedMails()
+		require.Equal(t, 1, len(mails))
+		assert.True(t, mails[0].tls)
+		assert.True(t, mails[0].authenticated)
+		assert.Equal(t, strings.ReplaceAll(testMessage, "\r\n", "\n"), mails[0].data)
+	})
+	t.Run("implicit TLS should work", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeSMTPServer(t, cert, true, false, false)
+		args := createMockArgsSMTPClient()
+		args.Port = server.port()
+		args.Security = ImplicitTLSSecurity
+		args.TLSConfig = &tls.Config{RootCAs: pool}
+		client, _ := NewSMTPClient(args)
+
+		err := client.Send(context.Background(), "monitor@example.com", recipients, []byte(testMessage))
+		require.Nil(t, err)
+
+		mails := server.receivedMails()
+		require.Equal(t, 1, len(mails))
+		assert.True(t, mails[0].tls)
+		assert.False(t, mails[0].authenticated)
+	})
+	t.Run("untrusted certificate should error", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeSMTPServer(t, cert, true, false, false)
+		args := createMockArgsSMTPClient()
+		args.Port = server.port()
+		args.Security = ImplicitTLSSecurity
+		client, _ := NewSMTPClient(args)
+
+		err := client.Send(context.Background(), "monitor@example.com", recipients, []byte(testMessage))
+		assert.NotNil(t, err)
+		assert.Empty(t, server.receivedMails())
+	})
+	t.Run("STARTTLS not supported should error", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeSMTPServer(t, cert, false, false, false)
+		args := createMockArgsSMTPClient()
+		args.Port = server.port()
+		args.Security = StartTLSSecurity
+		client, _ := NewSMTPClient(args)
+
+		err := client.Send(context.Background(), "monitor@example.com", recipients, []byte(testMessage))
+		assert.Equal(t, errStartTLSNotSupported, err)
+		assert.Empty(t, server.receivedMails())
+	})
+	t.Run("wrong credentials should error", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeSMTPServer(t, cert, false, false, true)
+		args := createMockArgsSMTPClient()
+		args.Port = server.port()
+		args.Username = testUsername
+		args.Password = "wrong"
+		client, _ := NewSMTPClient(args)
+
+		err := client.Send(context.Background(), "monitor@example.com", recipients, []byte(testMessage))
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "authentication failed"))
+		assert.Empty(t, server.receivedMails())
+	})
+	t.Run("server requiring authentication should error", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeSMTPServer(t, cert, false, false, true)
+		args := createMockArgsSMTPClient()
+		args.Port = server.port()
+		client, _ := NewSMTPClient(args)
+
+		err := client.Send(context.Background(), "monitor@example.com", recipients, []byte(testMessage))
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "authentication required"))
+	})
+	t.Run("unreachable server should error", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeSMTPServer(t, cert, false, false, false)
+		args := createMockArgsSMTPClient()
+		args.Port = server.port()
+		_ = server.listener.Close()
+		client, _ := NewSMTPClient(args)
+
+		err := client.Send(context.Background(), "monitor@example.com", recipients, []byte(testMessage))
+		assert.NotNil(t, err)
+	})
+}
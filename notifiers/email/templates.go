@@ -0,0 +1,63 @@
+package email
+
+import "html/template"
+
+// the HTML body only uses inline styles and tables as most of the email clients ignore the style sheets
+const htmlBody = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Subject}}</title></head>
+<body style="margin:0;padding:16px;background-color:#f4f4f4;font-family:Arial,Helvetica,sans-serif;color:#212121;">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" style="max-width:720px;margin:0 auto;background-color:#ffffff;border:1px solid #e0e0e0;">
+<tr><td style="background-color:{{.Color}};color:#ffffff;padding:16px;">
+<div style="font-size:12px;font-weight:bold;letter-spacing:1px;">{{.LevelName}}</div>
+<div style="font-size:20px;font-weight:bold;margin-top:4px;">{{.Title}}</div>
+<div style="font-size:12px;margin-top:4px;">{{.Timestamp}}</div>
+</td></tr>
+{{- if .Summary}}
+<tr><td style="padding:16px 16px 0 16px;">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0">
+<tr>
+{{- range .Summary}}
+<td style="padding:8px;text-align:center;border:1px solid #e0e0e0;">
+<div style="font-size:22px;font-weight:bold;">{{.Value}}</div>
+<div style="font-size:12px;color:#757575;">{{.Key}}</div>
+</td>
+{{- end}}
+</tr>
+</table>
+</td></tr>
+{{- end}}
+{{- if .Report}}
+<tr><td style="padding:16px;">
+<table width="100%" cellpadding="8" cellspacing="0" style="border-collapse:collapse;font-size:14px;">
+<tr style="background-color:#eeeeee;text-align:left;"><th style="border:1px solid #e0e0e0;">Alarm</th><th style="border:1px solid #e0e0e0;">Status</th></tr>
+{{- range .Report}}
+<tr style="background-color:{{.Background}};">
+<td style="border:1px solid #e0e0e0;border-left:4px solid {{.Color}};font-weight:bold;white-space:nowrap;">{{.Identifier}}</td>
+<td style="border:1px solid #e0e0e0;">{{.Status}}</td>
+</tr>
+{{- end}}
+</table>
+</td></tr>
+{{- end}}
+{{- if .Fields}}
+<tr><td style="padding:16px 16px 0 16px;">
+<table width="100%" cellpadding="6" cellspacing="0" style="border-collapse:collapse;font-size:14px;">
+{{- range .Fields}}
+<tr><td style="border:1px solid #e0e0e0;color:#757575;white-space:nowrap;">{{.Key}}</td><td style="border:1px solid #e0e0e0;">{{.Value}}</td></tr>
+{{- end}}
+</table>
+</td></tr>
+{{- end}}
+{{- if .Data}}
+<tr><td style="padding:16px;">
+<pre style="margin:0;padding:12px;background-color:#fafafa;border:1px solid #e0e0e0;font-size:13px;white-space:pre-wrap;word-wrap:break-word;">{{.Data}}</pre>
+</td></tr>
+{{- end}}
+<tr><td style="padding:8px 16px;font-size:11px;color:#9e9e9e;border-top:1px solid #e0e0e0;">Sent by node-monitoring</td></tr>
+</table>
+</body>
+</html>
+`
+
+var htmlTemplates = template.Must(template.New("html").Parse(htmlBody))
@@ -0,0 +1,10 @@
+package file
+
+import "errors"
+
+var errNilWriter = errors.New("nil writer")
+var errEmptyFilePath = errors.New("empty file path")
+var errInvalidMaxSize = errors.New("invalid maximum size")
+var errInvalidRotationInterval = errors.New("invalid rotation interval")
+var errInvalidMaxBackups = errors.New("invalid maximum number of backups")
+var errFileClosed = errors.New("file closed")
@@ -0,0 +1,70 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+// ArgsFileNotifier represents the arguments DTO for the fileNotifier constructor
+type ArgsFileNotifier struct {
+	Writer         Writer
+	IncludeNoEvent bool
+}
+
+type lineEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	data.AlarmResponse
+}
+
+type fileNotifier struct {
+	writer         Writer
+	includeNoEvent bool
+	getTime        func() time.Time
+}
+
+// NewFileNotifier creates a new notifier that appends each alarm response as a JSON line
+func NewFileNotifier(args ArgsFileNotifier) (*fileNotifier, error) {
+	if check.IfNil(args.Writer) {
+		return nil, errNilWriter
+	}
+
+	return &fileNotifier{
+		writer:         args.Writer,
+		includeNoEvent: args.IncludeNoEvent,
+		getTime:        time.Now,
+	}, nil
+}
+
+// ProcessAlarmResponse will write the alarm response, together with the current time, as a JSON line. The responses
+// without an event are ignored unless configured otherwise
+func (notifier *fileNotifier) ProcessAlarmResponse(_ context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent && !notifier.includeNoEvent {
+		return nil
+	}
+
+	buff, err := json.Marshal(lineEntry{
+		Timestamp:     notifier.getTime(),
+		AlarmResponse: response,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = notifier.writer.Write(append(buff, '\n'))
+
+	return err
+}
+
+// Close closes the writer, the responses processed afterwards not being stored anymore
+func (notifier *fileNotifier) Close() error {
+	return notifier.writer.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *fileNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
@@ -0,0 +1,158 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+type writerStub struct {
+	WriteCalled func(line []byte) (int, error)
+	CloseCalled func() error
+}
+
+// Write -
+func (stub *writerStub) Write(line []byte) (int, error) {
+	if stub.WriteCalled != nil {
+		return stub.WriteCalled(line)
+	}
+
+	return len(line), nil
+}
+
+// Close -
+func (stub *writerStub) Close() error {
+	if stub.CloseCalled != nil {
+		return stub.CloseCalled()
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *writerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func TestNewFileNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil writer should error", func(t *testing.T) {
+		notifier, err := NewFileNotifier(ArgsFileNotifier{})
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilWriter, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := NewFileNotifier(ArgsFileNotifier{Writer: &writerStub{}})
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
+
+func TestFileNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	response := data.AlarmResponse{
+		Identifier: "node-0",
+		Level:      data.Error,
+		Data:       "node is offline",
+		Labels:     map[string]string{"shard": "0"},
+	}
+	timestamp := time.Date(2022, 4, 5, 10, 11, 12, 0, time.UTC)
+
+	t.Run("no event should not write", func(t *testing.T) {
+		notifier, _ := NewFileNotifier(ArgsFileNotifier{
+			Writer: &writerStub{
+				WriteCalled: func(line []byte) (int, error) {
+					assert.Fail(t, "should have not written")
+					return 0, nil
+				},
+			},
+		})
+
+		noEventResponse := response
+		noEventResponse.Level = data.NoEvent
+		err := notifier.ProcessAlarmResponse(context.Background(), noEventResponse)
+		assert.Nil(t, err)
+	})
+	t.Run("no event should write if configured", func(t *testing.T) {
+		var written string
+		notifier, _ := NewFileNotifier(ArgsFileNotifier{
+			Writer: &writerStub{
+				WriteCalled: func(line []byte) (int, error) {
+					written = string(line)
+					return len(line), nil
+				},
+			},
+			IncludeNoEvent: true,
+		})
+		notifier.getTime = func() time.Time {
+			return timestamp
+		}
+
+		noEventResponse := response
+		noEventResponse.Level = data.NoEvent
+		noEventResponse.Labels = nil
+		err := notifier.ProcessAlarmResponse(context.Background(), noEventResponse)
+		assert.Nil(t, err)
+		expected := `{"timestamp":"2022-04-05T10:11:12Z","identifier":"node-0","level":"No event","data":"node is offline"}` + "\n"
+		assert.Equal(t, expected, written)
+	})
+	t.Run("should write a JSON line", func(t *testing.T) {
+		var written string
+		notifier, _ := NewFileNotifier(ArgsFileNotifier{
+			Writer: &writerStub{
+				WriteCalled: func(line []byte) (int, error) {
+					written = string(line)
+					return len(line), nil
+				},
+			},
+		})
+		notifier.getTime = func() time.Time {
+			return timestamp
+		}
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+		expected := `{"timestamp":"2022-04-05T10:11:12Z","identifier":"node-0","level":"Error","data":"node is offline",` +
+			`"labels":{"shard":"0"}}` + "\n"
+		assert.Equal(t, expected, written)
+	})
+	t.Run("write error should error", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		notifier, _ := NewFileNotifier(ArgsFileNotifier{
+			Writer: &writerStub{
+				WriteCalled: func(line []byte) (int, error) {
+					return 0, expectedErr
+				},
+			},
+		})
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestFileNotifier_Close(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	numCalls := 0
+	notifier, _ := NewFileNotifier(ArgsFileNotifier{
+		Writer: &writerStub{
+			CloseCalled: func() error {
+				numCalls++
+				return expectedErr
+			},
+		},
+	})
+
+	err := notifier.Close()
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 1, numCalls)
+}
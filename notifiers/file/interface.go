@@ -0,0 +1,8 @@
+package file
+
+// Writer defines the operations of the component storing the lines
+type Writer interface {
+	Write(line []byte) (int, error)
+	Close() error
+	IsInterfaceNil() bool
+}
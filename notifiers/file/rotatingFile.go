@@ -0,0 +1,366 @@
+package file
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+)
+
+var log = logger.GetOrCreate("notifiers/file")
+
+const (
+	filePermissions      = 0600
+	directoryPermissions = 0700
+	backupTimeLayout     = "2006-01-02T15-04-05.000"
+	compressedExtension  = ".gz"
+	temporaryExtension   = ".tmp"
+)
+
+// ArgsRotatingFile represents the arguments DTO for the rotatingFile constructor
+type ArgsRotatingFile struct {
+	FilePath         string
+	MaxSize          int64
+	RotationInterval time.Duration
+	MaxBackups       int
+	Compress         bool
+	SyncEachWrite    bool
+}
+
+// rotatingFile appends data to a file that is renamed to a timestamped backup when it grows over the maximum size
+// or when the rotation interval elapses. The backups can be compressed and only the newest ones can be kept, this
+// work being done on a background goroutine so the writes are not delayed
+type rotatingFile struct {
+	mut              sync.Mutex
+	filePath         string
+	maxSize          int64
+	rotationInterval time.Duration
+	maxBackups       int
+	compress         bool
+	syncEachWrite    bool
+	file             *os.File
+	size             int64
+	periodStart      time.Time
+	closed           bool
+	getTime          func() time.Time
+
+	backupsMut sync.Mutex
+	backupsWg  sync.WaitGroup
+}
+
+// NewRotatingFile creates a new rotating file, appending to the existing file if there is one. A zero maximum size or
+// rotation interval disables the corresponding rotation and a zero maximum number of backups keeps all of them. The
+// time based rotation is aligned to the interval, in UTC, so a 24 hours interval rotates the file at midnight
+func NewRotatingFile(args ArgsRotatingFile) (*rotatingFile, error) {
+	if len(args.FilePath) == 0 {
+		return nil, errEmptyFilePath
+	}
+	if args.MaxSize < 0 {
+		return nil, fmt.Errorf("%w, provided: %d", errInvalidMaxSize, args.MaxSize)
+	}
+	if args.RotationInterval < 0 {
+		return nil, fmt.Errorf("%w, provided: %v", errInvalidRotationInterval, args.RotationInterval)
+	}
+	if args.MaxBackups < 0 {
+		return nil, fmt.Errorf("%w, provided: %d", errInvalidMaxBackups, args.MaxBackups)
+	}
+
+	rf := &rotatingFile{
+		filePath:         filepath.Clean(args.FilePath),
+		maxSize:          args.MaxSize,
+		rotationInterval: args.RotationInterval,
+		maxBackups:       args.MaxBackups,
+		compress:         args.Compress,
+		syncEachWrite:    args.SyncEachWrite,
+		getTime:          time.Now,
+	}
+
+	err := os.MkdirAll(filepath.Dir(args.FilePath), directoryPermissions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rf.openFile()
+	if err != nil {
+		return nil, err
+	}
+
+	// the backups left by a previous run might not have been compressed or cleaned
+	rf.processBackups()
+
+	return rf, nil
+}
+
+func (rf *rotatingFile) openFile() error {
+	file, err := os.OpenFile(rf.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	rf.file = file
+	rf.size = info.Size()
+	rf.periodStart = rf.computePeriodStart(info.ModTime())
+
+	return nil
+}
+
+func (rf *rotatingFile) computePeriodStart(timestamp time.Time) time.Time {
+	if rf.rotationInterval == 0 {
+		return time.Time{}
+	}
+
+	return timestamp.UTC().Truncate(rf.rotationInterval)
+}
+
+// Write appends the provided data to the file, rotating it beforehand if needed. The data is never split between
+// two files
+func (rf *rotatingFile) Write(line []byte) (int, error) {
+	rf.mut.Lock()
+	defer rf.mut.Unlock()
+
+	if rf.closed {
+		return 0, errFileClosed
+	}
+
+	if rf.shouldRotate(int64(len(line))) {
+		err := rf.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if rf.size == 0 {
+		// the period of an empty file starts with its first write
+		rf.periodStart = rf.computePeriodStart(rf.getTime())
+	}
+
+	n, err := rf.file.Write(line)
+	rf.size += int64(n)
+	if err != nil {
+		return n, err
+	}
+
+	if rf.syncEachWrite {
+		err = rf.file.Sync()
+	}
+
+	return n, err
+}
+
+func (rf *rotatingFile) shouldRotate(writeSize int64) bool {
+	if rf.size == 0 {
+		return false
+	}
+	if rf.maxSize > 0 && rf.size+writeSize > rf.maxSize {
+		return true
+	}
+
+	return rf.rotationInterval > 0 && !rf.computePeriodStart(rf.getTime()).Equal(rf.periodStart)
+}
+
+func (rf *rotatingFile) rotate() error {
+	err := rf.file.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(rf.filePath, rf.backupPath(rf.getTime()))
+	if err != nil {
+		// keep writing in the current file rather than losing the data
+		errOpen := rf.openFile()
+		if errOpen != nil {
+			return errOpen
+		}
+
+		log.Warn("error rotating file, continuing with the current file", "file", rf.filePath, "error", err.Error())
+		return nil
+	}
+
+	err = rf.openFile()
+	if err != nil {
+		return err
+	}
+
+	rf.processBackups()
+
+	return nil
+}
+
+// backupPath returns an unused backup path, the timestamp being increased if a backup already exists for it
+func (rf *rotatingFile) backupPath(timestamp time.Time) string {
+	extension := filepath.Ext(rf.filePath)
+	base := strings.TrimSuffix(rf.filePath, extension)
+
+	for {
+		path := base + "-" + timestamp.UTC().Format(backupTimeLayout) + extension
+		if !fileExists(path) && !fileExists(path+compressedExtension) {
+			return path
+		}
+
+		timestamp = timestamp.Add(time.Millisecond)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
+
+func (rf *rotatingFile) processBackups() {
+	if !rf.compress && rf.maxBackups == 0 {
+		return
+	}
+
+	rf.backupsWg.Add(1)
+	go func() {
+		defer rf.backupsWg.Done()
+
+		rf.backupsMut.Lock()
+		defer rf.backupsMut.Unlock()
+
+		err := rf.compressAndCleanBackups()
+		if err != nil {
+			log.Error("error processing the backup files", "file", rf.filePath, "error", err.Error())
+		}
+	}()
+}
+
+func (rf *rotatingFile) compressAndCleanBackups() error {
+	backups, err := rf.listBackups()
+	if err != nil {
+		return err
+	}
+
+	if rf.compress {
+		for i, backup := range backups {
+			if strings.HasSuffix(backup, compressedExtension) {
+				continue
+			}
+
+			err = compressFile(backup)
+			if err != nil {
+				return err
+			}
+			backups[i] = backup + compressedExtension
+		}
+	}
+
+	if rf.maxBackups == 0 || len(backups) <= rf.maxBackups {
+		return nil
+	}
+
+	for _, backup := range backups[:len(backups)-rf.maxBackups] {
+		err = os.Remove(backup)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listBackups returns the backup files, the oldest first
+func (rf *rotatingFile) listBackups() ([]string, error) {
+	extension := filepath.Ext(rf.filePath)
+	base := strings.TrimSuffix(rf.filePath, extension)
+
+	entries, err := os.ReadDir(filepath.Dir(rf.filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		path := filepath.Join(filepath.Dir(rf.filePath), entry.Name())
+		if entry.IsDir() || !strings.HasPrefix(path, base+"-") {
+			continue
+		}
+
+		timestamp := strings.TrimSuffix(strings.TrimSuffix(path, compressedExtension), extension)
+		timestamp = strings.TrimPrefix(timestamp, base+"-")
+		_, errParse := time.Parse(backupTimeLayout, timestamp)
+		if errParse != nil {
+			continue
+		}
+
+		backups = append(backups, path)
+	}
+	sort.Strings(backups)
+
+	return backups, nil
+}
+
+func compressFile(path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = source.Close()
+	}()
+
+	temporaryPath := path + compressedExtension + temporaryExtension
+	destination, err := os.OpenFile(temporaryPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return err
+	}
+
+	writer := gzip.NewWriter(destination)
+	_, err = io.Copy(writer, source)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		err = destination.Sync()
+	}
+	errClose := destination.Close()
+	if err == nil {
+		err = errClose
+	}
+	if err != nil {
+		_ = os.Remove(temporaryPath)
+		return err
+	}
+
+	err = os.Rename(temporaryPath, path+compressedExtension)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// Close closes the file, waiting for the backups processing to finish
+func (rf *rotatingFile) Close() error {
+	rf.mut.Lock()
+	if rf.closed {
+		rf.mut.Unlock()
+		return nil
+	}
+	rf.closed = true
+	err := rf.file.Close()
+	rf.mut.Unlock()
+
+	rf.backupsWg.Wait()
+
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rf *rotatingFile) IsInterfaceNil() bool {
+	return rf == nil
+}
@@ -0,0 +1,218 @@
+package file
+
+import (
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsRotatingFile(dir string) ArgsRotatingFile {
+	return ArgsRotatingFile{
+		FilePath: filepath.Join(dir, "alarms.jsonl"),
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	buff, err := ioutil.ReadFile(path)
+	require.Nil(t, err)
+
+	return string(buff)
+}
+
+func readCompressedFile(t *testing.T, path string) string {
+	file, err := os.Open(path)
+	require.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader, err := gzip.NewReader(file)
+	require.Nil(t, err)
+	buff, err := ioutil.ReadAll(reader)
+	require.Nil(t, err)
+
+	return string(buff)
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.Nil(t, err)
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func TestNewRotatingFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty file path should error", func(t *testing.T) {
+		args := createMockArgsRotatingFile(t.TempDir())
+		args.FilePath = ""
+
+		rf, err := NewRotatingFile(args)
+		assert.True(t, check.IfNil(rf))
+		assert.Equal(t, errEmptyFilePath, err)
+	})
+	t.Run("invalid maximum size should error", func(t *testing.T) {
+		args := createMockArgsRotatingFile(t.TempDir())
+		args.MaxSize = -1
+
+		rf, err := NewRotatingFile(args)
+		assert.True(t, check.IfNil(rf))
+		assert.True(t, errors.Is(err, errInvalidMaxSize))
+	})
+	t.Run("invalid rotation interval should error", func(t *testing.T) {
+		args := createMockArgsRotatingFile(t.TempDir())
+		args.RotationInterval = -time.Hour
+
+		rf, err := NewRotatingFile(args)
+		assert.True(t, check.IfNil(rf))
+		assert.True(t, errors.Is(err, errInvalidRotationInterval))
+	})
+	t.Run("invalid maximum number of backups should error", func(t *testing.T) {
+		args := createMockArgsRotatingFile(t.TempDir())
+		args.MaxBackups = -1
+
+		rf, err := NewRotatingFile(args)
+		assert.True(t, check.IfNil(rf))
+		assert.True(t, errors.Is(err, errInvalidMaxBackups))
+	})
+	t.Run("should create the missing directories", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "logs", "audit")
+		rf, err := NewRotatingFile(createMockArgsRotatingFile(dir))
+		require.False(t, check.IfNil(rf))
+		require.Nil(t, err)
+		_ = rf.Close()
+
+		assert.Equal(t, []string{"alarms.jsonl"}, listFiles(t, dir))
+	})
+	t.Run("should compress the backups left by a previous run", func(t *testing.T) {
+		dir := t.TempDir()
+		err := ioutil.WriteFile(filepath.Join(dir, "alarms-2022-04-05T10-11-12.000.jsonl"), []byte("line 1\n"), 0600)
+		require.Nil(t, err)
+		err = ioutil.WriteFile(filepath.Join(dir, "other.txt"), []byte("other\n"), 0600)
+		require.Nil(t, err)
+
+		args := createMockArgsRotatingFile(dir)
+		args.Compress = true
+		rf, _ := NewRotatingFile(args)
+		_ = rf.Close()
+
+		assert.Equal(t, []string{"alarms-2022-04-05T10-11-12.000.jsonl.gz", "alarms.jsonl", "other.txt"}, listFiles(t, dir))
+		assert.Equal(t, "line 1\n", readCompressedFile(t, filepath.Join(dir, "alarms-2022-04-05T10-11-12.000.jsonl.gz")))
+	})
+}
+
+func TestRotatingFile_Write(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should append to the existing file", func(t *testing.T) {
+		dir := t.TempDir()
+		args := createMockArgsRotatingFile(dir)
+		err := ioutil.WriteFile(args.FilePath, []byte("line 1\n"), 0600)
+		require.Nil(t, err)
+
+		rf, _ := NewRotatingFile(args)
+		n, err := rf.Write([]byte("line 2\n"))
+		assert.Equal(t, 7, n)
+		assert.Nil(t, err)
+		_ = rf.Close()
+
+		assert.Equal(t, "line 1\nline 2\n", readFile(t, args.FilePath))
+	})
+	t.Run("should rotate when the maximum size is exceeded", func(t *testing.T) {
+		dir := t.TempDir()
+		args := createMockArgsRotatingFile(dir)
+		args.MaxSize = 15
+		args.SyncEachWrite = true
+		rf, _ := NewRotatingFile(args)
+		rf.getTime = func() time.Time {
+			return time.Date(2022, 4, 5, 10, 11, 12, 0, time.UTC)
+		}
+
+		_, _ = rf.Write([]byte("line 1\n"))
+		_, _ = rf.Write([]byte("line 2\n"))
+		_, _ = rf.Write([]byte("line 3\n"))
+		_, _ = rf.Write([]byte("a line longer than the maximum size\n"))
+		_ = rf.Close()
+
+		assert.Equal(t, []string{
+			"alarms-2022-04-05T10-11-12.000.jsonl",
+			"alarms-2022-04-05T10-11-12.001.jsonl",
+			"alarms.jsonl",
+		}, listFiles(t, dir))
+		assert.Equal(t, "line 1\nline 2\n", readFile(t, filepath.Join(dir, "alarms-2022-04-05T10-11-12.000.jsonl")))
+		assert.Equal(t, "line 3\n", readFile(t, filepath.Join(dir, "alarms-2022-04-05T10-11-12.001.jsonl")))
+		assert.Equal(t, "a line longer than the maximum size\n", readFile(t, args.FilePath))
+	})
+	t.Run("should rotate when the rotation interval elapses", func(t *testing.T) {
+		dir := t.TempDir()
+		args := createMockArgsRotatingFile(dir)
+		args.RotationInterval = time.Hour
+		rf, _ := NewRotatingFile(args)
+
+		currentTime := time.Date(2022, 4, 5, 10, 30, 0, 0, time.UTC)
+		rf.getTime = func() time.Time {
+			return currentTime
+		}
+
+		_, _ = rf.Write([]byte("line 1\n"))
+		currentTime = time.Date(2022, 4, 5, 10, 59, 0, 0, time.UTC)
+		_, _ = rf.Write([]byte("line 2\n"))
+		currentTime = time.Date(2022, 4, 5, 11, 0, 1, 0, time.UTC)
+		_, _ = rf.Write([]byte("line 3\n"))
+		_ = rf.Close()
+
+		assert.Equal(t, []string{"alarms-2022-04-05T11-00-01.000.jsonl", "alarms.jsonl"}, listFiles(t, dir))
+		assert.Equal(t, "line 1\nline 2\n", readFile(t, filepath.Join(dir, "alarms-2022-04-05T11-00-01.000.jsonl")))
+		assert.Equal(t, "line 3\n", readFile(t, args.FilePath))
+	})
+	t.Run("should compress the backups and keep only the newest ones", func(t *testing.T) {
+		dir := t.TempDir()
+		args := createMockArgsRotatingFile(dir)
+		args.RotationInterval = time.Hour
+		args.MaxBackups = 2
+		args.Compress = true
+		rf, _ := NewRotatingFile(args)
+
+		currentTime := time.Date(2022, 4, 5, 10, 0, 0, 0, time.UTC)
+		rf.getTime = func() time.Time {
+			return currentTime
+		}
+		for i := 0; i < 5; i++ {
+			_, _ = rf.Write([]byte("line\n"))
+			currentTime = currentTime.Add(time.Hour)
+		}
+		_ = rf.Close()
+
+		assert.Equal(t, []string{
+			"alarms-2022-04-05T13-00-00.000.jsonl.gz",
+			"alarms-2022-04-05T14-00-00.000.jsonl.gz",
+			"alarms.jsonl",
+		}, listFiles(t, dir))
+		assert.Equal(t, "line\n", readCompressedFile(t, filepath.Join(dir, "alarms-2022-04-05T14-00-00.000.jsonl.gz")))
+	})
+	t.Run("write after close should error", func(t *testing.T) {
+		rf, _ := NewRotatingFile(createMockArgsRotatingFile(t.TempDir()))
+		_ = rf.Close()
+
+		n, err := rf.Write([]byte("line\n"))
+		assert.Equal(t, 0, n)
+		assert.Equal(t, errFileClosed, err)
+		assert.Nil(t, rf.Close())
+	})
+}
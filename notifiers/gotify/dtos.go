@@ -0,0 +1,15 @@
+package gotify
+
+// messageRequest is the create message request body
+type messageRequest struct {
+	Title    string `json:"title"`
+	Message  string `json:"message"`
+	Priority int    `json:"priority"`
+}
+
+// errorResponse is the body of the failed requests
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorCode        int    `json:"errorCode"`
+	ErrorDescription string `json:"errorDescription"`
+}
@@ -0,0 +1,9 @@
+package gotify
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errEmptyServerUrl = errors.New("empty server URL")
+var errEmptyAppToken = errors.New("empty application token")
+var errInvalidPriority = errors.New("invalid priority")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
@@ -0,0 +1,189 @@
+package gotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+const (
+	messagePath          = "/message"
+	appTokenHeader       = "X-Gotify-Key"
+	minPriority          = 1
+	maxPriority          = 10
+	defaultErrorPriority = 8
+	defaultInfoPriority  = 4
+	maxMessageLength     = 8000
+	maxErrorBodyLength   = 512
+)
+
+// ArgsGotifyNotifier represents the arguments DTO for the gotifyNotifier constructor. The priorities are on the
+// Gotify 1 to 10 scale (the clients show the messages with priority 8 or above as high priority notifications),
+// 0 meaning the default priority of the level. The renderer is optional, the built-in title and message being used
+// without it
+type ArgsGotifyNotifier struct {
+	HTTPClient    HTTPClient
+	ServerUrl     string
+	AppToken      string
+	ErrorPriority int
+	InfoPriority  int
+	Renderer      templating.MessageRenderer
+}
+
+type gotifyNotifier struct {
+	httpClient HTTPClient
+	messageUrl string
+	appToken   string
+	priorities map[data.EventLevel]int
+	renderer   templating.MessageRenderer
+}
+
+// NewGotifyNotifier creates a new notifier that sends the alarm responses to a Gotify server as the application
+// identified by the provided token
+func NewGotifyNotifier(args ArgsGotifyNotifier) (*gotifyNotifier, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(args.ServerUrl) == 0 {
+		return nil, errEmptyServerUrl
+	}
+	if len(args.AppToken) == 0 {
+		return nil, errEmptyAppToken
+	}
+
+	errorPriority, err := checkPriority("ErrorPriority", args.ErrorPriority, defaultErrorPriority)
+	if err != nil {
+		return nil, err
+	}
+	infoPriority, err := checkPriority("InfoPriority", args.InfoPriority, defaultInfoPriority)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gotifyNotifier{
+		httpClient: args.HTTPClient,
+		messageUrl: strings.TrimSuffix(args.ServerUrl, "/") + messagePath,
+		appToken:   args.AppToken,
+		priorities: map[data.EventLevel]int{
+			data.Error: errorPriority,
+			data.Info:  infoPriority,
+		},
+		renderer: args.Renderer,
+	}, nil
+}
+
+func checkPriority(name string, priority int, defaultPriority int) (int, error) {
+	if priority == 0 {
+		return defaultPriority, nil
+	}
+	if priority < minPriority || priority > maxPriority {
+		return 0, fmt.Errorf("%w for %s, provided: %d, allowed: %d-%d", errInvalidPriority, name, priority, minPriority, maxPriority)
+	}
+
+	return priority, nil
+}
+
+// ProcessAlarmResponse will send the alarm response with the priority of its level. The responses without an event
+// are ignored
+func (notifier *gotifyNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	priority, found := notifier.priorities[response.Level]
+	if !found {
+		priority = defaultInfoPriority
+	}
+	title, message, err := notifier.createTexts(response)
+	if err != nil {
+		return err
+	}
+
+	buff, err := json.Marshal(messageRequest{
+		Title:    title,
+		Message:  formatting.Truncate(message, maxMessageLength),
+		Priority: priority,
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := notifier.httpClient.CallEndPoint(ctx, httpWrapper.Request{
+		Method:  http.MethodPost,
+		Url:     notifier.messageUrl,
+		Headers: map[string]string{appTokenHeader: notifier.appToken},
+		Body:    buff,
+	})
+	if err != nil {
+		return err
+	}
+	if result.StatusCode < http.StatusOK || result.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, result.StatusCode, describeErrorResponse(result.Body))
+	}
+
+	return nil
+}
+
+// createTexts returns the title and the message of the notification, rendered from the templates if a renderer
+// was provided
+func (notifier *gotifyNotifier) createTexts(response data.AlarmResponse) (string, string, error) {
+	if check.IfNil(notifier.renderer) {
+		return strings.ToUpper(string(response.Level)) + " | " + response.Identifier, createMessage(response), nil
+	}
+
+	message, err := notifier.renderer.Render(response)
+	if err != nil {
+		return "", "", err
+	}
+
+	return message.Title, message.Body, nil
+}
+
+func createMessage(response data.AlarmResponse) string {
+	labels := common.DisplayLabels(response.Labels)
+	lines := make([]string, 0, len(labels)+2)
+	for _, key := range common.SortedLabelKeys(labels) {
+		lines = append(lines, key+": "+labels[key])
+	}
+	if len(lines) > 0 && len(response.Data) > 0 {
+		lines = append(lines, "")
+	}
+	if len(response.Data) > 0 {
+		lines = append(lines, response.Data)
+	}
+	if len(lines) == 0 {
+		// Gotify rejects the messages without a text
+		lines = append(lines, response.Identifier)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func describeErrorResponse(body []byte) string {
+	response := errorResponse{}
+	err := json.Unmarshal(body, &response)
+	if err != nil || len(response.Error) == 0 {
+		return formatting.Truncate(string(body), maxErrorBodyLength)
+	}
+
+	description := response.Error
+	if len(response.ErrorDescription) > 0 {
+		description += ": " + response.ErrorDescription
+	}
+
+	return formatting.Truncate(description, maxErrorBodyLength)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *gotifyNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
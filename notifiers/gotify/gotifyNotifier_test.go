@@ -0,0 +1,215 @@
+package gotify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAppToken = "AppToken.1"
+
+type receivedMessage struct {
+	path     string
+	appToken string
+	request  messageRequest
+}
+
+func createMockArgsGotifyNotifier(serverUrl string) ArgsGotifyNotifier {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second * 5)
+
+	return ArgsGotifyNotifier{
+		HTTPClient: httpClient,
+		ServerUrl:  serverUrl,
+		AppToken:   testAppToken,
+	}
+}
+
+// createFakeServer answers like a Gotify server, rejecting the requests with a wrong application token
+func createFakeServer(received *[]receivedMessage) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buff, _ := ioutil.ReadAll(r.Body)
+		request := messageRequest{}
+		_ = json.Unmarshal(buff, &request)
+		*received = append(*received, receivedMessage{
+			path:     r.URL.Path,
+			appToken: r.Header.Get("X-Gotify-Key"),
+			request:  request,
+		})
+
+		if r.Header.Get("X-Gotify-Key") != testAppToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"Unauthorized","errorCode":401,"errorDescription":"you need to provide a valid access token or user credentials to access this api"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1,"appid":1}`))
+	}))
+}
+
+func TestNewGotifyNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsGotifyNotifier("http://localhost")
+		args.HTTPClient = nil
+		notifier, err := NewGotifyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty server URL should error", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, err := NewGotifyNotifier(createMockArgsGotifyNotifier(""))
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyServerUrl, err)
+	})
+	t.Run("empty application token should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsGotifyNotifier("http://localhost")
+		args.AppToken = ""
+		notifier, err := NewGotifyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyAppToken, err)
+	})
+	t.Run("invalid priorities should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsGotifyNotifier("http://localhost")
+		args.ErrorPriority = 11
+		notifier, err := NewGotifyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidPriority))
+		assert.True(t, strings.Contains(err.Error(), "ErrorPriority"))
+
+		args = createMockArgsGotifyNotifier("http://localhost")
+		args.InfoPriority = -2
+		notifier, err = NewGotifyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidPriority))
+		assert.True(t, strings.Contains(err.Error(), "InfoPriority"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, err := NewGotifyNotifier(createMockArgsGotifyNotifier("https://gotify.local/"))
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, "https://gotify.local/message", notifier.messageUrl)
+		assert.Equal(t, map[data.EventLevel]int{data.Error: 8, data.Info: 4}, notifier.priorities)
+	})
+}
+
+func TestGotifyNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no event should not send", func(t *testing.T) {
+		t.Parallel()
+
+		received := make([]receivedMessage, 0)
+		server := createFakeServer(&received)
+		defer server.Close()
+		notifier, _ := NewGotifyNotifier(createMockArgsGotifyNotifier(server.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.NoEvent})
+		assert.Nil(t, err)
+		assert.Empty(t, received)
+	})
+	t.Run("should send with the level priority", func(t *testing.T) {
+		t.Parallel()
+
+		received := make([]receivedMessage, 0)
+		server := createFakeServer(&received)
+		defer server.Close()
+		args := createMockArgsGotifyNotifier(server.URL)
+		args.InfoPriority = 2
+		notifier, _ := NewGotifyNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{
+			Identifier: "rating",
+			Level:      data.Error,
+			Data:       "rating dropped",
+			Labels:     map[string]string{"shard": "1"},
+		})
+		assert.Nil(t, err)
+		err = notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{
+			Identifier: "nonce",
+			Level:      data.Info,
+		})
+		assert.Nil(t, err)
+
+		require.Equal(t, 2, len(received))
+		assert.Equal(t, "/message", received[0].path)
+		assert.Equal(t, testAppToken, received[0].appToken)
+		assert.Equal(t, messageRequest{Title: "ERROR | rating", Message: "shard: 1\n\nrating dropped", Priority: 8}, received[0].request)
+		assert.Equal(t, messageRequest{Title: "INFO | nonce", Message: "nonce", Priority: 2}, received[1].request)
+	})
+	t.Run("should send the rendered texts if a renderer was provided", func(t *testing.T) {
+		t.Parallel()
+
+		received := make([]receivedMessage, 0)
+		server := createFakeServer(&received)
+		defer server.Close()
+		args := createMockArgsGotifyNotifier(server.URL)
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{Title: "title " + response.Identifier, Body: "body", HTMLBody: "<b>body</b>"}, nil
+			},
+		}
+		notifier, _ := NewGotifyNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Error})
+		assert.Nil(t, err)
+		require.Equal(t, 1, len(received))
+		assert.Equal(t, messageRequest{Title: "title rating", Message: "body", Priority: 8}, received[0].request)
+	})
+	t.Run("render error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		received := make([]receivedMessage, 0)
+		server := createFakeServer(&received)
+		defer server.Close()
+		args := createMockArgsGotifyNotifier(server.URL)
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{}, expectedErr
+			},
+		}
+		notifier, _ := NewGotifyNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Error})
+		assert.Equal(t, expectedErr, err)
+		assert.Empty(t, received)
+	})
+	t.Run("wrong token should error", func(t *testing.T) {
+		t.Parallel()
+
+		received := make([]receivedMessage, 0)
+		server := createFakeServer(&received)
+		defer server.Close()
+		args := createMockArgsGotifyNotifier(server.URL)
+		args.AppToken = "wrong"
+		notifier, _ := NewGotifyNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Error})
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.True(t, strings.Contains(err.Error(), "401: Unauthorized: you need to provide a valid access token"))
+		assert.False(t, strings.Contains(err.Error(), "wrong"))
+	})
+}
@@ -0,0 +1,13 @@
+package gotify
+
+import (
+	"context"
+
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+// HTTPClient defines the operations of the HTTP client used to create the messages
+type HTTPClient interface {
+	CallEndPoint(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error)
+	IsInterfaceNil() bool
+}
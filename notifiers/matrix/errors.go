@@ -0,0 +1,11 @@
+package matrix
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errEmptyHomeserverUrl = errors.New("empty homeserver URL")
+var errEmptyAccessToken = errors.New("empty access token")
+var errNoRooms = errors.New("no room IDs")
+var errInvalidRoomID = errors.New("invalid room ID")
+var errInvalidValue = errors.New("invalid value")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
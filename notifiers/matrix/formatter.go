@@ -0,0 +1,170 @@
+package matrix
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+const (
+	htmlFormat     = "org.matrix.custom.html"
+	textMsgType    = "m.text"
+	noticeMsgType  = "m.notice"
+	defaultColor   = "#9e9e9e"
+	maxDataLength  = 16000
+	pausedStatus   = "PAUSED"
+	titleSeparator = " | "
+)
+
+var levelColors = map[data.EventLevel]string{
+	data.Error: "#d32f2f",
+	data.Info:  "#1976d2",
+}
+
+var levelTitles = map[data.EventLevel]string{
+	data.Error: "🚨 ERROR",
+	data.Info:  "ℹ️ INFO",
+}
+
+// the errors are sent as m.text so they trigger the clients' notifications, the other levels are sent as m.notice
+// which, by convention, the clients and bots do not notify or react to
+var levelMsgTypes = map[data.EventLevel]string{
+	data.Error: textMsgType,
+}
+
+func levelColor(level data.EventLevel) string {
+	color, found := levelColors[level]
+	if !found {
+		return defaultColor
+	}
+
+	return color
+}
+
+func levelMsgType(level data.EventLevel) string {
+	msgType, found := levelMsgTypes[level]
+	if !found {
+		return noticeMsgType
+	}
+
+	return msgType
+}
+
+func levelTitle(level data.EventLevel) string {
+	title, found := levelTitles[level]
+	if !found {
+		return strings.ToUpper(string(level))
+	}
+
+	return title
+}
+
+func reportEntryStatus(entry data.ReportEntry) string {
+	if entry.Paused {
+		return pausedStatus
+	}
+
+	return strings.ToUpper(string(entry.Level))
+}
+
+// createMessageContent returns the message with both the plain text body, used by the clients that do not render
+// HTML and in the notifications, and the HTML formatted body
+func createMessageContent(response data.AlarmResponse) messageContent {
+	return messageContent{
+		MsgType:       levelMsgType(response.Level),
+		Body:          createPlainBody(response),
+		Format:        htmlFormat,
+		FormattedBody: createHTMLBody(response),
+	}
+}
+
+// createRenderedMessageContent returns the message holding the texts rendered from the templates, the title and
+// the plain body being joined in the plain text body
+func createRenderedMessageContent(level data.EventLevel, message templating.Message) messageContent {
+	lines := make([]string, 0, 2)
+	if len(message.Title) > 0 {
+		lines = append(lines, message.Title)
+	}
+	if len(message.Body) > 0 {
+		lines = append(lines, formatting.Truncate(message.Body, maxDataLength))
+	}
+
+	return messageContent{
+		MsgType:       levelMsgType(level),
+		Body:          strings.Join(lines, "\n"),
+		Format:        htmlFormat,
+		FormattedBody: formatting.Truncate(message.HTMLBody, maxDataLength),
+	}
+}
+
+func createPlainBody(response data.AlarmResponse) string {
+	lines := []string{levelTitle(response.Level) + titleSeparator + response.Identifier}
+	labels := common.DisplayLabels(response.Labels)
+	for _, key := range common.SortedLabelKeys(labels) {
+		lines = append(lines, fmt.Sprintf("%s: %s", key, labels[key]))
+	}
+	if len(response.Report) > 0 {
+		for _, field := range common.ReportSummary(response.Metrics) {
+			lines = append(lines, fmt.Sprintf("%s: %s", field.Name, field.Value))
+		}
+	}
+	for _, entry := range response.Report {
+		lines = append(lines, fmt.Sprintf("[%s] %s: %s", reportEntryStatus(entry), entry.Identifier, entry.Status))
+	}
+	if len(response.Data) > 0 && len(response.Report) == 0 {
+		lines = append(lines, "", formatting.Truncate(response.Data, maxDataLength))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func createHTMLBody(response data.AlarmResponse) string {
+	color := levelColor(response.Level)
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf(`<p><strong><font color="%s" data-mx-color="%s">%s</font>%s%s</strong></p>`,
+		color, color, html.EscapeString(levelTitle(response.Level)), titleSeparator, html.EscapeString(response.Identifier)))
+
+	labels := common.DisplayLabels(response.Labels)
+	if len(labels) > 0 {
+		builder.WriteString("<ul>")
+		for _, key := range common.SortedLabelKeys(labels) {
+			builder.WriteString(fmt.Sprintf("<li><strong>%s</strong>: %s</li>",
+				html.EscapeString(key), html.EscapeString(labels[key])))
+		}
+		builder.WriteString("</ul>")
+	}
+
+	if len(response.Report) > 0 {
+		summary := make([]string, 0)
+		for _, field := range common.ReportSummary(response.Metrics) {
+			summary = append(summary, fmt.Sprintf("%s: <strong>%s</strong>", html.EscapeString(field.Name), html.EscapeString(field.Value)))
+		}
+		if len(summary) > 0 {
+			builder.WriteString("<p>" + strings.Join(summary, " · ") + "</p>")
+		}
+
+		builder.WriteString("<ul>")
+		for _, entry := range response.Report {
+			entryColor := levelColor(entry.Level)
+			builder.WriteString(fmt.Sprintf(`<li><font color="%s" data-mx-color="%s">%s</font> <strong>%s</strong>: %s</li>`,
+				entryColor, entryColor, reportEntryStatus(entry), html.EscapeString(entry.Identifier), html.EscapeString(entry.Status)))
+		}
+		builder.WriteString("</ul>")
+
+		// the data only duplicates the structured report
+		return builder.String()
+	}
+
+	if len(response.Data) > 0 {
+		builder.WriteString("<pre><code>")
+		builder.WriteString(html.EscapeString(formatting.Truncate(response.Data, maxDataLength)))
+		builder.WriteString("</code></pre>")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,104 @@
+package matrix
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMessageContent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error response", func(t *testing.T) {
+		t.Parallel()
+
+		content := createMessageContent(data.AlarmResponse{
+			Identifier: "node <rating>",
+			Level:      data.Error,
+			Data:       "rating is 89 & dropping",
+			Labels:     map[string]string{"shard": "1", "network": "main<net>"},
+		})
+
+		assert.Equal(t, textMsgType, content.MsgType)
+		assert.Equal(t, htmlFormat, content.Format)
+		assert.Equal(t, "🚨 ERROR | node <rating>\nnetwork: main<net>\nshard: 1\n\nrating is 89 & dropping", content.Body)
+		expectedHTML := `<p><strong><font color="#d32f2f" data-mx-color="#d32f2f">🚨 ERROR</font> | node &lt;rating&gt;</strong></p>` +
+			`<ul><li><strong>network</strong>: main&lt;net&gt;</li><li><strong>shard</strong>: 1</li></ul>` +
+			`<pre><code>rating is 89 &amp; dropping</code></pre>`
+		assert.Equal(t, expectedHTML, content.FormattedBody)
+	})
+	t.Run("info response should be a notice", func(t *testing.T) {
+		t.Parallel()
+
+		content := createMessageContent(data.AlarmResponse{
+			Identifier: "nonce",
+			Level:      data.Info,
+		})
+
+		assert.Equal(t, noticeMsgType, content.MsgType)
+		assert.Equal(t, "ℹ️ INFO | nonce", content.Body)
+		assert.Equal(t, `<p><strong><font color="#1976d2" data-mx-color="#1976d2">ℹ️ INFO</font> | nonce</strong></p>`, content.FormattedBody)
+	})
+	t.Run("long data should be truncated", func(t *testing.T) {
+		t.Parallel()
+
+		content := createMessageContent(data.AlarmResponse{
+			Identifier: "nonce",
+			Level:      data.Error,
+			Data:       strings.Repeat("a", maxDataLength+100),
+		})
+
+		assert.True(t, strings.HasSuffix(content.Body, "a…"))
+		assert.True(t, strings.Contains(content.FormattedBody, "a…</code></pre>"))
+	})
+	t.Run("info report", func(t *testing.T) {
+		t.Parallel()
+
+		content := createMessageContent(data.AlarmResponse{
+			Identifier: data.SystemIdentifier,
+			Level:      data.Error,
+			Data:       "System is running",
+			Metrics:    map[string]float64{data.UptimeMetric: 60, data.ProcessingErrorsMetric: 1},
+			Report: []data.ReportEntry{
+				{Identifier: "rating", Level: data.Info, Status: "ok"},
+				{Identifier: "nonce", Level: data.NoEvent, Status: "paused", Paused: true},
+				{Identifier: "balance", Level: data.Error, Status: "error <timeout>"},
+			},
+		})
+
+		expectedBody := "🚨 ERROR | system\n" +
+			"Uptime: 1m0s\n" +
+			"Processing errors: 1\n" +
+			"[INFO] rating: ok\n" +
+			"[PAUSED] nonce: paused\n" +
+			"[ERROR] balance: error <timeout>"
+		assert.Equal(t, expectedBody, content.Body)
+		assert.True(t, strings.Contains(content.FormattedBody, "<p>Uptime: <strong>1m0s</strong> · Processing errors: <strong>1</strong></p>"))
+		assert.True(t, strings.Contains(content.FormattedBody, `<li><font color="#9e9e9e" data-mx-color="#9e9e9e">PAUSED</font> <strong>nonce</strong>: paused</li>`))
+		assert.True(t, strings.Contains(content.FormattedBody, "error &lt;timeout&gt;"))
+		assert.False(t, strings.Contains(content.FormattedBody, "System is running"))
+	})
+}
+
+func TestCreateRenderedMessageContent(t *testing.T) {
+	t.Parallel()
+
+	content := createRenderedMessageContent(data.Error, templating.Message{
+		Title:    "title",
+		Body:     "body",
+		HTMLBody: "<b>body</b>",
+	})
+	assert.Equal(t, messageContent{
+		MsgType:       textMsgType,
+		Body:          "title\nbody",
+		Format:        htmlFormat,
+		FormattedBody: "<b>body</b>",
+	}, content)
+
+	content = createRenderedMessageContent(data.Info, templating.Message{Body: "body"})
+	assert.Equal(t, noticeMsgType, content.MsgType)
+	assert.Equal(t, "body", content.Body)
+}
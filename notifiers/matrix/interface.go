@@ -0,0 +1,13 @@
+package matrix
+
+import (
+	"context"
+
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+// HTTPClient defines the operations of the HTTP client used to call the homeserver
+type HTTPClient interface {
+	CallEndPoint(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error)
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,219 @@
+package matrix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+var log = logger.GetOrCreate("notifiers/matrix")
+
+const (
+	sendMessagePath     = "/_matrix/client/v3/rooms/%s/send/m.room.message/%s"
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+	roomIDPrefix        = "!"
+	minimumRetryDelay   = time.Millisecond
+	// the rate limited requests are not retried if the homeserver asks to wait longer than this
+	maxRetryAfter      = time.Minute
+	maxErrorBodyLength = 512
+)
+
+// ArgsMatrixNotifier represents the arguments DTO for the matrixNotifier constructor. The renderer is optional, the
+// built-in message being used without it
+type ArgsMatrixNotifier struct {
+	HTTPClient    HTTPClient
+	HomeserverUrl string
+	AccessToken   string
+	RoomIDs       []string
+	MaxRetries    int
+	RetryDelay    time.Duration
+	Renderer      templating.MessageRenderer
+}
+
+type matrixNotifier struct {
+	httpClient    HTTPClient
+	homeserverUrl string
+	accessToken   string
+	roomIDs       []string
+	maxRetries    int
+	retryDelay    time.Duration
+	renderer      templating.MessageRenderer
+	txnPrefix     string
+	txnCounter    uint64
+}
+
+// NewMatrixNotifier creates a new notifier that posts the alarm responses as HTML formatted messages in Matrix rooms
+// through the client-server API
+func NewMatrixNotifier(args ArgsMatrixNotifier) (*matrixNotifier, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &matrixNotifier{
+		httpClient:    args.HTTPClient,
+		homeserverUrl: strings.TrimSuffix(args.HomeserverUrl, "/"),
+		accessToken:   args.AccessToken,
+		roomIDs:       append(make([]string, 0, len(args.RoomIDs)), args.RoomIDs...),
+		maxRetries:    args.MaxRetries,
+		retryDelay:    args.RetryDelay,
+		renderer:      args.Renderer,
+		// the transaction IDs are scoped to the access token, the prefix keeps them unique between runs
+		txnPrefix: fmt.Sprintf("nm%d", time.Now().UnixNano()),
+	}, nil
+}
+
+func checkArgs(args ArgsMatrixNotifier) error {
+	if check.IfNil(args.HTTPClient) {
+		return errNilHTTPClient
+	}
+	if len(args.HomeserverUrl) == 0 {
+		return errEmptyHomeserverUrl
+	}
+	if len(args.AccessToken) == 0 {
+		return errEmptyAccessToken
+	}
+	if len(args.RoomIDs) == 0 {
+		return errNoRooms
+	}
+	for _, roomID := range args.RoomIDs {
+		if !strings.HasPrefix(roomID, roomIDPrefix) || !strings.Contains(roomID, ":") {
+			return fmt.Errorf("%w: %s, the room IDs have the !opaque:server format", errInvalidRoomID, roomID)
+		}
+	}
+	if args.MaxRetries < 0 {
+		return fmt.Errorf("%w for MaxRetries, provided: %d", errInvalidValue, args.MaxRetries)
+	}
+	if args.RetryDelay < minimumRetryDelay {
+		return fmt.Errorf("%w for RetryDelay, provided: %v, minimum: %v", errInvalidValue, args.RetryDelay, minimumRetryDelay)
+	}
+
+	return nil
+}
+
+// ProcessAlarmResponse will post the alarm response in all the configured rooms. The responses without an event
+// are ignored
+func (notifier *matrixNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	content, err := notifier.createMessageContent(response)
+	if err != nil {
+		return err
+	}
+
+	buff, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	for _, roomID := range notifier.roomIDs {
+		err = notifier.sendMessage(ctx, roomID, buff)
+		if err != nil {
+			return fmt.Errorf("%w for room %s", err, roomID)
+		}
+	}
+
+	return nil
+}
+
+func (notifier *matrixNotifier) createMessageContent(response data.AlarmResponse) (messageContent, error) {
+	if check.IfNil(notifier.renderer) {
+		return createMessageContent(response), nil
+	}
+
+	message, err := notifier.renderer.Render(response)
+	if err != nil {
+		return messageContent{}, err
+	}
+
+	return createRenderedMessageContent(response.Level, message), nil
+}
+
+// sendMessage will put the message in the room, retrying on network errors, rate limits and server errors. All
+// the attempts use the same transaction ID so the homeserver will not duplicate the message if a previous attempt
+// was processed but its response was lost
+func (notifier *matrixNotifier) sendMessage(ctx context.Context, roomID string, content []byte) error {
+	txnID := notifier.newTransactionID()
+	request := httpWrapper.Request{
+		Method:  http.MethodPut,
+		Url:     notifier.homeserverUrl + fmt.Sprintf(sendMessagePath, url.PathEscape(roomID), url.PathEscape(txnID)),
+		Headers: map[string]string{authorizationHeader: bearerPrefix + notifier.accessToken},
+		Body:    content,
+	}
+
+	for attempt := 0; ; attempt++ {
+		response, err := notifier.httpClient.CallEndPoint(ctx, request)
+		retryDelay := notifier.retryDelay
+		if err == nil {
+			if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+				return nil
+			}
+
+			var retryAfter time.Duration
+			retryAfter, err = parseErrorResponse(response)
+			if !isRetryable(response.StatusCode) || retryAfter > maxRetryAfter {
+				return err
+			}
+			if retryAfter > 0 {
+				retryDelay = retryAfter
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if attempt >= notifier.maxRetries {
+			return err
+		}
+
+		log.Debug("Matrix message not sent, retrying", "room", roomID, "transaction", txnID,
+			"attempt", attempt+1, "retry after", retryDelay, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+func (notifier *matrixNotifier) newTransactionID() string {
+	return fmt.Sprintf("%s.%d", notifier.txnPrefix, atomic.AddUint64(&notifier.txnCounter, 1))
+}
+
+func isRetryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// parseErrorResponse returns the delay requested by the homeserver, if any, and the error described by the response
+func parseErrorResponse(response httpWrapper.Response) (time.Duration, error) {
+	errResponse := errorResponse{}
+	errUnmarshal := json.Unmarshal(response.Body, &errResponse)
+	if errUnmarshal != nil || len(errResponse.ErrCode) == 0 {
+		return 0, fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, response.StatusCode,
+			formatting.Truncate(string(response.Body), maxErrorBodyLength))
+	}
+
+	err := fmt.Errorf("%w %d: %s %s", errUnexpectedStatusCode, response.StatusCode, errResponse.ErrCode,
+		formatting.Truncate(errResponse.Error, maxErrorBodyLength))
+
+	return time.Duration(errResponse.RetryAfterMs) * time.Millisecond, err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *matrixNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
@@ -0,0 +1,351 @@
+package matrix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAccessToken = "syt_test_token"
+
+type receivedMessage struct {
+	method        string
+	path          string
+	authorization string
+	content       messageContent
+}
+
+// fakeHomeserver records the received messages and answers with the queued responses, then with the event ID
+type fakeHomeserver struct {
+	*httptest.Server
+	mut       sync.Mutex
+	messages  []receivedMessage
+	responses []func(w http.ResponseWriter)
+}
+
+func newFakeHomeserver() *fakeHomeserver {
+	homeserver := &fakeHomeserver{}
+	homeserver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buff, _ := ioutil.ReadAll(r.Body)
+		content := messageContent{}
+		_ = json.Unmarshal(buff, &content)
+
+		homeserver.mut.Lock()
+		homeserver.messages = append(homeserver.messages, receivedMessage{
+			method:        r.Method,
+			path:          r.URL.EscapedPath(),
+			authorization: r.Header.Get("Authorization"),
+			content:       content,
+		})
+		var respond func(w http.ResponseWriter)
+		if len(homeserver.responses) > 0 {
+			respond = homeserver.responses[0]
+			homeserver.responses = homeserver.responses[1:]
+		}
+		homeserver.mut.Unlock()
+
+		if respond != nil {
+			respond(w)
+			return
+		}
+		_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+	}))
+
+	return homeserver
+}
+
+func (homeserver *fakeHomeserver) receivedMessages() []receivedMessage {
+	homeserver.mut.Lock()
+	defer homeserver.mut.Unlock()
+
+	return append(make([]receivedMessage, 0, len(homeserver.messages)), homeserver.messages...)
+}
+
+func (homeserver *fakeHomeserver) queueResponses(responses ...func(w http.ResponseWriter)) {
+	homeserver.mut.Lock()
+	homeserver.responses = append(homeserver.responses, responses...)
+	homeserver.mut.Unlock()
+}
+
+func respondWith(statusCode int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func createMockArgsMatrixNotifier(homeserverUrl string) ArgsMatrixNotifier {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second * 5)
+
+	return ArgsMatrixNotifier{
+		HTTPClient:    httpClient,
+		HomeserverUrl: homeserverUrl,
+		AccessToken:   testAccessToken,
+		RoomIDs:       []string{"!room1:matrix.local"},
+		MaxRetries:    2,
+		RetryDelay:    time.Millisecond * 10,
+	}
+}
+
+func TestNewMatrixNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMatrixNotifier("http://localhost")
+		args.HTTPClient = nil
+		notifier, err := NewMatrixNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty homeserver URL should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMatrixNotifier("")
+		notifier, err := NewMatrixNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyHomeserverUrl, err)
+	})
+	t.Run("empty access token should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMatrixNotifier("http://localhost")
+		args.AccessToken = ""
+		notifier, err := NewMatrixNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyAccessToken, err)
+	})
+	t.Run("no rooms should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMatrixNotifier("http://localhost")
+		args.RoomIDs = nil
+		notifier, err := NewMatrixNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNoRooms, err)
+	})
+	t.Run("room alias should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMatrixNotifier("http://localhost")
+		args.RoomIDs = []string{"#alerts:matrix.local"}
+		notifier, err := NewMatrixNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidRoomID))
+	})
+	t.Run("invalid retries should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMatrixNotifier("http://localhost")
+		args.MaxRetries = -1
+		notifier, err := NewMatrixNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "MaxRetries"))
+
+		args = createMockArgsMatrixNotifier("http://localhost")
+		args.RetryDelay = 0
+		notifier, err = NewMatrixNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "RetryDelay"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, err := NewMatrixNotifier(createMockArgsMatrixNotifier("http://localhost/"))
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, "http://localhost", notifier.homeserverUrl)
+	})
+}
+
+func TestMatrixNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	response := data.AlarmResponse{
+		Identifier: "rating",
+		Level:      data.Error,
+		Data:       "rating dropped",
+	}
+
+	t.Run("no event should not send", func(t *testing.T) {
+		t.Parallel()
+
+		homeserver := newFakeHomeserver()
+		defer homeserver.Close()
+		notifier, _ := NewMatrixNotifier(createMockArgsMatrixNotifier(homeserver.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.NoEvent})
+		assert.Nil(t, err)
+		assert.Empty(t, homeserver.receivedMessages())
+	})
+	t.Run("should send in all rooms with distinct transaction IDs", func(t *testing.T) {
+		t.Parallel()
+
+		homeserver := newFakeHomeserver()
+		defer homeserver.Close()
+		args := createMockArgsMatrixNotifier(homeserver.URL)
+		args.RoomIDs = []string{"!room1:matrix.local", "!room2:matrix.local"}
+		notifier, _ := NewMatrixNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+
+		messages := homeserver.receivedMessages()
+		require.Equal(t, 2, len(messages))
+		assert.Equal(t, http.MethodPut, messages[0].method)
+		assert.Equal(t, "Bearer "+testAccessToken, messages[0].authorization)
+		assert.True(t, strings.HasPrefix(messages[0].path, "/_matrix/client/v3/rooms/%21room1:matrix.local/send/m.room.message/nm"))
+		assert.True(t, strings.HasPrefix(messages[1].path, "/_matrix/client/v3/rooms/%21room2:matrix.local/send/m.room.message/nm"))
+		assert.NotEqual(t, transactionID(messages[0].path), transactionID(messages[1].path))
+		assert.Equal(t, createMessageContent(response), messages[0].content)
+		assert.Equal(t, createMessageContent(response), messages[1].content)
+	})
+	t.Run("should send the rendered texts", func(t *testing.T) {
+		t.Parallel()
+
+		homeserver := newFakeHomeserver()
+		defer homeserver.Close()
+		args := createMockArgsMatrixNotifier(homeserver.URL)
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{Title: "title", Body: "body", HTMLBody: "<b>body</b>"}, nil
+			},
+		}
+		notifier, _ := NewMatrixNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+
+		messages := homeserver.receivedMessages()
+		require.Equal(t, 1, len(messages))
+		assert.Equal(t, "title\nbody", messages[0].content.Body)
+		assert.Equal(t, "<b>body</b>", messages[0].content.FormattedBody)
+	})
+	t.Run("render error should error", func(t *testing.T) {
+		t.Parallel()
+
+		homeserver := newFakeHomeserver()
+		defer homeserver.Close()
+		expectedErr := errors.New("expected error")
+		args := createMockArgsMatrixNotifier(homeserver.URL)
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{}, expectedErr
+			},
+		}
+		notifier, _ := NewMatrixNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Equal(t, expectedErr, err)
+		assert.Empty(t, homeserver.receivedMessages())
+	})
+	t.Run("retries should reuse the transaction ID", func(t *testing.T) {
+		t.Parallel()
+
+		homeserver := newFakeHomeserver()
+		defer homeserver.Close()
+		homeserver.queueResponses(
+			respondWith(http.StatusBadGateway, "bad gateway"),
+			respondWith(http.StatusTooManyRequests, `{"errcode":"M_LIMIT_EXCEEDED","error":"Too many requests","retry_after_ms":20}`),
+		)
+		notifier, _ := NewMatrixNotifier(createMockArgsMatrixNotifier(homeserver.URL))
+
+		start := time.Now()
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+		assert.True(t, time.Since(start) >= time.Millisecond*30)
+
+		messages := homeserver.receivedMessages()
+		require.Equal(t, 3, len(messages))
+		assert.Equal(t, messages[0].path, messages[1].path)
+		assert.Equal(t, messages[0].path, messages[2].path)
+
+		// a new message uses a new transaction ID
+		err = notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+		messages = homeserver.receivedMessages()
+		require.Equal(t, 4, len(messages))
+		assert.NotEqual(t, messages[0].path, messages[3].path)
+	})
+	t.Run("exhausted retries should error", func(t *testing.T) {
+		t.Parallel()
+
+		homeserver := newFakeHomeserver()
+		defer homeserver.Close()
+		homeserver.queueResponses(
+			respondWith(http.StatusInternalServerError, "error 1"),
+			respondWith(http.StatusInternalServerError, "error 2"),
+			respondWith(http.StatusInternalServerError, "error 3"),
+		)
+		notifier, _ := NewMatrixNotifier(createMockArgsMatrixNotifier(homeserver.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.Equal(t, "unexpected status code 500: error 3 for room !room1:matrix.local", err.Error())
+		assert.Equal(t, 3, len(homeserver.receivedMessages()))
+	})
+	t.Run("client errors should not be retried", func(t *testing.T) {
+		t.Parallel()
+
+		homeserver := newFakeHomeserver()
+		defer homeserver.Close()
+		homeserver.queueResponses(respondWith(http.StatusForbidden, `{"errcode":"M_FORBIDDEN","error":"User not in room"}`))
+		notifier, _ := NewMatrixNotifier(createMockArgsMatrixNotifier(homeserver.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.True(t, strings.Contains(err.Error(), "403: M_FORBIDDEN User not in room"))
+		assert.False(t, strings.Contains(err.Error(), testAccessToken))
+		assert.Equal(t, 1, len(homeserver.receivedMessages()))
+	})
+	t.Run("long rate limits should not be retried", func(t *testing.T) {
+		t.Parallel()
+
+		homeserver := newFakeHomeserver()
+		defer homeserver.Close()
+		homeserver.queueResponses(respondWith(http.StatusTooManyRequests, `{"errcode":"M_LIMIT_EXCEEDED","error":"Too many requests","retry_after_ms":600000}`))
+		notifier, _ := NewMatrixNotifier(createMockArgsMatrixNotifier(homeserver.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.Equal(t, 1, len(homeserver.receivedMessages()))
+	})
+	t.Run("context cancel should stop the retries", func(t *testing.T) {
+		t.Parallel()
+
+		homeserver := newFakeHomeserver()
+		defer homeserver.Close()
+		homeserver.queueResponses(respondWith(http.StatusServiceUnavailable, "unavailable"))
+		args := createMockArgsMatrixNotifier(homeserver.URL)
+		args.RetryDelay = time.Minute
+		notifier, _ := NewMatrixNotifier(args)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+		err := notifier.ProcessAlarmResponse(ctx, response)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, 1, len(homeserver.receivedMessages()))
+	})
+}
+
+func transactionID(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
@@ -0,0 +1,17 @@
+package ntfy
+
+// publishRequest is the JSON publishing request body
+type publishRequest struct {
+	Topic    string   `json:"topic"`
+	Title    string   `json:"title"`
+	Message  string   `json:"message"`
+	Priority int      `json:"priority"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// errorResponse is the body of the failed requests
+type errorResponse struct {
+	Code  int    `json:"code"`
+	Http  int    `json:"http"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,9 @@
+package ntfy
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errEmptyTopic = errors.New("empty topic")
+var errInvalidPriority = errors.New("invalid priority")
+var errAmbiguousAuth = errors.New("both token and username provided")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
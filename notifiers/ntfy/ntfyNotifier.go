@@ -0,0 +1,239 @@
+package ntfy
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+const (
+	// DefaultServerUrl is the public ntfy server
+	DefaultServerUrl = "https://ntfy.sh"
+
+	minPriority          = 1
+	maxPriority          = 5
+	defaultErrorPriority = 5
+	defaultInfoPriority  = 3
+	maxMessageLength     = 4000
+	maxErrorBodyLength   = 512
+	authorizationHeader  = "Authorization"
+)
+
+// the emoji short codes displayed by the ntfy clients in front of the title
+var levelTags = map[data.EventLevel]string{
+	data.Error: "rotating_light",
+	data.Info:  "information_source",
+}
+
+// ArgsNtfyNotifier represents the arguments DTO for the ntfyNotifier constructor. The priorities are on the ntfy
+// 1 (min) to 5 (max) scale, 0 meaning the default priority of the level. The renderer is optional, the built-in
+// title and message being used without it
+type ArgsNtfyNotifier struct {
+	HTTPClient    HTTPClient
+	ServerUrl     string
+	Topic         string
+	Token         string
+	Username      string
+	Password      string
+	ErrorPriority int
+	InfoPriority  int
+	Tags          []string
+	Renderer      templating.MessageRenderer
+}
+
+type ntfyNotifier struct {
+	httpClient    HTTPClient
+	serverUrl     string
+	topic         string
+	authorization string
+	priorities    map[data.EventLevel]int
+	tags          []string
+	renderer      templating.MessageRenderer
+}
+
+// NewNtfyNotifier creates a new notifier that publishes the alarm responses on a ntfy topic. An empty server URL
+// means the public ntfy.sh server. The server can be accessed either with an access token or with a username and
+// password
+func NewNtfyNotifier(args ArgsNtfyNotifier) (*ntfyNotifier, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(args.Topic) == 0 {
+		return nil, errEmptyTopic
+	}
+	if len(args.Token) > 0 && len(args.Username) > 0 {
+		return nil, errAmbiguousAuth
+	}
+
+	errorPriority, err := checkPriority("ErrorPriority", args.ErrorPriority, defaultErrorPriority)
+	if err != nil {
+		return nil, err
+	}
+	infoPriority, err := checkPriority("InfoPriority", args.InfoPriority, defaultInfoPriority)
+	if err != nil {
+		return nil, err
+	}
+
+	notifier := &ntfyNotifier{
+		httpClient: args.HTTPClient,
+		serverUrl:  strings.TrimSuffix(args.ServerUrl, "/"),
+		topic:      args.Topic,
+		priorities: map[data.EventLevel]int{
+			data.Error: errorPriority,
+			data.Info:  infoPriority,
+		},
+		tags:     append(make([]string, 0, len(args.Tags)), args.Tags...),
+		renderer: args.Renderer,
+	}
+	if len(notifier.serverUrl) == 0 {
+		notifier.serverUrl = DefaultServerUrl
+	}
+	if len(args.Token) > 0 {
+		notifier.authorization = "Bearer " + args.Token
+	}
+	if len(args.Username) > 0 {
+		credentials := base64.StdEncoding.EncodeToString([]byte(args.Username + ":" + args.Password))
+		notifier.authorization = "Basic " + credentials
+	}
+
+	return notifier, nil
+}
+
+func checkPriority(name string, priority int, defaultPriority int) (int, error) {
+	if priority == 0 {
+		return defaultPriority, nil
+	}
+	if priority < minPriority || priority > maxPriority {
+		return 0, fmt.Errorf("%w for %s, provided: %d, allowed: %d-%d", errInvalidPriority, name, priority, minPriority, maxPriority)
+	}
+
+	return priority, nil
+}
+
+// ProcessAlarmResponse will publish the alarm response on the topic with the priority of its level. The responses
+// without an event are ignored
+func (notifier *ntfyNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	publish, err := notifier.createPublishRequest(response)
+	if err != nil {
+		return err
+	}
+
+	buff, err := json.Marshal(publish)
+	if err != nil {
+		return err
+	}
+
+	request := httpWrapper.Request{
+		Method: http.MethodPost,
+		Url:    notifier.serverUrl,
+		Body:   buff,
+	}
+	if len(notifier.authorization) > 0 {
+		request.Headers = map[string]string{authorizationHeader: notifier.authorization}
+	}
+
+	result, err := notifier.httpClient.CallEndPoint(ctx, request)
+	if err != nil {
+		return err
+	}
+	if result.StatusCode < http.StatusOK || result.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, result.StatusCode, describeErrorResponse(result.Body))
+	}
+
+	return nil
+}
+
+func (notifier *ntfyNotifier) createPublishRequest(response data.AlarmResponse) (publishRequest, error) {
+	title, message, err := notifier.createTexts(response)
+	if err != nil {
+		return publishRequest{}, err
+	}
+	if len(message) == 0 {
+		// ntfy replaces an empty message with "triggered"
+		message = response.Identifier
+	}
+
+	priority, found := notifier.priorities[response.Level]
+	if !found {
+		priority = defaultInfoPriority
+	}
+
+	tags := make([]string, 0, len(notifier.tags)+1)
+	levelTag, found := levelTags[response.Level]
+	if found {
+		tags = append(tags, levelTag)
+	}
+	tags = append(tags, notifier.tags...)
+
+	return publishRequest{
+		Topic:    notifier.topic,
+		Title:    title,
+		Message:  formatting.Truncate(message, maxMessageLength),
+		Priority: priority,
+		Tags:     tags,
+	}, nil
+}
+
+// createTexts returns the title and the message of the notification, rendered from the templates if a renderer
+// was provided
+func (notifier *ntfyNotifier) createTexts(response data.AlarmResponse) (string, string, error) {
+	if check.IfNil(notifier.renderer) {
+		return strings.ToUpper(string(response.Level)) + " | " + response.Identifier, createMessage(response), nil
+	}
+
+	message, err := notifier.renderer.Render(response)
+	if err != nil {
+		return "", "", err
+	}
+
+	return message.Title, message.Body, nil
+}
+
+func createMessage(response data.AlarmResponse) string {
+	labels := common.DisplayLabels(response.Labels)
+	lines := make([]string, 0, len(labels)+2)
+	for _, key := range common.SortedLabelKeys(labels) {
+		lines = append(lines, key+": "+labels[key])
+	}
+	if len(lines) > 0 && len(response.Data) > 0 {
+		lines = append(lines, "")
+	}
+	if len(response.Data) > 0 {
+		lines = append(lines, response.Data)
+	}
+	if len(lines) == 0 {
+		// ntfy replaces an empty message with "triggered"
+		lines = append(lines, response.Identifier)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func describeErrorResponse(body []byte) string {
+	response := errorResponse{}
+	err := json.Unmarshal(body, &response)
+	if err != nil || len(response.Error) == 0 {
+		return formatting.Truncate(string(body), maxErrorBodyLength)
+	}
+
+	return formatting.Truncate(response.Error, maxErrorBodyLength)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *ntfyNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
@@ -0,0 +1,261 @@
+package ntfy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedPublish struct {
+	path          string
+	authorization string
+	request       publishRequest
+}
+
+func createMockArgsNtfyNotifier(serverUrl string) ArgsNtfyNotifier {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second * 5)
+
+	return ArgsNtfyNotifier{
+		HTTPClient: httpClient,
+		ServerUrl:  serverUrl,
+		Topic:      "node-alerts",
+	}
+}
+
+func createFakeServer(statusCode int, received *[]receivedPublish) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buff, _ := ioutil.ReadAll(r.Body)
+		request := publishRequest{}
+		_ = json.Unmarshal(buff, &request)
+		*received = append(*received, receivedPublish{
+			path:          r.URL.Path,
+			authorization: r.Header.Get("Authorization"),
+			request:       request,
+		})
+
+		w.WriteHeader(statusCode)
+		if statusCode != http.StatusOK {
+			_, _ = w.Write([]byte(`{"code":40301,"http":403,"error":"forbidden","link":"https://ntfy.sh/docs/publish/#authentication"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"msg","event":"message","topic":"node-alerts"}`))
+	}))
+}
+
+func TestNewNtfyNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNtfyNotifier("")
+		args.HTTPClient = nil
+		notifier, err := NewNtfyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty topic should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNtfyNotifier("")
+		args.Topic = ""
+		notifier, err := NewNtfyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyTopic, err)
+	})
+	t.Run("token and username should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNtfyNotifier("")
+		args.Token = "tk_token"
+		args.Username = "user"
+		notifier, err := NewNtfyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errAmbiguousAuth, err)
+	})
+	t.Run("invalid priorities should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNtfyNotifier("")
+		args.ErrorPriority = 6
+		notifier, err := NewNtfyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidPriority))
+		assert.True(t, strings.Contains(err.Error(), "ErrorPriority"))
+
+		args = createMockArgsNtfyNotifier("")
+		args.InfoPriority = -1
+		notifier, err = NewNtfyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidPriority))
+		assert.True(t, strings.Contains(err.Error(), "InfoPriority"))
+	})
+	t.Run("should work with defaults", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, err := NewNtfyNotifier(createMockArgsNtfyNotifier(""))
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, DefaultServerUrl, notifier.serverUrl)
+		assert.Equal(t, map[data.EventLevel]int{data.Error: 5, data.Info: 3}, notifier.priorities)
+		assert.Empty(t, notifier.authorization)
+	})
+	t.Run("should work with authentication", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNtfyNotifier("https://ntfy.local/")
+		args.Token = "tk_token"
+		notifier, err := NewNtfyNotifier(args)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://ntfy.local", notifier.serverUrl)
+		assert.Equal(t, "Bearer tk_token", notifier.authorization)
+
+		args = createMockArgsNtfyNotifier("")
+		args.Username = "user"
+		args.Password = "pass"
+		notifier, err = NewNtfyNotifier(args)
+		assert.Nil(t, err)
+		assert.Equal(t, "Basic dXNlcjpwYXNz", notifier.authorization)
+	})
+}
+
+func TestNtfyNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no event should not publish", func(t *testing.T) {
+		t.Parallel()
+
+		received := make([]receivedPublish, 0)
+		server := createFakeServer(http.StatusOK, &received)
+		defer server.Close()
+		notifier, _ := NewNtfyNotifier(createMockArgsNtfyNotifier(server.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.NoEvent})
+		assert.Nil(t, err)
+		assert.Empty(t, received)
+	})
+	t.Run("should publish with the level priority", func(t *testing.T) {
+		t.Parallel()
+
+		received := make([]receivedPublish, 0)
+		server := createFakeServer(http.StatusOK, &received)
+		defer server.Close()
+		args := createMockArgsNtfyNotifier(server.URL)
+		args.Token = "tk_token"
+		args.ErrorPriority = 4
+		args.Tags = []string{"validator"}
+		notifier, _ := NewNtfyNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{
+			Identifier: "rating",
+			Level:      data.Error,
+			Data:       "rating dropped",
+			Labels:     map[string]string{"shard": "1"},
+		})
+		assert.Nil(t, err)
+		err = notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{
+			Identifier: "nonce",
+			Level:      data.Info,
+		})
+		assert.Nil(t, err)
+
+		require.Equal(t, 2, len(received))
+		assert.Equal(t, "/", received[0].path)
+		assert.Equal(t, "Bearer tk_token", received[0].authorization)
+		assert.Equal(t, publishRequest{
+			Topic:    "node-alerts",
+			Title:    "ERROR | rating",
+			Message:  "shard: 1\n\nrating dropped",
+			Priority: 4,
+			Tags:     []string{"rotating_light", "validator"},
+		}, received[0].request)
+		assert.Equal(t, publishRequest{
+			Topic:    "node-alerts",
+			Title:    "INFO | nonce",
+			Message:  "nonce",
+			Priority: 3,
+			Tags:     []string{"information_source", "validator"},
+		}, received[1].request)
+	})
+	t.Run("should publish the rendered texts if a renderer was provided", func(t *testing.T) {
+		t.Parallel()
+
+		received := make([]receivedPublish, 0)
+		server := createFakeServer(http.StatusOK, &received)
+		defer server.Close()
+		args := createMockArgsNtfyNotifier(server.URL)
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				if response.Level == data.Info {
+					return templating.Message{Title: "empty body"}, nil
+				}
+				return templating.Message{Title: "title " + response.Identifier, Body: "body"}, nil
+			},
+		}
+		notifier, _ := NewNtfyNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Error})
+		assert.Nil(t, err)
+		err = notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "nonce", Level: data.Info})
+		assert.Nil(t, err)
+
+		require.Equal(t, 2, len(received))
+		assert.Equal(t, "title rating", received[0].request.Title)
+		assert.Equal(t, "body", received[0].request.Message)
+		assert.Equal(t, "empty body", received[1].request.Title)
+		assert.Equal(t, "nonce", received[1].request.Message)
+	})
+	t.Run("render error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		received := make([]receivedPublish, 0)
+		server := createFakeServer(http.StatusOK, &received)
+		defer server.Close()
+		args := createMockArgsNtfyNotifier(server.URL)
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{}, expectedErr
+			},
+		}
+		notifier, _ := NewNtfyNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Error})
+		assert.Equal(t, expectedErr, err)
+		assert.Empty(t, received)
+	})
+	t.Run("server errors should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		received := make([]receivedPublish, 0)
+		server := createFakeServer(http.StatusForbidden, &received)
+		defer server.Close()
+		notifier, _ := NewNtfyNotifier(createMockArgsNtfyNotifier(server.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Error})
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.Equal(t, "unexpected status code 403: forbidden", err.Error())
+	})
+}
+
+func TestCreateMessage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "id", createMessage(data.AlarmResponse{Identifier: "id"}))
+	assert.Equal(t, "data", createMessage(data.AlarmResponse{Identifier: "id", Data: "data"}))
+	assert.Equal(t, "a: 1\nb: 2", createMessage(data.AlarmResponse{Identifier: "id", Labels: map[string]string{"b": "2", "a": "1"}}))
+}
@@ -0,0 +1,85 @@
+package opsgenie
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+)
+
+const (
+	aliasPrefix          = "node-monitoring:"
+	maxMessageLength     = 130
+	maxAliasLength       = 512
+	maxDescriptionLength = 15000
+	maxTagLength         = 50
+	maxTags              = 20
+	maxDetailKeyLength   = 8000
+	maxDetailValueLength = 8000
+)
+
+// createAlias returns the deduplication alias of the alarm's alert for the provided level. Each level has its own
+// alias so a level change creates a new alert with the matching priority instead of updating the existing one
+func createAlias(identifier string, level data.EventLevel) string {
+	return formatting.Truncate(aliasPrefix+strings.ToLower(string(level))+":"+identifier, maxAliasLength)
+}
+
+func createMessage(response data.AlarmResponse) string {
+	message := response.Identifier
+	detail := strings.TrimSpace(strings.SplitN(strings.TrimSpace(response.Data), "\n", 2)[0])
+	if len(detail) > 0 {
+		message += ": " + detail
+	}
+
+	return formatting.Truncate(message, maxMessageLength)
+}
+
+// createTags converts the labels into "key:value" tags, in a stable order, within the Opsgenie limits
+func createTags(labels map[string]string) []string {
+	keys := common.SortedLabelKeys(labels)
+	if len(keys) > maxTags {
+		keys = keys[:maxTags]
+	}
+
+	tags := make([]string, 0, len(keys))
+	for _, key := range keys {
+		tag := key
+		if len(labels[key]) > 0 {
+			tag += ":" + labels[key]
+		}
+		tags = append(tags, formatting.Truncate(tag, maxTagLength))
+	}
+
+	return tags
+}
+
+// createDetails returns the alert's custom properties: the labels and the metrics
+func createDetails(response data.AlarmResponse) map[string]string {
+	details := make(map[string]string, len(response.Labels)+len(response.Metrics))
+	for key, value := range response.Labels {
+		details[formatting.Truncate(key, maxDetailKeyLength)] = formatting.Truncate(value, maxDetailValueLength)
+	}
+	for key, value := range response.Metrics {
+		details[formatting.Truncate(key, maxDetailKeyLength)] = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	if len(details) == 0 {
+		return nil
+	}
+
+	return details
+}
+
+func createAlertFromResponse(response data.AlarmResponse, priority string, source string) createAlertRequest {
+	return createAlertRequest{
+		Message:     createMessage(response),
+		Alias:       createAlias(response.Identifier, response.Level),
+		Description: formatting.Truncate(response.Data, maxDescriptionLength),
+		Tags:        createTags(response.Labels),
+		Details:     createDetails(response),
+		Entity:      response.Identifier,
+		Source:      source,
+		Priority:    priority,
+	}
+}
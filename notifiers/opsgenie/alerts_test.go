@@ -0,0 +1,56 @@
+package opsgenie
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAlias(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "node-monitoring:error:rating", createAlias("rating", data.Error))
+	assert.Equal(t, "node-monitoring:info:rating", createAlias("rating", data.Info))
+	assert.Equal(t, maxAliasLength, len([]rune(createAlias(strings.Repeat("a", 600), data.Error))))
+}
+
+func TestCreateMessage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "rating", createMessage(data.AlarmResponse{Identifier: "rating"}))
+	assert.Equal(t, "rating: first line", createMessage(data.AlarmResponse{Identifier: "rating", Data: "\n first line \nsecond"}))
+	message := createMessage(data.AlarmResponse{Identifier: "rating", Data: strings.Repeat("a", 200)})
+	assert.Equal(t, maxMessageLength, len([]rune(message)))
+}
+
+func TestCreateTags(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, createTags(nil))
+	tags := createTags(map[string]string{
+		"shard":   "1",
+		"network": "mainnet",
+		"primary": "",
+		"long":    strings.Repeat("v", 100),
+	})
+	assert.Equal(t, []string{"long:" + strings.Repeat("v", 44) + "…", "network:mainnet", "primary", "shard:1"}, tags)
+
+	labels := make(map[string]string)
+	for i := 0; i < 30; i++ {
+		labels[string(rune('a'+i))] = "x"
+	}
+	assert.Equal(t, maxTags, len(createTags(labels)))
+}
+
+func TestCreateDetails(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, createDetails(data.AlarmResponse{}))
+	details := createDetails(data.AlarmResponse{
+		Labels:  map[string]string{"shard": "1"},
+		Metrics: map[string]float64{"rating": 89.5},
+	})
+	assert.Equal(t, map[string]string{"shard": "1", "rating": "89.5"}, details)
+}
@@ -0,0 +1,26 @@
+package opsgenie
+
+// createAlertRequest is the Alert API create request body
+type createAlertRequest struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Priority    string            `json:"priority"`
+}
+
+// closeAlertRequest is the Alert API close request body
+type closeAlertRequest struct {
+	Source string `json:"source,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// apiResponse is the Alert API response body
+type apiResponse struct {
+	Result    string `json:"result"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId"`
+}
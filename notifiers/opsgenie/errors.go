@@ -0,0 +1,9 @@
+package opsgenie
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errEmptyApiKey = errors.New("empty API key")
+var errUnknownRegion = errors.New("unknown region")
+var errInvalidPriority = errors.New("invalid priority")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
@@ -0,0 +1,278 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+var log = logger.GetOrCreate("notifiers/opsgenie")
+
+const (
+	// USRegion is the default Opsgenie region
+	USRegion = "us"
+	// EURegion is the Opsgenie region for the accounts hosted in Europe
+	EURegion = "eu"
+
+	alertsPath           = "/v2/alerts"
+	authorizationHeader  = "Authorization"
+	authorizationPrefix  = "GenieKey "
+	source               = "node-monitoring"
+	closeNote            = "The alarm recovered"
+	defaultErrorPriority = "P1"
+	defaultInfoPriority  = "P5"
+	maxErrorBodyLength   = 512
+)
+
+var regionUrls = map[string]string{
+	USRegion: "https://api.opsgenie.com",
+	EURegion: "https://api.eu.opsgenie.com",
+}
+
+var priorities = map[string]struct{}{
+	"P1": {},
+	"P2": {},
+	"P3": {},
+	"P4": {},
+	"P5": {},
+}
+
+// the levels that create alerts, a response of any level closes the alerts of the other levels
+var alertLevels = []data.EventLevel{data.Error, data.Info}
+
+// ArgsOpsgenieNotifier represents the arguments DTO for the opsgenieNotifier constructor. The renderer is optional,
+// the alerts' message and description being built from the alarm response without it
+type ArgsOpsgenieNotifier struct {
+	HTTPClient     HTTPClient
+	ApiKey         string
+	Region         string
+	ApiUrl         string
+	ErrorPriority  string
+	InfoPriority   string
+	CloseOnStartup bool
+	Renderer       templating.MessageRenderer
+}
+
+type opsgenieNotifier struct {
+	httpClient HTTPClient
+	apiKey     string
+	alertsUrl  string
+	priorities map[data.EventLevel]string
+	renderer   templating.MessageRenderer
+	// closeOnStartup closes, on the first response of each alarm, the alerts possibly left open by a previous run
+	closeOnStartup bool
+
+	mutStates sync.Mutex
+	// the level of the open alert for each alarm, data.NoEvent meaning no open alert and a missing entry meaning
+	// the alert state is unknown (e.g. alerts left open by a previous run)
+	states map[string]data.EventLevel
+}
+
+// NewOpsgenieNotifier creates a new notifier that creates Opsgenie alerts for the alarm responses and closes them
+// once the alarms recover. The ApiUrl, if set, overrides the regional endpoint. Only the alerts created by this
+// notifier are closed unless CloseOnStartup is set
+func NewOpsgenieNotifier(args ArgsOpsgenieNotifier) (*opsgenieNotifier, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(args.ApiKey) == 0 {
+		return nil, errEmptyApiKey
+	}
+
+	apiUrl, err := resolveApiUrl(args.Region, args.ApiUrl)
+	if err != nil {
+		return nil, err
+	}
+	errorPriority, err := checkPriority(args.ErrorPriority, defaultErrorPriority)
+	if err != nil {
+		return nil, err
+	}
+	infoPriority, err := checkPriority(args.InfoPriority, defaultInfoPriority)
+	if err != nil {
+		return nil, err
+	}
+
+	return &opsgenieNotifier{
+		httpClient: args.HTTPClient,
+		apiKey:     args.ApiKey,
+		alertsUrl:  apiUrl + alertsPath,
+		priorities: map[data.EventLevel]string{
+			data.Error: errorPriority,
+			data.Info:  infoPriority,
+		},
+		renderer:       args.Renderer,
+		closeOnStartup: args.CloseOnStartup,
+		states:         make(map[string]data.EventLevel),
+	}, nil
+}
+
+func resolveApiUrl(region string, apiUrl string) (string, error) {
+	if len(apiUrl) > 0 {
+		return strings.TrimSuffix(apiUrl, "/"), nil
+	}
+
+	region = strings.ToLower(region)
+	if len(region) == 0 {
+		region = USRegion
+	}
+	regionUrl, found := regionUrls[region]
+	if !found {
+		return "", fmt.Errorf("%w: %s", errUnknownRegion, region)
+	}
+
+	return regionUrl, nil
+}
+
+func checkPriority(priority string, defaultPriority string) (string, error) {
+	if len(priority) == 0 {
+		return defaultPriority, nil
+	}
+
+	priority = strings.ToUpper(priority)
+	_, found := priorities[priority]
+	if !found {
+		return "", fmt.Errorf("%w: %s", errInvalidPriority, priority)
+	}
+
+	return priority, nil
+}
+
+// ProcessAlarmResponse will keep at most one open alert for each alarm, matching the alarm's level: the alerts of
+// the other levels are closed and, for the Error and Info levels, an alert is created. The info reports are not sent
+// as they are not actionable
+func (notifier *opsgenieNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Identifier == data.SystemIdentifier {
+		return nil
+	}
+
+	openLevel, known := notifier.getState(response.Identifier)
+	if !known && !notifier.closeOnStartup {
+		openLevel, known = data.NoEvent, true
+	}
+	if known && openLevel == response.Level {
+		return nil
+	}
+
+	for _, level := range alertLevels {
+		shouldClose := level != response.Level && (!known || level == openLevel)
+		if !shouldClose {
+			continue
+		}
+
+		err := notifier.closeAlert(ctx, createAlias(response.Identifier, level))
+		if err != nil {
+			return err
+		}
+	}
+	notifier.setState(response.Identifier, data.NoEvent)
+
+	priority, shouldCreate := notifier.priorities[response.Level]
+	if !shouldCreate {
+		return nil
+	}
+
+	alert, err := notifier.createAlert(response, priority)
+	if err != nil {
+		return err
+	}
+
+	err = notifier.call(ctx, notifier.alertsUrl, alert)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Opsgenie alert created", "identifier", response.Identifier, "priority", priority)
+	notifier.setState(response.Identifier, response.Level)
+
+	return nil
+}
+
+// createAlert uses the rendered title and body, if templates are configured, as the alert's message and description
+func (notifier *opsgenieNotifier) createAlert(response data.AlarmResponse, priority string) (createAlertRequest, error) {
+	alert := createAlertFromResponse(response, priority, source)
+	if check.IfNil(notifier.renderer) {
+		return alert, nil
+	}
+
+	message, err := notifier.renderer.Render(response)
+	if err != nil {
+		return createAlertRequest{}, err
+	}
+	alert.Message = formatting.Truncate(message.Title, maxMessageLength)
+	alert.Description = formatting.Truncate(message.Body, maxDescriptionLength)
+
+	return alert, nil
+}
+
+func (notifier *opsgenieNotifier) closeAlert(ctx context.Context, alias string) error {
+	closeUrl := fmt.Sprintf("%s/%s/close?identifierType=alias", notifier.alertsUrl, url.PathEscape(alias))
+	request := closeAlertRequest{
+		Source: source,
+		Note:   closeNote,
+	}
+
+	return notifier.call(ctx, closeUrl, request)
+}
+
+func (notifier *opsgenieNotifier) call(ctx context.Context, endpoint string, body interface{}) error {
+	buff, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	response, err := notifier.httpClient.CallEndPoint(ctx, httpWrapper.Request{
+		Method:  http.MethodPost,
+		Url:     endpoint,
+		Headers: map[string]string{authorizationHeader: authorizationPrefix + notifier.apiKey},
+		Body:    buff,
+	})
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, response.StatusCode, describeErrorResponse(response.Body))
+	}
+
+	return nil
+}
+
+func (notifier *opsgenieNotifier) getState(identifier string) (data.EventLevel, bool) {
+	notifier.mutStates.Lock()
+	defer notifier.mutStates.Unlock()
+
+	level, found := notifier.states[identifier]
+
+	return level, found
+}
+
+func (notifier *opsgenieNotifier) setState(identifier string, level data.EventLevel) {
+	notifier.mutStates.Lock()
+	notifier.states[identifier] = level
+	notifier.mutStates.Unlock()
+}
+
+func describeErrorResponse(body []byte) string {
+	response := apiResponse{}
+	err := json.Unmarshal(body, &response)
+	if err != nil || len(response.Message) == 0 {
+		return formatting.Truncate(string(body), maxErrorBodyLength)
+	}
+
+	return formatting.Truncate(response.Message, maxErrorBodyLength)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *opsgenieNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
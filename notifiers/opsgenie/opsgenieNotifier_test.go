@@ -0,0 +1,343 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testApiKey = "team-api-key"
+
+type receivedCall struct {
+	path          string
+	authorization string
+	body          string
+}
+
+// fakeAlertApi records the received calls and answers as the Opsgenie Alert API, the queued status codes being
+// used first
+type fakeAlertApi struct {
+	*httptest.Server
+	mut         sync.Mutex
+	calls       []receivedCall
+	statusCodes []int
+}
+
+func newFakeAlertApi() *fakeAlertApi {
+	api := &fakeAlertApi{}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buff, _ := ioutil.ReadAll(r.Body)
+
+		api.mut.Lock()
+		statusCode := http.StatusAccepted
+		if len(api.statusCodes) > 0 {
+			statusCode = api.statusCodes[0]
+			api.statusCodes = api.statusCodes[1:]
+		}
+		api.calls = append(api.calls, receivedCall{
+			path:          r.URL.RequestURI(),
+			authorization: r.Header.Get("Authorization"),
+			body:          string(buff),
+		})
+		api.mut.Unlock()
+
+		w.WriteHeader(statusCode)
+		if statusCode != http.StatusAccepted {
+			_, _ = w.Write([]byte(`{"message":"Key format is not valid!","took":0.001,"requestId":"id"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"result":"Request will be processed","took":0.1,"requestId":"id"}`))
+	}))
+
+	return api
+}
+
+func (api *fakeAlertApi) receivedCalls() []receivedCall {
+	api.mut.Lock()
+	defer api.mut.Unlock()
+
+	return append(make([]receivedCall, 0, len(api.calls)), api.calls...)
+}
+
+func (api *fakeAlertApi) queueStatusCodes(statusCodes ...int) {
+	api.mut.Lock()
+	api.statusCodes = append(api.statusCodes, statusCodes...)
+	api.mut.Unlock()
+}
+
+func createMockArgsOpsgenieNotifier(apiUrl string) ArgsOpsgenieNotifier {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second * 5)
+
+	return ArgsOpsgenieNotifier{
+		HTTPClient: httpClient,
+		ApiKey:     testApiKey,
+		ApiUrl:     apiUrl,
+	}
+}
+
+func TestNewOpsgenieNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsOpsgenieNotifier("")
+		args.HTTPClient = nil
+		notifier, err := NewOpsgenieNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty API key should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsOpsgenieNotifier("")
+		args.ApiKey = ""
+		notifier, err := NewOpsgenieNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyApiKey, err)
+	})
+	t.Run("unknown region should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsOpsgenieNotifier("")
+		args.Region = "asia"
+		notifier, err := NewOpsgenieNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errUnknownRegion))
+	})
+	t.Run("invalid priorities should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsOpsgenieNotifier("")
+		args.ErrorPriority = "P0"
+		notifier, err := NewOpsgenieNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidPriority))
+
+		args = createMockArgsOpsgenieNotifier("")
+		args.InfoPriority = "high"
+		notifier, err = NewOpsgenieNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidPriority))
+	})
+	t.Run("regions should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsOpsgenieNotifier("")
+		notifier, err := NewOpsgenieNotifier(args)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://api.opsgenie.com/v2/alerts", notifier.alertsUrl)
+		assert.Equal(t, map[data.EventLevel]string{data.Error: "P1", data.Info: "P5"}, notifier.priorities)
+
+		args.Region = "EU"
+		args.ErrorPriority = "p2"
+		args.InfoPriority = "P4"
+		notifier, err = NewOpsgenieNotifier(args)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://api.eu.opsgenie.com/v2/alerts", notifier.alertsUrl)
+		assert.Equal(t, map[data.EventLevel]string{data.Error: "P2", data.Info: "P4"}, notifier.priorities)
+	})
+	t.Run("API URL should override the region", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsOpsgenieNotifier("http://localhost:8080/")
+		args.Region = "eu"
+		notifier, err := NewOpsgenieNotifier(args)
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, "http://localhost:8080/v2/alerts", notifier.alertsUrl)
+	})
+}
+
+func TestOpsgenieNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create and close lifecycle", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeAlertApi()
+		defer api.Close()
+		notifier, _ := NewOpsgenieNotifier(createMockArgsOpsgenieNotifier(api.URL))
+
+		errorResponse := data.AlarmResponse{
+			Identifier: "node rating",
+			Level:      data.Error,
+			Data:       "rating dropped below 90",
+			Labels:     map[string]string{"shard": "1"},
+			Metrics:    map[string]float64{"rating": 89.5},
+		}
+		// the healthy responses do not close the alerts this notifier did not create
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "node rating", Level: data.NoEvent}))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), errorResponse))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), errorResponse))
+		// recovery: the error alert is closed
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "node rating", Level: data.NoEvent}))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "node rating", Level: data.NoEvent}))
+
+		calls := api.receivedCalls()
+		require.Equal(t, 2, len(calls))
+		for _, call := range calls {
+			assert.Equal(t, "GenieKey "+testApiKey, call.authorization)
+		}
+		assert.Equal(t, "/v2/alerts", calls[0].path)
+		assert.Equal(t, "/v2/alerts/node-monitoring:error:node%20rating/close?identifierType=alias", calls[1].path)
+		assert.Equal(t, `{"source":"node-monitoring","note":"The alarm recovered"}`, calls[1].body)
+
+		alert := createAlertRequest{}
+		require.Nil(t, json.Unmarshal([]byte(calls[0].body), &alert))
+		assert.Equal(t, createAlertRequest{
+			Message:     "node rating: rating dropped below 90",
+			Alias:       "node-monitoring:error:node rating",
+			Description: "rating dropped below 90",
+			Tags:        []string{"shard:1"},
+			Details:     map[string]string{"shard": "1", "rating": "89.5"},
+			Entity:      "node rating",
+			Source:      "node-monitoring",
+			Priority:    "P1",
+		}, alert)
+	})
+	t.Run("close on startup should close the alerts left open by a previous run", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeAlertApi()
+		defer api.Close()
+		args := createMockArgsOpsgenieNotifier(api.URL)
+		args.CloseOnStartup = true
+		notifier, _ := NewOpsgenieNotifier(args)
+
+		// unknown state: the alerts of both levels possibly left open are closed
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.NoEvent}))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.NoEvent}))
+		// unknown state: the info alert possibly left open is closed and the error alert is created
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "nonce", Level: data.Error}))
+
+		calls := api.receivedCalls()
+		require.Equal(t, 4, len(calls))
+		assert.Equal(t, "/v2/alerts/node-monitoring:error:rating/close?identifierType=alias", calls[0].path)
+		assert.Equal(t, "/v2/alerts/node-monitoring:info:rating/close?identifierType=alias", calls[1].path)
+		assert.Equal(t, "/v2/alerts/node-monitoring:info:nonce/close?identifierType=alias", calls[2].path)
+		assert.Equal(t, "/v2/alerts", calls[3].path)
+	})
+	t.Run("rendered message should be used", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeAlertApi()
+		defer api.Close()
+		args := createMockArgsOpsgenieNotifier(api.URL)
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{Title: "rendered title", Body: "rendered body"}, nil
+			},
+		}
+		notifier, _ := NewOpsgenieNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "node rating", Level: data.Error})
+		assert.Nil(t, err)
+
+		calls := api.receivedCalls()
+		require.Equal(t, 1, len(calls))
+		alert := createAlertRequest{}
+		require.Nil(t, json.Unmarshal([]byte(calls[0].body), &alert))
+		assert.Equal(t, "rendered title", alert.Message)
+		assert.Equal(t, "rendered body", alert.Description)
+		assert.Equal(t, "node-monitoring:error:node rating", alert.Alias)
+	})
+	t.Run("render error should not create the alert", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		api := newFakeAlertApi()
+		defer api.Close()
+		args := createMockArgsOpsgenieNotifier(api.URL)
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{}, expectedErr
+			},
+		}
+		notifier, _ := NewOpsgenieNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "node rating", Level: data.Error})
+		assert.Equal(t, expectedErr, err)
+		assert.Empty(t, api.receivedCalls())
+	})
+	t.Run("level change should replace the alert", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeAlertApi()
+		defer api.Close()
+		notifier, _ := NewOpsgenieNotifier(createMockArgsOpsgenieNotifier(api.URL))
+
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "nonce", Level: data.Info}))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "nonce", Level: data.Error}))
+
+		calls := api.receivedCalls()
+		require.Equal(t, 3, len(calls))
+		assert.Equal(t, "/v2/alerts", calls[0].path)
+		assert.True(t, strings.Contains(calls[0].body, `"priority":"P5"`))
+		assert.Equal(t, "/v2/alerts/node-monitoring:info:nonce/close?identifierType=alias", calls[1].path)
+		assert.Equal(t, "/v2/alerts", calls[2].path)
+		assert.True(t, strings.Contains(calls[2].body, `"priority":"P1"`))
+	})
+	t.Run("info reports should be ignored", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeAlertApi()
+		defer api.Close()
+		notifier, _ := NewOpsgenieNotifier(createMockArgsOpsgenieNotifier(api.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: data.SystemIdentifier, Level: data.Error})
+		assert.Nil(t, err)
+		assert.Empty(t, api.receivedCalls())
+	})
+	t.Run("failed create should be retried", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeAlertApi()
+		defer api.Close()
+		api.queueStatusCodes(http.StatusUnprocessableEntity)
+		notifier, _ := NewOpsgenieNotifier(createMockArgsOpsgenieNotifier(api.URL))
+
+		response := data.AlarmResponse{Identifier: "nonce", Level: data.Error}
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.True(t, strings.Contains(err.Error(), "422: Key format is not valid!"))
+
+		err = notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+
+		calls := api.receivedCalls()
+		require.Equal(t, 2, len(calls))
+		assert.Equal(t, "/v2/alerts", calls[0].path)
+		assert.Equal(t, "/v2/alerts", calls[1].path)
+	})
+	t.Run("failed close should error", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeAlertApi()
+		defer api.Close()
+		api.queueStatusCodes(http.StatusUnauthorized)
+		args := createMockArgsOpsgenieNotifier(api.URL)
+		args.CloseOnStartup = true
+		notifier, _ := NewOpsgenieNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "nonce", Level: data.NoEvent})
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		_, known := notifier.getState("nonce")
+		assert.False(t, known)
+	})
+}
@@ -0,0 +1,33 @@
+package pagerduty
+
+// event is the Events API v2 request body
+type event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction string        `json:"event_action"`
+	DedupKey    string        `json:"dedup_key"`
+	Client      string        `json:"client,omitempty"`
+	Payload     *eventPayload `json:"payload,omitempty"`
+}
+
+type eventPayload struct {
+	Summary       string        `json:"summary"`
+	Source        string        `json:"source"`
+	Severity      string        `json:"severity"`
+	Timestamp     string        `json:"timestamp,omitempty"`
+	Component     string        `json:"component,omitempty"`
+	CustomDetails customDetails `json:"custom_details"`
+}
+
+type customDetails struct {
+	Data    string             `json:"data"`
+	Labels  map[string]string  `json:"labels,omitempty"`
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+}
+
+// eventResponse is the Events API v2 response body
+type eventResponse struct {
+	Status   string   `json:"status"`
+	Message  string   `json:"message"`
+	DedupKey string   `json:"dedup_key"`
+	Errors   []string `json:"errors"`
+}
@@ -0,0 +1,8 @@
+package pagerduty
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errEmptyRoutingKey = errors.New("empty routing key")
+var errInvalidSeverity = errors.New("invalid severity")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
@@ -0,0 +1,291 @@
+package pagerduty
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+var log = logger.GetOrCreate("notifiers/pagerduty")
+
+const (
+	// DefaultEventsUrl is the PagerDuty Events API v2 endpoint
+	DefaultEventsUrl = "https://events.pagerduty.com/v2/enqueue"
+
+	triggerAction        = "trigger"
+	resolveAction        = "resolve"
+	clientName           = "node-monitoring"
+	dedupKeyPrefix       = "node-monitoring/"
+	defaultErrorSeverity = "critical"
+	defaultInfoSeverity  = "info"
+	defaultSource        = "node-monitoring"
+	severityLabel        = "severity"
+	maxSummaryLength     = 1024
+	maxDedupKeyLength    = 255
+	maxErrorBodyLength   = 512
+)
+
+var severities = map[string]struct{}{
+	"critical": {},
+	"error":    {},
+	"warning":  {},
+	"info":     {},
+}
+
+// ArgsPagerDutyNotifier represents the arguments DTO for the pagerDutyNotifier constructor
+type ArgsPagerDutyNotifier struct {
+	HTTPClient       HTTPClient
+	EventsUrl        string
+	RoutingKey       string
+	ErrorSeverity    string
+	InfoSeverity     string
+	Source           string
+	ResolveOnStartup bool
+}
+
+type pagerDutyNotifier struct {
+	httpClient       HTTPClient
+	eventsUrl        string
+	routingKey       string
+	severities       map[data.EventLevel]string
+	source           string
+	resolveOnStartup bool
+
+	mutStates sync.Mutex
+	// the level of the open incident for each alarm, data.NoEvent meaning no open incident and a missing entry
+	// meaning the incident state is unknown (e.g. incidents left open by a previous run)
+	states map[string]data.EventLevel
+}
+
+// NewPagerDutyNotifier creates a new notifier that triggers PagerDuty incidents for the alarms in error or reporting
+// info events and resolves them when the alarms recover. An empty events URL uses the public PagerDuty endpoint, the
+// empty severities mean critical for the Error level and info for the Info level and an empty source means the host
+// name. Only the incidents triggered by this notifier are resolved unless ResolveOnStartup is set, in which case the
+// first response of each alarm resolves the incident possibly left open by a previous run
+func NewPagerDutyNotifier(args ArgsPagerDutyNotifier) (*pagerDutyNotifier, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(args.RoutingKey) == 0 {
+		return nil, errEmptyRoutingKey
+	}
+
+	errorSeverity, err := checkSeverity(args.ErrorSeverity, defaultErrorSeverity)
+	if err != nil {
+		return nil, err
+	}
+	infoSeverity, err := checkSeverity(args.InfoSeverity, defaultInfoSeverity)
+	if err != nil {
+		return nil, err
+	}
+
+	notifier := &pagerDutyNotifier{
+		httpClient: args.HTTPClient,
+		eventsUrl:  args.EventsUrl,
+		routingKey: args.RoutingKey,
+		severities: map[data.EventLevel]string{
+			data.Error: errorSeverity,
+			data.Info:  infoSeverity,
+		},
+		source:           args.Source,
+		resolveOnStartup: args.ResolveOnStartup,
+		states:           make(map[string]data.EventLevel),
+	}
+	if len(notifier.eventsUrl) == 0 {
+		notifier.eventsUrl = DefaultEventsUrl
+	}
+	if len(notifier.source) == 0 {
+		notifier.source = hostName()
+	}
+
+	return notifier, nil
+}
+
+func checkSeverity(severity string, defaultSeverity string) (string, error) {
+	if len(severity) == 0 {
+		return defaultSeverity, nil
+	}
+
+	severity = strings.ToLower(severity)
+	if !isValidSeverity(severity) {
+		return "", fmt.Errorf("%w: %s", errInvalidSeverity, severity)
+	}
+
+	return severity, nil
+}
+
+// ProcessAlarmResponse will keep at most one open incident for each alarm, matching the alarm's level: an incident
+// is triggered with the level's severity when an alarm enters the Error or Info level and resolved once the alarm
+// reports any other level. The info reports are not sent as they are not actionable
+func (notifier *pagerDutyNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Identifier == data.SystemIdentifier {
+		return nil
+	}
+
+	openLevel, known := notifier.getState(response.Identifier)
+	if !known && !notifier.resolveOnStartup {
+		openLevel, known = data.NoEvent, true
+	}
+	if known && openLevel == response.Level {
+		return nil
+	}
+
+	severity, shouldTrigger := notifier.severities[response.Level]
+	// an unknown incident is not resolved before a trigger as PagerDuty merges the trigger into the open incident
+	shouldResolve := (known && openLevel != data.NoEvent) || (!known && !shouldTrigger)
+	if shouldResolve {
+		resolveEvent := event{
+			RoutingKey:  notifier.routingKey,
+			EventAction: resolveAction,
+			DedupKey:    createDedupKey(response.Identifier),
+		}
+
+		err := notifier.sendEvent(ctx, response.Identifier, resolveEvent, data.NoEvent)
+		if err != nil {
+			return err
+		}
+	}
+	if !shouldTrigger {
+		return nil
+	}
+
+	return notifier.sendEvent(ctx, response.Identifier, notifier.createTriggerEvent(response, severity), response.Level)
+}
+
+func (notifier *pagerDutyNotifier) createTriggerEvent(response data.AlarmResponse, severity string) event {
+	labelSeverity := strings.ToLower(response.Labels[severityLabel])
+	if isValidSeverity(labelSeverity) {
+		severity = labelSeverity
+	}
+
+	summary := response.Identifier
+	detail := firstLine(response.Data)
+	if len(detail) > 0 {
+		summary += ": " + detail
+	}
+
+	return event{
+		RoutingKey:  notifier.routingKey,
+		EventAction: triggerAction,
+		DedupKey:    createDedupKey(response.Identifier),
+		Client:      clientName,
+		Payload: &eventPayload{
+			Summary:   formatting.Truncate(summary, maxSummaryLength),
+			Source:    notifier.source,
+			Severity:  severity,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Component: response.Identifier,
+			CustomDetails: customDetails{
+				Data:    response.Data,
+				Labels:  response.Labels,
+				Metrics: response.Metrics,
+			},
+		},
+	}
+}
+
+// sendEvent will post the event and, only if it was accepted, will store the new incident state so the failed
+// events are sent again on the next response
+func (notifier *pagerDutyNotifier) sendEvent(ctx context.Context, identifier string, ev event, newState data.EventLevel) error {
+	buff, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	response, err := notifier.httpClient.CallEndPoint(ctx, httpWrapper.Request{
+		Method: http.MethodPost,
+		Url:    notifier.eventsUrl,
+		Body:   buff,
+	})
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w %d for %s event: %s", errUnexpectedStatusCode, response.StatusCode, ev.EventAction,
+			describeErrorResponse(response.Body))
+	}
+
+	log.Debug("PagerDuty event sent", "identifier", identifier, "action", ev.EventAction, "dedup key", ev.DedupKey)
+	notifier.setState(identifier, newState)
+
+	return nil
+}
+
+func (notifier *pagerDutyNotifier) getState(identifier string) (data.EventLevel, bool) {
+	notifier.mutStates.Lock()
+	defer notifier.mutStates.Unlock()
+
+	level, found := notifier.states[identifier]
+
+	return level, found
+}
+
+func (notifier *pagerDutyNotifier) setState(identifier string, state data.EventLevel) {
+	notifier.mutStates.Lock()
+	notifier.states[identifier] = state
+	notifier.mutStates.Unlock()
+}
+
+// createDedupKey derives the incident key from the alarm identifier so all the events of an alarm refer to the same
+// incident. The identifiers that would exceed the PagerDuty limit are hashed
+func createDedupKey(identifier string) string {
+	key := dedupKeyPrefix + identifier
+	if len(key) <= maxDedupKeyLength {
+		return key
+	}
+
+	hash := sha256.Sum256([]byte(identifier))
+
+	return dedupKeyPrefix + hex.EncodeToString(hash[:])
+}
+
+func describeErrorResponse(body []byte) string {
+	response := eventResponse{}
+	err := json.Unmarshal(body, &response)
+	if err != nil || len(response.Message) == 0 {
+		return formatting.Truncate(string(body), maxErrorBodyLength)
+	}
+
+	description := response.Message
+	if len(response.Errors) > 0 {
+		description += " (" + strings.Join(response.Errors, ", ") + ")"
+	}
+
+	return formatting.Truncate(description, maxErrorBodyLength)
+}
+
+func isValidSeverity(severity string) bool {
+	_, found := severities[severity]
+	return found
+}
+
+func firstLine(text string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+}
+
+func hostName() string {
+	name, err := os.Hostname()
+	if err != nil || len(name) == 0 {
+		return defaultSource
+	}
+
+	return name
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *pagerDutyNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
@@ -0,0 +1,321 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEventsApi records the received events and answers as the PagerDuty Events API v2, the queued status codes
+// being used first
+type fakeEventsApi struct {
+	*httptest.Server
+	mut         sync.Mutex
+	events      []event
+	statusCodes []int
+}
+
+func newFakeEventsApi() *fakeEventsApi {
+	api := &fakeEventsApi{}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buff, _ := ioutil.ReadAll(r.Body)
+		ev := event{}
+		_ = json.Unmarshal(buff, &ev)
+
+		api.mut.Lock()
+		statusCode := http.StatusAccepted
+		if len(api.statusCodes) > 0 {
+			statusCode = api.statusCodes[0]
+			api.statusCodes = api.statusCodes[1:]
+		}
+		if statusCode == http.StatusAccepted {
+			api.events = append(api.events, ev)
+		}
+		api.mut.Unlock()
+
+		w.WriteHeader(statusCode)
+		if statusCode != http.StatusAccepted {
+			_, _ = w.Write([]byte(`{"status":"invalid event","message":"Event object is invalid","errors":["'routing_key' is invalid"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","message":"Event processed","dedup_key":"` + ev.DedupKey + `"}`))
+	}))
+
+	return api
+}
+
+func (api *fakeEventsApi) receivedEvents() []event {
+	api.mut.Lock()
+	defer api.mut.Unlock()
+
+	return append(make([]event, 0, len(api.events)), api.events...)
+}
+
+func (api *fakeEventsApi) queueStatusCodes(statusCodes ...int) {
+	api.mut.Lock()
+	api.statusCodes = append(api.statusCodes, statusCodes...)
+	api.mut.Unlock()
+}
+
+func createMockArgsPagerDutyNotifier(url string) ArgsPagerDutyNotifier {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second * 5)
+
+	return ArgsPagerDutyNotifier{
+		HTTPClient: httpClient,
+		EventsUrl:  url,
+		RoutingKey: "routing-key",
+		Source:     "monitoring-host",
+	}
+}
+
+func createErrorResponse(identifier string) data.AlarmResponse {
+	return data.AlarmResponse{
+		Identifier: identifier,
+		Level:      data.Error,
+		Data:       "rating dropped below 90\nsecond line",
+		Labels:     map[string]string{"shard": "1"},
+		Metrics:    map[string]float64{"rating": 89.5},
+	}
+}
+
+func TestNewPagerDutyNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPagerDutyNotifier("")
+		args.HTTPClient = nil
+		notifier, err := NewPagerDutyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty routing key should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPagerDutyNotifier("")
+		args.RoutingKey = ""
+		notifier, err := NewPagerDutyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyRoutingKey, err)
+	})
+	t.Run("invalid error severity should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPagerDutyNotifier("")
+		args.ErrorSeverity = "fatal"
+		notifier, err := NewPagerDutyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidSeverity))
+	})
+	t.Run("invalid info severity should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPagerDutyNotifier("")
+		args.InfoSeverity = "notice"
+		notifier, err := NewPagerDutyNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidSeverity))
+	})
+	t.Run("should work with defaults", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPagerDutyNotifier("")
+		args.Source = ""
+		notifier, err := NewPagerDutyNotifier(args)
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, DefaultEventsUrl, notifier.eventsUrl)
+		assert.Equal(t, map[data.EventLevel]string{data.Error: "critical", data.Info: "info"}, notifier.severities)
+		assert.False(t, notifier.resolveOnStartup)
+		assert.NotEmpty(t, notifier.source)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsPagerDutyNotifier("http://localhost")
+		args.ErrorSeverity = "Error"
+		args.InfoSeverity = "Warning"
+		notifier, err := NewPagerDutyNotifier(args)
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, map[data.EventLevel]string{data.Error: "error", data.Info: "warning"}, notifier.severities)
+		assert.Equal(t, "monitoring-host", notifier.source)
+	})
+}
+
+func TestPagerDutyNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("trigger and resolve lifecycle", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeEventsApi()
+		defer api.Close()
+		notifier, _ := NewPagerDutyNotifier(createMockArgsPagerDutyNotifier(api.URL))
+
+		// the healthy responses do not resolve the incidents this notifier did not trigger
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.NoEvent}))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.NoEvent}))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), createErrorResponse("rating")))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), createErrorResponse("rating")))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Info}))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Info}))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.NoEvent}))
+
+		events := api.receivedEvents()
+		require.Equal(t, 4, len(events))
+		assert.Equal(t, triggerAction, events[0].EventAction)
+		assert.Equal(t, "node-monitoring/rating", events[0].DedupKey)
+		assert.Equal(t, event{RoutingKey: "routing-key", EventAction: resolveAction, DedupKey: "node-monitoring/rating"}, events[1])
+		assert.Equal(t, triggerAction, events[2].EventAction)
+		assert.Equal(t, "info", events[2].Payload.Severity)
+		assert.Equal(t, resolveAction, events[3].EventAction)
+		assert.Equal(t, "node-monitoring/rating", events[3].DedupKey)
+		assert.Nil(t, events[3].Payload)
+
+		trigger := events[0]
+		assert.Equal(t, "routing-key", trigger.RoutingKey)
+		assert.Equal(t, clientName, trigger.Client)
+		require.NotNil(t, trigger.Payload)
+		assert.Equal(t, "rating: rating dropped below 90", trigger.Payload.Summary)
+		assert.Equal(t, "monitoring-host", trigger.Payload.Source)
+		assert.Equal(t, "critical", trigger.Payload.Severity)
+		assert.Equal(t, "rating", trigger.Payload.Component)
+		_, err := time.Parse(time.RFC3339, trigger.Payload.Timestamp)
+		assert.Nil(t, err)
+		assert.Equal(t, customDetails{
+			Data:    "rating dropped below 90\nsecond line",
+			Labels:  map[string]string{"shard": "1"},
+			Metrics: map[string]float64{"rating": 89.5},
+		}, trigger.Payload.CustomDetails)
+	})
+	t.Run("resolve on startup should resolve the incidents left open by a previous run", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeEventsApi()
+		defer api.Close()
+		args := createMockArgsPagerDutyNotifier(api.URL)
+		args.ResolveOnStartup = true
+		notifier, _ := NewPagerDutyNotifier(args)
+
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.NoEvent}))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.NoEvent}))
+		// the trigger of an alarm in an unknown state is merged by PagerDuty into the incident possibly left open
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), createErrorResponse("nonce")))
+
+		events := api.receivedEvents()
+		require.Equal(t, 2, len(events))
+		assert.Equal(t, event{RoutingKey: "routing-key", EventAction: resolveAction, DedupKey: "node-monitoring/rating"}, events[0])
+		assert.Equal(t, triggerAction, events[1].EventAction)
+		assert.Equal(t, "node-monitoring/nonce", events[1].DedupKey)
+	})
+	t.Run("alarms should have separate incidents", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeEventsApi()
+		defer api.Close()
+		notifier, _ := NewPagerDutyNotifier(createMockArgsPagerDutyNotifier(api.URL))
+
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), createErrorResponse("rating")))
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), createErrorResponse("nonce")))
+
+		events := api.receivedEvents()
+		require.Equal(t, 2, len(events))
+		assert.Equal(t, "node-monitoring/rating", events[0].DedupKey)
+		assert.Equal(t, "node-monitoring/nonce", events[1].DedupKey)
+	})
+	t.Run("severity label should override the configured severity", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeEventsApi()
+		defer api.Close()
+		args := createMockArgsPagerDutyNotifier(api.URL)
+		args.ErrorSeverity = "error"
+		notifier, _ := NewPagerDutyNotifier(args)
+
+		response := createErrorResponse("rating")
+		response.Labels = map[string]string{severityLabel: "Warning"}
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+		response = createErrorResponse("nonce")
+		response.Labels = map[string]string{severityLabel: "unknown"}
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+
+		events := api.receivedEvents()
+		require.Equal(t, 2, len(events))
+		assert.Equal(t, "warning", events[0].Payload.Severity)
+		assert.Equal(t, "error", events[1].Payload.Severity)
+	})
+	t.Run("info reports should be ignored", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeEventsApi()
+		defer api.Close()
+		notifier, _ := NewPagerDutyNotifier(createMockArgsPagerDutyNotifier(api.URL))
+
+		response := createErrorResponse(data.SystemIdentifier)
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+		assert.Empty(t, api.receivedEvents())
+	})
+	t.Run("rejected events should error and be sent again", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeEventsApi()
+		defer api.Close()
+		api.queueStatusCodes(http.StatusBadRequest)
+		notifier, _ := NewPagerDutyNotifier(createMockArgsPagerDutyNotifier(api.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createErrorResponse("rating"))
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.True(t, strings.Contains(err.Error(), "400 for trigger event: Event object is invalid ('routing_key' is invalid)"))
+		assert.Empty(t, api.receivedEvents())
+
+		err = notifier.ProcessAlarmResponse(context.Background(), createErrorResponse("rating"))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(api.receivedEvents()))
+	})
+	t.Run("unreachable endpoint should error", func(t *testing.T) {
+		t.Parallel()
+
+		api := newFakeEventsApi()
+		api.Close()
+		notifier, _ := NewPagerDutyNotifier(createMockArgsPagerDutyNotifier(api.URL))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createErrorResponse("rating"))
+		assert.NotNil(t, err)
+		_, known := notifier.getState("rating")
+		assert.False(t, known)
+	})
+}
+
+func TestCreateDedupKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "node-monitoring/rating", createDedupKey("rating"))
+
+	longKey := createDedupKey(strings.Repeat("a", 300))
+	assert.Equal(t, len(dedupKeyPrefix)+64, len(longKey))
+	assert.True(t, strings.HasPrefix(longKey, dedupKeyPrefix))
+	assert.Equal(t, longKey, createDedupKey(strings.Repeat("a", 300)))
+}
+
+func TestDescribeErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "rate limited", describeErrorResponse([]byte("rate limited")))
+	assert.Equal(t, "Event object is invalid", describeErrorResponse([]byte(`{"status":"invalid event","message":"Event object is invalid"}`)))
+	assert.Equal(t, 512, len([]rune(describeErrorResponse([]byte(strings.Repeat("e", 1000))))))
+}
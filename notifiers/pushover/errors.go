@@ -0,0 +1,8 @@
+package pushover
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errEmptyApiUrl = errors.New("empty API URL")
+var errEmptyToken = errors.New("empty application token")
+var errEmptyUser = errors.New("empty user key")
@@ -0,0 +1,9 @@
+package pushover
+
+import "context"
+
+// HTTPClient defines the operations of the HTTP client used to call the Pushover API
+type HTTPClient interface {
+	CallPostRestEndPoint(ctx context.Context, url string, data interface{}) error
+	IsInterfaceNil() bool
+}
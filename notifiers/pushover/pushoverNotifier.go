@@ -0,0 +1,103 @@
+package pushover
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+)
+
+// DefaultApiUrl is the Pushover API end point used to send the messages
+const DefaultApiUrl = "https://api.pushover.net/1/messages.json"
+
+const (
+	maxTitleLength   = 250
+	maxMessageLength = 1024
+
+	normalPriority = 0
+	highPriority   = 1
+)
+
+// ArgsPushoverNotifier represents the arguments DTO for the pushoverNotifier constructor
+type ArgsPushoverNotifier struct {
+	HTTPClient HTTPClient
+	ApiUrl     string
+	Token      string
+	User       string
+}
+
+type messageDTO struct {
+	Token    string `json:"token"`
+	User     string `json:"user"`
+	Title    string `json:"title"`
+	Message  string `json:"message"`
+	Priority int    `json:"priority"`
+}
+
+type pushoverNotifier struct {
+	httpClient HTTPClient
+	apiUrl     string
+	token      string
+	user       string
+}
+
+// NewPushoverNotifier creates a new notifier that pushes the alarm responses to a Pushover user or group. The
+// errors are sent with high priority so they bypass the user's quiet hours
+func NewPushoverNotifier(args ArgsPushoverNotifier) (*pushoverNotifier, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(strings.TrimSpace(args.ApiUrl)) == 0 {
+		return nil, errEmptyApiUrl
+	}
+	if len(strings.TrimSpace(args.Token)) == 0 {
+		return nil, errEmptyToken
+	}
+	if len(strings.TrimSpace(args.User)) == 0 {
+		return nil, errEmptyUser
+	}
+
+	return &pushoverNotifier{
+		httpClient: args.HTTPClient,
+		apiUrl:     args.ApiUrl,
+		token:      args.Token,
+		user:       args.User,
+	}, nil
+}
+
+// ProcessAlarmResponse will push the alarm response. The responses without an event are ignored
+func (notifier *pushoverNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	message := messageDTO{
+		Token:    notifier.token,
+		User:     notifier.user,
+		Title:    formatting.Truncate(fmt.Sprintf("%s: %s", response.Level, response.Identifier), maxTitleLength),
+		Message:  formatting.Truncate(response.Data, maxMessageLength),
+		Priority: normalPriority,
+	}
+	if response.Level == data.Error {
+		message.Priority = highPriority
+	}
+	if len(message.Message) == 0 {
+		// Pushover rejects the messages without a body
+		message.Message = string(response.Level)
+	}
+
+	err := notifier.httpClient.CallPostRestEndPoint(ctx, notifier.apiUrl, message)
+	if err != nil {
+		return fmt.Errorf("%w while pushing the Pushover message", err)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *pushoverNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
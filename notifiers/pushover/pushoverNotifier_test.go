@@ -0,0 +1,175 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/stretchr/testify/assert"
+)
+
+type httpClientStub struct {
+	CallPostRestEndPointCalled func(ctx context.Context, url string, data interface{}) error
+}
+
+// CallPostRestEndPoint -
+func (stub *httpClientStub) CallPostRestEndPoint(ctx context.Context, url string, data interface{}) error {
+	if stub.CallPostRestEndPointCalled != nil {
+		return stub.CallPostRestEndPointCalled(ctx, url, data)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *httpClientStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createMockArgsPushoverNotifier() ArgsPushoverNotifier {
+	return ArgsPushoverNotifier{
+		HTTPClient: &httpClientStub{},
+		ApiUrl:     DefaultApiUrl,
+		Token:      "token",
+		User:       "user",
+	}
+}
+
+func TestNewPushoverNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsPushoverNotifier()
+		args.HTTPClient = nil
+
+		notifier, err := NewPushoverNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty API URL should error", func(t *testing.T) {
+		args := createMockArgsPushoverNotifier()
+		args.ApiUrl = ""
+
+		notifier, err := NewPushoverNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyApiUrl, err)
+	})
+	t.Run("empty token should error", func(t *testing.T) {
+		args := createMockArgsPushoverNotifier()
+		args.Token = " "
+
+		notifier, err := NewPushoverNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyToken, err)
+	})
+	t.Run("empty user should error", func(t *testing.T) {
+		args := createMockArgsPushoverNotifier()
+		args.User = ""
+
+		notifier, err := NewPushoverNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyUser, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := NewPushoverNotifier(createMockArgsPushoverNotifier())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
+
+func TestPushoverNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no event should not push", func(t *testing.T) {
+		args := createMockArgsPushoverNotifier()
+		args.HTTPClient = &httpClientStub{
+			CallPostRestEndPointCalled: func(ctx context.Context, url string, data interface{}) error {
+				assert.Fail(t, "should not have been called")
+				return nil
+			},
+		}
+		notifier, _ := NewPushoverNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.NoEvent})
+		assert.Nil(t, err)
+	})
+	t.Run("error should push with high priority", func(t *testing.T) {
+		var sent interface{}
+		args := createMockArgsPushoverNotifier()
+		args.HTTPClient = &httpClientStub{
+			CallPostRestEndPointCalled: func(ctx context.Context, url string, data interface{}) error {
+				assert.Equal(t, DefaultApiUrl, url)
+				sent = data
+				return nil
+			},
+		}
+		notifier, _ := NewPushoverNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{
+			Identifier: "node rating",
+			Level:      data.Error,
+			Data:       "rating below the threshold",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, messageDTO{
+			Token:    "token",
+			User:     "user",
+			Title:    "Error: node rating",
+			Message:  "rating below the threshold",
+			Priority: highPriority,
+		}, sent)
+	})
+	t.Run("info should push with normal priority and truncate the message", func(t *testing.T) {
+		var sent messageDTO
+		args := createMockArgsPushoverNotifier()
+		args.HTTPClient = &httpClientStub{
+			CallPostRestEndPointCalled: func(ctx context.Context, url string, data interface{}) error {
+				sent = data.(messageDTO)
+				return nil
+			},
+		}
+		notifier, _ := NewPushoverNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{
+			Identifier: "system",
+			Level:      data.Info,
+			Data:       strings.Repeat("ă", 2*maxMessageLength),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, normalPriority, sent.Priority)
+		assert.Equal(t, maxMessageLength, len([]rune(sent.Message)))
+		assert.True(t, strings.HasSuffix(sent.Message, formatting.TruncateSuffix))
+	})
+	t.Run("empty data should push the level as message", func(t *testing.T) {
+		var sent messageDTO
+		args := createMockArgsPushoverNotifier()
+		args.HTTPClient = &httpClientStub{
+			CallPostRestEndPointCalled: func(ctx context.Context, url string, data interface{}) error {
+				sent = data.(messageDTO)
+				return nil
+			},
+		}
+		notifier, _ := NewPushoverNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "alarm", Level: data.Error})
+		assert.Nil(t, err)
+		assert.Equal(t, "Error", sent.Message)
+	})
+	t.Run("HTTP error should error", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		args := createMockArgsPushoverNotifier()
+		args.HTTPClient = &httpClientStub{
+			CallPostRestEndPointCalled: func(ctx context.Context, url string, data interface{}) error {
+				return expectedErr
+			},
+		}
+		notifier, _ := NewPushoverNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.Error})
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
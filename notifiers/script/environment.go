@@ -0,0 +1,64 @@
+package script
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+)
+
+const (
+	// EnvironmentPrefix is the prefix of all the environment variables describing the alarm response
+	EnvironmentPrefix = "NODE_MONITORING_"
+
+	// the kernel limits each environment string, the full data is anyway available on stdin
+	maxEnvironmentValueLength = 32 * 1024
+)
+
+// createResponseEnvironment returns the environment variables describing the alarm response. The labels and
+// metrics keys are converted to upper case and all characters that are not letters or digits are replaced with _
+func createResponseEnvironment(response data.AlarmResponse) []string {
+	env := make([]string, 0, len(response.Labels)+len(response.Metrics)+3)
+	env = append(env,
+		EnvironmentPrefix+"IDENTIFIER="+response.Identifier,
+		EnvironmentPrefix+"LEVEL="+string(response.Level),
+		EnvironmentPrefix+"DATA="+formatting.Truncate(response.Data, maxEnvironmentValueLength),
+	)
+
+	for _, key := range common.SortedLabelKeys(response.Labels) {
+		value := formatting.Truncate(response.Labels[key], maxEnvironmentValueLength)
+		env = append(env, EnvironmentPrefix+"LABEL_"+sanitizeName(key)+"="+value)
+	}
+	for _, key := range sortedMetricKeys(response.Metrics) {
+		value := strconv.FormatFloat(response.Metrics[key], 'f', -1, 64)
+		env = append(env, EnvironmentPrefix+"METRIC_"+sanitizeName(key)+"="+value)
+	}
+
+	return env
+}
+
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+func sortedMetricKeys(metrics map[string]float64) []string {
+	keys := make([]string, 0, len(metrics))
+	for key := range metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
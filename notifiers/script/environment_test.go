@@ -0,0 +1,48 @@
+package script
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "SHARD", sanitizeName("shard"))
+	assert.Equal(t, "NODE_URL", sanitizeName("node-url"))
+	assert.Equal(t, "API_KEY_2", sanitizeName("API_KEY_2"))
+	assert.Equal(t, "_RATING_", sanitizeName("(rating)"))
+}
+
+func TestCreateResponseEnvironment(t *testing.T) {
+	t.Parallel()
+
+	response := data.AlarmResponse{
+		Identifier: "node-0",
+		Level:      data.Info,
+		Data:       strings.Repeat("a", maxEnvironmentValueLength+10),
+		Labels: map[string]string{
+			"shard": "0",
+			"alias": "validator",
+		},
+		Metrics: map[string]float64{
+			"nonce":  123456,
+			"rating": 0.25,
+		},
+	}
+
+	env := createResponseEnvironment(response)
+	assert.Equal(t, 7, len(env))
+	assert.Equal(t, "NODE_MONITORING_IDENTIFIER=node-0", env[0])
+	assert.Equal(t, "NODE_MONITORING_LEVEL=Info", env[1])
+	assert.Equal(t, maxEnvironmentValueLength, len([]rune(strings.TrimPrefix(env[2], "NODE_MONITORING_DATA="))))
+	assert.Equal(t, []string{
+		"NODE_MONITORING_LABEL_ALIAS=validator",
+		"NODE_MONITORING_LABEL_SHARD=0",
+		"NODE_MONITORING_METRIC_NONCE=123456",
+		"NODE_MONITORING_METRIC_RATING=0.25",
+	}, env[3:])
+}
@@ -0,0 +1,9 @@
+package script
+
+import "errors"
+
+var errEmptyCommand = errors.New("empty command")
+var errInvalidTimeout = errors.New("invalid timeout")
+var errInvalidEnvironmentName = errors.New("invalid environment variable name")
+var errScriptTimeout = errors.New("script timed out")
+var errNonZeroExitCode = errors.New("script exited with code")
@@ -0,0 +1,38 @@
+package script
+
+import "bytes"
+
+// limitedBuffer keeps the first maxSize bytes written and silently discards the rest so a chatty script can not
+// exhaust the memory. The writes never fail, otherwise the script would receive a broken pipe
+type limitedBuffer struct {
+	buff      bytes.Buffer
+	maxSize   int
+	truncated bool
+}
+
+// Write stores the bytes that still fit in the buffer
+func (lb *limitedBuffer) Write(p []byte) (int, error) {
+	available := lb.maxSize - lb.buff.Len()
+	if len(p) > available {
+		lb.truncated = true
+		if available > 0 {
+			lb.buff.Write(p[:available])
+		}
+
+		return len(p), nil
+	}
+
+	lb.buff.Write(p)
+
+	return len(p), nil
+}
+
+// String returns the stored bytes, marking the discarded output
+func (lb *limitedBuffer) String() string {
+	text := string(bytes.TrimSpace(lb.buff.Bytes()))
+	if lb.truncated {
+		text += " [truncated]"
+	}
+
+	return text
+}
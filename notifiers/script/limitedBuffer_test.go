@@ -0,0 +1,30 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitedBuffer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("output fitting in the buffer should be kept", func(t *testing.T) {
+		buff := &limitedBuffer{maxSize: 10}
+		n, err := buff.Write([]byte("line 1\n"))
+		assert.Equal(t, 7, n)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "line 1", buff.String())
+	})
+	t.Run("output exceeding the buffer should be truncated", func(t *testing.T) {
+		buff := &limitedBuffer{maxSize: 10}
+		_, _ = buff.Write([]byte("line 1\n"))
+		n, err := buff.Write([]byte("line 2\n"))
+		assert.Equal(t, 7, n)
+		assert.Nil(t, err)
+		_, _ = buff.Write([]byte("line 3\n"))
+
+		assert.Equal(t, "line 1\nlin [truncated]", buff.String())
+	})
+}
@@ -0,0 +1,180 @@
+package script
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+var log = logger.GetOrCreate("notifiers/script")
+
+const (
+	maxStderrLength = 4096
+	// the time to wait for the stderr to be closed after the script exited, a process started in the background
+	// by the script inherits the stderr and can keep it open indefinitely
+	stderrCloseDelay = time.Second
+)
+
+// ArgsScriptNotifier represents the arguments DTO for the scriptNotifier constructor
+type ArgsScriptNotifier struct {
+	Command          string
+	Args             []string
+	WorkingDirectory string
+	Environment      map[string]string
+	Timeout          time.Duration
+}
+
+type scriptNotifier struct {
+	command          string
+	args             []string
+	workingDirectory string
+	environment      []string
+	timeout          time.Duration
+}
+
+// NewScriptNotifier creates a new notifier that runs an executable for each alarm response. The command is resolved
+// using the PATH environment variable if it does not contain a path separator
+func NewScriptNotifier(args ArgsScriptNotifier) (*scriptNotifier, error) {
+	if len(args.Command) == 0 {
+		return nil, errEmptyCommand
+	}
+	if args.Timeout <= 0 {
+		return nil, fmt.Errorf("%w, provided: %v", errInvalidTimeout, args.Timeout)
+	}
+
+	command, err := exec.LookPath(args.Command)
+	if err != nil {
+		return nil, err
+	}
+
+	environment, err := createStaticEnvironment(args.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scriptNotifier{
+		command:          command,
+		args:             append(make([]string, 0, len(args.Args)), args.Args...),
+		workingDirectory: args.WorkingDirectory,
+		environment:      environment,
+		timeout:          args.Timeout,
+	}, nil
+}
+
+func createStaticEnvironment(variables map[string]string) ([]string, error) {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		if len(name) == 0 || sanitizeName(name) != name {
+			return nil, fmt.Errorf("%w: %q, only upper case letters, digits and _ are allowed", errInvalidEnvironmentName, name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	environment := make([]string, 0, len(names))
+	for _, name := range names {
+		environment = append(environment, name+"="+variables[name])
+	}
+
+	return environment, nil
+}
+
+// ProcessAlarmResponse will run the script, providing the alarm response as JSON on stdin and as environment
+// variables. A script that does not finish in time is killed. The responses without an event are ignored
+func (notifier *scriptNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	input, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, notifier.timeout)
+	defer cancel()
+
+	stderrReader, stderrWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = stderrReader.Close()
+	}()
+
+	cmd := exec.CommandContext(timeoutCtx, notifier.command, notifier.args...)
+	cmd.Dir = notifier.workingDirectory
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = stderrWriter
+	cmd.Env = append(os.Environ(), notifier.environment...)
+	cmd.Env = append(cmd.Env, createResponseEnvironment(response)...)
+
+	start := time.Now()
+	err = cmd.Start()
+	_ = stderrWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	stderr := &limitedBuffer{maxSize: maxStderrLength}
+	stderrDone := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(stderr, stderrReader)
+		close(stderrDone)
+	}()
+
+	err = cmd.Wait()
+	duration := time.Since(start)
+	waitStderr(stderrDone, stderrReader)
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+		log.Warn("script killed", "command", notifier.command, "identifier", response.Identifier,
+			"timeout", notifier.timeout, "stderr", stderr.String())
+		return fmt.Errorf("%w after %v", errScriptTimeout, notifier.timeout)
+	}
+
+	exitErr := &exec.ExitError{}
+	if errors.As(err, &exitErr) {
+		log.Warn("script failed", "command", notifier.command, "identifier", response.Identifier,
+			"exit code", exitErr.ExitCode(), "stderr", stderr.String())
+		return fmt.Errorf("%w %d: %s", errNonZeroExitCode, exitErr.ExitCode(), stderr.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Debug("script finished", "command", notifier.command, "identifier", response.Identifier,
+		"duration", duration, "stderr", stderr.String())
+
+	return nil
+}
+
+func waitStderr(stderrDone chan struct{}, stderrReader *os.File) {
+	timer := time.NewTimer(stderrCloseDelay)
+	defer timer.Stop()
+
+	select {
+	case <-stderrDone:
+	case <-timer.C:
+		_ = stderrReader.Close()
+		<-stderrDone
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *scriptNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
@@ -0,0 +1,180 @@
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsScriptNotifier(script string) ArgsScriptNotifier {
+	return ArgsScriptNotifier{
+		Command: "sh",
+		Args:    []string{"-c", script},
+		Timeout: time.Second * 5,
+	}
+}
+
+func createTestResponse() data.AlarmResponse {
+	return data.AlarmResponse{
+		Identifier: "node-0",
+		Level:      data.Error,
+		Data:       "node is offline",
+		Labels: map[string]string{
+			"shard":    "metachain",
+			"node-url": "http://127.0.0.1:8080",
+		},
+		Metrics: map[string]float64{
+			"rating": 99.5,
+		},
+	}
+}
+
+func TestNewScriptNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty command should error", func(t *testing.T) {
+		args := createMockArgsScriptNotifier("true")
+		args.Command = ""
+
+		notifier, err := NewScriptNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyCommand, err)
+	})
+	t.Run("invalid timeout should error", func(t *testing.T) {
+		args := createMockArgsScriptNotifier("true")
+		args.Timeout = 0
+
+		notifier, err := NewScriptNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidTimeout))
+	})
+	t.Run("missing command should error", func(t *testing.T) {
+		args := createMockArgsScriptNotifier("true")
+		args.Command = "./missing-notification-script.sh"
+
+		notifier, err := NewScriptNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.NotNil(t, err)
+	})
+	t.Run("invalid environment variable name should error", func(t *testing.T) {
+		args := createMockArgsScriptNotifier("true")
+		args.Environment = map[string]string{"api-key": "secret"}
+
+		notifier, err := NewScriptNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidEnvironmentName))
+		assert.True(t, strings.Contains(err.Error(), "api-key"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		args := createMockArgsScriptNotifier("true")
+		args.Environment = map[string]string{"API_KEY": "secret"}
+
+		notifier, err := NewScriptNotifier(args)
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"API_KEY=secret"}, notifier.environment)
+	})
+}
+
+func TestScriptNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no event should not run the script", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		notifier, _ := NewScriptNotifier(createMockArgsScriptNotifier("touch ran"))
+		notifier.workingDirectory = dir
+
+		response := createTestResponse()
+		response.Level = data.NoEvent
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+
+		_, err = os.Stat(filepath.Join(dir, "ran"))
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("should provide the response on stdin and as environment variables", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		args := createMockArgsScriptNotifier("cat > stdin.json && env > env.txt")
+		args.WorkingDirectory = dir
+		args.Environment = map[string]string{"API_KEY": "secret"}
+		notifier, _ := NewScriptNotifier(args)
+
+		response := createTestResponse()
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		require.Nil(t, err)
+
+		buff, err := ioutil.ReadFile(filepath.Join(dir, "stdin.json"))
+		require.Nil(t, err)
+		receivedResponse := data.AlarmResponse{}
+		err = json.Unmarshal(buff, &receivedResponse)
+		require.Nil(t, err)
+		assert.Equal(t, response, receivedResponse)
+
+		buff, err = ioutil.ReadFile(filepath.Join(dir, "env.txt"))
+		require.Nil(t, err)
+		env := strings.Split(string(buff), "\n")
+		assert.Contains(t, env, "API_KEY=secret")
+		assert.Contains(t, env, "NODE_MONITORING_IDENTIFIER=node-0")
+		assert.Contains(t, env, "NODE_MONITORING_LEVEL=Error")
+		assert.Contains(t, env, "NODE_MONITORING_DATA=node is offline")
+		assert.Contains(t, env, "NODE_MONITORING_LABEL_SHARD=metachain")
+		assert.Contains(t, env, "NODE_MONITORING_LABEL_NODE_URL=http://127.0.0.1:8080")
+		assert.Contains(t, env, "NODE_MONITORING_METRIC_RATING=99.5")
+	})
+	t.Run("non-zero exit code should error with the stderr", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, _ := NewScriptNotifier(createMockArgsScriptNotifier("echo 'invalid token' >&2; exit 3"))
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.True(t, errors.Is(err, errNonZeroExitCode))
+		assert.Equal(t, "script exited with code 3: invalid token", err.Error())
+	})
+	t.Run("script not finishing in time should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsScriptNotifier("sleep 10")
+		args.Timeout = time.Millisecond * 200
+		notifier, _ := NewScriptNotifier(args)
+
+		start := time.Now()
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.True(t, errors.Is(err, errScriptTimeout))
+		assert.True(t, time.Since(start) < time.Second*5)
+	})
+	t.Run("background process keeping the stderr open should not block", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, _ := NewScriptNotifier(createMockArgsScriptNotifier("echo started >&2; sleep 10 &"))
+
+		start := time.Now()
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Nil(t, err)
+		assert.True(t, time.Since(start) < time.Second*5)
+	})
+	t.Run("canceled context should error", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, _ := NewScriptNotifier(createMockArgsScriptNotifier("true"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := notifier.ProcessAlarmResponse(ctx, createTestResponse())
+		assert.Equal(t, context.Canceled, err)
+	})
+}
@@ -0,0 +1,40 @@
+package slack
+
+type textObject struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type block struct {
+	Type   string       `json:"type"`
+	Text   *textObject  `json:"text,omitempty"`
+	Fields []textObject `json:"fields,omitempty"`
+}
+
+type slackAttachment struct {
+	Color  string  `json:"color"`
+	Blocks []block `json:"blocks"`
+}
+
+type slackPayload struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type mattermostField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+type mattermostAttachment struct {
+	Fallback string            `json:"fallback"`
+	Color    string            `json:"color"`
+	Title    string            `json:"title"`
+	Text     string            `json:"text"`
+	Fields   []mattermostField `json:"fields,omitempty"`
+}
+
+type mattermostPayload struct {
+	Attachments []mattermostAttachment `json:"attachments"`
+}
@@ -0,0 +1,7 @@
+package slack
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errEmptyWebhookUrl = errors.New("empty webhook URL")
+var errInvalidFormat = errors.New("invalid payload format")
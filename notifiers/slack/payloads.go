@@ -0,0 +1,199 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+const (
+	// the section text is limited to 3000 characters, the code block markers need to fit as well
+	maxDataLength      = 2900
+	maxSectionLength   = 3000
+	maxHeaderLength    = 150
+	maxFieldsInSection = 10
+	defaultColor       = "#9e9e9e"
+	plainTextType      = "plain_text"
+	markdownTextType   = "mrkdwn"
+	headerBlockType    = "header"
+	sectionBlockType   = "section"
+)
+
+var levelColors = map[data.EventLevel]string{
+	data.Error: "#d32f2f",
+	data.Info:  "#1976d2",
+}
+
+var levelTitles = map[data.EventLevel]string{
+	data.Error: "🚨 ERROR",
+	data.Info:  "ℹ️ INFO",
+}
+
+// the characters that have to be escaped in Slack's mrkdwn texts
+var slackReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeAndTruncate escapes the text before truncating it so the escaped characters count towards Slack's limit, the
+// text being cut before an entity that would not fit
+func escapeAndTruncate(text string, maxLength int) string {
+	escaped := slackReplacer.Replace(text)
+	runes := []rune(escaped)
+	if len(runes) <= maxLength {
+		return escaped
+	}
+
+	kept := string(runes[:maxLength-1])
+	entityStart := strings.LastIndex(kept, "&")
+	if entityStart >= 0 && !strings.Contains(kept[entityStart:], ";") {
+		kept = kept[:entityStart]
+	}
+
+	return kept + formatting.TruncateSuffix
+}
+
+func levelColor(level data.EventLevel) string {
+	color, found := levelColors[level]
+	if !found {
+		return defaultColor
+	}
+
+	return color
+}
+
+func createTitle(response data.AlarmResponse) string {
+	title, found := levelTitles[response.Level]
+	if !found {
+		title = string(response.Level)
+	}
+
+	return formatting.Truncate(fmt.Sprintf("%s | %s", title, response.Identifier), maxHeaderLength)
+}
+
+// createSlackPayload creates the Block Kit attachment payload accepted by the Slack incoming webhooks
+func createSlackPayload(response data.AlarmResponse) slackPayload {
+	title := createTitle(response)
+	blocks := []block{
+		{
+			Type: headerBlockType,
+			Text: &textObject{Type: plainTextType, Text: title},
+		},
+	}
+
+	fields := []textObject{
+		{Type: markdownTextType, Text: fmt.Sprintf("*Identifier*\n%s", slackReplacer.Replace(response.Identifier))},
+	}
+	labels := common.DisplayLabels(response.Labels)
+	for _, key := range common.SortedLabelKeys(labels) {
+		fields = append(fields, textObject{
+			Type: markdownTextType,
+			Text: fmt.Sprintf("*%s*\n%s", slackReplacer.Replace(key), slackReplacer.Replace(labels[key])),
+		})
+	}
+	for start := 0; start < len(fields); start += maxFieldsInSection {
+		end := start + maxFieldsInSection
+		if end > len(fields) {
+			end = len(fields)
+		}
+
+		blocks = append(blocks, block{
+			Type:   sectionBlockType,
+			Fields: fields[start:end],
+		})
+	}
+
+	if len(response.Data) > 0 {
+		text := escapeAndTruncate(response.Data, maxDataLength)
+		blocks = append(blocks, block{
+			Type: sectionBlockType,
+			Text: &textObject{Type: markdownTextType, Text: "```" + text + "```"},
+		})
+	}
+
+	return slackPayload{
+		Text: title,
+		Attachments: []slackAttachment{
+			{
+				Color:  levelColor(response.Level),
+				Blocks: blocks,
+			},
+		},
+	}
+}
+
+// createMattermostPayload creates the Slack compatible attachment payload accepted by the Mattermost incoming
+// webhooks as they do not support the Block Kit layout
+func createMattermostPayload(response data.AlarmResponse) mattermostPayload {
+	title := createTitle(response)
+	attachment := mattermostAttachment{
+		Fallback: title,
+		Color:    levelColor(response.Level),
+		Title:    title,
+	}
+	if len(response.Data) > 0 {
+		attachment.Text = "```\n" + formatting.Truncate(response.Data, maxDataLength) + "\n```"
+	}
+
+	attachment.Fields = append(attachment.Fields, mattermostField{
+		Title: "Identifier",
+		Value: response.Identifier,
+		Short: true,
+	})
+	labels := common.DisplayLabels(response.Labels)
+	for _, key := range common.SortedLabelKeys(labels) {
+		attachment.Fields = append(attachment.Fields, mattermostField{
+			Title: key,
+			Value: labels[key],
+			Short: true,
+		})
+	}
+
+	return mattermostPayload{
+		Attachments: []mattermostAttachment{attachment},
+	}
+}
+
+// createRenderedSlackPayload creates the attachment payload holding the texts rendered from the templates. The body
+// is sent as mrkdwn so the templates can use Slack's formatting
+func createRenderedSlackPayload(level data.EventLevel, message templating.Message) slackPayload {
+	title := formatting.Truncate(message.Title, maxHeaderLength)
+	blocks := make([]block, 0, 2)
+	if len(title) > 0 {
+		blocks = append(blocks, block{
+			Type: headerBlockType,
+			Text: &textObject{Type: plainTextType, Text: title},
+		})
+	}
+	if len(message.Body) > 0 {
+		blocks = append(blocks, block{
+			Type: sectionBlockType,
+			Text: &textObject{Type: markdownTextType, Text: formatting.Truncate(message.Body, maxSectionLength)},
+		})
+	}
+
+	return slackPayload{
+		Text: title,
+		Attachments: []slackAttachment{
+			{
+				Color:  levelColor(level),
+				Blocks: blocks,
+			},
+		},
+	}
+}
+
+// createRenderedMattermostPayload creates the attachment payload holding the texts rendered from the templates
+func createRenderedMattermostPayload(level data.EventLevel, message templating.Message) mattermostPayload {
+	return mattermostPayload{
+		Attachments: []mattermostAttachment{
+			{
+				Fallback: message.Title,
+				Color:    levelColor(level),
+				Title:    message.Title,
+				Text:     message.Body,
+			},
+		},
+	}
+}
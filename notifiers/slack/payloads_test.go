@@ -0,0 +1,141 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestResponse() data.AlarmResponse {
+	return data.AlarmResponse{
+		Identifier: "testnet <rating>",
+		Level:      data.Error,
+		Data:       "rating 90 < 100 & dropping",
+		Labels: map[string]string{
+			"team":    "validators",
+			"network": "testnet",
+		},
+	}
+}
+
+func TestCreateSlackPayload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should contain the level color, identifier, labels and data", func(t *testing.T) {
+		payload := createSlackPayload(createTestResponse())
+
+		assert.Equal(t, "🚨 ERROR | testnet <rating>", payload.Text)
+		assert.Equal(t, 1, len(payload.Attachments))
+		attachment := payload.Attachments[0]
+		assert.Equal(t, "#d32f2f", attachment.Color)
+		assert.Equal(t, 3, len(attachment.Blocks))
+		assert.Equal(t, headerBlockType, attachment.Blocks[0].Type)
+		assert.Equal(t, plainTextType, attachment.Blocks[0].Text.Type)
+		assert.Equal(t, []textObject{
+			{Type: markdownTextType, Text: "*Identifier*\ntestnet &lt;rating&gt;"},
+			{Type: markdownTextType, Text: "*network*\ntestnet"},
+			{Type: markdownTextType, Text: "*team*\nvalidators"},
+		}, attachment.Blocks[1].Fields)
+		assert.Equal(t, "```rating 90 &lt; 100 &amp; dropping```", attachment.Blocks[2].Text.Text)
+	})
+	t.Run("info level without data", func(t *testing.T) {
+		payload := createSlackPayload(data.AlarmResponse{Identifier: "system", Level: data.Info})
+
+		assert.Equal(t, "#1976d2", payload.Attachments[0].Color)
+		assert.Equal(t, 2, len(payload.Attachments[0].Blocks))
+	})
+	t.Run("many labels should be split in multiple sections", func(t *testing.T) {
+		response := createTestResponse()
+		response.Labels = make(map[string]string)
+		for i := 0; i < 15; i++ {
+			response.Labels[fmt.Sprintf("label%02d", i)] = "value"
+		}
+
+		payload := createSlackPayload(response)
+		assert.Equal(t, maxFieldsInSection, len(payload.Attachments[0].Blocks[1].Fields))
+		assert.Equal(t, 6, len(payload.Attachments[0].Blocks[2].Fields))
+	})
+	t.Run("long data should be truncated", func(t *testing.T) {
+		response := createTestResponse()
+		response.Data = strings.Repeat("a", maxDataLength*2)
+
+		payload := createSlackPayload(response)
+		assert.True(t, len([]rune(payload.Attachments[0].Blocks[2].Text.Text)) <= 3000)
+	})
+	t.Run("long data with escaped characters should be truncated without cutting an entity", func(t *testing.T) {
+		response := createTestResponse()
+		response.Data = strings.Repeat("<", 3000)
+
+		payload := createSlackPayload(response)
+		text := payload.Attachments[0].Blocks[2].Text.Text
+		assert.True(t, len([]rune(text)) <= maxSectionLength)
+
+		text = strings.TrimPrefix(strings.TrimSuffix(text, "…```"), "```")
+		assert.Equal(t, 0, len(text)%len("&lt;"))
+		assert.Equal(t, strings.Repeat("&lt;", len(text)/len("&lt;")), text)
+	})
+}
+
+func TestEscapeAndTruncate(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "a &lt; b", escapeAndTruncate("a < b", 8))
+	assert.Equal(t, "a &lt;…", escapeAndTruncate("a < b", 7))
+	assert.Equal(t, "a …", escapeAndTruncate("a < b", 6))
+	assert.Equal(t, "a …", escapeAndTruncate("a < b", 4))
+	assert.Equal(t, "ă &amp;…", escapeAndTruncate("ă & î", 8))
+}
+
+func TestCreateMattermostPayload(t *testing.T) {
+	t.Parallel()
+
+	payload := createMattermostPayload(createTestResponse())
+
+	buff, _ := json.Marshal(payload)
+	assert.False(t, strings.Contains(string(buff), "blocks"), "Mattermost does not support Block Kit")
+
+	attachment := payload.Attachments[0]
+	assert.Equal(t, "#d32f2f", attachment.Color)
+	assert.Equal(t, "🚨 ERROR | testnet <rating>", attachment.Title)
+	assert.Equal(t, attachment.Title, attachment.Fallback)
+	assert.Equal(t, "```\nrating 90 < 100 & dropping\n```", attachment.Text)
+	assert.Equal(t, []mattermostField{
+		{Title: "Identifier", Value: "testnet <rating>", Short: true},
+		{Title: "network", Value: "testnet", Short: true},
+		{Title: "team", Value: "validators", Short: true},
+	}, attachment.Fields)
+}
+
+func TestCreateRenderedSlackPayload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should contain the title and the body", func(t *testing.T) {
+		payload := createRenderedSlackPayload(data.Info, templating.Message{Title: "title", Body: "*body*"})
+		assert.Equal(t, "title", payload.Text)
+		assert.Equal(t, "#1976d2", payload.Attachments[0].Color)
+		assert.Equal(t, []block{
+			{Type: headerBlockType, Text: &textObject{Type: plainTextType, Text: "title"}},
+			{Type: sectionBlockType, Text: &textObject{Type: markdownTextType, Text: "*body*"}},
+		}, payload.Attachments[0].Blocks)
+	})
+	t.Run("empty texts should be skipped", func(t *testing.T) {
+		payload := createRenderedSlackPayload(data.Error, templating.Message{Body: strings.Repeat("a", 4000)})
+		assert.Equal(t, "", payload.Text)
+		assert.Equal(t, 1, len(payload.Attachments[0].Blocks))
+		assert.Equal(t, maxSectionLength, len([]rune(payload.Attachments[0].Blocks[0].Text.Text)))
+	})
+}
+
+func TestCreateRenderedMattermostPayload(t *testing.T) {
+	t.Parallel()
+
+	payload := createRenderedMattermostPayload(data.Error, templating.Message{Title: "title", Body: "body"})
+	assert.Equal(t, []mattermostAttachment{
+		{Fallback: "title", Color: "#d32f2f", Title: "title", Text: "body"},
+	}, payload.Attachments)
+}
@@ -0,0 +1,101 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+// PayloadFormat represents the payload flavour sent to the incoming webhook
+type PayloadFormat string
+
+const (
+	// SlackFormat will send the Block Kit attachments understood by Slack
+	SlackFormat PayloadFormat = "slack"
+	// MattermostFormat will send the Slack compatible attachments understood by Mattermost
+	MattermostFormat PayloadFormat = "mattermost"
+)
+
+// ArgsWebhookNotifier represents the arguments DTO for the webhookNotifier constructor. The renderer is optional,
+// the built-in layout being used without it
+type ArgsWebhookNotifier struct {
+	HTTPClient HTTPClient
+	WebhookUrl string
+	Format     PayloadFormat
+	Renderer   templating.MessageRenderer
+}
+
+type webhookNotifier struct {
+	httpClient HTTPClient
+	webhookUrl string
+	format     PayloadFormat
+	renderer   templating.MessageRenderer
+}
+
+// NewWebhookNotifier creates a new notifier that posts the alarm responses on a Slack or Mattermost incoming webhook.
+// An empty format defaults to SlackFormat
+func NewWebhookNotifier(args ArgsWebhookNotifier) (*webhookNotifier, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(args.WebhookUrl) == 0 {
+		return nil, errEmptyWebhookUrl
+	}
+
+	format := args.Format
+	if len(format) == 0 {
+		format = SlackFormat
+	}
+	if format != SlackFormat && format != MattermostFormat {
+		return nil, fmt.Errorf("%w: %s", errInvalidFormat, format)
+	}
+
+	return &webhookNotifier{
+		httpClient: args.HTTPClient,
+		webhookUrl: args.WebhookUrl,
+		format:     format,
+		renderer:   args.Renderer,
+	}, nil
+}
+
+// ProcessAlarmResponse will post the alarm response on the incoming webhook. The responses without an event are ignored
+func (notifier *webhookNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	payload, err := notifier.createPayload(response)
+	if err != nil {
+		return err
+	}
+
+	return notifier.httpClient.CallPostRestEndPoint(ctx, notifier.webhookUrl, payload)
+}
+
+func (notifier *webhookNotifier) createPayload(response data.AlarmResponse) (interface{}, error) {
+	if check.IfNil(notifier.renderer) {
+		if notifier.format == MattermostFormat {
+			return createMattermostPayload(response), nil
+		}
+
+		return createSlackPayload(response), nil
+	}
+
+	message, err := notifier.renderer.Render(response)
+	if err != nil {
+		return nil, err
+	}
+	if notifier.format == MattermostFormat {
+		return createRenderedMattermostPayload(response.Level, message), nil
+	}
+
+	return createRenderedSlackPayload(response.Level, message), nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *webhookNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
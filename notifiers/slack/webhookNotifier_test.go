@@ -0,0 +1,156 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsWebhookNotifier() ArgsWebhookNotifier {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second)
+
+	return ArgsWebhookNotifier{
+		HTTPClient: httpClient,
+		WebhookUrl: "https://hooks.slack.com/services/T000/B000/XXXX",
+	}
+}
+
+func TestNewWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsWebhookNotifier()
+		args.HTTPClient = nil
+
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty webhook URL should error", func(t *testing.T) {
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = ""
+
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyWebhookUrl, err)
+	})
+	t.Run("invalid format should error", func(t *testing.T) {
+		args := createMockArgsWebhookNotifier()
+		args.Format = "discord"
+
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidFormat))
+	})
+	t.Run("empty format should default to Slack", func(t *testing.T) {
+		notifier, err := NewWebhookNotifier(createMockArgsWebhookNotifier())
+		assert.Nil(t, err)
+		assert.Equal(t, SlackFormat, notifier.format)
+	})
+	t.Run("should work", func(t *testing.T) {
+		args := createMockArgsWebhookNotifier()
+		args.Format = MattermostFormat
+
+		notifier, err := NewWebhookNotifier(args)
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
+
+func TestWebhookNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	var received []map[string]interface{}
+	status := http.StatusOK
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buff, err := ioutil.ReadAll(r.Body)
+		require.Nil(t, err)
+
+		payload := make(map[string]interface{})
+		_ = json.Unmarshal(buff, &payload)
+		received = append(received, payload)
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("invalid_payload"))
+	}))
+	defer svr.Close()
+
+	args := createMockArgsWebhookNotifier()
+	args.WebhookUrl = svr.URL
+
+	t.Run("no event responses should be ignored", func(t *testing.T) {
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.NoEvent})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(received))
+	})
+	t.Run("should post the Slack payload", func(t *testing.T) {
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(received))
+		assert.Contains(t, received[0], "text")
+	})
+	t.Run("should post the Mattermost payload", func(t *testing.T) {
+		mattermostArgs := args
+		mattermostArgs.Format = MattermostFormat
+		notifier, _ := NewWebhookNotifier(mattermostArgs)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(received))
+		assert.NotContains(t, received[1], "text")
+	})
+	t.Run("should post the rendered texts", func(t *testing.T) {
+		renderedArgs := args
+		renderedArgs.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{Title: "rendered title", Body: "rendered body"}, nil
+			},
+		}
+		notifier, _ := NewWebhookNotifier(renderedArgs)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(received))
+		assert.Equal(t, "rendered title", received[2]["text"])
+	})
+	t.Run("render error should error", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		renderedArgs := args
+		renderedArgs.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{}, expectedErr
+			},
+		}
+		notifier, _ := NewWebhookNotifier(renderedArgs)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 3, len(received))
+	})
+	t.Run("rejected payload should error", func(t *testing.T) {
+		status = http.StatusBadRequest
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "invalid_payload"))
+	})
+}
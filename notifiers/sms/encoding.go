@@ -0,0 +1,99 @@
+package sms
+
+const (
+	gsmSingleSegmentLength = 160
+	gsmMultiSegmentLength  = 153
+	ucsSingleSegmentLength = 70
+	ucsMultiSegmentLength  = 67
+	// the suffix is made of GSM characters so the truncation does not switch the message to UCS-2
+	truncateSuffix = "..."
+)
+
+// the GSM 03.38 basic character set, each character using one septet
+const gsmBasicCharacters = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// the GSM 03.38 extension table characters, each one using two septets (escape + character)
+const gsmExtensionCharacters = "\f^{}\\[~]|€"
+
+var gsmCharacterLengths = createGSMCharacterLengths()
+
+func createGSMCharacterLengths() map[rune]int {
+	lengths := make(map[rune]int)
+	for _, r := range gsmBasicCharacters {
+		lengths[r] = 1
+	}
+	for _, r := range gsmExtensionCharacters {
+		lengths[r] = 2
+	}
+
+	return lengths
+}
+
+// isGSMText returns true if the text can be sent with the GSM 7-bit encoding, otherwise the message is sent as UCS-2
+// and the segments hold less than half of the characters
+func isGSMText(text string) bool {
+	for _, r := range text {
+		_, found := gsmCharacterLengths[r]
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// characterLength returns the number of septets (GSM) or of 16-bit code units (UCS-2) used by the character
+func characterLength(r rune, gsm bool) int {
+	if gsm {
+		return gsmCharacterLengths[r]
+	}
+	if r > 0xFFFF {
+		// encoded as a surrogate pair
+		return 2
+	}
+
+	return 1
+}
+
+func messageLength(text string, gsm bool) int {
+	length := 0
+	for _, r := range text {
+		length += characterLength(r, gsm)
+	}
+
+	return length
+}
+
+func messageCapacity(gsm bool, maxSegments int) int {
+	switch {
+	case gsm && maxSegments == 1:
+		return gsmSingleSegmentLength
+	case gsm:
+		return gsmMultiSegmentLength * maxSegments
+	case maxSegments == 1:
+		return ucsSingleSegmentLength
+	default:
+		return ucsMultiSegmentLength * maxSegments
+	}
+}
+
+// fitMessage shortens the text so it fits in the provided number of SMS segments, taking the encoding into account
+func fitMessage(text string, maxSegments int) string {
+	gsm := isGSMText(text)
+	capacity := messageCapacity(gsm, maxSegments)
+	if messageLength(text, gsm) <= capacity {
+		return text
+	}
+
+	available := capacity - messageLength(truncateSuffix, gsm)
+	length := 0
+	for i, r := range text {
+		length += characterLength(r, gsm)
+		if length > available {
+			return text[:i] + truncateSuffix
+		}
+	}
+
+	return text
+}
@@ -0,0 +1,56 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsGSMText(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isGSMText("[ERROR] node-0: node is offline, nonce 123 @ 10:11 (Δ=5) {shard 0} €"))
+	assert.False(t, isGSMText("node is offline …"))
+	assert.False(t, isGSMText("nodul e oprit: ț"))
+	assert.False(t, isGSMText("node is offline 🚨"))
+}
+
+func TestMessageLength(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 5, messageLength("nonce", true))
+	assert.Equal(t, 5, messageLength("{0}", true))
+	assert.Equal(t, 2, messageLength("ț…", false))
+	assert.Equal(t, 3, messageLength("a🚨", false))
+}
+
+func TestFitMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("short message should not change", func(t *testing.T) {
+		assert.Equal(t, "node is offline", fitMessage("node is offline", 1))
+	})
+	t.Run("GSM message should fit in a single segment", func(t *testing.T) {
+		message := fitMessage(strings.Repeat("a", 200), 1)
+		assert.Equal(t, strings.Repeat("a", 157)+"...", message)
+		assert.Equal(t, gsmSingleSegmentLength, messageLength(message, true))
+	})
+	t.Run("GSM message should fit in multiple segments", func(t *testing.T) {
+		message := fitMessage(strings.Repeat("a", 400), 2)
+		assert.Equal(t, 2*gsmMultiSegmentLength, messageLength(message, true))
+	})
+	t.Run("extension characters should count double", func(t *testing.T) {
+		message := fitMessage(strings.Repeat("{", 100), 1)
+		assert.Equal(t, strings.Repeat("{", 78)+"...", message)
+	})
+	t.Run("UCS-2 message should fit in a single segment", func(t *testing.T) {
+		message := fitMessage(strings.Repeat("ț", 100), 1)
+		assert.Equal(t, strings.Repeat("ț", 67)+"...", message)
+		assert.Equal(t, ucsSingleSegmentLength, messageLength(message, false))
+	})
+	t.Run("UCS-2 message should not split a surrogate pair", func(t *testing.T) {
+		message := fitMessage("a"+strings.Repeat("🚨", 40), 1)
+		assert.Equal(t, "a"+strings.Repeat("🚨", 33)+"...", message)
+	})
+}
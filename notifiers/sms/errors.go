@@ -0,0 +1,18 @@
+package sms
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errNilGateway = errors.New("nil gateway")
+var errEmptyAccountSID = errors.New("empty account SID")
+var errEmptyAuthToken = errors.New("empty auth token")
+var errEmptySender = errors.New("empty sender, provide either the From number or the messaging service SID")
+var errEmptyUrl = errors.New("empty URL")
+var errInvalidTemplate = errors.New("invalid template")
+var errInvalidMethod = errors.New("invalid HTTP method")
+var errInvalidStatusCode = errors.New("invalid status code")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
+var errNoRecipients = errors.New("no recipients")
+var errInvalidPhoneNumber = errors.New("invalid phone number")
+var errInvalidMaxSegments = errors.New("invalid maximum number of segments")
+var errInvalidDailyLimit = errors.New("invalid daily limit")
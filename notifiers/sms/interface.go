@@ -0,0 +1,29 @@
+package sms
+
+import (
+	"context"
+
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+// Provider represents the kind of SMS gateway the messages are sent through
+type Provider string
+
+const (
+	// TwilioProvider sends the messages through the Twilio Messages API
+	TwilioProvider Provider = "twilio"
+	// TemplatedProvider sends the messages through an HTTP SMS gateway called with a templated request
+	TemplatedProvider Provider = "template"
+)
+
+// HTTPClient defines the operations of the HTTP client used by the gateways
+type HTTPClient interface {
+	CallEndPoint(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error)
+	IsInterfaceNil() bool
+}
+
+// Gateway defines the operations of a SMS provider adapter
+type Gateway interface {
+	SendSMS(ctx context.Context, to string, message string) error
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,223 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+var log = logger.GetOrCreate("notifiers/sms")
+
+const (
+	defaultMaxSegments = 1
+	maxSegmentsLimit   = 10
+	dayLayout          = "2006-01-02"
+)
+
+// the E.164 format: + followed by up to 15 digits, the first one not being 0
+var phoneNumberRegex = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// ArgsSMSNotifier represents the arguments DTO for the smsNotifier constructor. The renderer is optional, the
+// built-in text being used without it
+type ArgsSMSNotifier struct {
+	Gateway     Gateway
+	Recipients  []string
+	MaxSegments int
+	DailyLimit  int
+	SendInfo    bool
+	Renderer    templating.MessageRenderer
+}
+
+type dailyCounter struct {
+	day   string
+	count int
+}
+
+type smsNotifier struct {
+	gateway     Gateway
+	recipients  []string
+	maxSegments int
+	dailyLimit  int
+	sendInfo    bool
+	renderer    templating.MessageRenderer
+	getTime     func() time.Time
+
+	mutCounters sync.Mutex
+	counters    map[string]*dailyCounter
+}
+
+// NewSMSNotifier creates a new notifier that sends the error responses as SMS to the recipients, the info responses
+// being sent only if configured. The messages are shortened to fit in MaxSegments SMS segments (0 meaning 1) and
+// each recipient receives at most DailyLimit messages per day (0 meaning no limit)
+func NewSMSNotifier(args ArgsSMSNotifier) (*smsNotifier, error) {
+	if check.IfNil(args.Gateway) {
+		return nil, errNilGateway
+	}
+	if len(args.Recipients) == 0 {
+		return nil, errNoRecipients
+	}
+	for _, recipient := range args.Recipients {
+		if !phoneNumberRegex.MatchString(recipient) {
+			return nil, fmt.Errorf("%w: %q, the E.164 format is required (e.g. +40712345678)", errInvalidPhoneNumber, recipient)
+		}
+	}
+	if args.MaxSegments < 0 || args.MaxSegments > maxSegmentsLimit {
+		return nil, fmt.Errorf("%w, provided: %d, allowed: 0-%d", errInvalidMaxSegments, args.MaxSegments, maxSegmentsLimit)
+	}
+	if args.DailyLimit < 0 {
+		return nil, fmt.Errorf("%w, provided: %d", errInvalidDailyLimit, args.DailyLimit)
+	}
+
+	notifier := &smsNotifier{
+		gateway:     args.Gateway,
+		recipients:  append(make([]string, 0, len(args.Recipients)), args.Recipients...),
+		maxSegments: args.MaxSegments,
+		dailyLimit:  args.DailyLimit,
+		sendInfo:    args.SendInfo,
+		renderer:    args.Renderer,
+		getTime:     time.Now,
+		counters:    make(map[string]*dailyCounter),
+	}
+	if notifier.maxSegments == 0 {
+		notifier.maxSegments = defaultMaxSegments
+	}
+
+	return notifier, nil
+}
+
+// ProcessAlarmResponse will send the shortened alarm response to all the recipients that did not reach their daily
+// limit. A failed recipient does not stop the others, the first error being returned
+func (notifier *smsNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+	if response.Level != data.Error && !notifier.sendInfo {
+		return nil
+	}
+
+	text, err := notifier.createText(response)
+	if err != nil {
+		return err
+	}
+	message := fitMessage(text, notifier.maxSegments)
+
+	var firstErr error
+	for _, recipient := range notifier.recipients {
+		if !notifier.reserve(recipient) {
+			log.Warn("SMS daily limit reached, message dropped", "recipient", recipient,
+				"identifier", response.Identifier, "limit", notifier.dailyLimit)
+			continue
+		}
+
+		err := notifier.gateway.SendSMS(ctx, recipient, message)
+		if err != nil {
+			notifier.release(recipient)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%w for recipient %s", err, recipient)
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// reserve counts a message for the recipient, returning false if the daily limit was reached. The counters are
+// reset when the day changes
+func (notifier *smsNotifier) reserve(recipient string) bool {
+	if notifier.dailyLimit == 0 {
+		return true
+	}
+
+	notifier.mutCounters.Lock()
+	defer notifier.mutCounters.Unlock()
+
+	day := notifier.getTime().Format(dayLayout)
+	counter, found := notifier.counters[recipient]
+	if !found || counter.day != day {
+		counter = &dailyCounter{day: day}
+		notifier.counters[recipient] = counter
+	}
+	if counter.count >= notifier.dailyLimit {
+		return false
+	}
+
+	counter.count++
+
+	return true
+}
+
+// release gives back the message counted for a recipient as it could not be sent
+func (notifier *smsNotifier) release(recipient string) {
+	if notifier.dailyLimit == 0 {
+		return
+	}
+
+	notifier.mutCounters.Lock()
+	defer notifier.mutCounters.Unlock()
+
+	counter, found := notifier.counters[recipient]
+	if found && counter.count > 0 {
+		counter.count--
+	}
+}
+
+// createText returns the text of the message, rendered from the templates if a renderer was provided. The rendered
+// title and body are joined on a single line
+func (notifier *smsNotifier) createText(response data.AlarmResponse) (string, error) {
+	if check.IfNil(notifier.renderer) {
+		return createMessage(response), nil
+	}
+
+	message, err := notifier.renderer.Render(response)
+	if err != nil {
+		return "", err
+	}
+
+	title := strings.Join(strings.Fields(message.Title), " ")
+	body := strings.Join(strings.Fields(message.Body), " ")
+	if len(title) == 0 || len(body) == 0 {
+		return title + body, nil
+	}
+
+	return title + ": " + body, nil
+}
+
+// createMessage builds a single line text, the most important information being at the beginning as it might
+// get truncated
+func createMessage(response data.AlarmResponse) string {
+	prefix := "[" + strings.ToUpper(string(response.Level)) + "] "
+	if response.Identifier == data.SystemIdentifier {
+		fields := common.ReportSummary(response.Metrics)
+		parts := make([]string, 0, len(fields))
+		for _, field := range fields {
+			parts = append(parts, field.Name+": "+field.Value)
+		}
+
+		if len(parts) == 0 {
+			return prefix + "Node monitoring report"
+		}
+
+		return prefix + "Node monitoring report. " + strings.Join(parts, ", ")
+	}
+
+	text := strings.Join(strings.Fields(response.Data), " ")
+	if len(text) == 0 {
+		return prefix + response.Identifier
+	}
+
+	return prefix + response.Identifier + ": " + text
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *smsNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
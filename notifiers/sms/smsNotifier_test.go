@@ -0,0 +1,324 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type gatewayStub struct {
+	SendSMSCalled func(ctx context.Context, to string, message string) error
+}
+
+// SendSMS -
+func (stub *gatewayStub) SendSMS(ctx context.Context, to string, message string) error {
+	if stub.SendSMSCalled != nil {
+		return stub.SendSMSCalled(ctx, to, message)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *gatewayStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createMockArgsSMSNotifier() ArgsSMSNotifier {
+	return ArgsSMSNotifier{
+		Gateway:    &gatewayStub{},
+		Recipients: []string{"+40712345678", "+15005550006"},
+	}
+}
+
+func createTestResponse() data.AlarmResponse {
+	return data.AlarmResponse{
+		Identifier: "node-0",
+		Level:      data.Error,
+		Data:       "node is offline\n  last nonce: 123",
+	}
+}
+
+func TestNewSMSNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil gateway should error", func(t *testing.T) {
+		args := createMockArgsSMSNotifier()
+		args.Gateway = nil
+
+		notifier, err := NewSMSNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilGateway, err)
+	})
+	t.Run("no recipients should error", func(t *testing.T) {
+		args := createMockArgsSMSNotifier()
+		args.Recipients = nil
+
+		notifier, err := NewSMSNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNoRecipients, err)
+	})
+	t.Run("invalid phone number should error", func(t *testing.T) {
+		args := createMockArgsSMSNotifier()
+		args.Recipients = []string{"+40712345678", "0712 345 678"}
+
+		notifier, err := NewSMSNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidPhoneNumber))
+		assert.True(t, strings.Contains(err.Error(), "0712 345 678"))
+	})
+	t.Run("invalid maximum number of segments should error", func(t *testing.T) {
+		args := createMockArgsSMSNotifier()
+		args.MaxSegments = maxSegmentsLimit + 1
+
+		notifier, err := NewSMSNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidMaxSegments))
+	})
+	t.Run("invalid daily limit should error", func(t *testing.T) {
+		args := createMockArgsSMSNotifier()
+		args.DailyLimit = -1
+
+		notifier, err := NewSMSNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidDailyLimit))
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := NewSMSNotifier(createMockArgsSMSNotifier())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, defaultMaxSegments, notifier.maxSegments)
+	})
+}
+
+func TestSMSNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no event and info responses should not send by default", func(t *testing.T) {
+		args := createMockArgsSMSNotifier()
+		args.Gateway = &gatewayStub{
+			SendSMSCalled: func(ctx context.Context, to string, message string) error {
+				assert.Fail(t, "should have not sent")
+				return nil
+			},
+		}
+		notifier, _ := NewSMSNotifier(args)
+
+		response := createTestResponse()
+		response.Level = data.NoEvent
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+		response.Level = data.Info
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+	})
+	t.Run("should send to all the recipients", func(t *testing.T) {
+		sent := make(map[string]string)
+		args := createMockArgsSMSNotifier()
+		args.Gateway = &gatewayStub{
+			SendSMSCalled: func(ctx context.Context, to string, message string) error {
+				sent[to] = message
+				return nil
+			},
+		}
+		notifier, _ := NewSMSNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{
+			"+40712345678": "[ERROR] node-0: node is offline last nonce: 123",
+			"+15005550006": "[ERROR] node-0: node is offline last nonce: 123",
+		}, sent)
+	})
+	t.Run("info report should be summarized if info is enabled", func(t *testing.T) {
+		var sentMessage string
+		args := createMockArgsSMSNotifier()
+		args.Recipients = []string{"+40712345678"}
+		args.SendInfo = true
+		args.Gateway = &gatewayStub{
+			SendSMSCalled: func(ctx context.Context, to string, message string) error {
+				sentMessage = message
+				return nil
+			},
+		}
+		notifier, _ := NewSMSNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{
+			Identifier: data.SystemIdentifier,
+			Level:      data.Info,
+			Data:       "a long report",
+			Metrics: map[string]float64{
+				data.UptimeMetric:           7200,
+				data.ProcessingErrorsMetric: 2,
+				data.AlarmsWithErrorMetric:  1,
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "[INFO] Node monitoring report. Uptime: 2h0m0s, Processing errors: 2, Alarms with error: 1", sentMessage)
+	})
+	t.Run("long message should be shortened", func(t *testing.T) {
+		var sentMessage string
+		args := createMockArgsSMSNotifier()
+		args.Recipients = []string{"+40712345678"}
+		args.MaxSegments = 2
+		args.Gateway = &gatewayStub{
+			SendSMSCalled: func(ctx context.Context, to string, message string) error {
+				sentMessage = message
+				return nil
+			},
+		}
+		notifier, _ := NewSMSNotifier(args)
+
+		response := createTestResponse()
+		response.Data = strings.Repeat("a", 1000)
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+		assert.Equal(t, 2*gsmMultiSegmentLength, messageLength(sentMessage, true))
+		assert.True(t, strings.HasSuffix(sentMessage, truncateSuffix))
+	})
+	t.Run("should send the rendered texts on a single line", func(t *testing.T) {
+		sentMessages := make([]string, 0)
+		args := createMockArgsSMSNotifier()
+		args.Recipients = []string{"+40712345678"}
+		args.Gateway = &gatewayStub{
+			SendSMSCalled: func(ctx context.Context, to string, message string) error {
+				sentMessages = append(sentMessages, message)
+				return nil
+			},
+		}
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				if len(response.Data) == 0 {
+					return templating.Message{Body: "body only"}, nil
+				}
+				return templating.Message{Title: " rendered title ", Body: "rendered\n  body"}, nil
+			},
+		}
+		notifier, _ := NewSMSNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Nil(t, err)
+		err = notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "node-0", Level: data.Error})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"rendered title: rendered body", "body only"}, sentMessages)
+	})
+	t.Run("render error should error", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		args := createMockArgsSMSNotifier()
+		args.Gateway = &gatewayStub{
+			SendSMSCalled: func(ctx context.Context, to string, message string) error {
+				assert.Fail(t, "should have not sent")
+				return nil
+			},
+		}
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{}, expectedErr
+			},
+		}
+		notifier, _ := NewSMSNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("failed recipient should not stop the others", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		sentTo := make([]string, 0)
+		args := createMockArgsSMSNotifier()
+		args.Gateway = &gatewayStub{
+			SendSMSCalled: func(ctx context.Context, to string, message string) error {
+				sentTo = append(sentTo, to)
+				if to == "+40712345678" {
+					return expectedErr
+				}
+				return nil
+			},
+		}
+		notifier, _ := NewSMSNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.Equal(t, "expected error for recipient +40712345678", err.Error())
+		assert.Equal(t, []string{"+40712345678", "+15005550006"}, sentTo)
+	})
+	t.Run("daily limit should be applied per recipient and reset the next day", func(t *testing.T) {
+		numSent := make(map[string]int)
+		failFirst := true
+		args := createMockArgsSMSNotifier()
+		args.DailyLimit = 2
+		args.Gateway = &gatewayStub{
+			SendSMSCalled: func(ctx context.Context, to string, message string) error {
+				if to == "+15005550006" && failFirst {
+					failFirst = false
+					return errors.New("gateway error")
+				}
+				numSent[to]++
+				return nil
+			},
+		}
+		notifier, _ := NewSMSNotifier(args)
+		currentTime := time.Date(2022, 4, 5, 23, 0, 0, 0, time.Local)
+		notifier.getTime = func() time.Time {
+			return currentTime
+		}
+
+		for i := 0; i < 4; i++ {
+			_ = notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		}
+		// the failed message is not counted
+		assert.Equal(t, map[string]int{"+40712345678": 2, "+15005550006": 2}, numSent)
+
+		currentTime = currentTime.Add(2 * time.Hour)
+		_ = notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Equal(t, map[string]int{"+40712345678": 3, "+15005550006": 3}, numSent)
+	})
+	t.Run("should work with a local stub gateway", func(t *testing.T) {
+		mut := sync.Mutex{}
+		received := make([]map[string]string, 0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			message := make(map[string]string)
+			err := json.NewDecoder(r.Body).Decode(&message)
+			require.Nil(t, err)
+
+			mut.Lock()
+			received = append(received, message)
+			mut.Unlock()
+		}))
+		defer server.Close()
+
+		httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second)
+		gateway, _ := NewTemplatedGateway(ArgsTemplatedGateway{
+			HTTPClient: httpClient,
+			Url:        server.URL,
+			From:       "NodeMon",
+		})
+		args := createMockArgsSMSNotifier()
+		args.Gateway = gateway
+		args.DailyLimit = 1
+		notifier, _ := NewSMSNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Nil(t, err)
+		err = notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Nil(t, err)
+
+		mut.Lock()
+		defer mut.Unlock()
+		assert.Equal(t, []map[string]string{
+			{"from": "NodeMon", "to": "+40712345678", "message": "[ERROR] node-0: node is offline last nonce: 123"},
+			{"from": "NodeMon", "to": "+15005550006", "message": "[ERROR] node-0: node is offline last nonce: 123"},
+		}, received)
+	})
+}
@@ -0,0 +1,207 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+const (
+	defaultMethod = http.MethodPost
+	defaultBody   = `{"from": {{json .From}}, "to": {{json .To}}, "message": {{json .Message}}}`
+)
+
+// ArgsTemplatedGateway represents the arguments DTO for the templatedGateway constructor. The Url, Body and the
+// Headers values are text/template definitions executed on each message, having the To, From and Message fields
+type ArgsTemplatedGateway struct {
+	HTTPClient          HTTPClient
+	Url                 string
+	Method              string
+	Headers             map[string]string
+	Body                string
+	From                string
+	ExpectedStatusCodes []int
+}
+
+type messageData struct {
+	To      string
+	From    string
+	Message string
+}
+
+var templateFunctions = template.FuncMap{
+	"json": toJson,
+}
+
+type templatedGateway struct {
+	httpClient          HTTPClient
+	method              string
+	from                string
+	urlTemplate         *template.Template
+	bodyTemplate        *template.Template
+	headersTemplates    map[string]*template.Template
+	expectedStatusCodes map[int]struct{}
+}
+
+// NewTemplatedGateway creates a gateway that calls an HTTP SMS gateway with a request built from templates. The
+// values can be escaped with the json function for JSON bodies or with the builtin urlquery function for query
+// strings and forms. An empty method means POST, an empty body means a JSON object with the from, to and message
+// fields (no body for GET) and no expected status codes means any 2xx status code
+func NewTemplatedGateway(args ArgsTemplatedGateway) (*templatedGateway, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(strings.TrimSpace(args.Url)) == 0 {
+		return nil, errEmptyUrl
+	}
+
+	gateway := &templatedGateway{
+		httpClient:          args.HTTPClient,
+		method:              strings.ToUpper(strings.TrimSpace(args.Method)),
+		from:                args.From,
+		headersTemplates:    make(map[string]*template.Template, len(args.Headers)),
+		expectedStatusCodes: make(map[int]struct{}, len(args.ExpectedStatusCodes)),
+	}
+	if len(gateway.method) == 0 {
+		gateway.method = defaultMethod
+	}
+	if !isValidMethod(gateway.method) {
+		return nil, fmt.Errorf("%w: %q", errInvalidMethod, args.Method)
+	}
+	for _, statusCode := range args.ExpectedStatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return nil, fmt.Errorf("%w: %d", errInvalidStatusCode, statusCode)
+		}
+		gateway.expectedStatusCodes[statusCode] = struct{}{}
+	}
+
+	body := args.Body
+	if len(strings.TrimSpace(body)) == 0 && gateway.method != http.MethodGet {
+		body = defaultBody
+	}
+
+	var err error
+	gateway.urlTemplate, err = parseTemplate("Url", args.Url)
+	if err != nil {
+		return nil, err
+	}
+	gateway.bodyTemplate, err = parseTemplate("Body", body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range args.Headers {
+		gateway.headersTemplates[key], err = parseTemplate("header "+key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return gateway, nil
+}
+
+// SendSMS will render the templates for the message and call the gateway
+func (gateway *templatedGateway) SendSMS(ctx context.Context, to string, message string) error {
+	value := messageData{
+		To:      to,
+		From:    gateway.from,
+		Message: message,
+	}
+
+	url, err := executeTemplate(gateway.urlTemplate, value)
+	if err != nil {
+		return err
+	}
+	body, err := executeTemplate(gateway.bodyTemplate, value)
+	if err != nil {
+		return err
+	}
+	headers := make(map[string]string, len(gateway.headersTemplates))
+	for key, tmpl := range gateway.headersTemplates {
+		headers[key], err = executeTemplate(tmpl, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := gateway.httpClient.CallEndPoint(ctx, httpWrapper.Request{
+		Method:  gateway.method,
+		Url:     strings.TrimSpace(url),
+		Headers: headers,
+		Body:    []byte(body),
+	})
+	if err != nil {
+		return err
+	}
+	if !gateway.isExpectedStatusCode(result.StatusCode) {
+		return fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, result.StatusCode,
+			formatting.Truncate(string(result.Body), maxErrorBodyLength))
+	}
+
+	return nil
+}
+
+func (gateway *templatedGateway) isExpectedStatusCode(statusCode int) bool {
+	if len(gateway.expectedStatusCodes) == 0 {
+		return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+	}
+
+	_, found := gateway.expectedStatusCodes[statusCode]
+
+	return found
+}
+
+// toJson encodes the value as JSON, useful for embedding strings in JSON payloads: "text": {{json .Message}}
+func toJson(value interface{}) (string, error) {
+	buff, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buff), nil
+}
+
+func parseTemplate(name string, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Funcs(templateFunctions).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s: %s", errInvalidTemplate, name, err.Error())
+	}
+
+	return tmpl, nil
+}
+
+func executeTemplate(tmpl *template.Template, value messageData) (string, error) {
+	buff := bytes.Buffer{}
+	err := tmpl.Execute(&buff, value)
+	if err != nil {
+		return "", err
+	}
+
+	return buff.String(), nil
+}
+
+// isValidMethod checks that the method is a non-empty token made of uppercase letters
+func isValidMethod(method string) bool {
+	if len(method) == 0 {
+		return false
+	}
+	for _, c := range method {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (gateway *templatedGateway) IsInterfaceNil() bool {
+	return gateway == nil
+}
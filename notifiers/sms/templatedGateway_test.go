@@ -0,0 +1,137 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockArgsTemplatedGateway() ArgsTemplatedGateway {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second)
+
+	return ArgsTemplatedGateway{
+		HTTPClient: httpClient,
+		Url:        "http://127.0.0.1/send",
+		From:       "NodeMon",
+	}
+}
+
+func TestNewTemplatedGateway(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsTemplatedGateway()
+		args.HTTPClient = nil
+
+		gateway, err := NewTemplatedGateway(args)
+		assert.True(t, check.IfNil(gateway))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty URL should error", func(t *testing.T) {
+		args := createMockArgsTemplatedGateway()
+		args.Url = " "
+
+		gateway, err := NewTemplatedGateway(args)
+		assert.True(t, check.IfNil(gateway))
+		assert.Equal(t, errEmptyUrl, err)
+	})
+	t.Run("invalid method should error", func(t *testing.T) {
+		args := createMockArgsTemplatedGateway()
+		args.Method = "po st"
+
+		gateway, err := NewTemplatedGateway(args)
+		assert.True(t, check.IfNil(gateway))
+		assert.True(t, errors.Is(err, errInvalidMethod))
+	})
+	t.Run("invalid status code should error", func(t *testing.T) {
+		args := createMockArgsTemplatedGateway()
+		args.ExpectedStatusCodes = []int{2000}
+
+		gateway, err := NewTemplatedGateway(args)
+		assert.True(t, check.IfNil(gateway))
+		assert.True(t, errors.Is(err, errInvalidStatusCode))
+	})
+	t.Run("invalid template should error", func(t *testing.T) {
+		args := createMockArgsTemplatedGateway()
+		args.Headers = map[string]string{"X-Api-Key": "{{.Key"}
+
+		gateway, err := NewTemplatedGateway(args)
+		assert.True(t, check.IfNil(gateway))
+		assert.True(t, errors.Is(err, errInvalidTemplate))
+		assert.True(t, strings.Contains(err.Error(), "X-Api-Key"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		gateway, err := NewTemplatedGateway(createMockArgsTemplatedGateway())
+		assert.False(t, check.IfNil(gateway))
+		assert.Nil(t, err)
+		assert.Equal(t, defaultMethod, gateway.method)
+	})
+}
+
+func TestTemplatedGateway_SendSMS(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default body should be JSON", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, `{"from": "NodeMon", "to": "+40712345678", "message": "[ERROR] node-0: \"down\""}`, string(body))
+		}))
+		defer server.Close()
+
+		args := createMockArgsTemplatedGateway()
+		args.Url = server.URL
+		args.Headers = map[string]string{"X-Api-Key": "secret"}
+		gateway, _ := NewTemplatedGateway(args)
+
+		err := gateway.SendSMS(context.Background(), "+40712345678", `[ERROR] node-0: "down"`)
+		assert.Nil(t, err)
+	})
+	t.Run("GET with query parameters should work", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "+40712345678", r.URL.Query().Get("to"))
+			assert.Equal(t, "node is offline & syncing", r.URL.Query().Get("text"))
+
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Empty(t, body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		args := createMockArgsTemplatedGateway()
+		args.Method = "get"
+		args.Url = server.URL + "/send?to={{urlquery .To}}&text={{urlquery .Message}}"
+		args.ExpectedStatusCodes = []int{http.StatusAccepted}
+		gateway, _ := NewTemplatedGateway(args)
+
+		err := gateway.SendSMS(context.Background(), "+40712345678", "node is offline & syncing")
+		assert.Nil(t, err)
+	})
+	t.Run("unexpected status code should error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusPaymentRequired)
+			_, _ = w.Write([]byte("insufficient credit"))
+		}))
+		defer server.Close()
+
+		args := createMockArgsTemplatedGateway()
+		args.Url = server.URL
+		gateway, _ := NewTemplatedGateway(args)
+
+		err := gateway.SendSMS(context.Background(), "+40712345678", "message")
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.Equal(t, "unexpected status code 402: insufficient credit", err.Error())
+	})
+}
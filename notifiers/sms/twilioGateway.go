@@ -0,0 +1,125 @@
+package sms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+const (
+	// DefaultTwilioApiUrl is the Twilio REST API base URL
+	DefaultTwilioApiUrl = "https://api.twilio.com"
+
+	formContentType     = "application/x-www-form-urlencoded"
+	maxErrorBodyLength  = 512
+	contentTypeHeader   = "Content-Type"
+	authorizationHeader = "Authorization"
+)
+
+// ArgsTwilioGateway represents the arguments DTO for the twilioGateway constructor. The messages are sent either
+// from the From number or through the messaging service, if its SID is provided
+type ArgsTwilioGateway struct {
+	HTTPClient          HTTPClient
+	ApiUrl              string
+	AccountSID          string
+	AuthToken           string
+	From                string
+	MessagingServiceSID string
+}
+
+type twilioErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type twilioGateway struct {
+	httpClient          HTTPClient
+	messagesUrl         string
+	authorization       string
+	from                string
+	messagingServiceSID string
+}
+
+// NewTwilioGateway creates a gateway that sends the messages through the Twilio Messages API (or any provider
+// implementing the same form based API). An empty API URL means the Twilio one
+func NewTwilioGateway(args ArgsTwilioGateway) (*twilioGateway, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(args.AccountSID) == 0 {
+		return nil, errEmptyAccountSID
+	}
+	if len(args.AuthToken) == 0 {
+		return nil, errEmptyAuthToken
+	}
+	if len(args.From) == 0 && len(args.MessagingServiceSID) == 0 {
+		return nil, errEmptySender
+	}
+
+	apiUrl := strings.TrimSuffix(args.ApiUrl, "/")
+	if len(apiUrl) == 0 {
+		apiUrl = DefaultTwilioApiUrl
+	}
+	credentials := base64.StdEncoding.EncodeToString([]byte(args.AccountSID + ":" + args.AuthToken))
+
+	return &twilioGateway{
+		httpClient:          args.HTTPClient,
+		messagesUrl:         apiUrl + "/2010-04-01/Accounts/" + url.PathEscape(args.AccountSID) + "/Messages.json",
+		authorization:       "Basic " + credentials,
+		from:                args.From,
+		messagingServiceSID: args.MessagingServiceSID,
+	}, nil
+}
+
+// SendSMS will create a message resource for the provided recipient
+func (gateway *twilioGateway) SendSMS(ctx context.Context, to string, message string) error {
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("Body", message)
+	if len(gateway.messagingServiceSID) > 0 {
+		form.Set("MessagingServiceSid", gateway.messagingServiceSID)
+	} else {
+		form.Set("From", gateway.from)
+	}
+
+	result, err := gateway.httpClient.CallEndPoint(ctx, httpWrapper.Request{
+		Method: http.MethodPost,
+		Url:    gateway.messagesUrl,
+		Headers: map[string]string{
+			contentTypeHeader:   formContentType,
+			authorizationHeader: gateway.authorization,
+		},
+		Body: []byte(form.Encode()),
+	})
+	if err != nil {
+		return err
+	}
+	if result.StatusCode < http.StatusOK || result.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, result.StatusCode, describeTwilioError(result.Body))
+	}
+
+	return nil
+}
+
+func describeTwilioError(body []byte) string {
+	response := twilioErrorResponse{}
+	err := json.Unmarshal(body, &response)
+	if err != nil || len(response.Message) == 0 {
+		return formatting.Truncate(string(body), maxErrorBodyLength)
+	}
+
+	return fmt.Sprintf("error %d, %s", response.Code, formatting.Truncate(response.Message, maxErrorBodyLength))
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (gateway *twilioGateway) IsInterfaceNil() bool {
+	return gateway == nil
+}
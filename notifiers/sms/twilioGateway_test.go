@@ -0,0 +1,141 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsTwilioGateway() ArgsTwilioGateway {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second)
+
+	return ArgsTwilioGateway{
+		HTTPClient: httpClient,
+		AccountSID: "AC123",
+		AuthToken:  "token",
+		From:       "+15005550006",
+	}
+}
+
+func TestNewTwilioGateway(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsTwilioGateway()
+		args.HTTPClient = nil
+
+		gateway, err := NewTwilioGateway(args)
+		assert.True(t, check.IfNil(gateway))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty account SID should error", func(t *testing.T) {
+		args := createMockArgsTwilioGateway()
+		args.AccountSID = ""
+
+		gateway, err := NewTwilioGateway(args)
+		assert.True(t, check.IfNil(gateway))
+		assert.Equal(t, errEmptyAccountSID, err)
+	})
+	t.Run("empty auth token should error", func(t *testing.T) {
+		args := createMockArgsTwilioGateway()
+		args.AuthToken = ""
+
+		gateway, err := NewTwilioGateway(args)
+		assert.True(t, check.IfNil(gateway))
+		assert.Equal(t, errEmptyAuthToken, err)
+	})
+	t.Run("empty sender should error", func(t *testing.T) {
+		args := createMockArgsTwilioGateway()
+		args.From = ""
+
+		gateway, err := NewTwilioGateway(args)
+		assert.True(t, check.IfNil(gateway))
+		assert.Equal(t, errEmptySender, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		gateway, err := NewTwilioGateway(createMockArgsTwilioGateway())
+		assert.False(t, check.IfNil(gateway))
+		assert.Nil(t, err)
+		assert.Equal(t, DefaultTwilioApiUrl+"/2010-04-01/Accounts/AC123/Messages.json", gateway.messagesUrl)
+	})
+}
+
+func TestTwilioGateway_SendSMS(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should post the form", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", r.URL.Path)
+			assert.Equal(t, formContentType, r.Header.Get(contentTypeHeader))
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "AC123", username)
+			assert.Equal(t, "token", password)
+
+			body, _ := ioutil.ReadAll(r.Body)
+			form, err := url.ParseQuery(string(body))
+			require.Nil(t, err)
+			assert.Equal(t, url.Values{
+				"To":   []string{"+40712345678"},
+				"From": []string{"+15005550006"},
+				"Body": []string{"[ERROR] node-0: node is offline"},
+			}, form)
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"sid": "SM123", "status": "queued"}`))
+		}))
+		defer server.Close()
+
+		args := createMockArgsTwilioGateway()
+		args.ApiUrl = server.URL + "/"
+		gateway, _ := NewTwilioGateway(args)
+
+		err := gateway.SendSMS(context.Background(), "+40712345678", "[ERROR] node-0: node is offline")
+		assert.Nil(t, err)
+	})
+	t.Run("messaging service should replace the From number", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			form, _ := url.ParseQuery(string(body))
+			assert.Equal(t, "MG123", form.Get("MessagingServiceSid"))
+			assert.Empty(t, form.Get("From"))
+
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		args := createMockArgsTwilioGateway()
+		args.ApiUrl = server.URL
+		args.MessagingServiceSID = "MG123"
+		gateway, _ := NewTwilioGateway(args)
+
+		err := gateway.SendSMS(context.Background(), "+40712345678", "message")
+		assert.Nil(t, err)
+	})
+	t.Run("error response should error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code": 21211, "message": "The 'To' number is not a valid phone number.", "status": 400}`))
+		}))
+		defer server.Close()
+
+		args := createMockArgsTwilioGateway()
+		args.ApiUrl = server.URL
+		gateway, _ := NewTwilioGateway(args)
+
+		err := gateway.SendSMS(context.Background(), "+40712345678", "message")
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.Equal(t, "unexpected status code 400: error 21211, The 'To' number is not a valid phone number.", err.Error())
+	})
+}
@@ -0,0 +1,10 @@
+package syslog
+
+import "errors"
+
+var errInvalidNetwork = errors.New("invalid network")
+var errEmptyAddress = errors.New("empty address")
+var errInvalidFacility = errors.New("invalid facility")
+var errInvalidTimeout = errors.New("invalid timeout")
+var errInvalidHeaderField = errors.New("invalid header field")
+var errLocalSyslogNotFound = errors.New("local syslog socket not found")
@@ -0,0 +1,203 @@
+package syslog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+)
+
+const (
+	// DefaultEnterpriseNumber is the private enterprise number reserved for documentation (RFC 5612) used in the
+	// structured data IDs when none is configured
+	DefaultEnterpriseNumber = 32473
+
+	nilValue             = "-"
+	version              = "1"
+	timestampLayout      = "2006-01-02T15:04:05.000000Z07:00"
+	maxHostnameLength    = 255
+	maxAppNameLength     = 48
+	maxProcIDLength      = 128
+	maxParamNameLength   = 32
+	maxMessageLength     = 1024
+	byteOrderMark        = "\xef\xbb\xbf"
+	alarmMessageID       = "alarm"
+	reportMessageID      = "report"
+	alarmElementName     = "alarm"
+	labelsElementName    = "labels"
+	metricsElementName   = "metrics"
+	lineBreakReplacement = " | "
+)
+
+// RFC 5424 severities
+const (
+	severityError         = 3
+	severityNotice        = 5
+	severityInformational = 6
+)
+
+var severities = map[data.EventLevel]int{
+	data.Error: severityError,
+	data.Info:  severityInformational,
+}
+
+var facilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// messageHeader holds the RFC 5424 header fields that are the same for all the messages of a notifier
+type messageHeader struct {
+	facility         int
+	hostname         string
+	appName          string
+	procID           string
+	enterpriseNumber int
+}
+
+// formatMessage creates the RFC 5424 message for the alarm response. The labels and metrics are added as
+// structured data elements and the line breaks of the data are replaced as many collectors split on them
+func formatMessage(header messageHeader, response data.AlarmResponse, timestamp time.Time) string {
+	severity, found := severities[response.Level]
+	if !found {
+		severity = severityNotice
+	}
+	messageID := alarmMessageID
+	if response.Identifier == data.SystemIdentifier {
+		messageID = reportMessageID
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("<" + strconv.Itoa(header.facility*8+severity) + ">" + version)
+	builder.WriteString(" " + timestamp.Format(timestampLayout))
+	builder.WriteString(" " + header.hostname)
+	builder.WriteString(" " + header.appName)
+	builder.WriteString(" " + header.procID)
+	builder.WriteString(" " + messageID + " ")
+	builder.WriteString(formatStructuredData(header.enterpriseNumber, response))
+
+	message := joinLines(response.Data)
+	if len(message) > 0 {
+		builder.WriteString(" " + byteOrderMark + formatting.Truncate(message, maxMessageLength))
+	}
+
+	return builder.String()
+}
+
+func joinLines(text string) string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, lineBreakReplacement)
+}
+
+func formatStructuredData(enterpriseNumber int, response data.AlarmResponse) string {
+	suffix := "@" + strconv.Itoa(enterpriseNumber)
+
+	builder := strings.Builder{}
+	builder.WriteString("[" + alarmElementName + suffix)
+	writeParam(&builder, "identifier", response.Identifier)
+	writeParam(&builder, "level", string(response.Level))
+	builder.WriteString("]")
+
+	if len(response.Labels) > 0 {
+		builder.WriteString("[" + labelsElementName + suffix)
+		for _, key := range common.SortedLabelKeys(response.Labels) {
+			writeParam(&builder, key, response.Labels[key])
+		}
+		builder.WriteString("]")
+	}
+
+	if len(response.Metrics) > 0 {
+		builder.WriteString("[" + metricsElementName + suffix)
+		for _, key := range sortedMetricKeys(response.Metrics) {
+			writeParam(&builder, key, strconv.FormatFloat(response.Metrics[key], 'f', -1, 64))
+		}
+		builder.WriteString("]")
+	}
+
+	return builder.String()
+}
+
+func writeParam(builder *strings.Builder, name string, value string) {
+	builder.WriteString(" " + sanitizeParamName(name) + `="` + escapeParamValue(value) + `"`)
+}
+
+// sanitizeParamName replaces the characters not allowed in a SD-NAME with _
+func sanitizeParamName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+	if len(sanitized) == 0 {
+		return "_"
+	}
+	if len(sanitized) > maxParamNameLength {
+		return sanitized[:maxParamNameLength]
+	}
+
+	return sanitized
+}
+
+func escapeParamValue(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+	return replacer.Replace(value)
+}
+
+// checkHeaderField validates a header field, an empty field being replaced with the nil value
+func checkHeaderField(name string, value string, maxLength int) (string, error) {
+	if len(value) == 0 {
+		return nilValue, nil
+	}
+	if len(value) > maxLength {
+		return "", fmt.Errorf("%w %s: maximum length is %d", errInvalidHeaderField, name, maxLength)
+	}
+	for _, r := range value {
+		if r <= ' ' || r > '~' {
+			return "", fmt.Errorf("%w %s: only printable ASCII characters without spaces are allowed", errInvalidHeaderField, name)
+		}
+	}
+
+	return value, nil
+}
+
+func sortedMetricKeys(metrics map[string]float64) []string {
+	keys := make([]string, 0, len(metrics))
+	for key := range metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
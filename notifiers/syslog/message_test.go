@@ -0,0 +1,100 @@
+package syslog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestHeader() messageHeader {
+	return messageHeader{
+		facility:         facilities["local0"],
+		hostname:         "monitor-1",
+		appName:          DefaultAppName,
+		procID:           "1234",
+		enterpriseNumber: DefaultEnterpriseNumber,
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2022, 4, 5, 10, 11, 12, 345678000, time.UTC)
+
+	t.Run("error response with labels and metrics", func(t *testing.T) {
+		response := data.AlarmResponse{
+			Identifier: "node-0",
+			Level:      data.Error,
+			Data:       "node is offline\n\n  last nonce: 123\n",
+			Labels: map[string]string{
+				"shard":      "0",
+				"node url":   "http://127.0.0.1:8080",
+				"validator]": `name "with" \ escapes]`,
+			},
+			Metrics: map[string]float64{
+				"rating": 99.5,
+			},
+		}
+
+		expected := `<131>1 2022-04-05T10:11:12.345678Z monitor-1 node-monitoring 1234 alarm ` +
+			`[alarm@32473 identifier="node-0" level="Error"]` +
+			`[labels@32473 node_url="http://127.0.0.1:8080" shard="0" validator_="name \"with\" \\ escapes\]"]` +
+			`[metrics@32473 rating="99.5"] ` + byteOrderMark + `node is offline | last nonce: 123`
+		assert.Equal(t, expected, formatMessage(createTestHeader(), response, timestamp))
+	})
+	t.Run("info report without data", func(t *testing.T) {
+		response := data.AlarmResponse{
+			Identifier: data.SystemIdentifier,
+			Level:      data.Info,
+		}
+
+		expected := `<134>1 2022-04-05T10:11:12.345678Z monitor-1 node-monitoring 1234 report ` +
+			`[alarm@32473 identifier="system" level="Info"]`
+		assert.Equal(t, expected, formatMessage(createTestHeader(), response, timestamp))
+	})
+	t.Run("long data should be truncated", func(t *testing.T) {
+		response := data.AlarmResponse{
+			Identifier: "node-0",
+			Level:      data.Error,
+			Data:       strings.Repeat("a", maxMessageLength*2),
+		}
+
+		message := formatMessage(createTestHeader(), response, timestamp)
+		parts := strings.Split(message, byteOrderMark)
+		assert.Equal(t, 2, len(parts))
+		assert.Equal(t, maxMessageLength, len([]rune(parts[1])))
+	})
+}
+
+func TestSanitizeParamName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "shard", sanitizeParamName("shard"))
+	assert.Equal(t, "node_url", sanitizeParamName("node url"))
+	assert.Equal(t, "a_b_c_d_", sanitizeParamName(`a=b]c"dé`))
+	assert.Equal(t, "_", sanitizeParamName(""))
+	assert.Equal(t, strings.Repeat("x", maxParamNameLength), sanitizeParamName(strings.Repeat("x", 40)))
+}
+
+func TestCheckHeaderField(t *testing.T) {
+	t.Parallel()
+
+	value, err := checkHeaderField("Hostname", "", maxHostnameLength)
+	assert.Nil(t, err)
+	assert.Equal(t, nilValue, value)
+
+	value, err = checkHeaderField("Hostname", "monitor-1.local", maxHostnameLength)
+	assert.Nil(t, err)
+	assert.Equal(t, "monitor-1.local", value)
+
+	_, err = checkHeaderField("AppName", "node monitoring", maxAppNameLength)
+	assert.True(t, errors.Is(err, errInvalidHeaderField))
+	assert.True(t, strings.Contains(err.Error(), "AppName"))
+
+	_, err = checkHeaderField("AppName", strings.Repeat("a", maxAppNameLength+1), maxAppNameLength)
+	assert.True(t, errors.Is(err, errInvalidHeaderField))
+}
@@ -0,0 +1,252 @@
+package syslog
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+const (
+	// LocalNetwork is the network used to write to the local syslog daemon (or journald) through its unix socket
+	LocalNetwork = ""
+	// DefaultAppName is the APP-NAME header field used when none is configured
+	DefaultAppName = "node-monitoring"
+	// DefaultFacility is the facility used when none is configured
+	DefaultFacility = "daemon"
+)
+
+// the sockets the local syslog daemons listen on, on Linux, macOS and BSD respectively
+var localSocketPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+var streamNetworks = map[string]bool{
+	"tcp":  true,
+	"tcp4": true,
+	"tcp6": true,
+	"unix": true,
+}
+
+var datagramNetworks = map[string]bool{
+	"udp":      true,
+	"udp4":     true,
+	"udp6":     true,
+	"unixgram": true,
+}
+
+// ArgsSyslogNotifier represents the arguments DTO for the syslogNotifier constructor
+type ArgsSyslogNotifier struct {
+	Network          string
+	Address          string
+	Facility         string
+	AppName          string
+	Hostname         string
+	EnterpriseNumber int
+	Timeout          time.Duration
+}
+
+type syslogNotifier struct {
+	mut     sync.Mutex
+	network string
+	address string
+	header  messageHeader
+	timeout time.Duration
+	conn    net.Conn
+	stream  bool
+}
+
+// NewSyslogNotifier creates a new notifier that writes the alarm responses as RFC 5424 messages to a syslog server
+// over UDP, TCP or unix sockets. The local network uses the local syslog daemon socket, found automatically if the
+// address is empty. The connection is established on the first message and re-established after a write error
+func NewSyslogNotifier(args ArgsSyslogNotifier) (*syslogNotifier, error) {
+	network := strings.ToLower(args.Network)
+	if network != LocalNetwork && !streamNetworks[network] && !datagramNetworks[network] {
+		return nil, fmt.Errorf("%w: %s", errInvalidNetwork, args.Network)
+	}
+	if network != LocalNetwork && len(args.Address) == 0 {
+		return nil, errEmptyAddress
+	}
+	if args.Timeout <= 0 {
+		return nil, fmt.Errorf("%w, provided: %v", errInvalidTimeout, args.Timeout)
+	}
+
+	facilityName := strings.ToLower(args.Facility)
+	if len(facilityName) == 0 {
+		facilityName = DefaultFacility
+	}
+	facility, found := facilities[facilityName]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", errInvalidFacility, args.Facility)
+	}
+
+	header, err := createMessageHeader(facility, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &syslogNotifier{
+		network: network,
+		address: args.Address,
+		header:  header,
+		timeout: args.Timeout,
+	}, nil
+}
+
+func createMessageHeader(facility int, args ArgsSyslogNotifier) (messageHeader, error) {
+	appName := args.AppName
+	if len(appName) == 0 {
+		appName = DefaultAppName
+	}
+	appName, err := checkHeaderField("AppName", appName, maxAppNameLength)
+	if err != nil {
+		return messageHeader{}, err
+	}
+
+	hostname := args.Hostname
+	if len(hostname) == 0 {
+		hostname, _ = os.Hostname()
+	}
+	hostname, err = checkHeaderField("Hostname", hostname, maxHostnameLength)
+	if err != nil {
+		return messageHeader{}, err
+	}
+
+	procID, err := checkHeaderField("ProcID", strconv.Itoa(os.Getpid()), maxProcIDLength)
+	if err != nil {
+		return messageHeader{}, err
+	}
+
+	enterpriseNumber := args.EnterpriseNumber
+	if enterpriseNumber <= 0 {
+		enterpriseNumber = DefaultEnterpriseNumber
+	}
+
+	return messageHeader{
+		facility:         facility,
+		hostname:         hostname,
+		appName:          appName,
+		procID:           procID,
+		enterpriseNumber: enterpriseNumber,
+	}, nil
+}
+
+// ProcessAlarmResponse will write the alarm response to the syslog server with the severity mapped from its
+// level. The responses without an event are ignored
+func (notifier *syslogNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	message := formatMessage(notifier.header, response, time.Now())
+
+	notifier.mut.Lock()
+	defer notifier.mut.Unlock()
+
+	err := notifier.write(ctx, message)
+	if err == nil {
+		return nil
+	}
+
+	// the server might have closed an idle stream connection, retry once on a new connection
+	notifier.closeConnection()
+	if ctx.Err() != nil {
+		return err
+	}
+
+	err = notifier.write(ctx, message)
+	if err != nil {
+		notifier.closeConnection()
+	}
+
+	return err
+}
+
+func (notifier *syslogNotifier) write(ctx context.Context, message string) error {
+	if notifier.conn == nil {
+		err := notifier.connect(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	deadline := time.Now().Add(notifier.timeout)
+	ctxDeadline, hasDeadline := ctx.Deadline()
+	if hasDeadline && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	err := notifier.conn.SetWriteDeadline(deadline)
+	if err != nil {
+		return err
+	}
+
+	if notifier.stream {
+		// octet counting framing (RFC 6587) as the message is not guaranteed to be free of line breaks
+		message = strconv.Itoa(len(message)) + " " + message
+	}
+
+	_, err = notifier.conn.Write([]byte(message))
+
+	return err
+}
+
+func (notifier *syslogNotifier) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: notifier.timeout}
+	if notifier.network != LocalNetwork {
+		conn, err := dialer.DialContext(ctx, notifier.network, notifier.address)
+		if err != nil {
+			return err
+		}
+
+		notifier.conn = conn
+		notifier.stream = streamNetworks[notifier.network]
+
+		return nil
+	}
+
+	paths := localSocketPaths
+	if len(notifier.address) > 0 {
+		paths = []string{notifier.address}
+	}
+	for _, path := range paths {
+		for _, network := range []string{"unixgram", "unix"} {
+			conn, err := dialer.DialContext(ctx, network, path)
+			if err == nil {
+				notifier.conn = conn
+				notifier.stream = streamNetworks[network]
+
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w, tried: %s", errLocalSyslogNotFound, strings.Join(paths, ", "))
+}
+
+func (notifier *syslogNotifier) closeConnection() {
+	if notifier.conn == nil {
+		return
+	}
+
+	_ = notifier.conn.Close()
+	notifier.conn = nil
+}
+
+// Close closes the connection to the syslog server, a new one being established on the next message
+func (notifier *syslogNotifier) Close() error {
+	notifier.mut.Lock()
+	defer notifier.mut.Unlock()
+
+	notifier.closeConnection()
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *syslogNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
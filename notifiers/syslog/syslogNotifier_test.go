@@ -0,0 +1,293 @@
+package syslog
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsSyslogNotifier() ArgsSyslogNotifier {
+	return ArgsSyslogNotifier{
+		Network:  "udp",
+		Address:  "127.0.0.1:514",
+		Facility: "local0",
+		Hostname: "monitor-1",
+		Timeout:  time.Second,
+	}
+}
+
+func createTestResponse() data.AlarmResponse {
+	return data.AlarmResponse{
+		Identifier: "node-0",
+		Level:      data.Error,
+		Data:       "node is offline",
+	}
+}
+
+func readPackets(t *testing.T, conn net.PacketConn, numPackets int) []string {
+	packets := make([]string, 0, numPackets)
+	buff := make([]byte, 4096)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	for i := 0; i < numPackets; i++ {
+		n, _, err := conn.ReadFrom(buff)
+		require.Nil(t, err)
+		packets = append(packets, string(buff[:n]))
+	}
+
+	return packets
+}
+
+// readFrame reads a message framed with octet counting
+func readFrame(reader *bufio.Reader) (string, error) {
+	length, err := reader.ReadString(' ')
+	if err != nil {
+		return "", err
+	}
+	size, err := strconv.Atoi(strings.TrimSpace(length))
+	if err != nil {
+		return "", err
+	}
+
+	buff := make([]byte, size)
+	_, err = io.ReadFull(reader, buff)
+
+	return string(buff), err
+}
+
+func TestNewSyslogNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid network should error", func(t *testing.T) {
+		args := createMockArgsSyslogNotifier()
+		args.Network = "ip"
+
+		notifier, err := NewSyslogNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidNetwork))
+	})
+	t.Run("empty address should error", func(t *testing.T) {
+		args := createMockArgsSyslogNotifier()
+		args.Address = ""
+
+		notifier, err := NewSyslogNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyAddress, err)
+	})
+	t.Run("invalid timeout should error", func(t *testing.T) {
+		args := createMockArgsSyslogNotifier()
+		args.Timeout = 0
+
+		notifier, err := NewSyslogNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidTimeout))
+	})
+	t.Run("invalid facility should error", func(t *testing.T) {
+		args := createMockArgsSyslogNotifier()
+		args.Facility = "local8"
+
+		notifier, err := NewSyslogNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidFacility))
+	})
+	t.Run("invalid app name should error", func(t *testing.T) {
+		args := createMockArgsSyslogNotifier()
+		args.AppName = "node monitoring"
+
+		notifier, err := NewSyslogNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidHeaderField))
+	})
+	t.Run("invalid hostname should error", func(t *testing.T) {
+		args := createMockArgsSyslogNotifier()
+		args.Hostname = "monitor 1"
+
+		notifier, err := NewSyslogNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidHeaderField))
+	})
+	t.Run("local network without address should work", func(t *testing.T) {
+		args := createMockArgsSyslogNotifier()
+		args.Network = LocalNetwork
+		args.Address = ""
+
+		notifier, err := NewSyslogNotifier(args)
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+	t.Run("should apply the defaults", func(t *testing.T) {
+		args := createMockArgsSyslogNotifier()
+		args.Network = "UDP"
+		args.Facility = ""
+
+		notifier, err := NewSyslogNotifier(args)
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, "udp", notifier.network)
+		assert.Equal(t, facilities[DefaultFacility], notifier.header.facility)
+		assert.Equal(t, DefaultAppName, notifier.header.appName)
+		assert.Equal(t, DefaultEnterpriseNumber, notifier.header.enterpriseNumber)
+	})
+}
+
+func TestSyslogNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no event should not connect", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSyslogNotifier()
+		args.Network = "tcp"
+		args.Address = "127.0.0.1:1"
+		notifier, _ := NewSyslogNotifier(args)
+
+		response := createTestResponse()
+		response.Level = data.NoEvent
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+	})
+	t.Run("UDP should send a datagram for each message", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.Nil(t, err)
+		defer func() {
+			_ = server.Close()
+		}()
+
+		args := createMockArgsSyslogNotifier()
+		args.Address = server.LocalAddr().String()
+		notifier, _ := NewSyslogNotifier(args)
+		defer func() {
+			_ = notifier.Close()
+		}()
+
+		err = notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		require.Nil(t, err)
+		response := createTestResponse()
+		response.Level = data.Info
+		err = notifier.ProcessAlarmResponse(context.Background(), response)
+		require.Nil(t, err)
+
+		packets := readPackets(t, server, 2)
+		assert.True(t, strings.HasPrefix(packets[0], "<131>1 "))
+		assert.True(t, strings.HasSuffix(packets[0], ` monitor-1 node-monitoring `+notifier.header.procID+
+			` alarm [alarm@32473 identifier="node-0" level="Error"] `+byteOrderMark+"node is offline"))
+		assert.True(t, strings.HasPrefix(packets[1], "<134>1 "))
+	})
+	t.Run("TCP should use octet counting and reconnect after the server closed the connection", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.Nil(t, err)
+		defer func() {
+			_ = listener.Close()
+		}()
+
+		messages := make(chan string, 10)
+		go func() {
+			for {
+				conn, errAccept := listener.Accept()
+				if errAccept != nil {
+					return
+				}
+
+				// each connection serves a single message
+				message, errRead := readFrame(bufio.NewReader(conn))
+				if errRead == nil {
+					messages <- message
+				}
+				_ = conn.Close()
+			}
+		}()
+
+		args := createMockArgsSyslogNotifier()
+		args.Network = "tcp"
+		args.Address = listener.Addr().String()
+		notifier, _ := NewSyslogNotifier(args)
+		defer func() {
+			_ = notifier.Close()
+		}()
+
+		err = notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		require.Nil(t, err)
+		message := <-messages
+		assert.True(t, strings.HasPrefix(message, "<131>1 "))
+		assert.True(t, strings.HasSuffix(message, "node is offline"))
+
+		// the first write on the closed connection might not fail, keep sending until one is received
+		for i := 0; i < 10; i++ {
+			_ = notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+			select {
+			case message = <-messages:
+				assert.True(t, strings.HasSuffix(message, "node is offline"))
+				return
+			case <-time.After(time.Millisecond * 100):
+			}
+		}
+		assert.Fail(t, "should have reconnected")
+	})
+	t.Run("local network should write to the provided unix socket", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "log")
+		server, err := net.ListenPacket("unixgram", path)
+		require.Nil(t, err)
+		defer func() {
+			_ = server.Close()
+		}()
+
+		args := createMockArgsSyslogNotifier()
+		args.Network = LocalNetwork
+		args.Address = path
+		notifier, _ := NewSyslogNotifier(args)
+		defer func() {
+			_ = notifier.Close()
+		}()
+
+		err = notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		require.Nil(t, err)
+
+		packets := readPackets(t, server, 1)
+		assert.True(t, strings.HasPrefix(packets[0], "<131>1 "))
+	})
+	t.Run("missing local socket should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsSyslogNotifier()
+		args.Network = LocalNetwork
+		args.Address = filepath.Join(t.TempDir(), "missing")
+		notifier, _ := NewSyslogNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.True(t, errors.Is(err, errLocalSyslogNotFound))
+	})
+	t.Run("unreachable server should error", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.Nil(t, err)
+		address := listener.Addr().String()
+		_ = listener.Close()
+
+		args := createMockArgsSyslogNotifier()
+		args.Network = "tcp"
+		args.Address = address
+		notifier, _ := NewSyslogNotifier(args)
+
+		err = notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.NotNil(t, err)
+		assert.Nil(t, notifier.conn)
+	})
+}
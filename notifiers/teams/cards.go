@@ -0,0 +1,218 @@
+package teams
+
+import (
+	"strings"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+const (
+	messageType          = "message"
+	adaptiveCardType     = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema   = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion  = "1.4"
+	adaptiveCardElement  = "AdaptiveCard"
+	fullWidth            = "Full"
+	textBlockType        = "TextBlock"
+	containerType        = "Container"
+	factSetType          = "FactSet"
+	defaultStyle         = "default"
+	pausedStatus         = "PAUSED"
+	timestampLayout      = "2006-01-02 15:04:05 MST"
+	titleSeparator       = " | "
+	maxTitleLength       = 200
+	maxFactValueLength   = 1000
+	maxDataLength        = 10000
+	bolderWeight         = "Bolder"
+	largeSize            = "Large"
+	monospaceFont        = "Monospace"
+	noSpacing            = "None"
+	mediumSpacing        = "Medium"
+	reportSectionSpacing = "Large"
+)
+
+// the container styles are rendered by Teams with the theme's red and blue accents
+var levelStyles = map[data.EventLevel]string{
+	data.Error: "attention",
+	data.Info:  "accent",
+}
+
+var levelTitles = map[data.EventLevel]string{
+	data.Error: "🚨 ERROR",
+	data.Info:  "ℹ️ INFO",
+}
+
+func levelStyle(level data.EventLevel) string {
+	style, found := levelStyles[level]
+	if !found {
+		return defaultStyle
+	}
+
+	return style
+}
+
+func levelTitle(level data.EventLevel) string {
+	title, found := levelTitles[level]
+	if !found {
+		return strings.ToUpper(string(level))
+	}
+
+	return title
+}
+
+func reportEntryStatus(entry data.ReportEntry) string {
+	if entry.Paused {
+		return pausedStatus
+	}
+
+	return strings.ToUpper(string(entry.Level))
+}
+
+// createMessage wraps the alarm response's card in the message envelope expected by the workflow webhooks
+func createMessage(response data.AlarmResponse, timestamp time.Time) message {
+	return message{
+		Type: messageType,
+		Attachments: []attachment{
+			{
+				ContentType: adaptiveCardType,
+				Content:     createCard(response, timestamp),
+			},
+		},
+	}
+}
+
+// createRenderedMessage wraps the card built from the rendered title and body in the message envelope, the header
+// being colored by the response level
+func createRenderedMessage(level data.EventLevel, rendered templating.Message) message {
+	body := []cardElement{
+		{
+			Type:  containerType,
+			Style: levelStyle(level),
+			Bleed: true,
+			Items: []cardElement{
+				{
+					Type:   textBlockType,
+					Text:   formatting.Truncate(rendered.Title, maxTitleLength),
+					Weight: bolderWeight,
+					Size:   largeSize,
+					Wrap:   true,
+				},
+			},
+		},
+	}
+	if len(rendered.Body) > 0 {
+		body = append(body, cardElement{
+			Type:    textBlockType,
+			Text:    formatting.Truncate(rendered.Body, maxDataLength),
+			Wrap:    true,
+			Spacing: mediumSpacing,
+		})
+	}
+
+	return message{
+		Type: messageType,
+		Attachments: []attachment{
+			{
+				ContentType: adaptiveCardType,
+				Content: adaptiveCard{
+					Schema:  adaptiveCardSchema,
+					Type:    adaptiveCardElement,
+					Version: adaptiveCardVersion,
+					Body:    body,
+					MSTeams: &msTeams{Width: fullWidth},
+				},
+			},
+		},
+	}
+}
+
+func createCard(response data.AlarmResponse, timestamp time.Time) adaptiveCard {
+	body := []cardElement{createHeader(response, timestamp)}
+
+	labels := common.DisplayLabels(response.Labels)
+	facts := make([]fact, 0, len(labels))
+	for _, key := range common.SortedLabelKeys(labels) {
+		facts = append(facts, createFact(key, labels[key]))
+	}
+	if len(response.Report) > 0 {
+		for _, field := range common.ReportSummary(response.Metrics) {
+			facts = append(facts, createFact(field.Name, field.Value))
+		}
+	}
+	if len(facts) > 0 {
+		body = append(body, cardElement{
+			Type:    factSetType,
+			Spacing: mediumSpacing,
+			Facts:   facts,
+		})
+	}
+
+	if len(response.Report) > 0 {
+		// the data only duplicates the structured report
+		body = append(body, createReportFacts(response.Report))
+	} else if len(response.Data) > 0 {
+		body = append(body, cardElement{
+			Type:     textBlockType,
+			Text:     formatting.Truncate(response.Data, maxDataLength),
+			FontType: monospaceFont,
+			Wrap:     true,
+			Spacing:  mediumSpacing,
+		})
+	}
+
+	return adaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    adaptiveCardElement,
+		Version: adaptiveCardVersion,
+		Body:    body,
+		MSTeams: &msTeams{Width: fullWidth},
+	}
+}
+
+// createHeader returns the full width container colored by the response level
+func createHeader(response data.AlarmResponse, timestamp time.Time) cardElement {
+	return cardElement{
+		Type:  containerType,
+		Style: levelStyle(response.Level),
+		Bleed: true,
+		Items: []cardElement{
+			{
+				Type:   textBlockType,
+				Text:   formatting.Truncate(levelTitle(response.Level)+titleSeparator+response.Identifier, maxTitleLength),
+				Weight: bolderWeight,
+				Size:   largeSize,
+				Wrap:   true,
+			},
+			{
+				Type:     textBlockType,
+				Text:     timestamp.Format(timestampLayout),
+				IsSubtle: true,
+				Spacing:  noSpacing,
+			},
+		},
+	}
+}
+
+func createReportFacts(report []data.ReportEntry) cardElement {
+	facts := make([]fact, 0, len(report))
+	for _, entry := range report {
+		facts = append(facts, createFact(entry.Identifier, "["+reportEntryStatus(entry)+"] "+entry.Status))
+	}
+
+	return cardElement{
+		Type:    factSetType,
+		Spacing: reportSectionSpacing,
+		Facts:   facts,
+	}
+}
+
+func createFact(title string, value string) fact {
+	return fact{
+		Title: title,
+		Value: formatting.Truncate(value, maxFactValueLength),
+	}
+}
@@ -0,0 +1,126 @@
+package teams
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTimestamp = time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+func TestCreateMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error response", func(t *testing.T) {
+		t.Parallel()
+
+		msg := createMessage(data.AlarmResponse{
+			Identifier: "node rating",
+			Level:      data.Error,
+			Data:       "rating dropped",
+			Labels:     map[string]string{"shard": "1", "network": "mainnet"},
+		}, testTimestamp)
+
+		assert.Equal(t, messageType, msg.Type)
+		require.Equal(t, 1, len(msg.Attachments))
+		assert.Equal(t, adaptiveCardType, msg.Attachments[0].ContentType)
+
+		card := msg.Attachments[0].Content
+		assert.Equal(t, adaptiveCardSchema, card.Schema)
+		assert.Equal(t, adaptiveCardElement, card.Type)
+		assert.Equal(t, adaptiveCardVersion, card.Version)
+		assert.Equal(t, &msTeams{Width: fullWidth}, card.MSTeams)
+		require.Equal(t, 3, len(card.Body))
+
+		header := card.Body[0]
+		assert.Equal(t, containerType, header.Type)
+		assert.Equal(t, "attention", header.Style)
+		assert.True(t, header.Bleed)
+		require.Equal(t, 2, len(header.Items))
+		assert.Equal(t, "🚨 ERROR | node rating", header.Items[0].Text)
+		assert.Equal(t, "2026-10-18 09:30:00 UTC", header.Items[1].Text)
+
+		assert.Equal(t, factSetType, card.Body[1].Type)
+		assert.Equal(t, []fact{{Title: "network", Value: "mainnet"}, {Title: "shard", Value: "1"}}, card.Body[1].Facts)
+
+		assert.Equal(t, textBlockType, card.Body[2].Type)
+		assert.Equal(t, "rating dropped", card.Body[2].Text)
+		assert.Equal(t, monospaceFont, card.Body[2].FontType)
+		assert.True(t, card.Body[2].Wrap)
+	})
+	t.Run("info response without labels and data", func(t *testing.T) {
+		t.Parallel()
+
+		msg := createMessage(data.AlarmResponse{
+			Identifier: "nonce",
+			Level:      data.Info,
+		}, testTimestamp)
+
+		card := msg.Attachments[0].Content
+		require.Equal(t, 1, len(card.Body))
+		assert.Equal(t, "accent", card.Body[0].Style)
+		assert.Equal(t, "ℹ️ INFO | nonce", card.Body[0].Items[0].Text)
+	})
+	t.Run("unknown level should use the default style", func(t *testing.T) {
+		t.Parallel()
+
+		msg := createMessage(data.AlarmResponse{Identifier: "nonce", Level: "Custom"}, testTimestamp)
+		header := msg.Attachments[0].Content.Body[0]
+		assert.Equal(t, defaultStyle, header.Style)
+		assert.Equal(t, "CUSTOM | nonce", header.Items[0].Text)
+	})
+	t.Run("long texts should be truncated", func(t *testing.T) {
+		t.Parallel()
+
+		msg := createMessage(data.AlarmResponse{
+			Identifier: strings.Repeat("i", 300),
+			Level:      data.Error,
+			Data:       strings.Repeat("d", maxDataLength+1),
+			Labels:     map[string]string{"label": strings.Repeat("v", maxFactValueLength+1)},
+		}, testTimestamp)
+
+		card := msg.Attachments[0].Content
+		assert.Equal(t, maxTitleLength, len([]rune(card.Body[0].Items[0].Text)))
+		assert.Equal(t, maxFactValueLength, len([]rune(card.Body[1].Facts[0].Value)))
+		assert.Equal(t, maxDataLength, len([]rune(card.Body[2].Text)))
+	})
+	t.Run("info report", func(t *testing.T) {
+		t.Parallel()
+
+		msg := createMessage(data.AlarmResponse{
+			Identifier: data.SystemIdentifier,
+			Level:      data.Info,
+			Data:       "System is running",
+			Metrics:    map[string]float64{data.UptimeMetric: 3600, data.AlarmsWithErrorMetric: 0},
+			Report: []data.ReportEntry{
+				{Identifier: "rating", Level: data.Info, Status: "ok"},
+				{Identifier: "nonce", Level: data.NoEvent, Status: "paused", Paused: true},
+			},
+		}, testTimestamp)
+
+		card := msg.Attachments[0].Content
+		require.Equal(t, 3, len(card.Body))
+		assert.Equal(t, []fact{{Title: "Uptime", Value: "1h0m0s"}, {Title: "Alarms with error", Value: "0"}}, card.Body[1].Facts)
+		assert.Equal(t, []fact{{Title: "rating", Value: "[INFO] ok"}, {Title: "nonce", Value: "[PAUSED] paused"}}, card.Body[2].Facts)
+	})
+	t.Run("JSON layout", func(t *testing.T) {
+		t.Parallel()
+
+		msg := createMessage(data.AlarmResponse{Identifier: "nonce", Level: data.Info}, testTimestamp)
+		buff, err := json.Marshal(msg)
+		require.Nil(t, err)
+
+		expected := `{"type":"message","attachments":[{"contentType":"application/vnd.microsoft.card.adaptive","contentUrl":null,` +
+			`"content":{"$schema":"http://adaptivecards.io/schemas/adaptive-card.json","type":"AdaptiveCard","version":"1.4",` +
+			`"body":[{"type":"Container","style":"accent","bleed":true,"items":[` +
+			`{"type":"TextBlock","text":"ℹ️ INFO | nonce","weight":"Bolder","size":"Large","wrap":true},` +
+			`{"type":"TextBlock","text":"2026-10-18 09:30:00 UTC","isSubtle":true,"spacing":"None"}]}],` +
+			`"msteams":{"width":"Full"}}}]}`
+		assert.Equal(t, expected, string(buff))
+	})
+}
@@ -0,0 +1,46 @@
+package teams
+
+// message is the payload accepted by the Teams workflow webhooks
+type message struct {
+	Type        string       `json:"type"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	ContentType string       `json:"contentType"`
+	ContentUrl  *string      `json:"contentUrl"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string        `json:"$schema"`
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Body    []cardElement `json:"body"`
+	MSTeams *msTeams      `json:"msteams,omitempty"`
+}
+
+type msTeams struct {
+	Width string `json:"width"`
+}
+
+// cardElement holds the fields used by the TextBlock, Container and FactSet elements
+type cardElement struct {
+	Type     string        `json:"type"`
+	Text     string        `json:"text,omitempty"`
+	Weight   string        `json:"weight,omitempty"`
+	Size     string        `json:"size,omitempty"`
+	FontType string        `json:"fontType,omitempty"`
+	IsSubtle bool          `json:"isSubtle,omitempty"`
+	Wrap     bool          `json:"wrap,omitempty"`
+	Style    string        `json:"style,omitempty"`
+	Bleed    bool          `json:"bleed,omitempty"`
+	Spacing  string        `json:"spacing,omitempty"`
+	Items    []cardElement `json:"items,omitempty"`
+	Facts    []fact        `json:"facts,omitempty"`
+}
+
+type fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
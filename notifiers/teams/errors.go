@@ -0,0 +1,6 @@
+package teams
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errEmptyWebhookUrl = errors.New("empty webhook URL")
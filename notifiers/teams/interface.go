@@ -0,0 +1,9 @@
+package teams
+
+import "context"
+
+// HTTPClient defines the operations of the HTTP client used to call the workflow webhook
+type HTTPClient interface {
+	CallPostRestEndPoint(ctx context.Context, url string, data interface{}) error
+	IsInterfaceNil() bool
+}
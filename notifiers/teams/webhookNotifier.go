@@ -0,0 +1,66 @@
+package teams
+
+import (
+	"context"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+// ArgsWebhookNotifier represents the arguments DTO for the webhookNotifier constructor. The renderer is optional,
+// the built-in card layout being used without it
+type ArgsWebhookNotifier struct {
+	HTTPClient HTTPClient
+	WebhookUrl string
+	Renderer   templating.MessageRenderer
+}
+
+type webhookNotifier struct {
+	httpClient HTTPClient
+	webhookUrl string
+	renderer   templating.MessageRenderer
+}
+
+// NewWebhookNotifier creates a new notifier that posts the alarm responses as Adaptive Cards on a Teams workflow
+// webhook
+func NewWebhookNotifier(args ArgsWebhookNotifier) (*webhookNotifier, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(args.WebhookUrl) == 0 {
+		return nil, errEmptyWebhookUrl
+	}
+
+	return &webhookNotifier{
+		httpClient: args.HTTPClient,
+		webhookUrl: args.WebhookUrl,
+		renderer:   args.Renderer,
+	}, nil
+}
+
+// ProcessAlarmResponse will post the alarm response on the workflow webhook. The responses without an event are ignored
+func (notifier *webhookNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	if check.IfNil(notifier.renderer) {
+		return notifier.httpClient.CallPostRestEndPoint(ctx, notifier.webhookUrl, createMessage(response, time.Now()))
+	}
+
+	rendered, err := notifier.renderer.Render(response)
+	if err != nil {
+		return err
+	}
+
+	payload := createRenderedMessage(response.Level, rendered)
+
+	return notifier.httpClient.CallPostRestEndPoint(ctx, notifier.webhookUrl, payload)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *webhookNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
@@ -0,0 +1,157 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsWebhookNotifier() ArgsWebhookNotifier {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second)
+
+	return ArgsWebhookNotifier{
+		HTTPClient: httpClient,
+		WebhookUrl: "https://prod-00.westeurope.logic.azure.com/workflows/xxx/triggers/manual/paths/invoke",
+	}
+}
+
+func TestNewWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsWebhookNotifier()
+		args.HTTPClient = nil
+
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty webhook URL should error", func(t *testing.T) {
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = ""
+
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyWebhookUrl, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := NewWebhookNotifier(createMockArgsWebhookNotifier())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
+
+func TestWebhookNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no event should not post", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should have not called the webhook")
+		}))
+		defer server.Close()
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = server.URL
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.NoEvent})
+		assert.Nil(t, err)
+	})
+	t.Run("should post the adaptive card", func(t *testing.T) {
+		var received message
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			buff, _ := ioutil.ReadAll(r.Body)
+			_ = json.Unmarshal(buff, &received)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = server.URL
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{
+			Identifier: "rating",
+			Level:      data.Error,
+			Data:       "rating dropped",
+		})
+		assert.Nil(t, err)
+		require.Equal(t, 1, len(received.Attachments))
+		assert.Equal(t, "🚨 ERROR | rating", received.Attachments[0].Content.Body[0].Items[0].Text)
+	})
+	t.Run("should post the rendered card", func(t *testing.T) {
+		var received message
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			buff, _ := ioutil.ReadAll(r.Body)
+			_ = json.Unmarshal(buff, &received)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = server.URL
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{Title: "rendered title", Body: "rendered body"}, nil
+			},
+		}
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Error})
+		assert.Nil(t, err)
+		require.Equal(t, 1, len(received.Attachments))
+		body := received.Attachments[0].Content.Body
+		require.Equal(t, 2, len(body))
+		assert.Equal(t, "attention", body[0].Style)
+		assert.Equal(t, "rendered title", body[0].Items[0].Text)
+		assert.Equal(t, "rendered body", body[1].Text)
+	})
+	t.Run("render error should error", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should have not called the webhook")
+		}))
+		defer server.Close()
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = server.URL
+		args.Renderer = &mocks.MessageRendererStub{
+			RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+				return templating.Message{}, expectedErr
+			},
+		}
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Error})
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("webhook errors should be returned", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("invalid card"))
+		}))
+		defer server.Close()
+
+		args := createMockArgsWebhookNotifier()
+		args.WebhookUrl = server.URL
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "rating", Level: data.Error})
+		assert.NotNil(t, err)
+		assert.True(t, strings.Contains(err.Error(), "invalid card"))
+	})
+}
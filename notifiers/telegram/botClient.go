@@ -0,0 +1,122 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+)
+
+var log = logger.GetOrCreate("notifiers/telegram")
+
+const (
+	sendMessageMethod = "sendMessage"
+	getUpdatesMethod  = "getUpdates"
+	hiddenToken       = "<token>"
+)
+
+// ArgsBotClient represents the arguments DTO for the botClient constructor
+type ArgsBotClient struct {
+	HTTPClient HTTPClient
+	ApiUrl     string
+	Token      string
+}
+
+type botClient struct {
+	httpClient HTTPClient
+	apiUrl     string
+	token      string
+}
+
+// NewBotClient creates a new Telegram Bot API client
+func NewBotClient(args ArgsBotClient) (*botClient, error) {
+	if check.IfNil(args.HTTPClient) {
+		return nil, errNilHTTPClient
+	}
+	if len(args.ApiUrl) == 0 {
+		return nil, errEmptyApiUrl
+	}
+	if len(args.Token) == 0 {
+		return nil, errEmptyToken
+	}
+
+	return &botClient{
+		httpClient: args.HTTPClient,
+		apiUrl:     strings.TrimSuffix(args.ApiUrl, "/"),
+		token:      args.Token,
+	}, nil
+}
+
+// SendMessage will send the text message to the provided chat
+func (bc *botClient) SendMessage(ctx context.Context, chatID int64, text string, parseMode string) error {
+	request := sendMessageRequest{
+		ChatID:                chatID,
+		Text:                  text,
+		ParseMode:             parseMode,
+		DisableWebPagePreview: true,
+	}
+
+	buff, err := bc.httpClient.CallPostRestEndPointWithResponse(ctx, bc.methodUrl(sendMessageMethod), request)
+	if err != nil {
+		return bc.hideToken(err)
+	}
+
+	_, err = parseBotResponse(buff)
+
+	return err
+}
+
+// GetUpdates will fetch the incoming updates starting with the provided offset. The call will block for at most
+// the provided timeout if there are no updates
+func (bc *botClient) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	url := fmt.Sprintf("%s?offset=%d&timeout=%d", bc.methodUrl(getUpdatesMethod), offset, int(timeout.Seconds()))
+	buff, err := bc.httpClient.CallGetRestEndPoint(ctx, url)
+	if err != nil {
+		return nil, bc.hideToken(err)
+	}
+
+	result, err := parseBotResponse(buff)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make([]Update, 0)
+	err = json.Unmarshal(result, &updates)
+	if err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+func (bc *botClient) methodUrl(method string) string {
+	return fmt.Sprintf("%s/bot%s/%s", bc.apiUrl, bc.token, method)
+}
+
+// hideToken removes the bot token from the errors as the HTTP client errors contain the requested URL
+func (bc *botClient) hideToken(err error) error {
+	return errors.New(strings.ReplaceAll(err.Error(), bc.token, hiddenToken))
+}
+
+func parseBotResponse(buff []byte) (json.RawMessage, error) {
+	response := botResponse{}
+	err := json.Unmarshal(buff, &response)
+	if err != nil {
+		return nil, fmt.Errorf("%w while decoding the bot API response", err)
+	}
+	if !response.Ok {
+		return nil, fmt.Errorf("%w, code %d: %s", errBotApi, response.ErrorCode, response.Description)
+	}
+
+	return response.Result, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bc *botClient) IsInterfaceNil() bool {
+	return bc == nil
+}
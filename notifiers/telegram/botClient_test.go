@@ -0,0 +1,135 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockArgsBotClient() ArgsBotClient {
+	httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second)
+
+	return ArgsBotClient{
+		HTTPClient: httpClient,
+		ApiUrl:     "https://api.telegram.org",
+		Token:      testToken,
+	}
+}
+
+func TestNewBotClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		args := createMockArgsBotClient()
+		args.HTTPClient = nil
+
+		client, err := NewBotClient(args)
+		assert.True(t, check.IfNil(client))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty API URL should error", func(t *testing.T) {
+		args := createMockArgsBotClient()
+		args.ApiUrl = ""
+
+		client, err := NewBotClient(args)
+		assert.True(t, check.IfNil(client))
+		assert.Equal(t, errEmptyApiUrl, err)
+	})
+	t.Run("empty token should error", func(t *testing.T) {
+		args := createMockArgsBotClient()
+		args.Token = ""
+
+		client, err := NewBotClient(args)
+		assert.True(t, check.IfNil(client))
+		assert.Equal(t, errEmptyToken, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		client, err := NewBotClient(createMockArgsBotClient())
+		assert.False(t, check.IfNil(client))
+		assert.Nil(t, err)
+	})
+}
+
+func TestBotClient_SendMessage(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeBotApi()
+	defer api.Close()
+
+	t.Run("should work", func(t *testing.T) {
+		client := createTestBotClient(t, api.URL+"/")
+
+		err := client.SendMessage(context.Background(), 1, "text", markdownV2ParseMode)
+		assert.Nil(t, err)
+
+		messages := api.getSentMessages()
+		assert.Equal(t, 1, len(messages))
+		assert.Equal(t, sendMessageRequest{
+			ChatID:                1,
+			Text:                  "text",
+			ParseMode:             markdownV2ParseMode,
+			DisableWebPagePreview: true,
+		}, messages[0])
+	})
+	t.Run("bot API error should error", func(t *testing.T) {
+		api.failChat(2)
+		client := createTestBotClient(t, api.URL)
+
+		err := client.SendMessage(context.Background(), 2, "text", "")
+		assert.True(t, errors.Is(err, errBotApi))
+		assert.True(t, strings.Contains(err.Error(), "chat not found"))
+	})
+	t.Run("connection error should not contain the token", func(t *testing.T) {
+		client := createTestBotClient(t, "http://127.0.0.1:1")
+
+		err := client.SendMessage(context.Background(), 1, "text", "")
+		assert.NotNil(t, err)
+		assert.False(t, strings.Contains(err.Error(), testToken))
+		assert.True(t, strings.Contains(err.Error(), hiddenToken))
+	})
+}
+
+func TestBotClient_GetUpdates(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeBotApi()
+	defer api.Close()
+	api.addUpdate(Update{UpdateID: 10, Message: &Message{Text: "first"}})
+	api.addUpdate(Update{UpdateID: 11, Message: &Message{Text: "second"}})
+
+	client := createTestBotClient(t, api.URL)
+
+	updates, err := client.GetUpdates(context.Background(), 0, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(updates))
+
+	updates, err = client.GetUpdates(context.Background(), 11, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(updates))
+	assert.Equal(t, "second", updates[0].Message.Text)
+
+	updates, err = client.GetUpdates(context.Background(), 12, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(updates))
+}
+
+func TestParseBotResponse(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseBotResponse([]byte("not a json"))
+	assert.NotNil(t, err)
+
+	_, err = parseBotResponse([]byte(`{"ok":false,"error_code":401,"description":"Unauthorized"}`))
+	assert.True(t, errors.Is(err, errBotApi))
+	assert.True(t, strings.Contains(err.Error(), "code 401: Unauthorized"))
+
+	result, err := parseBotResponse([]byte(`{"ok":true,"result":[1,2]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2]", string(result))
+}
@@ -0,0 +1,298 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+)
+
+const (
+	minLongPollingTimeout = time.Second
+	retryInterval         = time.Second * 5
+	statusCommand         = "/status"
+	ackCommand            = "/ack"
+	silenceCommand        = "/silence"
+	pauseCommand          = "/pause"
+	resumeCommand         = "/resume"
+	helpMessage           = `Available commands:
+/status - shows the state of all alarms together with their info
+/ack <identifier> - stops the notifications of an alerting alarm until it recovers
+/silence <duration> <identifier> - stops the notifications of an alarm for the provided duration (e.g. 30m, 2h, 0 to remove)
+/pause <identifier> - stops querying the alarm
+/resume <identifier> - resumes querying the alarm`
+)
+
+// ArgsCommandsHandler represents the arguments DTO for the commandsHandler constructor
+type ArgsCommandsHandler struct {
+	BotClient          BotClient
+	AlarmsController   AlarmsController
+	AllowedUserIDs     []int64
+	LongPollingTimeout time.Duration
+}
+
+// commandsHandler long polls the Bot API for incoming messages and executes the commands sent by the allowed users
+type commandsHandler struct {
+	botClient          BotClient
+	alarmsController   AlarmsController
+	allowedUserIDs     map[int64]struct{}
+	longPollingTimeout time.Duration
+	cancel             func()
+}
+
+// NewCommandsHandler creates a new commands handler instance that starts polling for the bot's updates
+func NewCommandsHandler(args ArgsCommandsHandler) (*commandsHandler, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := &commandsHandler{
+		botClient:          args.BotClient,
+		alarmsController:   args.AlarmsController,
+		allowedUserIDs:     make(map[int64]struct{}, len(args.AllowedUserIDs)),
+		longPollingTimeout: args.LongPollingTimeout,
+	}
+	for _, userID := range args.AllowedUserIDs {
+		handler.allowedUserIDs[userID] = struct{}{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	handler.cancel = cancel
+
+	go handler.processLoop(ctx)
+
+	return handler, nil
+}
+
+func checkArgs(args ArgsCommandsHandler) error {
+	if check.IfNil(args.BotClient) {
+		return errNilBotClient
+	}
+	if check.IfNil(args.AlarmsController) {
+		return errNilAlarmsController
+	}
+	if len(args.AllowedUserIDs) == 0 {
+		return errNoAllowedUsers
+	}
+	if args.LongPollingTimeout < minLongPollingTimeout {
+		return fmt.Errorf("%w for LongPollingTimeout, provided: %v, minimum: %v", errInvalidValue, args.LongPollingTimeout, minLongPollingTimeout)
+	}
+
+	return nil
+}
+
+func (handler *commandsHandler) processLoop(ctx context.Context) {
+	log.Debug("Telegram commands handler started")
+	defer log.Debug("Telegram commands handler stopped")
+
+	offset := int64(0)
+	for {
+		updates, err := handler.botClient.GetUpdates(ctx, offset, handler.longPollingTimeout)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Warn("error fetching the Telegram updates", "error", err.Error())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryInterval):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			handler.processUpdate(ctx, update)
+		}
+	}
+}
+
+func (handler *commandsHandler) processUpdate(ctx context.Context, update Update) {
+	message := update.Message
+	if message == nil || message.From == nil || !strings.HasPrefix(message.Text, "/") {
+		return
+	}
+
+	_, isAllowed := handler.allowedUserIDs[message.From.ID]
+	if !isAllowed {
+		log.Warn("Telegram command from a user that is not allowed",
+			"user ID", message.From.ID, "username", message.From.Username, "command", message.Text)
+		return
+	}
+
+	log.Info("Telegram command received", "user ID", message.From.ID, "username", message.From.Username, "command", message.Text)
+
+	reply := formatting.Truncate(handler.executeCommand(ctx, message.Text), maxMessageLength)
+	err := handler.botClient.SendMessage(ctx, message.Chat.ID, reply, "")
+	if err != nil {
+		log.Warn("error replying to the Telegram command", "chat ID", message.Chat.ID, "error", err.Error())
+	}
+}
+
+func (handler *commandsHandler) executeCommand(ctx context.Context, text string) string {
+	command, argument := splitCommand(text)
+
+	var reply string
+	var err error
+	switch command {
+	case statusCommand:
+		return handler.createStatusReply(ctx)
+	case ackCommand:
+		reply, err = handler.acknowledge(argument)
+	case silenceCommand:
+		reply, err = handler.silence(argument)
+	case pauseCommand:
+		reply, err = handler.pause(argument)
+	case resumeCommand:
+		reply, err = handler.resume(argument)
+	default:
+		return helpMessage
+	}
+
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+
+	return reply
+}
+
+// splitCommand separates the command from its argument. The commands sent in groups might have the bot's
+// username appended, e.g. /status@my_bot
+func splitCommand(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	command := text
+	argument := ""
+	idx := strings.IndexAny(text, " \n")
+	if idx >= 0 {
+		command = text[:idx]
+		argument = strings.TrimSpace(text[idx+1:])
+	}
+
+	idx = strings.Index(command, "@")
+	if idx >= 0 {
+		command = command[:idx]
+	}
+
+	return strings.ToLower(command), argument
+}
+
+func (handler *commandsHandler) createStatusReply(ctx context.Context) string {
+	statuses := handler.alarmsController.AlarmsStatus()
+	if len(statuses) == 0 {
+		return "No alarms defined"
+	}
+
+	lines := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		line := fmt.Sprintf("%s: %s", status.Identifier, status.State)
+		if status.Acknowledged {
+			line += ", acknowledged"
+		}
+		if !status.SilencedUntil.IsZero() {
+			line += fmt.Sprintf(", silenced until %s", status.SilencedUntil.Format(time.RFC3339))
+		}
+
+		info, err := handler.alarmsController.QueryAlarmInfo(ctx, status.Identifier)
+		if err != nil {
+			info = "error fetching info: " + err.Error()
+		}
+
+		lines = append(lines, line+"\n"+info)
+	}
+
+	return strings.Join(lines, "\n\n")
+}
+
+func (handler *commandsHandler) acknowledge(identifier string) (string, error) {
+	if len(identifier) == 0 {
+		return "", fmt.Errorf("%w, usage: %s <identifier>", errMissingArgument, ackCommand)
+	}
+
+	err := handler.alarmsController.AcknowledgeAlarm(identifier)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Alarm %s acknowledged", identifier), nil
+}
+
+func (handler *commandsHandler) silence(argument string) (string, error) {
+	parts := strings.SplitN(argument, " ", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("%w, usage: %s <duration> <identifier>", errMissingArgument, silenceCommand)
+	}
+
+	duration, err := parseDuration(parts[0])
+	if err != nil {
+		return "", err
+	}
+
+	identifier := strings.TrimSpace(parts[1])
+	err = handler.alarmsController.SilenceAlarm(identifier, duration)
+	if err != nil {
+		return "", err
+	}
+
+	if duration <= 0 {
+		return fmt.Sprintf("Alarm %s is no longer silenced", identifier), nil
+	}
+
+	return fmt.Sprintf("Alarm %s silenced for %v", identifier, duration), nil
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	if value == "0" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w for duration: %s", errInvalidValue, value)
+	}
+
+	return duration, nil
+}
+
+func (handler *commandsHandler) pause(identifier string) (string, error) {
+	if len(identifier) == 0 {
+		return "", fmt.Errorf("%w, usage: %s <identifier>", errMissingArgument, pauseCommand)
+	}
+
+	err := handler.alarmsController.PauseAlarm(identifier)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Alarm %s paused", identifier), nil
+}
+
+func (handler *commandsHandler) resume(identifier string) (string, error) {
+	if len(identifier) == 0 {
+		return "", fmt.Errorf("%w, usage: %s <identifier>", errMissingArgument, resumeCommand)
+	}
+
+	err := handler.alarmsController.ResumeAlarm(identifier)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Alarm %s resumed", identifier), nil
+}
+
+// Close stops polling for the bot's updates
+func (handler *commandsHandler) Close() error {
+	handler.cancel()
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (handler *commandsHandler) IsInterfaceNil() bool {
+	return handler == nil
+}
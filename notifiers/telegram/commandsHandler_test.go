@@ -0,0 +1,190 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockArgsCommandsHandler(t *testing.T) ArgsCommandsHandler {
+	return ArgsCommandsHandler{
+		BotClient:          createTestBotClient(t, "http://127.0.0.1:1"),
+		AlarmsController:   &mocks.AlarmsControllerStub{},
+		AllowedUserIDs:     []int64{100},
+		LongPollingTimeout: time.Second,
+	}
+}
+
+func TestNewCommandsHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil bot client should error", func(t *testing.T) {
+		args := createMockArgsCommandsHandler(t)
+		args.BotClient = nil
+
+		handler, err := NewCommandsHandler(args)
+		assert.True(t, check.IfNil(handler))
+		assert.Equal(t, errNilBotClient, err)
+	})
+	t.Run("nil alarms controller should error", func(t *testing.T) {
+		args := createMockArgsCommandsHandler(t)
+		args.AlarmsController = nil
+
+		handler, err := NewCommandsHandler(args)
+		assert.True(t, check.IfNil(handler))
+		assert.Equal(t, errNilAlarmsController, err)
+	})
+	t.Run("no allowed users should error", func(t *testing.T) {
+		args := createMockArgsCommandsHandler(t)
+		args.AllowedUserIDs = nil
+
+		handler, err := NewCommandsHandler(args)
+		assert.True(t, check.IfNil(handler))
+		assert.Equal(t, errNoAllowedUsers, err)
+	})
+	t.Run("invalid long polling timeout should error", func(t *testing.T) {
+		args := createMockArgsCommandsHandler(t)
+		args.LongPollingTimeout = time.Millisecond
+
+		handler, err := NewCommandsHandler(args)
+		assert.True(t, check.IfNil(handler))
+		assert.True(t, errors.Is(err, errInvalidValue))
+	})
+	t.Run("should work", func(t *testing.T) {
+		handler, err := NewCommandsHandler(createMockArgsCommandsHandler(t))
+		assert.False(t, check.IfNil(handler))
+		assert.Nil(t, err)
+
+		_ = handler.Close()
+	})
+}
+
+func TestSplitCommand(t *testing.T) {
+	t.Parallel()
+
+	command, argument := splitCommand("/status")
+	assert.Equal(t, "/status", command)
+	assert.Equal(t, "", argument)
+
+	command, argument = splitCommand(" /Pause@monitoring_bot  testnet - rating ")
+	assert.Equal(t, "/pause", command)
+	assert.Equal(t, "testnet - rating", argument)
+}
+
+func TestCommandsHandler_ExecuteCommand(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	calls := make(map[string]string)
+	controller := &mocks.AlarmsControllerStub{
+		AlarmsStatusCalled: func() []data.AlarmStatus {
+			return []data.AlarmStatus{
+				{Identifier: "alarm 1", State: data.StateAlerting, Acknowledged: true},
+				{Identifier: "alarm 2", State: data.StateOk, SilencedUntil: time.Unix(0, 0).UTC()},
+			}
+		},
+		QueryAlarmInfoCalled: func(ctx context.Context, identifier string) (string, error) {
+			if identifier == "alarm 2" {
+				return "", expectedErr
+			}
+			return "info " + identifier, nil
+		},
+		PauseAlarmCalled: func(identifier string) error {
+			calls["pause"] = identifier
+			return nil
+		},
+		ResumeAlarmCalled: func(identifier string) error {
+			calls["resume"] = identifier
+			return expectedErr
+		},
+		AcknowledgeAlarmCalled: func(identifier string) error {
+			calls["ack"] = identifier
+			return nil
+		},
+		SilenceAlarmCalled: func(identifier string, duration time.Duration) error {
+			calls["silence"] = identifier + " " + duration.String()
+			return nil
+		},
+	}
+	args := createMockArgsCommandsHandler(t)
+	args.AlarmsController = controller
+	handler, _ := NewCommandsHandler(args)
+	defer func() {
+		_ = handler.Close()
+	}()
+
+	t.Run("status", func(t *testing.T) {
+		reply := handler.executeCommand(context.Background(), "/status")
+		assert.Equal(t, "alarm 1: Alerting, acknowledged\ninfo alarm 1\n\n"+
+			"alarm 2: Ok, silenced until 1970-01-01T00:00:00Z\nerror fetching info: expected error", reply)
+	})
+	t.Run("ack", func(t *testing.T) {
+		assert.True(t, strings.Contains(handler.executeCommand(context.Background(), "/ack"), errMissingArgument.Error()))
+		assert.Equal(t, "Alarm alarm 1 acknowledged", handler.executeCommand(context.Background(), "/ack alarm 1"))
+		assert.Equal(t, "alarm 1", calls["ack"])
+	})
+	t.Run("silence", func(t *testing.T) {
+		assert.True(t, strings.Contains(handler.executeCommand(context.Background(), "/silence 1h"), errMissingArgument.Error()))
+		assert.True(t, strings.Contains(handler.executeCommand(context.Background(), "/silence soon alarm 1"), errInvalidValue.Error()))
+		assert.Equal(t, "Alarm alarm 1 silenced for 1h30m0s", handler.executeCommand(context.Background(), "/silence 1h30m alarm 1"))
+		assert.Equal(t, "alarm 1 1h30m0s", calls["silence"])
+		assert.Equal(t, "Alarm alarm 1 is no longer silenced", handler.executeCommand(context.Background(), "/silence 0 alarm 1"))
+	})
+	t.Run("pause", func(t *testing.T) {
+		assert.Equal(t, "Alarm alarm 1 paused", handler.executeCommand(context.Background(), "/pause alarm 1"))
+		assert.Equal(t, "alarm 1", calls["pause"])
+	})
+	t.Run("resume error should be returned", func(t *testing.T) {
+		assert.Equal(t, "Error: expected error", handler.executeCommand(context.Background(), "/resume alarm 1"))
+	})
+	t.Run("unknown command should return the help", func(t *testing.T) {
+		assert.Equal(t, helpMessage, handler.executeCommand(context.Background(), "/start"))
+	})
+}
+
+func TestCommandsHandler_ShouldOnlyAnswerToAllowedUsers(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeBotApi()
+	defer api.Close()
+
+	paused := make(chan string, 10)
+	args := createMockArgsCommandsHandler(t)
+	args.BotClient = createTestBotClient(t, api.URL)
+	args.AlarmsController = &mocks.AlarmsControllerStub{
+		PauseAlarmCalled: func(identifier string) error {
+			paused <- identifier
+			return nil
+		},
+	}
+	handler, _ := NewCommandsHandler(args)
+	defer func() {
+		_ = handler.Close()
+	}()
+
+	api.addUpdate(Update{UpdateID: 1, Message: &Message{From: &User{ID: 200}, Chat: Chat{ID: 5}, Text: "/pause alarm 1"}})
+	api.addUpdate(Update{UpdateID: 2, Message: &Message{From: &User{ID: 100}, Chat: Chat{ID: 6}, Text: "not a command"}})
+	api.addUpdate(Update{UpdateID: 3, Message: &Message{From: &User{ID: 100}, Chat: Chat{ID: 7}, Text: "/pause alarm 2"}})
+
+	select {
+	case identifier := <-paused:
+		assert.Equal(t, "alarm 2", identifier)
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "timeout waiting for the command to be executed")
+	}
+
+	time.Sleep(time.Millisecond * 200)
+	assert.Equal(t, 0, len(paused), "each update should be processed once")
+
+	messages := api.getSentMessages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, int64(7), messages[0].ChatID)
+	assert.Equal(t, "Alarm alarm 2 paused", messages[0].Text)
+}
@@ -0,0 +1,14 @@
+package telegram
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errNilBotClient = errors.New("nil bot client")
+var errNilAlarmsController = errors.New("nil alarms controller")
+var errEmptyApiUrl = errors.New("empty API URL")
+var errEmptyToken = errors.New("empty bot token")
+var errNoChatIDs = errors.New("no chat IDs")
+var errNoAllowedUsers = errors.New("no allowed user IDs")
+var errInvalidValue = errors.New("invalid value")
+var errBotApi = errors.New("bot API error")
+var errMissingArgument = errors.New("missing argument")
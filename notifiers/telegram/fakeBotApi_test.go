@@ -0,0 +1,123 @@
+package telegram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "123456:test-token"
+
+// fakeBotApi is a local server that mimics the sendMessage and getUpdates Bot API methods
+type fakeBotApi struct {
+	*httptest.Server
+	mut           sync.Mutex
+	sentMessages  []sendMessageRequest
+	updates       []Update
+	failedChatIDs map[int64]struct{}
+}
+
+func newFakeBotApi() *fakeBotApi {
+	api := &fakeBotApi{
+		failedChatIDs: make(map[int64]struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/bot"+testToken+"/"+sendMessageMethod, api.handleSendMessage)
+	mux.HandleFunc("/bot"+testToken+"/"+getUpdatesMethod, api.handleGetUpdates)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeBotResponse(w, http.StatusNotFound, botResponse{ErrorCode: http.StatusNotFound, Description: "Not Found"})
+	})
+	api.Server = httptest.NewServer(mux)
+
+	return api
+}
+
+func (api *fakeBotApi) handleSendMessage(w http.ResponseWriter, r *http.Request) {
+	request := sendMessageRequest{}
+	_ = json.NewDecoder(r.Body).Decode(&request)
+
+	api.mut.Lock()
+	_, shouldFail := api.failedChatIDs[request.ChatID]
+	if !shouldFail {
+		api.sentMessages = append(api.sentMessages, request)
+	}
+	api.mut.Unlock()
+
+	if shouldFail {
+		writeBotResponse(w, http.StatusBadRequest, botResponse{ErrorCode: http.StatusBadRequest, Description: "Bad Request: chat not found"})
+		return
+	}
+
+	writeBotResponse(w, http.StatusOK, botResponse{Ok: true, Result: json.RawMessage("{}")})
+}
+
+func (api *fakeBotApi) handleGetUpdates(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+
+	api.mut.Lock()
+	updates := make([]Update, 0)
+	for _, update := range api.updates {
+		if update.UpdateID >= offset {
+			updates = append(updates, update)
+		}
+	}
+	api.mut.Unlock()
+
+	if len(updates) == 0 {
+		// mimic a short long polling
+		time.Sleep(time.Millisecond * 20)
+	}
+
+	result, _ := json.Marshal(updates)
+	writeBotResponse(w, http.StatusOK, botResponse{Ok: true, Result: result})
+}
+
+func (api *fakeBotApi) addUpdate(update Update) {
+	api.mut.Lock()
+	api.updates = append(api.updates, update)
+	api.mut.Unlock()
+}
+
+func (api *fakeBotApi) failChat(chatID int64) {
+	api.mut.Lock()
+	api.failedChatIDs[chatID] = struct{}{}
+	api.mut.Unlock()
+}
+
+func (api *fakeBotApi) getSentMessages() []sendMessageRequest {
+	api.mut.Lock()
+	defer api.mut.Unlock()
+
+	messages := make([]sendMessageRequest, len(api.sentMessages))
+	copy(messages, api.sentMessages)
+
+	return messages
+}
+
+func writeBotResponse(w http.ResponseWriter, status int, response botResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func createTestBotClient(t *testing.T, apiUrl string) BotClient {
+	httpClient, err := httpWrapper.NewHTTPClientWrapper(time.Second * 5)
+	require.Nil(t, err)
+
+	client, err := NewBotClient(ArgsBotClient{
+		HTTPClient: httpClient,
+		ApiUrl:     apiUrl,
+		Token:      testToken,
+	})
+	require.Nil(t, err)
+
+	return client
+}
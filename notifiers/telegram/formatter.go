@@ -0,0 +1,60 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+const (
+	markdownV2ParseMode = "MarkdownV2"
+	maxMessageLength    = 4096
+	// the escaping can double the data length so it is truncated well below the message limit
+	maxDataLength = 2000
+)
+
+var levelHeaders = map[data.EventLevel]string{
+	data.Error: "🚨 *ERROR*",
+	data.Info:  "ℹ️ *INFO*",
+}
+
+// the characters that must be escaped in a MarkdownV2 message, as specified in the Bot API documentation
+var markdownV2Replacer = strings.NewReplacer(
+	"\\", "\\\\", "_", "\\_", "*", "\\*", "[", "\\[", "]", "\\]", "(", "\\(", ")", "\\)", "~", "\\~",
+	"`", "\\`", ">", "\\>", "#", "\\#", "+", "\\+", "-", "\\-", "=", "\\=", "|", "\\|", "{", "\\{",
+	"}", "\\}", ".", "\\.", "!", "\\!",
+)
+
+func escapeMarkdown(text string) string {
+	return markdownV2Replacer.Replace(text)
+}
+
+// formatMessage creates the MarkdownV2 message for the provided alarm response
+func formatMessage(response data.AlarmResponse) string {
+	header, found := levelHeaders[response.Level]
+	if !found {
+		header = "*" + escapeMarkdown(string(response.Level)) + "*"
+	}
+
+	return fmt.Sprintf("%s \\| *%s*\n%s",
+		header,
+		escapeMarkdown(response.Identifier),
+		escapeMarkdown(formatting.Truncate(response.Data, maxDataLength)),
+	)
+}
+
+// formatRenderedMessage creates the plain text message from the texts rendered from the templates
+func formatRenderedMessage(message templating.Message) string {
+	lines := make([]string, 0, 2)
+	if len(message.Title) > 0 {
+		lines = append(lines, message.Title)
+	}
+	if len(message.Body) > 0 {
+		lines = append(lines, message.Body)
+	}
+
+	return formatting.Truncate(strings.Join(lines, "\n"), maxMessageLength)
+}
@@ -0,0 +1,53 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "plain text", escapeMarkdown("plain text"))
+	assert.Equal(t, "rating 99\\.5 \\> 100\\! \\(node\\_1\\) \\\\", escapeMarkdown("rating 99.5 > 100! (node_1) \\"))
+}
+
+func TestFormatMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error level", func(t *testing.T) {
+		message := formatMessage(data.AlarmResponse{Identifier: "testnet - rating", Level: data.Error, Data: "rating 90.5"})
+		assert.Equal(t, "🚨 *ERROR* \\| *testnet \\- rating*\nrating 90\\.5", message)
+	})
+	t.Run("info level", func(t *testing.T) {
+		message := formatMessage(data.AlarmResponse{Identifier: "system", Level: data.Info, Data: "running"})
+		assert.Equal(t, "ℹ️ *INFO* \\| *system*\nrunning", message)
+	})
+	t.Run("unknown level", func(t *testing.T) {
+		message := formatMessage(data.AlarmResponse{Identifier: "system", Level: data.NoEvent})
+		assert.True(t, strings.HasPrefix(message, "*No event*"))
+	})
+	t.Run("long data should be truncated", func(t *testing.T) {
+		message := formatMessage(data.AlarmResponse{Level: data.Error, Data: strings.Repeat(".", maxDataLength*2)})
+		assert.True(t, utf8.RuneCountInString(message) <= maxMessageLength)
+		assert.True(t, strings.HasSuffix(message, formatting.TruncateSuffix))
+	})
+}
+
+func TestFormatRenderedMessage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "title\nbody", formatRenderedMessage(templating.Message{Title: "title", Body: "body", HTMLBody: "html"}))
+	assert.Equal(t, "body", formatRenderedMessage(templating.Message{Body: "body"}))
+	assert.Equal(t, "title", formatRenderedMessage(templating.Message{Title: "title"}))
+
+	message := formatRenderedMessage(templating.Message{Body: strings.Repeat(".", maxMessageLength+1)})
+	assert.Equal(t, maxMessageLength, utf8.RuneCountInString(message))
+	assert.True(t, strings.HasSuffix(message, formatting.TruncateSuffix))
+}
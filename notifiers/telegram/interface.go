@@ -0,0 +1,33 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+// HTTPClient defines the operations of the HTTP client used to call the Bot API
+type HTTPClient interface {
+	CallGetRestEndPoint(ctx context.Context, url string) ([]byte, error)
+	CallPostRestEndPointWithResponse(ctx context.Context, url string, data interface{}) ([]byte, error)
+	IsInterfaceNil() bool
+}
+
+// BotClient defines the Bot API operations used by the notifier and the commands handler
+type BotClient interface {
+	SendMessage(ctx context.Context, chatID int64, text string, parseMode string) error
+	GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error)
+	IsInterfaceNil() bool
+}
+
+// AlarmsController defines the operations of a component able to provide the alarms status and to control them
+type AlarmsController interface {
+	AlarmsStatus() []data.AlarmStatus
+	QueryAlarmInfo(ctx context.Context, identifier string) (string, error)
+	PauseAlarm(identifier string) error
+	ResumeAlarm(identifier string) error
+	AcknowledgeAlarm(identifier string) error
+	SilenceAlarm(identifier string, duration time.Duration) error
+	IsInterfaceNil() bool
+}
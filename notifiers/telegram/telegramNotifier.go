@@ -0,0 +1,84 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+// ArgsTelegramNotifier represents the arguments DTO for the telegramNotifier constructor. The renderer is optional,
+// the built-in MarkdownV2 message being used without it
+type ArgsTelegramNotifier struct {
+	BotClient BotClient
+	ChatIDs   []int64
+	Renderer  templating.MessageRenderer
+}
+
+type telegramNotifier struct {
+	botClient BotClient
+	chatIDs   []int64
+	renderer  templating.MessageRenderer
+}
+
+// NewTelegramNotifier creates a new notifier that posts the alarm responses in the configured Telegram chats
+func NewTelegramNotifier(args ArgsTelegramNotifier) (*telegramNotifier, error) {
+	if check.IfNil(args.BotClient) {
+		return nil, errNilBotClient
+	}
+	if len(args.ChatIDs) == 0 {
+		return nil, errNoChatIDs
+	}
+
+	return &telegramNotifier{
+		botClient: args.BotClient,
+		chatIDs:   args.ChatIDs,
+		renderer:  args.Renderer,
+	}, nil
+}
+
+// ProcessAlarmResponse will send the alarm response to all configured chats. The responses without an event are
+// ignored. The error of the last failed chat is returned
+func (notifier *telegramNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	text, parseMode, err := notifier.createText(response)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, chatID := range notifier.chatIDs {
+		err = notifier.botClient.SendMessage(ctx, chatID, text, parseMode)
+		if err != nil {
+			log.Warn("error sending Telegram message", "chat ID", chatID, "error", err.Error())
+			lastErr = fmt.Errorf("%w for chat ID %d", err, chatID)
+		}
+	}
+
+	return lastErr
+}
+
+// createText returns the message text and its parse mode. The texts rendered from the templates are sent as plain
+// text so they do not need any escaping
+func (notifier *telegramNotifier) createText(response data.AlarmResponse) (string, string, error) {
+	if check.IfNil(notifier.renderer) {
+		return formatMessage(response), markdownV2ParseMode, nil
+	}
+
+	message, err := notifier.renderer.Render(response)
+	if err != nil {
+		return "", "", err
+	}
+
+	return formatRenderedMessage(message), "", nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *telegramNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
@@ -0,0 +1,135 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/mocks"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTelegramNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil bot client should error", func(t *testing.T) {
+		notifier, err := NewTelegramNotifier(ArgsTelegramNotifier{ChatIDs: []int64{1}})
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilBotClient, err)
+	})
+	t.Run("no chat IDs should error", func(t *testing.T) {
+		notifier, err := NewTelegramNotifier(ArgsTelegramNotifier{BotClient: createTestBotClient(t, "http://localhost")})
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNoChatIDs, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		notifier, err := NewTelegramNotifier(ArgsTelegramNotifier{
+			BotClient: createTestBotClient(t, "http://localhost"),
+			ChatIDs:   []int64{1},
+		})
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+	})
+}
+
+func TestTelegramNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no event responses should be ignored", func(t *testing.T) {
+		api := newFakeBotApi()
+		defer api.Close()
+
+		notifier, _ := NewTelegramNotifier(ArgsTelegramNotifier{
+			BotClient: createTestBotClient(t, api.URL),
+			ChatIDs:   []int64{1},
+		})
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.NoEvent})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(api.getSentMessages()))
+	})
+	t.Run("should send to all chats", func(t *testing.T) {
+		api := newFakeBotApi()
+		defer api.Close()
+
+		notifier, _ := NewTelegramNotifier(ArgsTelegramNotifier{
+			BotClient: createTestBotClient(t, api.URL),
+			ChatIDs:   []int64{1, 2},
+		})
+
+		response := data.AlarmResponse{Identifier: "alarm", Level: data.Error, Data: "node down"}
+		err := notifier.ProcessAlarmResponse(context.Background(), response)
+		assert.Nil(t, err)
+
+		messages := api.getSentMessages()
+		assert.Equal(t, 2, len(messages))
+		assert.Equal(t, int64(1), messages[0].ChatID)
+		assert.Equal(t, int64(2), messages[1].ChatID)
+		assert.Equal(t, formatMessage(response), messages[0].Text)
+		assert.Equal(t, markdownV2ParseMode, messages[0].ParseMode)
+	})
+	t.Run("should send the rendered texts as plain text", func(t *testing.T) {
+		api := newFakeBotApi()
+		defer api.Close()
+
+		notifier, _ := NewTelegramNotifier(ArgsTelegramNotifier{
+			BotClient: createTestBotClient(t, api.URL),
+			ChatIDs:   []int64{1},
+			Renderer: &mocks.MessageRendererStub{
+				RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+					return templating.Message{Title: "rendered title", Body: "rendered body."}, nil
+				},
+			},
+		})
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "alarm", Level: data.Error})
+		assert.Nil(t, err)
+
+		messages := api.getSentMessages()
+		require.Equal(t, 1, len(messages))
+		assert.Equal(t, "rendered title\nrendered body.", messages[0].Text)
+		assert.Empty(t, messages[0].ParseMode)
+	})
+	t.Run("render error should error", func(t *testing.T) {
+		api := newFakeBotApi()
+		defer api.Close()
+
+		expectedErr := errors.New("expected error")
+		notifier, _ := NewTelegramNotifier(ArgsTelegramNotifier{
+			BotClient: createTestBotClient(t, api.URL),
+			ChatIDs:   []int64{1},
+			Renderer: &mocks.MessageRendererStub{
+				RenderCalled: func(response data.AlarmResponse) (templating.Message, error) {
+					return templating.Message{}, expectedErr
+				},
+			},
+		})
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Identifier: "alarm", Level: data.Error})
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, 0, len(api.getSentMessages()))
+	})
+	t.Run("failed chat should not stop the others", func(t *testing.T) {
+		api := newFakeBotApi()
+		defer api.Close()
+		api.failChat(1)
+
+		notifier, _ := NewTelegramNotifier(ArgsTelegramNotifier{
+			BotClient: createTestBotClient(t, api.URL),
+			ChatIDs:   []int64{1, 2},
+		})
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.Info})
+		assert.True(t, errors.Is(err, errBotApi))
+		assert.True(t, strings.Contains(err.Error(), "for chat ID 1"))
+
+		messages := api.getSentMessages()
+		assert.Equal(t, 1, len(messages))
+		assert.Equal(t, int64(2), messages[0].ChatID)
+	})
+}
@@ -0,0 +1,32 @@
+package templating
+
+// DefaultTitle is the built-in template of the notifications' title
+const DefaultTitle = `{{with levelIcon .Level}}{{.}} {{end}}{{.Level | print | upper}} | {{.Identifier}}`
+
+// DefaultBody is the built-in template of the notifications' plain text body. The info report lists the summary
+// and the status of each alarm while the other responses list the labels and the data
+const DefaultBody = `
+{{- range .Summary}}{{.Name}}: {{.Value}}
+{{end}}
+{{- range $name, $value := displayLabels .Labels}}{{$name}}: {{$value}}
+{{end}}
+{{- if or .Summary .Labels}}
+{{end}}
+{{- if .Report}}
+{{- range $index, $entry := .Report}}{{if $index}}
+{{end}}{{$entry.Identifier}}: {{$entry.Status}}{{end}}
+{{- else}}{{.Data}}{{end}}`
+
+// DefaultHTMLBody is the built-in template of the notifications' HTML body, for the notifiers supporting it
+const DefaultHTMLBody = `<p><b>{{.Level | print | upper}}</b> | <b>{{.Identifier}}</b></p>
+{{- if .Summary}}
+<ul>{{range .Summary}}<li>{{.Name}}: {{.Value}}</li>{{end}}</ul>
+{{- end}}
+{{- if .Labels}}
+<ul>{{range $name, $value := displayLabels .Labels}}<li>{{$name}}: {{$value}}</li>{{end}}</ul>
+{{- end}}
+{{- if .Report}}
+<ul>{{range .Report}}<li><b>{{.Identifier}}</b>: {{.Status}}</li>{{end}}</ul>
+{{- else if .Data}}
+<pre>{{.Data}}</pre>
+{{- end}}`
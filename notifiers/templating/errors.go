@@ -0,0 +1,7 @@
+package templating
+
+import "errors"
+
+var errInvalidTemplate = errors.New("invalid template")
+var errInvalidNumber = errors.New("invalid number")
+var errInvalidDuration = errors.New("invalid duration")
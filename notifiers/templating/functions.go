@@ -0,0 +1,184 @@
+package templating
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+)
+
+const (
+	ratingDecimals = 2
+)
+
+var levelIcons = map[data.EventLevel]string{
+	data.Error: "🚨",
+	data.Info:  "ℹ️",
+}
+
+var durationUnits = []struct {
+	suffix  string
+	seconds int64
+}{
+	{suffix: "d", seconds: 86400},
+	{suffix: "h", seconds: 3600},
+	{suffix: "m", seconds: 60},
+	{suffix: "s", seconds: 1},
+}
+
+// Functions returns the helper functions available in all the templates. A new map is returned on each call so
+// the caller can safely extend it
+func Functions() map[string]interface{} {
+	return map[string]interface{}{
+		"json":             toJson,
+		"upper":            strings.ToUpper,
+		"lower":            strings.ToLower,
+		"truncate":         truncate,
+		"formatTime":       formatTime,
+		"default":          defaultValue,
+		"humanizeDuration": humanizeDuration,
+		"shortenPubkey":    formatting.ShortenPubkey,
+		"displayLabels":    common.DisplayLabels,
+		"formatRating":     formatRating,
+		"levelIcon":        levelIcon,
+	}
+}
+
+// toJson encodes the value as JSON, useful for embedding strings in JSON payloads: "text": {{json .Data}}
+func toJson(value interface{}) (string, error) {
+	buff, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buff), nil
+}
+
+// truncate has the text as the last argument so it can be used in pipelines: {{.Data | truncate 100}}
+func truncate(maxLength int, text string) string {
+	if maxLength < 1 {
+		return ""
+	}
+
+	return formatting.Truncate(text, maxLength)
+}
+
+// formatTime has the time as the last argument so it can be used in pipelines: {{.Timestamp | formatTime "15:04"}}
+func formatTime(layout string, timestamp time.Time) string {
+	return timestamp.Format(layout)
+}
+
+// defaultValue returns the provided default if the value is empty: {{.Labels.team | default "ops"}}
+func defaultValue(defaultText string, value interface{}) interface{} {
+	if value == nil || value == "" {
+		return defaultText
+	}
+
+	return value
+}
+
+// humanizeDuration renders a duration, or a number of seconds like the uptime metric, with its most significant
+// unit and the next one: {{.Metrics.uptimeSeconds | humanizeDuration}} gives "3d 4h". The empty values (e.g. missing
+// labels) are rendered as empty texts
+func humanizeDuration(value interface{}) (string, error) {
+	if value == nil || value == "" {
+		return "", nil
+	}
+
+	duration, err := toDuration(value)
+	if err != nil {
+		return "", err
+	}
+
+	sign := ""
+	if duration < 0 {
+		sign = "-"
+		duration = -duration
+	}
+
+	seconds := int64(duration.Round(time.Second) / time.Second)
+	for i, unit := range durationUnits {
+		if seconds < unit.seconds {
+			continue
+		}
+
+		text := fmt.Sprintf("%d%s", seconds/unit.seconds, unit.suffix)
+		if i+1 < len(durationUnits) {
+			nextUnit := durationUnits[i+1]
+			count := (seconds % unit.seconds) / nextUnit.seconds
+			if count > 0 {
+				text += fmt.Sprintf(" %d%s", count, nextUnit.suffix)
+			}
+		}
+
+		return sign + text, nil
+	}
+
+	return "0s", nil
+}
+
+func toDuration(value interface{}) (time.Duration, error) {
+	switch typedValue := value.(type) {
+	case time.Duration:
+		return typedValue, nil
+	case string:
+		duration, err := time.ParseDuration(typedValue)
+		if err == nil {
+			return duration, nil
+		}
+	}
+
+	seconds, err := toFloat(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", errInvalidDuration, value)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// formatRating renders a node rating with two decimals: {{.Metrics.rating | formatRating}} gives "98.75". The empty
+// values (e.g. missing labels) are rendered as empty texts
+func formatRating(value interface{}) (string, error) {
+	if value == nil || value == "" {
+		return "", nil
+	}
+
+	rating, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatFloat(rating, 'f', ratingDecimals, 64), nil
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch typedValue := value.(type) {
+	case float64:
+		return typedValue, nil
+	case float32:
+		return float64(typedValue), nil
+	case int:
+		return float64(typedValue), nil
+	case int64:
+		return float64(typedValue), nil
+	case uint64:
+		return float64(typedValue), nil
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(typedValue), 64)
+		if err == nil {
+			return number, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %v", errInvalidNumber, value)
+}
+
+// levelIcon returns the emoji of the level, or an empty text for the levels without one
+func levelIcon(level data.EventLevel) string {
+	return levelIcons[level]
+}
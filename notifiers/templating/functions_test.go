@@ -0,0 +1,93 @@
+package templating
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanizeDuration(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{name: "empty value", value: "", expected: ""},
+		{name: "zero", value: 0.0, expected: "0s"},
+		{name: "seconds", value: 42.0, expected: "42s"},
+		{name: "minutes and seconds", value: 125, expected: "2m 5s"},
+		{name: "whole hours", value: int64(7200), expected: "2h"},
+		{name: "days and hours", value: 273900.0, expected: "3d 4h"},
+		{name: "days without hours", value: 86400.0 + 300, expected: "1d"},
+		{name: "duration", value: 90 * time.Minute, expected: "1h 30m"},
+		{name: "negative duration", value: -90 * time.Second, expected: "-1m 30s"},
+		{name: "duration text", value: "36h", expected: "1d 12h"},
+		{name: "seconds text", value: "3600", expected: "1h"},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := humanizeDuration(tc.value)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("invalid value should error", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := humanizeDuration("forever")
+		assert.Empty(t, result)
+		assert.True(t, errors.Is(err, errInvalidDuration))
+	})
+}
+
+func TestFormatRating(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{name: "empty value", value: "", expected: ""},
+		{name: "float", value: 98.7512, expected: "98.75"},
+		{name: "integer", value: 100, expected: "100.00"},
+		{name: "text", value: " 89.5 ", expected: "89.50"},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := formatRating(tc.value)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("invalid value should error", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := formatRating("high")
+		assert.Empty(t, result)
+		assert.True(t, errors.Is(err, errInvalidNumber))
+	})
+}
+
+func TestLevelIcon(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "🚨", levelIcon(data.Error))
+	assert.Equal(t, "ℹ️", levelIcon(data.Info))
+	assert.Equal(t, "", levelIcon(data.NoEvent))
+}
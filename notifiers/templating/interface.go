@@ -0,0 +1,9 @@
+package templating
+
+import "github.com/iulianpascalau/node-monitoring/data"
+
+// MessageRenderer defines the operations of the component that renders the notifications from templates
+type MessageRenderer interface {
+	Render(response data.AlarmResponse) (Message, error)
+	IsInterfaceNil() bool
+}
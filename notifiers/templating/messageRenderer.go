@@ -0,0 +1,178 @@
+package templating
+
+import (
+	"bytes"
+	"fmt"
+	htmlTemplate "html/template"
+	"io"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/common"
+)
+
+// MessageTemplate holds the texts of the templates rendering a notification. The empty ones are replaced by
+// the default templates
+type MessageTemplate struct {
+	Title    string
+	Body     string
+	HTMLBody string
+}
+
+// Message is the rendered notification. The notifiers without HTML support only use the title and the plain body
+type Message struct {
+	Title    string
+	Body     string
+	HTMLBody string
+}
+
+// TemplateData is the value the templates are executed on: all the alarm response fields are promoted so they can
+// be used directly (e.g. {{.Identifier}}, {{.Labels.shard}}, {{.Metrics.rating}}) together with the notification
+// timestamp and the info report summary
+type TemplateData struct {
+	data.AlarmResponse
+	Timestamp time.Time
+	Summary   []common.SummaryField
+}
+
+// ArgsMessageRenderer represents the arguments DTO for the messageRenderer constructor. The templates of a level
+// take precedence over the default ones which take precedence over the built-in ones
+type ArgsMessageRenderer struct {
+	Default MessageTemplate
+	Levels  map[data.EventLevel]MessageTemplate
+}
+
+type executor interface {
+	Execute(writer io.Writer, data interface{}) error
+}
+
+type messageTemplates struct {
+	title    executor
+	body     executor
+	htmlBody executor
+}
+
+type messageRenderer struct {
+	defaultTemplates messageTemplates
+	levelTemplates   map[data.EventLevel]messageTemplates
+	getTime          func() time.Time
+}
+
+// NewMessageRenderer creates a new component that renders the notifications from the provided templates. The title
+// and the plain body use text/template while the HTML body uses html/template, so the alarm's values are escaped
+func NewMessageRenderer(args ArgsMessageRenderer) (*messageRenderer, error) {
+	builtIn := MessageTemplate{
+		Title:    DefaultTitle,
+		Body:     DefaultBody,
+		HTMLBody: DefaultHTMLBody,
+	}
+	defaultTemplate := mergeTemplates(args.Default, builtIn)
+	defaultTemplates, err := parseTemplates("Default", defaultTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer := &messageRenderer{
+		defaultTemplates: defaultTemplates,
+		levelTemplates:   make(map[data.EventLevel]messageTemplates, len(args.Levels)),
+		getTime:          time.Now,
+	}
+	for level, levelTemplate := range args.Levels {
+		renderer.levelTemplates[level], err = parseTemplates(string(level), mergeTemplates(levelTemplate, defaultTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return renderer, nil
+}
+
+func mergeTemplates(preferred MessageTemplate, fallback MessageTemplate) MessageTemplate {
+	if len(preferred.Title) == 0 {
+		preferred.Title = fallback.Title
+	}
+	if len(preferred.Body) == 0 {
+		preferred.Body = fallback.Body
+	}
+	if len(preferred.HTMLBody) == 0 {
+		preferred.HTMLBody = fallback.HTMLBody
+	}
+
+	return preferred
+}
+
+// parseTemplates uses missingkey=zero so the missing labels or metrics are rendered as empty values instead of
+// the "<no value>" text
+func parseTemplates(name string, messageTemplate MessageTemplate) (messageTemplates, error) {
+	title, err := textTemplate.New("Title").Option("missingkey=zero").Funcs(Functions()).Parse(messageTemplate.Title)
+	if err != nil {
+		return messageTemplates{}, fmt.Errorf("%w for %s Title: %s", errInvalidTemplate, name, err.Error())
+	}
+	body, err := textTemplate.New("Body").Option("missingkey=zero").Funcs(Functions()).Parse(messageTemplate.Body)
+	if err != nil {
+		return messageTemplates{}, fmt.Errorf("%w for %s Body: %s", errInvalidTemplate, name, err.Error())
+	}
+	htmlBody, err := htmlTemplate.New("HTMLBody").Option("missingkey=zero").Funcs(Functions()).Parse(messageTemplate.HTMLBody)
+	if err != nil {
+		return messageTemplates{}, fmt.Errorf("%w for %s HTMLBody: %s", errInvalidTemplate, name, err.Error())
+	}
+
+	return messageTemplates{
+		title:    title,
+		body:     body,
+		htmlBody: htmlBody,
+	}, nil
+}
+
+// Render executes the templates of the response's level. The rendered texts are trimmed so the templates can be
+// freely laid out on multiple lines
+func (renderer *messageRenderer) Render(response data.AlarmResponse) (Message, error) {
+	templates, found := renderer.levelTemplates[response.Level]
+	if !found {
+		templates = renderer.defaultTemplates
+	}
+
+	value := TemplateData{
+		AlarmResponse: response,
+		Timestamp:     renderer.getTime(),
+	}
+	if response.Identifier == data.SystemIdentifier {
+		value.Summary = common.ReportSummary(response.Metrics)
+	}
+
+	title, err := execute(templates.title, value)
+	if err != nil {
+		return Message{}, fmt.Errorf("%w while rendering the title", err)
+	}
+	body, err := execute(templates.body, value)
+	if err != nil {
+		return Message{}, fmt.Errorf("%w while rendering the body", err)
+	}
+	htmlBody, err := execute(templates.htmlBody, value)
+	if err != nil {
+		return Message{}, fmt.Errorf("%w while rendering the HTML body", err)
+	}
+
+	return Message{
+		Title:    title,
+		Body:     body,
+		HTMLBody: htmlBody,
+	}, nil
+}
+
+func execute(tmpl executor, value TemplateData) (string, error) {
+	buff := bytes.Buffer{}
+	err := tmpl.Execute(&buff, value)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buff.String()), nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (renderer *messageRenderer) IsInterfaceNil() bool {
+	return renderer == nil
+}
@@ -0,0 +1,149 @@
+package templating
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessageRenderer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid default template should error", func(t *testing.T) {
+		t.Parallel()
+
+		renderer, err := NewMessageRenderer(ArgsMessageRenderer{
+			Default: MessageTemplate{Body: "{{.Identifier"},
+		})
+		assert.True(t, check.IfNil(renderer))
+		assert.True(t, errors.Is(err, errInvalidTemplate))
+		assert.True(t, strings.Contains(err.Error(), "for Default Body"))
+	})
+	t.Run("invalid level template should error", func(t *testing.T) {
+		t.Parallel()
+
+		renderer, err := NewMessageRenderer(ArgsMessageRenderer{
+			Levels: map[data.EventLevel]MessageTemplate{
+				data.Error: {HTMLBody: "{{.Identifier | missing}}"},
+			},
+		})
+		assert.True(t, check.IfNil(renderer))
+		assert.True(t, errors.Is(err, errInvalidTemplate))
+		assert.True(t, strings.Contains(err.Error(), "for Error HTMLBody"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		renderer, err := NewMessageRenderer(ArgsMessageRenderer{})
+		assert.False(t, check.IfNil(renderer))
+		assert.Nil(t, err)
+	})
+}
+
+func TestMessageRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	t.Run("built-in templates for an alarm", func(t *testing.T) {
+		t.Parallel()
+
+		renderer, _ := NewMessageRenderer(ArgsMessageRenderer{})
+		response := SampleResponse(data.Error)
+		response.Labels["pubkey"] = "<key>"
+
+		message, err := renderer.Render(response)
+		require.Nil(t, err)
+		assert.Equal(t, "🚨 ERROR | node rating", message.Title)
+		assert.Equal(t, "pubkey: <key>\nshard: 1\n\nrating dropped to 89.5, below the 95 threshold", message.Body)
+		assert.Equal(t, "<p><b>ERROR</b> | <b>node rating</b></p>\n"+
+			"<ul><li>pubkey: &lt;key&gt;</li><li>shard: 1</li></ul>\n"+
+			"<pre>rating dropped to 89.5, below the 95 threshold</pre>", message.HTMLBody)
+	})
+	t.Run("built-in templates should shorten the public key of a known node", func(t *testing.T) {
+		t.Parallel()
+
+		renderer, _ := NewMessageRenderer(ArgsMessageRenderer{})
+		response := SampleResponse(data.Error)
+		response.Labels[data.NodeLabel] = "validator-01"
+
+		message, err := renderer.Render(response)
+		require.Nil(t, err)
+		assert.Equal(t, "node: validator-01\npubkey: c26a1d…f80f0c\nshard: 1\n\nrating dropped to 89.5, below the 95 threshold",
+			message.Body)
+		assert.Equal(t, samplePubkey, response.Labels[data.PubkeyLabel])
+	})
+	t.Run("built-in templates for the info report", func(t *testing.T) {
+		t.Parallel()
+
+		renderer, _ := NewMessageRenderer(ArgsMessageRenderer{})
+		message, err := renderer.Render(SampleResponse(data.Info))
+		require.Nil(t, err)
+		assert.Equal(t, "ℹ️ INFO | system", message.Title)
+		assert.Equal(t, "Uptime: 76h5m0s\nProcessing errors: 0\nAlarms with error: 0\n\n"+
+			"node rating: rating 98.75\nnode nonce: nonce 12345678", message.Body)
+	})
+	t.Run("the level templates should take precedence", func(t *testing.T) {
+		t.Parallel()
+
+		renderer, _ := NewMessageRenderer(ArgsMessageRenderer{
+			Default: MessageTemplate{
+				Title: "{{.Identifier}} is {{.Level}}",
+				Body:  "default body",
+			},
+			Levels: map[data.EventLevel]MessageTemplate{
+				data.Error: {
+					Body: `
+						{{.Labels.pubkey | shortenPubkey}} on shard {{.Labels.shard}}
+						rating {{.Metrics.rating | formatRating}} at {{.Timestamp | formatTime "15:04"}}
+					`,
+				},
+			},
+		})
+		renderer.getTime = func() time.Time {
+			return time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+		}
+
+		message, err := renderer.Render(SampleResponse(data.Error))
+		require.Nil(t, err)
+		assert.Equal(t, "node rating is Error", message.Title)
+		assert.Equal(t, "c26a1d…f80f0c on shard 1\n\t\t\t\t\t\trating 89.50 at 09:30", message.Body)
+		assert.True(t, strings.HasPrefix(message.HTMLBody, "<p><b>ERROR</b>"))
+
+		message, err = renderer.Render(SampleResponse(data.Info))
+		require.Nil(t, err)
+		assert.Equal(t, "system is Info", message.Title)
+		assert.Equal(t, "default body", message.Body)
+	})
+	t.Run("missing values should be rendered as empty", func(t *testing.T) {
+		t.Parallel()
+
+		renderer, _ := NewMessageRenderer(ArgsMessageRenderer{
+			Default: MessageTemplate{
+				Body: `[{{.Labels.missing}}] [{{.Labels.missing | formatRating}}] [{{.Labels.team | default "ops"}}]`,
+			},
+		})
+
+		message, err := renderer.Render(SampleResponse(data.Error))
+		require.Nil(t, err)
+		assert.Equal(t, "[] [] [ops]", message.Body)
+	})
+	t.Run("execution error should error", func(t *testing.T) {
+		t.Parallel()
+
+		renderer, _ := NewMessageRenderer(ArgsMessageRenderer{
+			Default: MessageTemplate{
+				Title: "{{.Data | formatRating}}",
+			},
+		})
+
+		message, err := renderer.Render(SampleResponse(data.Error))
+		assert.Equal(t, Message{}, message)
+		assert.True(t, errors.Is(err, errInvalidNumber))
+		assert.True(t, strings.Contains(err.Error(), "while rendering the title"))
+	})
+}
@@ -0,0 +1,43 @@
+package templating
+
+import (
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+const samplePubkey = "c26a1d7dcbbd5b7ac75f3a06d30bd42bd3b4d1ae2cde1d4a1b5a6e1c0e4d1b7f2c1f8a7f0d2e5b6c3a9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f80f0c"
+
+// SampleResponse returns an alarm response of the provided level, used to preview the templates. The Info level
+// returns an info report as created by the monitoring tool
+func SampleResponse(level data.EventLevel) data.AlarmResponse {
+	if level == data.Info {
+		return data.AlarmResponse{
+			Identifier: data.SystemIdentifier,
+			Level:      data.Info,
+			Data: "Node monitoring report. Uptime: 76h5m0s, processing errors: 0, alarms with error: 0" +
+				"\nStatus for alarm node rating: rating 98.75" +
+				"\nStatus for alarm node nonce: nonce 12345678",
+			Metrics: map[string]float64{
+				data.UptimeMetric:           273900,
+				data.ProcessingErrorsMetric: 0,
+				data.AlarmsWithErrorMetric:  0,
+			},
+			Report: []data.ReportEntry{
+				{Identifier: "node rating", Level: data.Info, Status: "rating 98.75"},
+				{Identifier: "node nonce", Level: data.Info, Status: "nonce 12345678"},
+			},
+		}
+	}
+
+	return data.AlarmResponse{
+		Identifier: "node rating",
+		Level:      level,
+		Data:       "rating dropped to 89.5, below the 95 threshold",
+		Metrics: map[string]float64{
+			"rating": 89.5,
+		},
+		Labels: map[string]string{
+			"pubkey": samplePubkey,
+			"shard":  "1",
+		},
+	}
+}
@@ -0,0 +1,10 @@
+package webhook
+
+import "errors"
+
+var errNilHTTPClient = errors.New("nil HTTP client")
+var errEmptyUrl = errors.New("empty URL")
+var errInvalidTemplate = errors.New("invalid template")
+var errInvalidMethod = errors.New("invalid HTTP method")
+var errInvalidStatusCode = errors.New("invalid status code")
+var errUnexpectedStatusCode = errors.New("unexpected status code")
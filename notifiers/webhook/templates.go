@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/notifiers/templating"
+)
+
+// templateData is the value the templates are executed on: all the alarm response fields are promoted so they
+// can be used directly (e.g. {{.Identifier}}, {{.Labels.shard}}) together with the notification timestamp
+type templateData struct {
+	data.AlarmResponse
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// parseTemplate uses missingkey=zero so the missing labels or metrics are rendered as empty values instead of
+// the "<no value>" text
+func parseTemplate(name string, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Funcs(templating.Functions()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s: %s", errInvalidTemplate, name, err.Error())
+	}
+
+	return tmpl, nil
+}
+
+func executeTemplate(tmpl *template.Template, value templateData) (string, error) {
+	buff := bytes.Buffer{}
+	err := tmpl.Execute(&buff, value)
+	if err != nil {
+		return "", err
+	}
+
+	return buff.String(), nil
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestTemplateData() templateData {
+	return templateData{
+		AlarmResponse: data.AlarmResponse{
+			Identifier: "node rating",
+			Level:      data.Error,
+			Data:       "rating is \"low\"\nsecond line",
+			Metrics:    map[string]float64{"rating": 89.5},
+			Labels:     map[string]string{"shard": "1"},
+		},
+		Timestamp: time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestParseTemplate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid template should error", func(t *testing.T) {
+		t.Parallel()
+
+		tmpl, err := parseTemplate("Body", "{{.Identifier")
+		assert.Nil(t, tmpl)
+		assert.True(t, errors.Is(err, errInvalidTemplate))
+		assert.Contains(t, err.Error(), "for Body")
+	})
+	t.Run("unknown function should error", func(t *testing.T) {
+		t.Parallel()
+
+		tmpl, err := parseTemplate("Body", "{{.Identifier | missing}}")
+		assert.Nil(t, tmpl)
+		assert.True(t, errors.Is(err, errInvalidTemplate))
+	})
+}
+
+func TestExecuteTemplate(t *testing.T) {
+	t.Parallel()
+
+	value := createTestTemplateData()
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "fields", text: "{{.Identifier}} {{.Level}} {{.Labels.shard}} {{.Metrics.rating}}", expected: "node rating Error 1 89.5"},
+		{name: "json", text: `{"text": {{json .Data}}}`, expected: `{"text": "rating is \"low\"\nsecond line"}`},
+		{name: "upper and lower", text: "{{upper .Identifier}} {{lower (print .Level)}}", expected: "NODE RATING error"},
+		{name: "truncate", text: "{{.Identifier | truncate 5}}", expected: "node…"},
+		{name: "truncate non positive", text: "{{.Identifier | truncate 0}}", expected: ""},
+		{name: "format time", text: `{{.Timestamp | formatTime "2006-01-02T15:04"}}`, expected: "2026-10-18T09:30"},
+		{name: "default", text: `{{.Labels.team | default "ops"}} {{.Labels.shard | default "meta"}}`, expected: "ops 1"},
+		{name: "whole value as json", text: "{{json .}}", expected: `{"identifier":"node rating","level":"Error","data":"rating is \"low\"\nsecond line","metrics":{"rating":89.5},"labels":{"shard":"1"},"timestamp":"2026-10-18T09:30:00Z"}`},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpl, err := parseTemplate(tc.name, tc.text)
+			assert.Nil(t, err)
+
+			result, err := executeTemplate(tmpl, value)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
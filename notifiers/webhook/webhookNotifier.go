@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/formatting"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+)
+
+const (
+	defaultMethod          = http.MethodPost
+	defaultBody            = "{{json .}}"
+	defaultSignatureHeader = "X-Signature-256"
+	signaturePrefix        = "sha256="
+	maxErrorBodyLength     = 512
+)
+
+// ArgsWebhookNotifier represents the arguments DTO for the webhookNotifier constructor. The Url, Method, Body and
+// the Headers values are text/template definitions executed on each alarm response
+type ArgsWebhookNotifier struct {
+	HTTPClient          HTTPClient
+	Url                 string
+	Method              string
+	Headers             map[string]string
+	Body                string
+	SigningSecret       string
+	SignatureHeader     string
+	ExpectedStatusCodes []int
+}
+
+type webhookNotifier struct {
+	httpClient          HTTPClient
+	urlTemplate         *template.Template
+	methodTemplate      *template.Template
+	headersTemplates    map[string]*template.Template
+	bodyTemplate        *template.Template
+	signingSecret       []byte
+	signatureHeader     string
+	expectedStatusCodes map[int]struct{}
+}
+
+// NewWebhookNotifier creates a new notifier that calls a webhook with a payload built from templates. If the body
+// is not provided, the alarm response is sent as JSON. An empty method means POST and no expected status codes means
+// any 2xx status code
+func NewWebhookNotifier(args ArgsWebhookNotifier) (*webhookNotifier, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	notifier := &webhookNotifier{
+		httpClient:          args.HTTPClient,
+		headersTemplates:    make(map[string]*template.Template, len(args.Headers)),
+		signingSecret:       []byte(args.SigningSecret),
+		signatureHeader:     args.SignatureHeader,
+		expectedStatusCodes: make(map[int]struct{}, len(args.ExpectedStatusCodes)),
+	}
+	if len(notifier.signatureHeader) == 0 {
+		notifier.signatureHeader = defaultSignatureHeader
+	}
+	for _, statusCode := range args.ExpectedStatusCodes {
+		notifier.expectedStatusCodes[statusCode] = struct{}{}
+	}
+
+	err = notifier.parseTemplates(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifier, nil
+}
+
+func checkArgs(args ArgsWebhookNotifier) error {
+	if check.IfNil(args.HTTPClient) {
+		return errNilHTTPClient
+	}
+	if len(strings.TrimSpace(args.Url)) == 0 {
+		return errEmptyUrl
+	}
+	for _, statusCode := range args.ExpectedStatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf("%w: %d", errInvalidStatusCode, statusCode)
+		}
+	}
+
+	return nil
+}
+
+func (notifier *webhookNotifier) parseTemplates(args ArgsWebhookNotifier) error {
+	method := args.Method
+	if len(strings.TrimSpace(method)) == 0 {
+		method = defaultMethod
+	}
+	body := args.Body
+	if len(strings.TrimSpace(body)) == 0 {
+		body = defaultBody
+	}
+
+	var err error
+	notifier.urlTemplate, err = parseTemplate("Url", args.Url)
+	if err != nil {
+		return err
+	}
+	notifier.methodTemplate, err = parseTemplate("Method", method)
+	if err != nil {
+		return err
+	}
+	notifier.bodyTemplate, err = parseTemplate("Body", body)
+	if err != nil {
+		return err
+	}
+	for key, value := range args.Headers {
+		notifier.headersTemplates[key], err = parseTemplate("header "+key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProcessAlarmResponse will render the templates on the alarm response and call the webhook. The responses without
+// an event are ignored
+func (notifier *webhookNotifier) ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error {
+	if response.Level == data.NoEvent {
+		return nil
+	}
+
+	request, err := notifier.createRequest(templateData{
+		AlarmResponse: response,
+		Timestamp:     time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := notifier.httpClient.CallEndPoint(ctx, request)
+	if err != nil {
+		return err
+	}
+	if !notifier.isExpectedStatusCode(result.StatusCode) {
+		return fmt.Errorf("%w %d: %s", errUnexpectedStatusCode, result.StatusCode,
+			formatting.Truncate(string(result.Body), maxErrorBodyLength))
+	}
+
+	return nil
+}
+
+func (notifier *webhookNotifier) createRequest(value templateData) (httpWrapper.Request, error) {
+	url, err := executeTemplate(notifier.urlTemplate, value)
+	if err != nil {
+		return httpWrapper.Request{}, err
+	}
+	url = strings.TrimSpace(url)
+	if len(url) == 0 {
+		return httpWrapper.Request{}, errEmptyUrl
+	}
+
+	method, err := executeTemplate(notifier.methodTemplate, value)
+	if err != nil {
+		return httpWrapper.Request{}, err
+	}
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if !isValidMethod(method) {
+		return httpWrapper.Request{}, fmt.Errorf("%w: %q", errInvalidMethod, method)
+	}
+
+	body, err := executeTemplate(notifier.bodyTemplate, value)
+	if err != nil {
+		return httpWrapper.Request{}, err
+	}
+
+	headers := make(map[string]string, len(notifier.headersTemplates)+1)
+	for key, tmpl := range notifier.headersTemplates {
+		headers[key], err = executeTemplate(tmpl, value)
+		if err != nil {
+			return httpWrapper.Request{}, err
+		}
+	}
+	if len(notifier.signingSecret) > 0 {
+		headers[notifier.signatureHeader] = signaturePrefix + computeSignature(notifier.signingSecret, []byte(body))
+	}
+
+	return httpWrapper.Request{
+		Method:  method,
+		Url:     url,
+		Headers: headers,
+		Body:    []byte(body),
+	}, nil
+}
+
+func (notifier *webhookNotifier) isExpectedStatusCode(statusCode int) bool {
+	if len(notifier.expectedStatusCodes) == 0 {
+		return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+	}
+
+	_, found := notifier.expectedStatusCodes[statusCode]
+
+	return found
+}
+
+// computeSignature returns the hex encoded HMAC-SHA256 of the body, so the receiver can authenticate the request
+func computeSignature(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// isValidMethod checks that the rendered method is a non-empty token made of uppercase letters
+func isValidMethod(method string) bool {
+	if len(method) == 0 {
+		return false
+	}
+	for _, c := range method {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *webhookNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
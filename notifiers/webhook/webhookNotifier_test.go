@@ -0,0 +1,315 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/data"
+	httpWrapper "github.com/iulianpascalau/node-monitoring/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// httpClientStub -
+type httpClientStub struct {
+	CallEndPointCalled func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error)
+}
+
+// CallEndPoint -
+func (stub *httpClientStub) CallEndPoint(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+	if stub.CallEndPointCalled != nil {
+		return stub.CallEndPointCalled(ctx, request)
+	}
+
+	return httpWrapper.Response{StatusCode: http.StatusOK}, nil
+}
+
+// IsInterfaceNil -
+func (stub *httpClientStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+type receivedRequest struct {
+	method  string
+	path    string
+	headers http.Header
+	body    string
+}
+
+func createMockArgsWebhookNotifier() ArgsWebhookNotifier {
+	return ArgsWebhookNotifier{
+		HTTPClient: &httpClientStub{},
+		Url:        "https://example.com/alerts",
+	}
+}
+
+func createTestResponse() data.AlarmResponse {
+	return data.AlarmResponse{
+		Identifier: "node rating",
+		Level:      data.Error,
+		Data:       "rating is low",
+		Labels:     map[string]string{"shard": "1"},
+	}
+}
+
+func TestNewWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil HTTP client should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookNotifier()
+		args.HTTPClient = nil
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errNilHTTPClient, err)
+	})
+	t.Run("empty URL should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookNotifier()
+		args.Url = "  "
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.Equal(t, errEmptyUrl, err)
+	})
+	t.Run("invalid expected status code should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookNotifier()
+		args.ExpectedStatusCodes = []int{200, 600}
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidStatusCode))
+		assert.True(t, strings.Contains(err.Error(), "600"))
+	})
+	t.Run("invalid templates should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookNotifier()
+		args.Url = "https://example.com/{{.Identifier"
+		notifier, err := NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, errors.Is(err, errInvalidTemplate))
+		assert.True(t, strings.Contains(err.Error(), "for Url"))
+
+		args = createMockArgsWebhookNotifier()
+		args.Method = "{{"
+		notifier, err = NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Method"))
+
+		args = createMockArgsWebhookNotifier()
+		args.Body = "{{end}}"
+		notifier, err = NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for Body"))
+
+		args = createMockArgsWebhookNotifier()
+		args.Headers = map[string]string{"X-Team": "{{.Labels.team"}
+		notifier, err = NewWebhookNotifier(args)
+		assert.True(t, check.IfNil(notifier))
+		assert.True(t, strings.Contains(err.Error(), "for header X-Team"))
+	})
+	t.Run("should work with defaults", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, err := NewWebhookNotifier(createMockArgsWebhookNotifier())
+		assert.False(t, check.IfNil(notifier))
+		assert.Nil(t, err)
+		assert.Equal(t, defaultSignatureHeader, notifier.signatureHeader)
+		assert.Empty(t, notifier.expectedStatusCodes)
+	})
+}
+
+func TestWebhookNotifier_ProcessAlarmResponse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no event should not call the webhook", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookNotifier()
+		args.HTTPClient = &httpClientStub{
+			CallEndPointCalled: func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+				assert.Fail(t, "should have not called the webhook")
+				return httpWrapper.Response{}, nil
+			},
+		}
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.NoEvent})
+		assert.Nil(t, err)
+	})
+	t.Run("default body and method should work", func(t *testing.T) {
+		t.Parallel()
+
+		var sentRequest httpWrapper.Request
+		args := createMockArgsWebhookNotifier()
+		args.HTTPClient = &httpClientStub{
+			CallEndPointCalled: func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+				sentRequest = request
+				return httpWrapper.Response{StatusCode: http.StatusNoContent}, nil
+			},
+		}
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Nil(t, err)
+		assert.Equal(t, http.MethodPost, sentRequest.Method)
+		assert.Equal(t, "https://example.com/alerts", sentRequest.Url)
+		assert.True(t, strings.HasPrefix(string(sentRequest.Body),
+			`{"identifier":"node rating","level":"Error","data":"rating is low","labels":{"shard":"1"},"timestamp":"`))
+		assert.Empty(t, sentRequest.Headers)
+	})
+	t.Run("rendering errors should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookNotifier()
+		args.Body = "{{index .Report 5}}"
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.NotNil(t, err)
+	})
+	t.Run("empty rendered URL should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookNotifier()
+		args.Url = "{{.Labels.missing}}"
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Equal(t, errEmptyUrl, err)
+	})
+	t.Run("invalid rendered method should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookNotifier()
+		args.Method = "{{.Identifier}}"
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.True(t, errors.Is(err, errInvalidMethod))
+	})
+	t.Run("HTTP client errors should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockArgsWebhookNotifier()
+		args.HTTPClient = &httpClientStub{
+			CallEndPointCalled: func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+				return httpWrapper.Response{}, expectedErr
+			},
+		}
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("unexpected status code should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsWebhookNotifier()
+		args.HTTPClient = &httpClientStub{
+			CallEndPointCalled: func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+				return httpWrapper.Response{StatusCode: http.StatusBadRequest, Body: []byte(strings.Repeat("e", 1000))}, nil
+			},
+		}
+		notifier, _ := NewWebhookNotifier(args)
+
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+		assert.True(t, strings.Contains(err.Error(), "400"))
+		assert.True(t, len(err.Error()) < 600)
+	})
+	t.Run("configured status codes should be checked", func(t *testing.T) {
+		t.Parallel()
+
+		statusCode := http.StatusAccepted
+		args := createMockArgsWebhookNotifier()
+		args.ExpectedStatusCodes = []int{http.StatusAccepted, http.StatusConflict}
+		args.HTTPClient = &httpClientStub{
+			CallEndPointCalled: func(ctx context.Context, request httpWrapper.Request) (httpWrapper.Response, error) {
+				return httpWrapper.Response{StatusCode: statusCode}, nil
+			},
+		}
+		notifier, _ := NewWebhookNotifier(args)
+
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), createTestResponse()))
+		statusCode = http.StatusConflict
+		assert.Nil(t, notifier.ProcessAlarmResponse(context.Background(), createTestResponse()))
+		statusCode = http.StatusOK
+		err := notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		assert.True(t, errors.Is(err, errUnexpectedStatusCode))
+	})
+	t.Run("templated request through a real server should work", func(t *testing.T) {
+		t.Parallel()
+
+		mut := sync.Mutex{}
+		requests := make([]receivedRequest, 0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			buff, _ := ioutil.ReadAll(r.Body)
+			mut.Lock()
+			requests = append(requests, receivedRequest{
+				method:  r.Method,
+				path:    r.URL.RequestURI(),
+				headers: r.Header,
+				body:    string(buff),
+			})
+			mut.Unlock()
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		httpClient, _ := httpWrapper.NewHTTPClientWrapper(time.Second * 5)
+		notifier, err := NewWebhookNotifier(ArgsWebhookNotifier{
+			HTTPClient: httpClient,
+			Url:        server.URL + "/incidents/{{.Labels.shard}}",
+			Method:     `{{if eq (print .Level) "Error"}}put{{else}}POST{{end}}`,
+			Headers: map[string]string{
+				"X-Alarm":      "{{.Identifier}}",
+				"Content-Type": "application/vnd.incident+json",
+			},
+			Body:            `{"title": {{json .Identifier}}, "severity": "{{lower (print .Level)}}", "details": {{json .Data}}}`,
+			SigningSecret:   "secret",
+			SignatureHeader: "X-Hub-Signature-256",
+		})
+		require.Nil(t, err)
+
+		err = notifier.ProcessAlarmResponse(context.Background(), createTestResponse())
+		require.Nil(t, err)
+
+		mut.Lock()
+		defer mut.Unlock()
+		require.Equal(t, 1, len(requests))
+		expectedBody := `{"title": "node rating", "severity": "error", "details": "rating is low"}`
+		assert.Equal(t, http.MethodPut, requests[0].method)
+		assert.Equal(t, "/incidents/1", requests[0].path)
+		assert.Equal(t, expectedBody, requests[0].body)
+		assert.Equal(t, "node rating", requests[0].headers.Get("X-Alarm"))
+		assert.Equal(t, "application/vnd.incident+json", requests[0].headers.Get("Content-Type"))
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write([]byte(expectedBody))
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), requests[0].headers.Get("X-Hub-Signature-256"))
+	})
+}
+
+func TestComputeSignature(t *testing.T) {
+	t.Parallel()
+
+	// RFC 4231 test case 2
+	signature := computeSignature([]byte("Jefe"), []byte("what do ya want for nothing?"))
+	assert.Equal(t, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", signature)
+}
@@ -6,3 +6,11 @@ var errNoAlarmsSet = errors.New("no alarms set")
 var errNoActiveNotifiers = errors.New("no active notifiers")
 var errNilAlarmHandler = errors.New("nil alarm handler")
 var errNilNotifier = errors.New("nil notifier")
+var errNilEventsPublisher = errors.New("nil events publisher")
+var errEmptyNetworkName = errors.New("empty network name")
+
+// ErrAlarmNotFound signals that the alarm was not found
+var ErrAlarmNotFound = errors.New("alarm not found")
+
+// ErrAlarmNotAlerting signals that the alarm is not in the alerting state
+var ErrAlarmNotAlerting = errors.New("alarm is not alerting")
@@ -2,6 +2,7 @@ package poll
 
 import (
 	"context"
+	"time"
 
 	"github.com/iulianpascalau/node-monitoring/data"
 )
@@ -11,6 +12,7 @@ type AlarmHandler interface {
 	ShouldQuery() bool
 	Query(ctx context.Context) (data.AlarmResponse, error)
 	QueryInfo(ctx context.Context) (string, error)
+	NextQueryTime() time.Time
 	Identifier() string
 	IsInterfaceNil() bool
 }
@@ -20,3 +22,24 @@ type NotifierHandler interface {
 	ProcessAlarmResponse(ctx context.Context, response data.AlarmResponse) error
 	IsInterfaceNil() bool
 }
+
+// networkAlarmHandler defines an alarm belonging to a network profile
+type networkAlarmHandler interface {
+	Network() string
+}
+
+// responseFilter defines a notifier that processes only some of the alarm responses
+type responseFilter interface {
+	AcceptsResponse(response data.AlarmResponse) bool
+}
+
+// notifierWrapper defines a notifier wrapping another notifier
+type notifierWrapper interface {
+	Unwrap() NotifierHandler
+}
+
+// EventsPublisher defines the operations implemented by a component able to dispatch the alarm events
+type EventsPublisher interface {
+	Publish(event data.AlarmEvent)
+	IsInterfaceNil() bool
+}
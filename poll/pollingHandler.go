@@ -3,6 +3,7 @@ package poll
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go-core/core/check"
@@ -12,9 +13,12 @@ import (
 )
 
 const pollingInterval = time.Millisecond * 100
-const systemIdentifier = "system"
+const maxHistoryPoints = 60
+const systemIdentifier = data.SystemIdentifier
 const systemMessage = `System is running. Uptime: %v. 
 Number of processing error: %d, number of alarms with error: %d`
+const networkSystemMessage = `System is running. Uptime: %v. 
+Report for network %s`
 
 var log = logger.GetOrCreate("poll")
 
@@ -22,19 +26,39 @@ var log = logger.GetOrCreate("poll")
 type ArgsPollingHandler struct {
 	Alarms         []AlarmHandler
 	Notifiers      []NotifierHandler
+	Publisher      EventsPublisher
 	SendInfo       bool
 	SendInfoHour   int
 	SendInfoMinute int
 	SendInfoSecond int
+	NetworksInfo   []ArgsNetworkInfo
+}
+
+// ArgsNetworkInfo defines the time of day when the separate info report of a network's alarms is sent
+type ArgsNetworkInfo struct {
+	Network        string
+	SendInfoHour   int
+	SendInfoMinute int
+	SendInfoSecond int
+}
+
+type networkInfo struct {
+	notifiers.TimeOfDayNotifier
+	network string
 }
 
 type pollingHandler struct {
 	notifiers.TimeOfDayNotifier
+	networksInfo []networkInfo
 	*pollingHandlerState
-	alarms    []AlarmHandler
-	notifiers []NotifierHandler
-	startTime time.Time
-	cancel    func()
+	mutComponents sync.RWMutex
+	alarms        []AlarmHandler
+	notifiers     []NotifierHandler
+	publisher     EventsPublisher
+	mutProcess    sync.Mutex
+	startTime     time.Time
+	cancel        func()
+	loopStopped   chan struct{}
 }
 
 // NewPollingHandler creates a new polling handler instance
@@ -49,14 +73,24 @@ func NewPollingHandler(args ArgsPollingHandler) (*pollingHandler, error) {
 		return nil, err
 	}
 
+	networksInfo, err := createNetworksInfo(args.NetworksInfo)
+	if err != nil {
+		return nil, err
+	}
+
 	ph := &pollingHandler{
 		TimeOfDayNotifier:   timeOfDay,
-		pollingHandlerState: &pollingHandlerState{},
+		networksInfo:        networksInfo,
+		pollingHandlerState: newPollingHandlerState(),
 		alarms:              args.Alarms,
 		notifiers:           args.Notifiers,
+		publisher:           args.Publisher,
 		startTime:           time.Now(),
+		loopStopped:         make(chan struct{}),
 	}
 
+	ph.resetNotifiersStatus(notifiersNames(args.Notifiers))
+
 	ctx, cancel := context.WithCancel(context.Background())
 	ph.cancel = cancel
 
@@ -65,20 +99,49 @@ func NewPollingHandler(args ArgsPollingHandler) (*pollingHandler, error) {
 	return ph, nil
 }
 
+func createNetworksInfo(args []ArgsNetworkInfo) ([]networkInfo, error) {
+	networksInfo := make([]networkInfo, 0, len(args))
+	for _, networkArgs := range args {
+		if len(networkArgs.Network) == 0 {
+			return nil, errEmptyNetworkName
+		}
+
+		timeOfDay, err := notifiers.NewTimeOfDayNotifier(networkArgs.SendInfoHour, networkArgs.SendInfoMinute, networkArgs.SendInfoSecond)
+		if err != nil {
+			return nil, fmt.Errorf("%w for network %s", err, networkArgs.Network)
+		}
+
+		networksInfo = append(networksInfo, networkInfo{
+			TimeOfDayNotifier: timeOfDay,
+			network:           networkArgs.Network,
+		})
+	}
+
+	return networksInfo, nil
+}
+
 func checkArgs(args ArgsPollingHandler) error {
-	if len(args.Alarms) == 0 {
+	if check.IfNil(args.Publisher) {
+		return errNilEventsPublisher
+	}
+
+	return checkComponents(args.Alarms, args.Notifiers)
+}
+
+func checkComponents(alarms []AlarmHandler, notifiers []NotifierHandler) error {
+	if len(alarms) == 0 {
 		return errNoAlarmsSet
 	}
-	for idx, alarm := range args.Alarms {
+	for idx, alarm := range alarms {
 		if check.IfNil(alarm) {
 			return fmt.Errorf("%w at index %d", errNilAlarmHandler, idx)
 		}
 	}
 
-	if len(args.Notifiers) == 0 {
+	if len(notifiers) == 0 {
 		return errNoActiveNotifiers
 	}
-	for idx, notifier := range args.Notifiers {
+	for idx, notifier := range notifiers {
 		if check.IfNil(notifier) {
 			return fmt.Errorf("%w at index %d", errNilNotifier, idx)
 		}
@@ -93,6 +156,7 @@ func (ph *pollingHandler) processLoop(ctx context.Context) {
 	defer func() {
 		log.Debug("polling handler's process loop has been stopped")
 		ph.setIsStopped()
+		close(ph.loopStopped)
 	}()
 
 	for {
@@ -106,34 +170,67 @@ func (ph *pollingHandler) processLoop(ctx context.Context) {
 }
 
 func (ph *pollingHandler) poll(ctx context.Context) {
+	ph.mutProcess.Lock()
+	defer ph.mutProcess.Unlock()
+
 	if ph.IsTimeOfDay(time.Now()) {
 		response := ph.createInfoMessage(ctx)
 		ph.notifyAll(ctx, response)
 	}
+	for _, info := range ph.networksInfo {
+		if info.IsTimeOfDay(time.Now()) {
+			response := ph.createNetworkInfoMessage(ctx, info.network)
+			ph.notifyAll(ctx, response)
+		}
+	}
 
-	for _, alarm := range ph.alarms {
-		if !alarm.ShouldQuery() {
+	for _, alarm := range ph.getAlarms() {
+		if ph.isPaused(alarm.Identifier()) {
 			continue
 		}
-
-		response, err := alarm.Query(ctx)
-		if err != nil {
-			log.Error("error querying alarm", "identifier", alarm.Identifier(), "error", err.Error())
-			ph.incrementErrors()
+		if !alarm.ShouldQuery() {
 			continue
 		}
 
-		ph.notifyAll(ctx, response)
+		_, _ = ph.queryAndNotify(ctx, alarm)
 	}
 }
 
-func (ph *pollingHandler) notifyAll(ctx context.Context, response data.AlarmResponse) {
-	if response.Level == data.Error {
-		ph.incrementAlarmsWithError()
+func (ph *pollingHandler) queryAndNotify(ctx context.Context, alarm AlarmHandler) (data.AlarmResponse, error) {
+	response, err := alarm.Query(ctx)
+	previousState := ph.getAlarmStatus(alarm.Identifier()).State
+	ph.setQueryResult(alarm.Identifier(), time.Now(), response, err)
+	ph.publishStateTransition(alarm.Identifier(), previousState, response.Level)
+	if err != nil {
+		log.Error("error querying alarm", "identifier", alarm.Identifier(), "error", err.Error())
+		ph.incrementErrors()
+		return data.AlarmResponse{}, err
 	}
 
-	for _, notifier := range ph.notifiers {
+	// the NoEvent responses are always sent so the stateful notifiers (PagerDuty, Opsgenie, Alertmanager) can
+	// resolve the incidents opened before the alarm was muted
+	if response.Level != data.NoEvent && ph.isMuted(alarm.Identifier()) {
+		log.Debug("alarm is acknowledged or silenced, notifiers skipped", "identifier", alarm.Identifier(), "level", response.Level)
+		ph.recordResponse(response)
+		return response, nil
+	}
+
+	ph.notifyAll(ctx, response)
+
+	return response, nil
+}
+
+func (ph *pollingHandler) notifyAll(ctx context.Context, response data.AlarmResponse) {
+	ph.recordResponse(response)
+
+	for idx, notifier := range ph.getNotifiers() {
+		filter, ok := notifier.(responseFilter)
+		if ok && !filter.AcceptsResponse(response) {
+			continue
+		}
+
 		err := notifier.ProcessAlarmResponse(ctx, response)
+		ph.setNotifierResult(idx, time.Now(), err)
 		if err != nil {
 			log.Error("error pushing notification", "error", err.Error())
 			ph.incrementErrors()
@@ -142,41 +239,380 @@ func (ph *pollingHandler) notifyAll(ctx context.Context, response data.AlarmResp
 	}
 }
 
+// recordResponse counts the error responses and publishes the response, regardless of the notifiers being muted
+func (ph *pollingHandler) recordResponse(response data.AlarmResponse) {
+	if response.Level == data.Error {
+		ph.incrementAlarmsWithError()
+	}
+
+	ph.publishResponse(response)
+}
+
+func (ph *pollingHandler) publishResponse(response data.AlarmResponse) {
+	ph.publisher.Publish(data.AlarmEvent{
+		Type:       data.ResponseEvent,
+		Timestamp:  time.Now(),
+		Identifier: response.Identifier,
+		Level:      response.Level,
+		Response:   &response,
+	})
+}
+
+func (ph *pollingHandler) publishStateTransition(identifier string, previousState data.AlarmState, level data.EventLevel) {
+	state := ph.getAlarmStatus(identifier).State
+	if state == previousState {
+		return
+	}
+
+	log.Debug("alarm state changed", "identifier", identifier, "previous state", previousState, "state", state)
+	ph.publisher.Publish(data.AlarmEvent{
+		Type:          data.StateTransitionEvent,
+		Timestamp:     time.Now(),
+		Identifier:    identifier,
+		Level:         level,
+		PreviousState: previousState,
+		State:         state,
+	})
+}
+
 func (ph *pollingHandler) createInfoMessage(ctx context.Context) data.AlarmResponse {
+	uptime := time.Since(ph.startTime).Truncate(time.Second)
 	response := data.AlarmResponse{
 		Identifier: systemIdentifier,
 		Level:      data.Info,
 		Data: fmt.Sprintf(systemMessage,
-			time.Since(time.Now()),
+			uptime,
 			ph.getNumErrors(),
 			ph.getNumAlarmsWithError()),
+		Metrics: map[string]float64{
+			data.UptimeMetric:           uptime.Seconds(),
+			data.ProcessingErrorsMetric: float64(ph.getNumErrors()),
+			data.AlarmsWithErrorMetric:  float64(ph.getNumAlarmsWithError()),
+		},
 	}
 
 	if ph.getNumErrors()+ph.getNumAlarmsWithError() > 0 {
 		response.Level = data.Error
 	}
 
-	for _, alarm := range ph.alarms {
+	ph.appendAlarmsReport(ctx, &response, ph.getAlarmsWithoutNetworkInfo())
+
+	ph.resetNumErrors()
+
+	log.Debug("polling handler creating info message",
+		"time", time.Now(), "identifier", response.Identifier, "level", response.Level, "message", "\r\n"+response.Data)
+
+	return response
+}
+
+// createNetworkInfoMessage creates the info report of the alarms belonging to the provided network. The processing
+// errors counters are only reported and reset by the main info report
+func (ph *pollingHandler) createNetworkInfoMessage(ctx context.Context, network string) data.AlarmResponse {
+	uptime := time.Since(ph.startTime).Truncate(time.Second)
+	response := data.AlarmResponse{
+		Identifier: systemIdentifier,
+		Level:      data.Info,
+		Data:       fmt.Sprintf(networkSystemMessage, uptime, network),
+		Metrics: map[string]float64{
+			data.UptimeMetric: uptime.Seconds(),
+		},
+		Labels: map[string]string{
+			data.NetworkLabel: network,
+		},
+	}
+
+	alarms := make([]AlarmHandler, 0)
+	for _, alarm := range ph.getAlarms() {
+		if alarmNetwork(alarm) == network {
+			alarms = append(alarms, alarm)
+		}
+	}
+	ph.appendAlarmsReport(ctx, &response, alarms)
+
+	log.Debug("polling handler creating network info message", "network", network,
+		"time", time.Now(), "identifier", response.Identifier, "level", response.Level, "message", "\r\n"+response.Data)
+
+	return response
+}
+
+func (ph *pollingHandler) appendAlarmsReport(ctx context.Context, response *data.AlarmResponse, alarms []AlarmHandler) {
+	for _, alarm := range alarms {
+		if ph.isPaused(alarm.Identifier()) {
+			response.Data += fmt.Sprintf("\nAlarm %s is paused", alarm.Identifier())
+			response.Report = append(response.Report, data.ReportEntry{
+				Identifier: alarm.Identifier(),
+				Level:      data.NoEvent,
+				Status:     "paused",
+				Paused:     true,
+			})
+			continue
+		}
+
+		entry := data.ReportEntry{
+			Identifier: alarm.Identifier(),
+			Level:      data.Info,
+		}
 		status, err := alarm.QueryInfo(ctx)
 		if err == nil {
 			response.Data += fmt.Sprintf("\nStatus for alarm %s: %s", alarm.Identifier(), status)
+			entry.Status = status
 		} else {
 			response.Data += fmt.Sprintf("\nError fetching status info for alarm %s: %s", alarm.Identifier(), err.Error())
 			response.Level = data.Error
+			entry.Level = data.Error
+			entry.Status = "error fetching status info: " + err.Error()
 		}
+		response.Report = append(response.Report, entry)
 	}
+}
 
-	ph.resetNumErrors()
+// getAlarmsWithoutNetworkInfo returns the alarms reported by the main info report, the alarms of the networks that
+// have their own info report being excluded
+func (ph *pollingHandler) getAlarmsWithoutNetworkInfo() []AlarmHandler {
+	alarms := ph.getAlarms()
+	if len(ph.networksInfo) == 0 {
+		return alarms
+	}
 
-	log.Debug("polling handler creating info message",
-		"time", time.Now(), "identifier", response.Identifier, "level", response.Level, "message", "\r\n"+response.Data)
+	reportedNetworks := make(map[string]struct{}, len(ph.networksInfo))
+	for _, info := range ph.networksInfo {
+		reportedNetworks[info.network] = struct{}{}
+	}
+
+	filtered := make([]AlarmHandler, 0, len(alarms))
+	for _, alarm := range alarms {
+		_, reported := reportedNetworks[alarmNetwork(alarm)]
+		if !reported {
+			filtered = append(filtered, alarm)
+		}
+	}
+
+	return filtered
+}
+
+func alarmNetwork(alarm AlarmHandler) string {
+	handler, ok := alarm.(networkAlarmHandler)
+	if !ok {
+		return ""
+	}
+
+	return handler.Network()
+}
+
+func (ph *pollingHandler) getAlarms() []AlarmHandler {
+	ph.mutComponents.RLock()
+	defer ph.mutComponents.RUnlock()
+
+	return ph.alarms
+}
+
+func (ph *pollingHandler) getNotifiers() []NotifierHandler {
+	ph.mutComponents.RLock()
+	defer ph.mutComponents.RUnlock()
+
+	return ph.notifiers
+}
+
+// AlarmsStatus returns the current status of all defined alarms
+func (ph *pollingHandler) AlarmsStatus() []data.AlarmStatus {
+	alarms := ph.getAlarms()
+	statuses := make([]data.AlarmStatus, 0, len(alarms))
+	for _, alarm := range alarms {
+		status := ph.getAlarmStatus(alarm.Identifier())
+		status.NextQueryTime = alarm.NextQueryTime()
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// AlarmsHistory returns the last query outcomes of all defined alarms
+func (ph *pollingHandler) AlarmsHistory() map[string][]data.AlarmHistoryPoint {
+	alarms := ph.getAlarms()
+	history := make(map[string][]data.AlarmHistoryPoint, len(alarms))
+	for _, alarm := range alarms {
+		history[alarm.Identifier()] = ph.getHistory(alarm.Identifier())
+	}
+
+	return history
+}
+
+// NotifiersStatus returns the health information of all defined notifiers
+func (ph *pollingHandler) NotifiersStatus() []data.NotifierStatus {
+	return ph.getNotifiersStatus()
+}
+
+func notifiersNames(notifiers []NotifierHandler) []string {
+	names := make([]string, 0, len(notifiers))
+	for idx, notifier := range notifiers {
+		names = append(names, fmt.Sprintf("%d: %T", idx, unwrapNotifier(notifier)))
+	}
+
+	return names
+}
+
+func unwrapNotifier(notifier NotifierHandler) NotifierHandler {
+	for {
+		wrapper, ok := notifier.(notifierWrapper)
+		if !ok {
+			return notifier
+		}
+		notifier = wrapper.Unwrap()
+	}
+}
+
+// QueryAlarmInfo will call the QueryInfo on the alarm with the provided identifier
+func (ph *pollingHandler) QueryAlarmInfo(ctx context.Context, identifier string) (string, error) {
+	alarm, err := ph.getAlarm(identifier)
+	if err != nil {
+		return "", err
+	}
+
+	return alarm.QueryInfo(ctx)
+}
+
+// PauseAlarm will stop querying the alarm with the provided identifier until it is resumed
+func (ph *pollingHandler) PauseAlarm(identifier string) error {
+	_, err := ph.getAlarm(identifier)
+	if err != nil {
+		return err
+	}
+
+	previousState := ph.getAlarmStatus(identifier).State
+	ph.setPaused(identifier, true)
+	ph.publishStateTransition(identifier, previousState, data.NoEvent)
+	log.Info("alarm paused", "identifier", identifier)
+
+	return nil
+}
+
+// ResumeAlarm will resume querying the alarm with the provided identifier
+func (ph *pollingHandler) ResumeAlarm(identifier string) error {
+	_, err := ph.getAlarm(identifier)
+	if err != nil {
+		return err
+	}
+
+	previousState := ph.getAlarmStatus(identifier).State
+	ph.setPaused(identifier, false)
+	ph.publishStateTransition(identifier, previousState, data.NoEvent)
+	log.Info("alarm resumed", "identifier", identifier)
+
+	return nil
+}
+
+// AcknowledgeAlarm will stop sending the notifications for the alerting alarm with the provided identifier until
+// the alarm recovers
+func (ph *pollingHandler) AcknowledgeAlarm(identifier string) error {
+	_, err := ph.getAlarm(identifier)
+	if err != nil {
+		return err
+	}
+
+	if ph.getAlarmStatus(identifier).State != data.StateAlerting {
+		return fmt.Errorf("%w: %s", ErrAlarmNotAlerting, identifier)
+	}
+
+	ph.setAcknowledged(identifier)
+	log.Info("alarm acknowledged", "identifier", identifier)
+
+	return nil
+}
+
+// SilenceAlarm will stop sending the notifications for the alarm with the provided identifier for the provided
+// duration. The alarm is still queried. A zero or negative duration will remove the silence
+func (ph *pollingHandler) SilenceAlarm(identifier string, duration time.Duration) error {
+	_, err := ph.getAlarm(identifier)
+	if err != nil {
+		return err
+	}
+
+	if duration <= 0 {
+		ph.setSilencedUntil(identifier, time.Time{})
+		log.Info("alarm silence removed", "identifier", identifier)
+		return nil
+	}
+
+	until := time.Now().Add(duration)
+	ph.setSilencedUntil(identifier, until)
+	log.Info("alarm silenced", "identifier", identifier, "until", until)
+
+	return nil
+}
+
+// TriggerAlarmQuery will query the alarm with the provided identifier right away, notifying the result. This will
+// work also for the paused alarms
+func (ph *pollingHandler) TriggerAlarmQuery(ctx context.Context, identifier string) (data.AlarmResponse, error) {
+	alarm, err := ph.getAlarm(identifier)
+	if err != nil {
+		return data.AlarmResponse{}, err
+	}
+
+	ph.mutProcess.Lock()
+	defer ph.mutProcess.Unlock()
+
+	return ph.queryAndNotify(ctx, alarm)
+}
+
+// TriggerInfoMessage will create and send the info message right away
+func (ph *pollingHandler) TriggerInfoMessage(ctx context.Context) data.AlarmResponse {
+	ph.mutProcess.Lock()
+	defer ph.mutProcess.Unlock()
+
+	response := ph.createInfoMessage(ctx)
+	ph.notifyAll(ctx, response)
 
 	return response
 }
 
-// Close will close the running processLoop go routine
+// UpdateComponents will replace the alarms and notifiers used by the polling handler. The state of the alarms
+// (last query result, paused, acknowledged and silenced flags) is kept for the identifiers that are still present, the errors counters are kept
+func (ph *pollingHandler) UpdateComponents(alarms []AlarmHandler, notifiers []NotifierHandler) error {
+	err := checkComponents(alarms, notifiers)
+	if err != nil {
+		return err
+	}
+
+	ph.mutProcess.Lock()
+	defer ph.mutProcess.Unlock()
+
+	ph.mutComponents.Lock()
+	ph.alarms = alarms
+	ph.notifiers = notifiers
+	ph.mutComponents.Unlock()
+
+	ph.resetNotifiersStatus(notifiersNames(notifiers))
+
+	identifiers := make(map[string]struct{})
+	for _, alarm := range alarms {
+		identifiers[alarm.Identifier()] = struct{}{}
+	}
+	ph.retainAlarmsState(identifiers)
+
+	log.Info("polling handler components updated", "num alarms", len(alarms), "num notifiers", len(notifiers))
+
+	return nil
+}
+
+func (ph *pollingHandler) getAlarm(identifier string) (AlarmHandler, error) {
+	for _, alarm := range ph.getAlarms() {
+		if alarm.Identifier() == identifier {
+			return alarm, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w for identifier %s", ErrAlarmNotFound, identifier)
+}
+
+// Close will close the running processLoop go routine and waits for it to stop, together with any query triggered
+// through the control API, so the alarms and notifiers can be safely closed afterwards
 func (ph *pollingHandler) Close() error {
 	ph.cancel()
+	<-ph.loopStopped
+
+	ph.mutProcess.Lock()
+	ph.mutProcess.Unlock()
 
 	return nil
 }
@@ -1,12 +1,39 @@
 package poll
 
-import "sync"
+import (
+	"sync"
+	"time"
+
+	"github.com/iulianpascalau/node-monitoring/data"
+)
+
+type alarmQueryResult struct {
+	queryTime time.Time
+	response  data.AlarmResponse
+	err       error
+}
 
 type pollingHandlerState struct {
 	mut                sync.RWMutex
 	numErrors          int
 	numAlarmsWithError int
 	isRunning          bool
+	queryResults       map[string]alarmQueryResult
+	pausedAlarms       map[string]struct{}
+	acknowledgedAlarms map[string]struct{}
+	silencedAlarms     map[string]time.Time
+	history            map[string][]data.AlarmHistoryPoint
+	notifiersStatus    []data.NotifierStatus
+}
+
+func newPollingHandlerState() *pollingHandlerState {
+	return &pollingHandlerState{
+		queryResults:       make(map[string]alarmQueryResult),
+		pausedAlarms:       make(map[string]struct{}),
+		acknowledgedAlarms: make(map[string]struct{}),
+		silencedAlarms:     make(map[string]time.Time),
+		history:            make(map[string][]data.AlarmHistoryPoint),
+	}
 }
 
 func (state *pollingHandlerState) incrementErrors() {
@@ -42,6 +69,219 @@ func (state *pollingHandlerState) resetNumErrors() {
 	state.mut.Unlock()
 }
 
+func (state *pollingHandlerState) setQueryResult(identifier string, queryTime time.Time, response data.AlarmResponse, err error) {
+	state.mut.Lock()
+	defer state.mut.Unlock()
+
+	state.queryResults[identifier] = alarmQueryResult{
+		queryTime: queryTime,
+		response:  response,
+		err:       err,
+	}
+	if err != nil {
+		return
+	}
+	if response.Level != data.Error {
+		// the alarm recovered, a new failure should be notified again
+		delete(state.acknowledgedAlarms, identifier)
+	}
+
+	history := append(state.history[identifier], data.AlarmHistoryPoint{
+		Timestamp: queryTime,
+		Level:     response.Level,
+		Metrics:   response.Metrics,
+	})
+	if len(history) > maxHistoryPoints {
+		history = history[len(history)-maxHistoryPoints:]
+	}
+	state.history[identifier] = history
+}
+
+func (state *pollingHandlerState) getHistory(identifier string) []data.AlarmHistoryPoint {
+	state.mut.RLock()
+	defer state.mut.RUnlock()
+
+	history := make([]data.AlarmHistoryPoint, len(state.history[identifier]))
+	copy(history, state.history[identifier])
+
+	return history
+}
+
+func (state *pollingHandlerState) resetNotifiersStatus(names []string) {
+	state.mut.Lock()
+	defer state.mut.Unlock()
+
+	state.notifiersStatus = make([]data.NotifierStatus, len(names))
+	for idx, name := range names {
+		state.notifiersStatus[idx] = data.NotifierStatus{
+			Name:    name,
+			Healthy: true,
+		}
+	}
+}
+
+func (state *pollingHandlerState) setNotifierResult(index int, sendTime time.Time, err error) {
+	state.mut.Lock()
+	defer state.mut.Unlock()
+
+	if index >= len(state.notifiersStatus) {
+		return
+	}
+
+	status := &state.notifiersStatus[index]
+	status.Healthy = err == nil
+	if err != nil {
+		status.NumErrors++
+		status.LastError = err.Error()
+		status.LastErrorTime = sendTime
+		return
+	}
+
+	status.NumSent++
+	status.LastSendTime = sendTime
+}
+
+func (state *pollingHandlerState) getNotifiersStatus() []data.NotifierStatus {
+	state.mut.RLock()
+	defer state.mut.RUnlock()
+
+	statuses := make([]data.NotifierStatus, len(state.notifiersStatus))
+	copy(statuses, state.notifiersStatus)
+
+	return statuses
+}
+
+func (state *pollingHandlerState) setPaused(identifier string, paused bool) {
+	state.mut.Lock()
+	if paused {
+		state.pausedAlarms[identifier] = struct{}{}
+	} else {
+		delete(state.pausedAlarms, identifier)
+	}
+	state.mut.Unlock()
+}
+
+func (state *pollingHandlerState) isPaused(identifier string) bool {
+	state.mut.RLock()
+	defer state.mut.RUnlock()
+
+	_, found := state.pausedAlarms[identifier]
+
+	return found
+}
+
+func (state *pollingHandlerState) setAcknowledged(identifier string) {
+	state.mut.Lock()
+	state.acknowledgedAlarms[identifier] = struct{}{}
+	state.mut.Unlock()
+}
+
+func (state *pollingHandlerState) setSilencedUntil(identifier string, until time.Time) {
+	state.mut.Lock()
+	defer state.mut.Unlock()
+
+	if until.IsZero() {
+		delete(state.silencedAlarms, identifier)
+		return
+	}
+
+	state.silencedAlarms[identifier] = until
+}
+
+func (state *pollingHandlerState) getSilencedUntil(identifier string) time.Time {
+	state.mut.RLock()
+	defer state.mut.RUnlock()
+
+	until := state.silencedAlarms[identifier]
+	if time.Now().After(until) {
+		return time.Time{}
+	}
+
+	return until
+}
+
+func (state *pollingHandlerState) isAcknowledged(identifier string) bool {
+	state.mut.RLock()
+	defer state.mut.RUnlock()
+
+	_, found := state.acknowledgedAlarms[identifier]
+
+	return found
+}
+
+// isMuted returns true if the notifications for the provided alarm should not be sent
+func (state *pollingHandlerState) isMuted(identifier string) bool {
+	return state.isAcknowledged(identifier) || !state.getSilencedUntil(identifier).IsZero()
+}
+
+func (state *pollingHandlerState) retainAlarmsState(identifiers map[string]struct{}) {
+	state.mut.Lock()
+	defer state.mut.Unlock()
+
+	for identifier := range state.queryResults {
+		_, found := identifiers[identifier]
+		if !found {
+			delete(state.queryResults, identifier)
+		}
+	}
+	for identifier := range state.pausedAlarms {
+		_, found := identifiers[identifier]
+		if !found {
+			delete(state.pausedAlarms, identifier)
+		}
+	}
+	for identifier := range state.acknowledgedAlarms {
+		_, found := identifiers[identifier]
+		if !found {
+			delete(state.acknowledgedAlarms, identifier)
+		}
+	}
+	for identifier := range state.silencedAlarms {
+		_, found := identifiers[identifier]
+		if !found {
+			delete(state.silencedAlarms, identifier)
+		}
+	}
+	for identifier := range state.history {
+		_, found := identifiers[identifier]
+		if !found {
+			delete(state.history, identifier)
+		}
+	}
+}
+
+func (state *pollingHandlerState) getAlarmStatus(identifier string) data.AlarmStatus {
+	state.mut.RLock()
+	result, found := state.queryResults[identifier]
+	state.mut.RUnlock()
+
+	status := data.AlarmStatus{
+		Identifier:    identifier,
+		LastQueryTime: result.queryTime,
+		LastResponse:  result.response,
+		Acknowledged:  state.isAcknowledged(identifier),
+		SilencedUntil: state.getSilencedUntil(identifier),
+	}
+	if result.err != nil {
+		status.LastError = result.err.Error()
+	}
+
+	switch {
+	case state.isPaused(identifier):
+		status.State = data.StatePaused
+	case !found:
+		status.State = data.StatePending
+	case result.err != nil:
+		status.State = data.StateQueryError
+	case result.response.Level == data.Error:
+		status.State = data.StateAlerting
+	default:
+		status.State = data.StateOk
+	}
+
+	return status
+}
+
 func (state *pollingHandlerState) setIsRunning() {
 	state.mut.Lock()
 	state.isRunning = true
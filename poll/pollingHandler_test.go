@@ -15,12 +15,43 @@ import (
 	"github.com/iulianpascalau/node-monitoring/mocks"
 	"github.com/iulianpascalau/node-monitoring/poll/notifiers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+type networkAlarmHandlerStub struct {
+	*mocks.AlarmHandlerStub
+	network string
+}
+
+// Network -
+func (stub *networkAlarmHandlerStub) Network() string {
+	return stub.network
+}
+
+type filteredNotifierStub struct {
+	*mocks.NotifierHandlerStub
+	AcceptsResponseCalled func(response data.AlarmResponse) bool
+}
+
+// AcceptsResponse -
+func (stub *filteredNotifierStub) AcceptsResponse(response data.AlarmResponse) bool {
+	if stub.AcceptsResponseCalled != nil {
+		return stub.AcceptsResponseCalled(response)
+	}
+
+	return true
+}
+
+// Unwrap -
+func (stub *filteredNotifierStub) Unwrap() NotifierHandler {
+	return stub.NotifierHandlerStub
+}
+
 func createMockArgsPollingHandler() ArgsPollingHandler {
 	return ArgsPollingHandler{
 		Alarms:         []AlarmHandler{&mocks.AlarmHandlerStub{}},
 		Notifiers:      []NotifierHandler{&mocks.NotifierHandlerStub{}},
+		Publisher:      &mocks.EventsPublisherStub{},
 		SendInfo:       false,
 		SendInfoHour:   0,
 		SendInfoMinute: 0,
@@ -65,6 +96,14 @@ func TestNewPollingHandler(t *testing.T) {
 		assert.True(t, errors.Is(err, errNilNotifier))
 		assert.True(t, strings.Contains(err.Error(), "at index 1"))
 	})
+	t.Run("nil events publisher should error", func(t *testing.T) {
+		args := createMockArgsPollingHandler()
+		args.Publisher = nil
+
+		pollHandler, err := NewPollingHandler(args)
+		assert.True(t, check.IfNil(pollHandler))
+		assert.Equal(t, errNilEventsPublisher, err)
+	})
 	t.Run("invalid time of day should error", func(t *testing.T) {
 		args := createMockArgsPollingHandler()
 		args.SendInfoMinute = 60
@@ -74,6 +113,23 @@ func TestNewPollingHandler(t *testing.T) {
 		assert.True(t, check.IfNil(pollHandler))
 		assert.True(t, errors.Is(err, notifiers.ErrInvalidValue))
 	})
+	t.Run("empty network name should error", func(t *testing.T) {
+		args := createMockArgsPollingHandler()
+		args.NetworksInfo = []ArgsNetworkInfo{{Network: ""}}
+
+		pollHandler, err := NewPollingHandler(args)
+		assert.True(t, check.IfNil(pollHandler))
+		assert.Equal(t, errEmptyNetworkName, err)
+	})
+	t.Run("invalid network time of day should error", func(t *testing.T) {
+		args := createMockArgsPollingHandler()
+		args.NetworksInfo = []ArgsNetworkInfo{{Network: "testnet", SendInfoHour: 24}}
+
+		pollHandler, err := NewPollingHandler(args)
+		assert.True(t, check.IfNil(pollHandler))
+		assert.True(t, errors.Is(err, notifiers.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "for network testnet"))
+	})
 	t.Run("should work", func(t *testing.T) {
 		args := createMockArgsPollingHandler()
 
@@ -97,20 +153,50 @@ func TestPollingHandler_Close(t *testing.T) {
 	t.Run("first close should work", func(t *testing.T) {
 		err := pollHandler.Close()
 		assert.Nil(t, err)
-
-		time.Sleep(time.Second) // wait for go routine to finish
 		assert.False(t, pollHandler.IsRunning())
-
 	})
 	t.Run("double close should be ok", func(t *testing.T) {
 		err := pollHandler.Close()
 		assert.Nil(t, err)
-
-		time.Sleep(time.Second)
 		assert.False(t, pollHandler.IsRunning())
 	})
 }
 
+func TestPollingHandler_CloseShouldWaitForTheInFlightPoll(t *testing.T) {
+	t.Parallel()
+
+	queryStarted := make(chan struct{})
+	queryFinished := uint32(0)
+	args := createMockArgsPollingHandler()
+	args.Alarms = []AlarmHandler{
+		&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				close(queryStarted)
+				<-ctx.Done()
+				time.Sleep(time.Millisecond * 100)
+				atomic.StoreUint32(&queryFinished, 1)
+
+				return data.AlarmResponse{}, ctx.Err()
+			},
+			ShouldQueryCalled: func() bool {
+				return atomic.LoadUint32(&queryFinished) == 0
+			},
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+
+	select {
+	case <-queryStarted:
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "the alarm was not queried")
+	}
+
+	err := pollHandler.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&queryFinished))
+	assert.False(t, pollHandler.IsRunning())
+}
+
 func TestPollingHandler_AlarmShouldNotQueryIfNotRequired(t *testing.T) {
 	t.Parallel()
 	args := createMockArgsPollingHandler()
@@ -192,7 +278,7 @@ func TestPollingHandler_AlarmFailsOnQueryShouldNotNotify(t *testing.T) {
 	wg.Wait()
 	_ = pollHandler.Close()
 
-	assert.Equal(t, 2, pollHandler.getNumErrors())
+	assert.Equal(t, 3, pollHandler.getNumErrors())
 }
 
 func TestPollingHandler_AlarmReturnsResultShouldNotifyInfoLevel(t *testing.T) {
@@ -241,7 +327,7 @@ func TestPollingHandler_AlarmReturnsResultShouldNotifyInfoLevel(t *testing.T) {
 
 	assert.Equal(t, 0, pollHandler.getNumErrors())
 	assert.Equal(t, 0, pollHandler.getNumAlarmsWithError())
-	assert.Equal(t, uint64(2), atomic.LoadUint64(&numNotified))
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&numNotified))
 }
 
 func TestPollingHandler_AlarmReturnsResultShouldNotifyInfoLevel2Alarms3Notifiers(t *testing.T) {
@@ -322,7 +408,7 @@ func TestPollingHandler_AlarmReturnsResultShouldNotifyInfoLevel2Alarms3Notifiers
 
 	assert.Equal(t, 0, pollHandler.getNumErrors())
 	assert.Equal(t, 0, pollHandler.getNumAlarmsWithError())
-	assert.Equal(t, uint64(15), atomic.LoadUint64(&numNotified)) // 3 notifiers * 2 alarms * 2 times + 3 notifiers from first alarm 3-rd time
+	assert.Equal(t, uint64(18), atomic.LoadUint64(&numNotified)) // 3 notifiers * 2 alarms * 3 times, Close waiting for the in-flight poll
 }
 
 func TestPollingHandler_AlarmReturnsResultShouldNotifyErrorLevel(t *testing.T) {
@@ -370,8 +456,8 @@ func TestPollingHandler_AlarmReturnsResultShouldNotifyErrorLevel(t *testing.T) {
 	_ = pollHandler.Close()
 
 	assert.Equal(t, 0, pollHandler.getNumErrors())
-	assert.Equal(t, 2, pollHandler.getNumAlarmsWithError())
-	assert.Equal(t, uint64(2), atomic.LoadUint64(&numNotified))
+	assert.Equal(t, 3, pollHandler.getNumAlarmsWithError())
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&numNotified))
 }
 
 func TestPollingHandler_AlarmReturnsResultNotifierErrors(t *testing.T) {
@@ -419,9 +505,9 @@ func TestPollingHandler_AlarmReturnsResultNotifierErrors(t *testing.T) {
 	wg.Wait()
 	_ = pollHandler.Close()
 
-	assert.Equal(t, 2, pollHandler.getNumErrors())
+	assert.Equal(t, 3, pollHandler.getNumErrors())
 	assert.Equal(t, 0, pollHandler.getNumAlarmsWithError())
-	assert.Equal(t, uint64(2), atomic.LoadUint64(&numNotified))
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&numNotified))
 }
 
 func TestPollingHandler_CreateInfoMessageWithErrors(t *testing.T) {
@@ -509,6 +595,13 @@ Error fetching status info for alarm 3: expected error`
 	assert.Equal(t, systemIdentifier, receivedResponse.Identifier)
 	fmt.Println(receivedResponse.Data)
 	assert.True(t, strings.Contains(receivedResponse.Data, expectedPartialString))
+	assert.Equal(t, []data.ReportEntry{
+		{Identifier: "1", Level: data.Info, Status: "query string 1"},
+		{Identifier: "2", Level: data.Info, Status: "query string 2"},
+		{Identifier: "3", Level: data.Error, Status: "error fetching status info: expected error"},
+	}, receivedResponse.Report)
+	assert.Equal(t, 1.0, receivedResponse.Metrics[data.ProcessingErrorsMetric])
+	assert.Equal(t, 2.0, receivedResponse.Metrics[data.AlarmsWithErrorMetric])
 
 	_ = pollHandler.Close()
 }
@@ -596,3 +689,731 @@ Status for alarm 3: query string 3`
 
 	_ = pollHandler.Close()
 }
+
+func TestPollingHandler_AlarmsStatus(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	expectedErr := errors.New("expected error")
+	nextQueryTime := time.Date(2022, 07, 06, 12, 0, 0, 0, time.UTC)
+	alarmResponse := data.AlarmResponse{
+		Identifier: "alerting",
+		Level:      data.Error,
+		Data:       "test message",
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	createAlarm := func(identifier string, shouldQuery bool, response data.AlarmResponse, err error) *mocks.AlarmHandlerStub {
+		once := sync.Once{}
+		return &mocks.AlarmHandlerStub{
+			ShouldQueryCalled: func() bool {
+				return shouldQuery
+			},
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				once.Do(wg.Done)
+				return response, err
+			},
+			NextQueryTimeCalled: func() time.Time {
+				return nextQueryTime
+			},
+			IdentifierCalled: func() string {
+				return identifier
+			},
+		}
+	}
+	args.Alarms = []AlarmHandler{
+		createAlarm("alerting", true, alarmResponse, nil),
+		createAlarm("failing", true, data.AlarmResponse{}, expectedErr),
+		createAlarm("pending", false, data.AlarmResponse{}, nil),
+	}
+
+	pollHandler, _ := NewPollingHandler(args)
+	wg.Wait()
+	_ = pollHandler.Close()
+
+	statuses := pollHandler.AlarmsStatus()
+	assert.Equal(t, 3, len(statuses))
+
+	assert.Equal(t, "alerting", statuses[0].Identifier)
+	assert.Equal(t, data.StateAlerting, statuses[0].State)
+	assert.Equal(t, alarmResponse, statuses[0].LastResponse)
+	assert.False(t, statuses[0].LastQueryTime.IsZero())
+	assert.Equal(t, nextQueryTime, statuses[0].NextQueryTime)
+
+	assert.Equal(t, "failing", statuses[1].Identifier)
+	assert.Equal(t, data.StateQueryError, statuses[1].State)
+	assert.Equal(t, expectedErr.Error(), statuses[1].LastError)
+	assert.False(t, statuses[1].LastQueryTime.IsZero())
+
+	assert.Equal(t, "pending", statuses[2].Identifier)
+	assert.Equal(t, data.StatePending, statuses[2].State)
+	assert.True(t, statuses[2].LastQueryTime.IsZero())
+	assert.Equal(t, nextQueryTime, statuses[2].NextQueryTime)
+}
+
+func TestPollingHandler_QueryAlarmInfo(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	args.Alarms = []AlarmHandler{
+		&mocks.AlarmHandlerStub{
+			QueryInfoCalled: func(ctx context.Context) (string, error) {
+				return "query string 1", nil
+			},
+			IdentifierCalled: func() string {
+				return "1"
+			},
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	t.Run("unknown alarm should error", func(t *testing.T) {
+		info, err := pollHandler.QueryAlarmInfo(context.Background(), "2")
+		assert.Empty(t, info)
+		assert.True(t, errors.Is(err, ErrAlarmNotFound))
+		assert.True(t, strings.Contains(err.Error(), "for identifier 2"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		info, err := pollHandler.QueryAlarmInfo(context.Background(), "1")
+		assert.Nil(t, err)
+		assert.Equal(t, "query string 1", info)
+	})
+}
+
+func TestPollingHandler_PauseResumeAlarm(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	numQueried := uint64(0)
+	args.Alarms = []AlarmHandler{
+		&mocks.AlarmHandlerStub{
+			ShouldQueryCalled: func() bool {
+				return true
+			},
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				atomic.AddUint64(&numQueried, 1)
+				return data.AlarmResponse{}, nil
+			},
+			IdentifierCalled: func() string {
+				return "1"
+			},
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	t.Run("unknown alarm should error", func(t *testing.T) {
+		err := pollHandler.PauseAlarm("2")
+		assert.True(t, errors.Is(err, ErrAlarmNotFound))
+
+		err = pollHandler.ResumeAlarm("2")
+		assert.True(t, errors.Is(err, ErrAlarmNotFound))
+	})
+	t.Run("paused alarm should not be queried", func(t *testing.T) {
+		err := pollHandler.PauseAlarm("1")
+		assert.Nil(t, err)
+		assert.Equal(t, data.StatePaused, pollHandler.AlarmsStatus()[0].State)
+
+		time.Sleep(pollingInterval * 3) // wait for the current poll to finish
+		numQueriedAfterPause := atomic.LoadUint64(&numQueried)
+		time.Sleep(pollingInterval * 5)
+		assert.Equal(t, numQueriedAfterPause, atomic.LoadUint64(&numQueried))
+	})
+	t.Run("resumed alarm should be queried", func(t *testing.T) {
+		numQueriedBeforeResume := atomic.LoadUint64(&numQueried)
+		err := pollHandler.ResumeAlarm("1")
+		assert.Nil(t, err)
+
+		time.Sleep(pollingInterval * 5)
+		assert.True(t, atomic.LoadUint64(&numQueried) > numQueriedBeforeResume)
+		assert.Equal(t, data.StateOk, pollHandler.AlarmsStatus()[0].State)
+	})
+}
+
+func TestPollingHandler_TriggerAlarmQuery(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	expectedErr := errors.New("expected error")
+	alarmResponse := data.AlarmResponse{
+		Identifier: "1",
+		Level:      data.Error,
+		Data:       "test message",
+	}
+	args.Alarms = []AlarmHandler{
+		&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				return alarmResponse, nil
+			},
+			IdentifierCalled: func() string {
+				return "1"
+			},
+		},
+		&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				return data.AlarmResponse{}, expectedErr
+			},
+			IdentifierCalled: func() string {
+				return "2"
+			},
+		},
+	}
+	var notifiedResponses []data.AlarmResponse
+	args.Notifiers = []NotifierHandler{
+		&mocks.NotifierHandlerStub{
+			ProcessAlarmResponseCalled: func(ctx context.Context, response data.AlarmResponse) error {
+				notifiedResponses = append(notifiedResponses, response)
+				return nil
+			},
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	t.Run("unknown alarm should error", func(t *testing.T) {
+		response, err := pollHandler.TriggerAlarmQuery(context.Background(), "3")
+		assert.True(t, errors.Is(err, ErrAlarmNotFound))
+		assert.Equal(t, data.AlarmResponse{}, response)
+	})
+	t.Run("query error should error", func(t *testing.T) {
+		response, err := pollHandler.TriggerAlarmQuery(context.Background(), "2")
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, data.AlarmResponse{}, response)
+		assert.Equal(t, 1, pollHandler.getNumErrors())
+		assert.Equal(t, 0, len(notifiedResponses))
+	})
+	t.Run("paused alarm should work", func(t *testing.T) {
+		_ = pollHandler.PauseAlarm("1")
+
+		response, err := pollHandler.TriggerAlarmQuery(context.Background(), "1")
+		assert.Nil(t, err)
+		assert.Equal(t, alarmResponse, response)
+		assert.Equal(t, []data.AlarmResponse{alarmResponse}, notifiedResponses)
+		assert.Equal(t, 1, pollHandler.getNumAlarmsWithError())
+	})
+}
+
+func TestPollingHandler_TriggerInfoMessage(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	args.Alarms = []AlarmHandler{
+		&mocks.AlarmHandlerStub{
+			QueryInfoCalled: func(ctx context.Context) (string, error) {
+				return "query string 1", nil
+			},
+			IdentifierCalled: func() string {
+				return "1"
+			},
+		},
+		&mocks.AlarmHandlerStub{
+			QueryInfoCalled: func(ctx context.Context) (string, error) {
+				assert.Fail(t, "should have not called QueryInfo on a paused alarm")
+				return "", nil
+			},
+			IdentifierCalled: func() string {
+				return "2"
+			},
+		},
+	}
+	numNotified := 0
+	args.Notifiers = []NotifierHandler{
+		&mocks.NotifierHandlerStub{
+			ProcessAlarmResponseCalled: func(ctx context.Context, response data.AlarmResponse) error {
+				numNotified++
+				return nil
+			},
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	_ = pollHandler.PauseAlarm("2")
+	response := pollHandler.TriggerInfoMessage(context.Background())
+
+	assert.Equal(t, 1, numNotified)
+	assert.Equal(t, systemIdentifier, response.Identifier)
+	assert.Equal(t, data.Info, response.Level)
+	assert.True(t, strings.Contains(response.Data, "Status for alarm 1: query string 1\nAlarm 2 is paused"))
+	assert.Equal(t, []data.ReportEntry{
+		{Identifier: "1", Level: data.Info, Status: "query string 1"},
+		{Identifier: "2", Level: data.NoEvent, Status: "paused", Paused: true},
+	}, response.Report)
+}
+
+func TestPollingHandler_UpdateComponents(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	t.Run("invalid components should error", func(t *testing.T) {
+		err := pollHandler.UpdateComponents(nil, args.Notifiers)
+		assert.Equal(t, errNoAlarmsSet, err)
+
+		err = pollHandler.UpdateComponents(args.Alarms, []NotifierHandler{nil})
+		assert.True(t, errors.Is(err, errNilNotifier))
+
+		assert.Equal(t, args.Alarms, pollHandler.getAlarms())
+	})
+	t.Run("should work", func(t *testing.T) {
+		newAlarms := []AlarmHandler{&mocks.AlarmHandlerStub{}, &mocks.AlarmHandlerStub{}}
+		newNotifiers := []NotifierHandler{&mocks.NotifierHandlerStub{}, &mocks.NotifierHandlerStub{}}
+
+		err := pollHandler.UpdateComponents(newAlarms, newNotifiers)
+		assert.Nil(t, err)
+		assert.Equal(t, newAlarms, pollHandler.getAlarms())
+		assert.Equal(t, newNotifiers, pollHandler.getNotifiers())
+	})
+}
+
+func TestPollingHandler_UpdateComponentsShouldKeepStateForExistingAlarms(t *testing.T) {
+	t.Parallel()
+
+	createAlarm := func(identifier string) *mocks.AlarmHandlerStub {
+		return &mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				return data.AlarmResponse{Identifier: identifier, Level: data.Info}, nil
+			},
+			IdentifierCalled: func() string {
+				return identifier
+			},
+		}
+	}
+
+	args := createMockArgsPollingHandler()
+	args.Alarms = []AlarmHandler{createAlarm("kept"), createAlarm("removed")}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "kept")
+	_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "removed")
+	_ = pollHandler.PauseAlarm("removed")
+	pollHandler.incrementErrors()
+
+	err := pollHandler.UpdateComponents([]AlarmHandler{createAlarm("kept"), createAlarm("added")}, args.Notifiers)
+	assert.Nil(t, err)
+
+	statuses := pollHandler.AlarmsStatus()
+	assert.Equal(t, data.StateOk, statuses[0].State)
+	assert.Equal(t, "kept", statuses[0].LastResponse.Identifier)
+	assert.Equal(t, data.StatePending, statuses[1].State)
+	assert.Equal(t, 1, pollHandler.getNumErrors())
+	assert.False(t, pollHandler.isPaused("removed"))
+	_, found := pollHandler.queryResults["removed"]
+	assert.False(t, found)
+}
+
+func TestPollingHandler_AlarmsHistory(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	rating := 0.0
+	args.Alarms = []AlarmHandler{
+		&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				rating++
+				return data.AlarmResponse{
+					Identifier: "1",
+					Level:      data.Info,
+					Metrics:    map[string]float64{"rating": rating},
+				}, nil
+			},
+			IdentifierCalled: func() string {
+				return "1"
+			},
+		},
+		&mocks.AlarmHandlerStub{
+			IdentifierCalled: func() string {
+				return "2"
+			},
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	for i := 0; i < maxHistoryPoints+10; i++ {
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+	}
+
+	history := pollHandler.AlarmsHistory()
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, 0, len(history["2"]))
+	assert.Equal(t, maxHistoryPoints, len(history["1"]))
+	assert.Equal(t, 11.0, history["1"][0].Metrics["rating"])
+	assert.Equal(t, float64(maxHistoryPoints+10), history["1"][maxHistoryPoints-1].Metrics["rating"])
+}
+
+func TestPollingHandler_NotifiersStatus(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	args := createMockArgsPollingHandler()
+	args.Alarms = []AlarmHandler{
+		&mocks.AlarmHandlerStub{
+			IdentifierCalled: func() string {
+				return "1"
+			},
+		},
+	}
+	args.Notifiers = []NotifierHandler{
+		&mocks.NotifierHandlerStub{},
+		&mocks.NotifierHandlerStub{
+			ProcessAlarmResponseCalled: func(ctx context.Context, response data.AlarmResponse) error {
+				return expectedErr
+			},
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	statuses := pollHandler.NotifiersStatus()
+	assert.Equal(t, "0: *mocks.NotifierHandlerStub", statuses[0].Name)
+	assert.True(t, statuses[0].Healthy)
+	assert.True(t, statuses[1].Healthy)
+
+	_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+	_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+
+	statuses = pollHandler.NotifiersStatus()
+	assert.True(t, statuses[0].Healthy)
+	assert.Equal(t, uint64(2), statuses[0].NumSent)
+	assert.False(t, statuses[0].LastSendTime.IsZero())
+	assert.False(t, statuses[1].Healthy)
+	assert.Equal(t, uint64(2), statuses[1].NumErrors)
+	assert.Equal(t, expectedErr.Error(), statuses[1].LastError)
+}
+
+func TestPollingHandler_PublishedEvents(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	level := data.Info
+	args.Alarms = []AlarmHandler{
+		&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				return data.AlarmResponse{Identifier: "1", Level: level}, nil
+			},
+			IdentifierCalled: func() string {
+				return "1"
+			},
+		},
+	}
+	mutEvents := sync.Mutex{}
+	events := make([]data.AlarmEvent, 0)
+	args.Publisher = &mocks.EventsPublisherStub{
+		PublishCalled: func(event data.AlarmEvent) {
+			mutEvents.Lock()
+			events = append(events, event)
+			mutEvents.Unlock()
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	getEvents := func() []data.AlarmEvent {
+		mutEvents.Lock()
+		defer mutEvents.Unlock()
+
+		result := events
+		events = make([]data.AlarmEvent, 0)
+
+		return result
+	}
+
+	t.Run("first query should publish the response and the transition from pending", func(t *testing.T) {
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+
+		published := getEvents()
+		assert.Equal(t, 2, len(published))
+		assert.Equal(t, data.StateTransitionEvent, published[0].Type)
+		assert.Equal(t, data.StatePending, published[0].PreviousState)
+		assert.Equal(t, data.StateOk, published[0].State)
+		assert.Equal(t, data.ResponseEvent, published[1].Type)
+		assert.Equal(t, "1", published[1].Response.Identifier)
+	})
+	t.Run("same state should only publish the response", func(t *testing.T) {
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+
+		published := getEvents()
+		assert.Equal(t, 1, len(published))
+		assert.Equal(t, data.ResponseEvent, published[0].Type)
+	})
+	t.Run("error level should publish the transition to alerting", func(t *testing.T) {
+		level = data.Error
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+
+		published := getEvents()
+		assert.Equal(t, 2, len(published))
+		assert.Equal(t, data.StateOk, published[0].PreviousState)
+		assert.Equal(t, data.StateAlerting, published[0].State)
+		assert.Equal(t, data.Error, published[0].Level)
+	})
+	t.Run("pause and resume should publish transitions", func(t *testing.T) {
+		_ = pollHandler.PauseAlarm("1")
+		_ = pollHandler.ResumeAlarm("1")
+
+		published := getEvents()
+		assert.Equal(t, 2, len(published))
+		assert.Equal(t, data.StatePaused, published[0].State)
+		assert.Equal(t, data.StatePaused, published[1].PreviousState)
+		assert.Equal(t, data.StateAlerting, published[1].State)
+	})
+}
+
+func TestPollingHandler_AcknowledgeAlarm(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	level := data.Info
+	args.Alarms = []AlarmHandler{
+		&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				return data.AlarmResponse{Identifier: "1", Level: level}, nil
+			},
+			IdentifierCalled: func() string {
+				return "1"
+			},
+		},
+	}
+	numNotified := uint64(0)
+	args.Notifiers = []NotifierHandler{
+		&mocks.NotifierHandlerStub{
+			ProcessAlarmResponseCalled: func(ctx context.Context, response data.AlarmResponse) error {
+				atomic.AddUint64(&numNotified, 1)
+				return nil
+			},
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	t.Run("unknown alarm should error", func(t *testing.T) {
+		err := pollHandler.AcknowledgeAlarm("2")
+		assert.True(t, errors.Is(err, ErrAlarmNotFound))
+	})
+	t.Run("not alerting alarm should error", func(t *testing.T) {
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+
+		err := pollHandler.AcknowledgeAlarm("1")
+		assert.True(t, errors.Is(err, ErrAlarmNotAlerting))
+	})
+	t.Run("acknowledged alarm should not notify until it recovers", func(t *testing.T) {
+		level = data.Error
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+
+		err := pollHandler.AcknowledgeAlarm("1")
+		assert.Nil(t, err)
+		assert.True(t, pollHandler.AlarmsStatus()[0].Acknowledged)
+
+		numNotifiedAfterAck := atomic.LoadUint64(&numNotified)
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+		assert.Equal(t, numNotifiedAfterAck, atomic.LoadUint64(&numNotified))
+
+		level = data.Info
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+		assert.Equal(t, numNotifiedAfterAck+1, atomic.LoadUint64(&numNotified))
+		assert.False(t, pollHandler.AlarmsStatus()[0].Acknowledged)
+	})
+}
+
+func TestPollingHandler_SilenceAlarm(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	level := data.Error
+	args.Alarms = []AlarmHandler{
+		&mocks.AlarmHandlerStub{
+			QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+				return data.AlarmResponse{Identifier: "1", Level: level}, nil
+			},
+			IdentifierCalled: func() string {
+				return "1"
+			},
+		},
+	}
+	numNotified := uint64(0)
+	var lastNotified data.AlarmResponse
+	args.Notifiers = []NotifierHandler{
+		&mocks.NotifierHandlerStub{
+			ProcessAlarmResponseCalled: func(ctx context.Context, response data.AlarmResponse) error {
+				atomic.AddUint64(&numNotified, 1)
+				lastNotified = response
+				return nil
+			},
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	t.Run("unknown alarm should error", func(t *testing.T) {
+		err := pollHandler.SilenceAlarm("2", time.Hour)
+		assert.True(t, errors.Is(err, ErrAlarmNotFound))
+	})
+	t.Run("silenced alarm should be queried but not notified", func(t *testing.T) {
+		err := pollHandler.SilenceAlarm("1", time.Hour)
+		assert.Nil(t, err)
+		assert.False(t, pollHandler.AlarmsStatus()[0].SilencedUntil.IsZero())
+
+		response, err := pollHandler.TriggerAlarmQuery(context.Background(), "1")
+		assert.Nil(t, err)
+		assert.Equal(t, data.Error, response.Level)
+		assert.Equal(t, uint64(0), atomic.LoadUint64(&numNotified))
+		assert.Equal(t, data.StateAlerting, pollHandler.AlarmsStatus()[0].State)
+		assert.Equal(t, 1, pollHandler.getNumAlarmsWithError())
+	})
+	t.Run("silenced alarm should still notify its recovery", func(t *testing.T) {
+		level = data.NoEvent
+		defer func() {
+			level = data.Error
+			atomic.StoreUint64(&numNotified, 0)
+		}()
+
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+		assert.Equal(t, uint64(1), atomic.LoadUint64(&numNotified))
+		assert.Equal(t, data.NoEvent, lastNotified.Level)
+		assert.False(t, pollHandler.AlarmsStatus()[0].SilencedUntil.IsZero())
+	})
+	t.Run("expired silence should notify", func(t *testing.T) {
+		err := pollHandler.SilenceAlarm("1", time.Millisecond)
+		assert.Nil(t, err)
+		time.Sleep(time.Millisecond * 10)
+
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+		assert.Equal(t, uint64(1), atomic.LoadUint64(&numNotified))
+		assert.True(t, pollHandler.AlarmsStatus()[0].SilencedUntil.IsZero())
+	})
+	t.Run("zero duration should remove the silence", func(t *testing.T) {
+		_ = pollHandler.SilenceAlarm("1", time.Hour)
+		err := pollHandler.SilenceAlarm("1", 0)
+		assert.Nil(t, err)
+
+		_, _ = pollHandler.TriggerAlarmQuery(context.Background(), "1")
+		assert.Equal(t, uint64(2), atomic.LoadUint64(&numNotified))
+	})
+}
+
+func createIdentifiedAlarmStub(identifier string) *mocks.AlarmHandlerStub {
+	return &mocks.AlarmHandlerStub{
+		QueryInfoCalled: func(ctx context.Context) (string, error) {
+			return "info " + identifier, nil
+		},
+		IdentifierCalled: func() string {
+			return identifier
+		},
+	}
+}
+
+func TestPollingHandler_NetworkInfoMessage(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	args.Alarms = []AlarmHandler{
+		createIdentifiedAlarmStub("global"),
+		&networkAlarmHandlerStub{AlarmHandlerStub: createIdentifiedAlarmStub("mainnet rating"), network: "mainnet"},
+		&networkAlarmHandlerStub{AlarmHandlerStub: createIdentifiedAlarmStub("testnet rating"), network: "testnet"},
+	}
+	args.NetworksInfo = []ArgsNetworkInfo{{Network: "testnet", SendInfoHour: 11}}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	response := pollHandler.createInfoMessage(context.Background())
+	assert.Equal(t, []data.ReportEntry{
+		{Identifier: "global", Level: data.Info, Status: "info global"},
+		{Identifier: "mainnet rating", Level: data.Info, Status: "info mainnet rating"},
+	}, response.Report)
+	assert.Nil(t, response.Labels)
+
+	response = pollHandler.createNetworkInfoMessage(context.Background(), "testnet")
+	assert.Equal(t, systemIdentifier, response.Identifier)
+	assert.Equal(t, data.Info, response.Level)
+	assert.Equal(t, map[string]string{data.NetworkLabel: "testnet"}, response.Labels)
+	assert.Equal(t, []data.ReportEntry{
+		{Identifier: "testnet rating", Level: data.Info, Status: "info testnet rating"},
+	}, response.Report)
+	assert.True(t, strings.Contains(response.Data, "Report for network testnet\nStatus for alarm testnet rating: info testnet rating"))
+	_, found := response.Metrics[data.ProcessingErrorsMetric]
+	assert.False(t, found)
+}
+
+func TestPollingHandler_FilteredNotifiers(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsPollingHandler()
+	args.Alarms = []AlarmHandler{
+		&networkAlarmHandlerStub{
+			AlarmHandlerStub: &mocks.AlarmHandlerStub{
+				QueryCalled: func(ctx context.Context) (data.AlarmResponse, error) {
+					return data.AlarmResponse{
+						Identifier: "testnet rating",
+						Level:      data.Error,
+						Labels:     map[string]string{data.NetworkLabel: "testnet"},
+					}, nil
+				},
+				IdentifierCalled: func() string {
+					return "testnet rating"
+				},
+			},
+			network: "testnet",
+		},
+	}
+	numProcessed := uint32(0)
+	args.Notifiers = []NotifierHandler{
+		&mocks.NotifierHandlerStub{
+			ProcessAlarmResponseCalled: func(ctx context.Context, response data.AlarmResponse) error {
+				atomic.AddUint32(&numProcessed, 1)
+				return nil
+			},
+		},
+		&filteredNotifierStub{
+			NotifierHandlerStub: &mocks.NotifierHandlerStub{
+				ProcessAlarmResponseCalled: func(ctx context.Context, response data.AlarmResponse) error {
+					assert.Fail(t, "should have not processed the rejected response")
+					return nil
+				},
+			},
+			AcceptsResponseCalled: func(response data.AlarmResponse) bool {
+				return response.Labels[data.NetworkLabel] != "testnet"
+			},
+		},
+	}
+	pollHandler, _ := NewPollingHandler(args)
+	defer func() {
+		_ = pollHandler.Close()
+	}()
+
+	_, err := pollHandler.TriggerAlarmQuery(context.Background(), "testnet rating")
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numProcessed))
+
+	statuses := pollHandler.NotifiersStatus()
+	assert.Equal(t, "1: *mocks.NotifierHandlerStub", statuses[1].Name)
+	assert.Equal(t, uint64(1), statuses[0].NumSent)
+	assert.Equal(t, uint64(0), statuses[1].NumSent)
+}
@@ -0,0 +1,20 @@
+package reload
+
+import "github.com/iulianpascalau/node-monitoring/poll"
+
+// the stub is defined here and not in the mocks package as the poll package tests are using the mocks package
+type componentsUpdaterStub struct {
+	updateComponentsCalled func(alarms []poll.AlarmHandler, notifiers []poll.NotifierHandler) error
+}
+
+func (stub *componentsUpdaterStub) UpdateComponents(alarms []poll.AlarmHandler, notifiers []poll.NotifierHandler) error {
+	if stub.updateComponentsCalled != nil {
+		return stub.updateComponentsCalled(alarms, notifiers)
+	}
+
+	return nil
+}
+
+func (stub *componentsUpdaterStub) IsInterfaceNil() bool {
+	return stub == nil
+}
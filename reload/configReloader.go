@@ -0,0 +1,157 @@
+package reload
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/factory"
+)
+
+var log = logger.GetOrCreate("reload")
+
+// ArgsConfigReloader represents the arguments DTO for the configReloader constructor
+type ArgsConfigReloader struct {
+	ConfigPath        string
+	Config            config.GeneralConfig
+	Components        *factory.Components
+	ComponentsUpdater ComponentsUpdater
+}
+
+type configReloader struct {
+	mut               sync.Mutex
+	configPath        string
+	config            config.GeneralConfig
+	components        *factory.Components
+	componentsUpdater ComponentsUpdater
+}
+
+// NewConfigReloader creates a new config reloader instance
+func NewConfigReloader(args ArgsConfigReloader) (*configReloader, error) {
+	if args.Components == nil {
+		return nil, errNilComponents
+	}
+	if check.IfNil(args.ComponentsUpdater) {
+		return nil, errNilComponentsUpdater
+	}
+
+	return &configReloader{
+		configPath:        args.ConfigPath,
+		config:            args.Config,
+		components:        args.Components,
+		componentsUpdater: args.ComponentsUpdater,
+	}, nil
+}
+
+// Reload will read again the config file and will apply the differences on the running components. The alarms
+// and notifiers whose config did not change are kept, the removed and replaced ones being closed. If the new config
+// is invalid, the old config remains active
+func (reloader *configReloader) Reload() error {
+	reloader.mut.Lock()
+	defer reloader.mut.Unlock()
+
+	newConfig, err := config.LoadConfig(reloader.configPath)
+	if err != nil {
+		log.Error("config reload rejected", "error", err.Error())
+		return err
+	}
+
+	_, err = factory.ParseTimeOfDay(newConfig.InfoTimeOfDay)
+	if err != nil {
+		log.Error("config reload rejected", "error", err.Error())
+		return err
+	}
+
+	_, err = factory.CreateNetworksInfo(newConfig.Networks)
+	if err != nil {
+		log.Error("config reload rejected", "error", err.Error())
+		return err
+	}
+
+	newComponents, diff, err := reloader.components.Update(newConfig)
+	if err != nil {
+		log.Error("config reload rejected", "error", err.Error())
+		return err
+	}
+
+	err = reloader.componentsUpdater.UpdateComponents(newComponents.Alarms(), newComponents.Notifiers())
+	if err != nil {
+		log.Error("config reload rejected", "error", err.Error())
+		_ = newComponents.Discard()
+		return err
+	}
+
+	err = newComponents.CloseReplaced()
+	if err != nil {
+		log.Warn("error closing the replaced components", "error", err.Error())
+	}
+
+	reloader.warnOnRestartRequired(newConfig)
+	reloader.config = newConfig
+	reloader.components = newComponents
+
+	log.Info("configuration reloaded", "config", reloader.configPath,
+		"added alarms", diff.AddedAlarms, "removed alarms", diff.RemovedAlarms,
+		"updated alarms", diff.UpdatedAlarms, "unchanged alarms", diff.UnchangedAlarms,
+		"added notifiers", diff.AddedNotifiers, "removed notifiers", diff.RemovedNotifiers)
+
+	return nil
+}
+
+func (reloader *configReloader) warnOnRestartRequired(newConfig config.GeneralConfig) {
+	if !reflect.DeepEqual(reloader.config.Api, newConfig.Api) {
+		log.Warn("the Api config section changed, a restart is required to apply it")
+	}
+	if !reflect.DeepEqual(reloader.config.ConfigReload, newConfig.ConfigReload) {
+		log.Warn("the ConfigReload config section changed, a restart is required to apply it")
+	}
+	if !reflect.DeepEqual(telegramCommandsConfigs(reloader.config), telegramCommandsConfigs(newConfig)) {
+		log.Warn("the Telegram commands config changed, a restart is required to apply it")
+	}
+	if reloader.config.InfoTimeOfDay != newConfig.InfoTimeOfDay {
+		log.Warn("the InfoTimeOfDay config value changed, a restart is required to apply it")
+	}
+	if !reflect.DeepEqual(networksInfoTimes(reloader.config), networksInfoTimes(newConfig)) {
+		log.Warn("the networks InfoTimeOfDay config values changed, a restart is required to apply them")
+	}
+}
+
+// networksInfoTimes returns the networks' info report times as the polling handler only schedules them at startup
+func networksInfoTimes(cfg config.GeneralConfig) map[string]string {
+	times := make(map[string]string)
+	for _, network := range cfg.Networks {
+		if len(network.InfoTimeOfDay) > 0 {
+			times[network.Name] = network.InfoTimeOfDay
+		}
+	}
+
+	return times
+}
+
+// telegramCommandsConfigs returns the configs of the Telegram notifiers that have the commands enabled as the
+// commands handlers are only created at startup
+func telegramCommandsConfigs(cfg config.GeneralConfig) []config.TelegramNotifier {
+	configs := make([]config.TelegramNotifier, 0)
+	for _, notifierConfig := range cfg.Notifiers.Telegram {
+		if notifierConfig.Commands.Enabled {
+			configs = append(configs, notifierConfig)
+		}
+	}
+
+	return configs
+}
+
+// Close closes the alarms and notifiers of the current config
+func (reloader *configReloader) Close() error {
+	reloader.mut.Lock()
+	defer reloader.mut.Unlock()
+
+	return reloader.components.Close()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (reloader *configReloader) IsInterfaceNil() bool {
+	return reloader == nil
+}
@@ -0,0 +1,243 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/iulianpascalau/node-monitoring/config"
+	"github.com/iulianpascalau/node-monitoring/data"
+	"github.com/iulianpascalau/node-monitoring/factory"
+	"github.com/iulianpascalau/node-monitoring/poll"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockArgsConfigReloader(t *testing.T) ArgsConfigReloader {
+	components, err := factory.CreateComponents(config.GeneralConfig{})
+	require.Nil(t, err)
+
+	return ArgsConfigReloader{
+		ConfigPath:        filepath.Join(t.TempDir(), "config.toml"),
+		Config:            config.GeneralConfig{},
+		Components:        components,
+		ComponentsUpdater: &componentsUpdaterStub{},
+	}
+}
+
+func writeConfig(t *testing.T, path string, content string) {
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	require.Nil(t, err)
+}
+
+// createSyslogServer starts a TCP server accepting one syslog connection, the returned channel being closed when
+// the connection is closed by the notifier
+func createSyslogServer(t *testing.T) (string, chan struct{}) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	connectionClosed := make(chan struct{})
+	go func() {
+		conn, errAccept := listener.Accept()
+		if errAccept != nil {
+			return
+		}
+
+		_, _ = io.Copy(ioutil.Discard, conn)
+		_ = conn.Close()
+		close(connectionClosed)
+	}()
+
+	return listener.Addr().String(), connectionClosed
+}
+
+func createSyslogConfig(t *testing.T, address string) (config.GeneralConfig, *factory.Components) {
+	cfg := config.GeneralConfig{}
+	cfg.Notifiers.Syslog = []config.SyslogNotifier{{Network: "tcp", Address: address, TimeoutInSeconds: 1}}
+	components, err := factory.CreateComponents(cfg)
+	require.Nil(t, err)
+
+	err = components.Notifiers()[0].ProcessAlarmResponse(context.Background(), data.AlarmResponse{Level: data.Error})
+	require.Nil(t, err)
+
+	return cfg, components
+}
+
+func TestNewConfigReloader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil components should error", func(t *testing.T) {
+		args := createMockArgsConfigReloader(t)
+		args.Components = nil
+
+		reloader, err := NewConfigReloader(args)
+		assert.True(t, check.IfNil(reloader))
+		assert.Equal(t, errNilComponents, err)
+	})
+	t.Run("nil components updater should error", func(t *testing.T) {
+		args := createMockArgsConfigReloader(t)
+		args.ComponentsUpdater = nil
+
+		reloader, err := NewConfigReloader(args)
+		assert.True(t, check.IfNil(reloader))
+		assert.Equal(t, errNilComponentsUpdater, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		reloader, err := NewConfigReloader(createMockArgsConfigReloader(t))
+		assert.False(t, check.IfNil(reloader))
+		assert.Nil(t, err)
+	})
+}
+
+func TestConfigReloader_Reload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing config file should keep the old config", func(t *testing.T) {
+		args := createMockArgsConfigReloader(t)
+		args.ComponentsUpdater = &componentsUpdaterStub{
+			updateComponentsCalled: func(alarms []poll.AlarmHandler, notifiers []poll.NotifierHandler) error {
+				assert.Fail(t, "should have not called UpdateComponents")
+				return nil
+			},
+		}
+		reloader, _ := NewConfigReloader(args)
+
+		err := reloader.Reload()
+		assert.NotNil(t, err)
+		assert.Equal(t, args.Config, reloader.config)
+	})
+	t.Run("invalid time of day should keep the old config", func(t *testing.T) {
+		args := createMockArgsConfigReloader(t)
+		writeConfig(t, args.ConfigPath, `InfoTimeOfDay = "25:00:00"`)
+		reloader, _ := NewConfigReloader(args)
+
+		err := reloader.Reload()
+		assert.NotNil(t, err)
+		assert.Equal(t, args.Config, reloader.config)
+	})
+	t.Run("invalid network time of day should keep the old config", func(t *testing.T) {
+		args := createMockArgsConfigReloader(t)
+		writeConfig(t, args.ConfigPath, `
+[[Networks]]
+  Name = "testnet"
+  InfoTimeOfDay = "11:60:00"
+`)
+		reloader, _ := NewConfigReloader(args)
+
+		err := reloader.Reload()
+		assert.NotNil(t, err)
+		assert.Equal(t, args.Config, reloader.config)
+	})
+	t.Run("components creation error should keep the old config", func(t *testing.T) {
+		args := createMockArgsConfigReloader(t)
+		writeConfig(t, args.ConfigPath, `
+[Alarms]
+  [[Alarms.NodeNonce]]
+    Identifier = "nonce"
+  [[Alarms.NodeNonce]]
+    Identifier = "nonce"
+`)
+		reloader, _ := NewConfigReloader(args)
+
+		err := reloader.Reload()
+		assert.NotNil(t, err)
+		assert.Equal(t, args.Config, reloader.config)
+		assert.True(t, args.Components == reloader.components)
+	})
+	t.Run("components updater error should keep the old config", func(t *testing.T) {
+		expectedErr := errors.New("expected error")
+		args := createMockArgsConfigReloader(t)
+		args.ComponentsUpdater = &componentsUpdaterStub{
+			updateComponentsCalled: func(alarms []poll.AlarmHandler, notifiers []poll.NotifierHandler) error {
+				return expectedErr
+			},
+		}
+		writeConfig(t, args.ConfigPath, `InfoTimeOfDay = "11:00:00"`)
+		reloader, _ := NewConfigReloader(args)
+
+		err := reloader.Reload()
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, args.Config, reloader.config)
+		assert.True(t, args.Components == reloader.components)
+	})
+	t.Run("should work", func(t *testing.T) {
+		numCalls := 0
+		args := createMockArgsConfigReloader(t)
+		args.ComponentsUpdater = &componentsUpdaterStub{
+			updateComponentsCalled: func(alarms []poll.AlarmHandler, notifiers []poll.NotifierHandler) error {
+				numCalls++
+				return nil
+			},
+		}
+		writeConfig(t, args.ConfigPath, `InfoTimeOfDay = "11:00:00"`)
+		reloader, _ := NewConfigReloader(args)
+
+		err := reloader.Reload()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, numCalls)
+		assert.Equal(t, "11:00:00", reloader.config.InfoTimeOfDay)
+		assert.False(t, args.Components == reloader.components)
+	})
+	t.Run("should close the removed notifiers", func(t *testing.T) {
+		address, connectionClosed := createSyslogServer(t)
+
+		args := createMockArgsConfigReloader(t)
+		args.Config, args.Components = createSyslogConfig(t, address)
+
+		writeConfig(t, args.ConfigPath, `InfoTimeOfDay = "11:00:00"`)
+		reloader, _ := NewConfigReloader(args)
+
+		err := reloader.Reload()
+		assert.Nil(t, err)
+		select {
+		case <-connectionClosed:
+		case <-time.After(time.Second * 5):
+			assert.Fail(t, "the syslog connection should have been closed")
+		}
+	})
+	t.Run("should close the removed file notifiers", func(t *testing.T) {
+		args := createMockArgsConfigReloader(t)
+		args.Config.Notifiers.File = []config.FileNotifier{
+			{FilePath: filepath.Join(t.TempDir(), "alarms.jsonl"), MaxSizeInMB: 1, RotationIntervalInHours: 24},
+		}
+		var err error
+		args.Components, err = factory.CreateComponents(args.Config)
+		require.Nil(t, err)
+		notifier := args.Components.Notifiers()[0]
+		response := data.AlarmResponse{Level: data.Error}
+		require.Nil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+
+		writeConfig(t, args.ConfigPath, `InfoTimeOfDay = "11:00:00"`)
+		reloader, _ := NewConfigReloader(args)
+
+		err = reloader.Reload()
+		assert.Nil(t, err)
+		assert.NotNil(t, notifier.ProcessAlarmResponse(context.Background(), response))
+	})
+}
+
+func TestConfigReloader_Close(t *testing.T) {
+	t.Parallel()
+
+	address, connectionClosed := createSyslogServer(t)
+	args := createMockArgsConfigReloader(t)
+	args.Config, args.Components = createSyslogConfig(t, address)
+	reloader, _ := NewConfigReloader(args)
+
+	err := reloader.Close()
+	assert.Nil(t, err)
+	select {
+	case <-connectionClosed:
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "the syslog connection should have been closed")
+	}
+}
@@ -0,0 +1,8 @@
+package reload
+
+import "errors"
+
+var errNilComponents = errors.New("nil components")
+var errNilComponentsUpdater = errors.New("nil components updater")
+var errNilOnChangeHandler = errors.New("nil on change handler")
+var errInvalidValue = errors.New("invalid value")
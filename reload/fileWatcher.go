@@ -0,0 +1,100 @@
+package reload
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+const minCheckInterval = time.Second
+
+// ArgsFileWatcher represents the arguments DTO for the fileWatcher constructor
+type ArgsFileWatcher struct {
+	FilePath      string
+	CheckInterval time.Duration
+	OnChange      func()
+}
+
+// fileWatcher periodically checks the modification time and size of a file and calls the OnChange handler
+// whenever one of them changed
+type fileWatcher struct {
+	filePath      string
+	checkInterval time.Duration
+	onChange      func()
+	modTime       time.Time
+	size          int64
+	cancel        func()
+}
+
+// NewFileWatcher creates a new file watcher instance
+func NewFileWatcher(args ArgsFileWatcher) (*fileWatcher, error) {
+	if args.CheckInterval < minCheckInterval {
+		return nil, fmt.Errorf("%w for CheckInterval, provided: %v, minimum: %v", errInvalidValue, args.CheckInterval, minCheckInterval)
+	}
+	if args.OnChange == nil {
+		return nil, errNilOnChangeHandler
+	}
+
+	info, err := os.Stat(args.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &fileWatcher{
+		filePath:      args.FilePath,
+		checkInterval: args.CheckInterval,
+		onChange:      args.OnChange,
+		modTime:       info.ModTime(),
+		size:          info.Size(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fw.cancel = cancel
+
+	go fw.processLoop(ctx)
+
+	return fw, nil
+}
+
+func (fw *fileWatcher) processLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("file watcher stopped", "file", fw.filePath)
+			return
+		case <-time.After(fw.checkInterval):
+			fw.check()
+		}
+	}
+}
+
+func (fw *fileWatcher) check() {
+	info, err := os.Stat(fw.filePath)
+	if err != nil {
+		log.Warn("error checking watched file", "file", fw.filePath, "error", err.Error())
+		return
+	}
+
+	if info.ModTime().Equal(fw.modTime) && info.Size() == fw.size {
+		return
+	}
+
+	fw.modTime = info.ModTime()
+	fw.size = info.Size()
+
+	log.Info("watched file changed", "file", fw.filePath)
+	fw.onChange()
+}
+
+// Close stops the file watcher
+func (fw *fileWatcher) Close() error {
+	fw.cancel()
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fw *fileWatcher) IsInterfaceNil() bool {
+	return fw == nil
+}
@@ -0,0 +1,83 @@
+package reload
+
+import (
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go-core/core/check"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockArgsFileWatcher(t *testing.T) ArgsFileWatcher {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	writeConfig(t, path, "initial")
+
+	return ArgsFileWatcher{
+		FilePath:      path,
+		CheckInterval: time.Second,
+		OnChange:      func() {},
+	}
+}
+
+func TestNewFileWatcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid check interval should error", func(t *testing.T) {
+		args := createMockArgsFileWatcher(t)
+		args.CheckInterval = time.Second - time.Nanosecond
+
+		fw, err := NewFileWatcher(args)
+		assert.True(t, check.IfNil(fw))
+		assert.True(t, errors.Is(err, errInvalidValue))
+	})
+	t.Run("nil on change handler should error", func(t *testing.T) {
+		args := createMockArgsFileWatcher(t)
+		args.OnChange = nil
+
+		fw, err := NewFileWatcher(args)
+		assert.True(t, check.IfNil(fw))
+		assert.Equal(t, errNilOnChangeHandler, err)
+	})
+	t.Run("missing file should error", func(t *testing.T) {
+		args := createMockArgsFileWatcher(t)
+		args.FilePath += ".missing"
+
+		fw, err := NewFileWatcher(args)
+		assert.True(t, check.IfNil(fw))
+		assert.NotNil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		fw, err := NewFileWatcher(createMockArgsFileWatcher(t))
+		assert.False(t, check.IfNil(fw))
+		assert.Nil(t, err)
+
+		_ = fw.Close()
+	})
+}
+
+func TestFileWatcher_ShouldCallOnChange(t *testing.T) {
+	t.Parallel()
+
+	numCalls := uint32(0)
+	args := createMockArgsFileWatcher(t)
+	args.OnChange = func() {
+		atomic.AddUint32(&numCalls, 1)
+	}
+	fw, _ := NewFileWatcher(args)
+	defer func() {
+		_ = fw.Close()
+	}()
+
+	time.Sleep(time.Millisecond * 1500)
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&numCalls))
+
+	writeConfig(t, args.FilePath, "changed content")
+	time.Sleep(time.Millisecond * 1500)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+
+	time.Sleep(time.Millisecond * 1500)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+}
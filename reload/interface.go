@@ -0,0 +1,9 @@
+package reload
+
+import "github.com/iulianpascalau/node-monitoring/poll"
+
+// ComponentsUpdater defines the operations of a component that can have its alarms and notifiers updated
+type ComponentsUpdater interface {
+	UpdateComponents(alarms []poll.AlarmHandler, notifiers []poll.NotifierHandler) error
+	IsInterfaceNil() bool
+}